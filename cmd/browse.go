@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/tui"
+)
+
+func BrowseCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "browse",
+		Usage: "Browse starred repositories interactively",
+		Description: `Show the local database as a scrollable list, most stars first, with the
+long-form details of the selected repository beside it. Repositories load a
+page at a time as you scroll.
+
+Keys:
+  ↑/↓ or j/k     move            /        filter as you type
+  pgup/pgdown    page            esc      clear the filter
+  enter          toggle details on narrow terminals
+  ctrl+d/ctrl+u  scroll details  o        open in the browser
+  q              quit
+
+The filter matches every word against the name, description, language and
+topics. For ranked search, use 'gh star-search query'.`,
+		Action: runBrowse,
+	}
+}
+
+func runBrowse(ctx context.Context, _ *cli.Command) error {
+	if !stdoutIsTerminal() {
+		return errors.New(errors.ErrTypeValidation, "browse needs an interactive terminal").
+			WithSuggestion("Use 'gh star-search list' or 'gh star-search query' for non-interactive output")
+	}
+
+	cfg := getConfigFromContext(ctx)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	f := formatter.NewFormatter(cfg.Formatter)
+
+	return tui.Run(ctx, repo, tui.Options{
+		Render: func(r storage.StoredRepo) string {
+			return f.FormatRepository(r, formatter.FormatLong)
+		},
+		Open: func(r storage.StoredRepo) error {
+			return launchBrowser(ctx, "https://github.com/"+r.FullName)
+		},
+	})
+}
@@ -4,38 +4,68 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/KyleKing/gh-star-search/internal/cache"
+	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
+// ClearOptions controls what clear removes besides the database contents
+type ClearOptions struct {
+	Force    bool   // Skip the confirmation prompt
+	CacheDir string // Also purge the file cache in this directory; empty keeps it
+}
+
 func ClearCommand() *cli.Command {
 	return &cli.Command{
-		Name:        "clear",
-		Usage:       "Clear the local database",
-		Description: `Remove all data and the database file. This action requires confirmation.`,
+		Name:  "clear",
+		Usage: "Clear the local database",
+		Description: `Remove all repositories and derived data from the local database, for
+rebuilding the index from scratch with 'gh star-search sync'. This action
+requires confirmation unless --yes is given.
+
+--cache also purges the file cache (fetched content, ETags and cached
+metadata), so the next sync fetches everything from GitHub again.
+
+Examples:
+  gh star-search clear
+  gh star-search clear --yes --cache`,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    "force",
-				Aliases: []string{"f"},
+				Aliases: []string{"f", "yes", "y"},
 				Usage:   "Skip confirmation prompt",
 			},
+			&cli.BoolFlag{
+				Name:  "cache",
+				Usage: "Also purge the file cache directory",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			force := cmd.Bool("force")
-			return runClear(ctx, force)
+			opts := ClearOptions{Force: cmd.Bool("force")}
+
+			if cmd.Bool("cache") {
+				opts.CacheDir = config.ExpandPath(getConfigFromContext(ctx).Cache.Directory)
+			}
+
+			return RunClearWithOptions(ctx, opts, nil, os.Stdin)
 		},
 	}
 }
 
-func runClear(ctx context.Context, force bool) error {
-	return RunClearWithStorage(ctx, force, nil)
+// RunClearWithStorage clears the database, keeping the file cache
+func RunClearWithStorage(ctx context.Context, force bool, repo storage.Repository) error {
+	return RunClearWithOptions(ctx, ClearOptions{Force: force}, repo, os.Stdin)
 }
 
-func RunClearWithStorage(ctx context.Context, force bool, repo storage.Repository) error {
+// RunClearWithOptions clears the database, and the file cache when opts.CacheDir
+// is set, reading the confirmation from in
+func RunClearWithOptions(ctx context.Context, opts ClearOptions, repo storage.Repository, in io.Reader) error {
 	// Initialize storage if not provided (for testing)
 	if repo == nil {
 		var err error
@@ -58,6 +88,12 @@ func RunClearWithStorage(ctx context.Context, force bool, repo storage.Repositor
 
 	if stats.TotalRepositories == 0 {
 		fmt.Println("Database is already empty.")
+
+		// The cache can be rebuilt from GitHub, so it is purged without asking
+		if opts.CacheDir != "" {
+			return purgeFileCache(ctx, opts.CacheDir)
+		}
+
 		return nil
 	}
 
@@ -66,12 +102,16 @@ func RunClearWithStorage(ctx context.Context, force bool, repo storage.Repositor
 	fmt.Printf("  • %d repositories\n", stats.TotalRepositories)
 	fmt.Printf("  • %.2f MB of data\n", stats.DatabaseSizeMB)
 
+	if opts.CacheDir != "" {
+		fmt.Printf("  • the file cache in %s\n", opts.CacheDir)
+	}
+
 	// Confirmation prompt (unless force flag is used)
-	if !force {
+	if !opts.Force {
 		fmt.Printf("\nAre you sure you want to clear all data? This action cannot be undone.\n")
 		fmt.Printf("Type 'yes' to confirm: ")
 
-		reader := bufio.NewReader(os.Stdin)
+		reader := bufio.NewReader(in)
 
 		response, err := reader.ReadString('\n')
 		if err != nil {
@@ -91,6 +131,33 @@ func RunClearWithStorage(ctx context.Context, force bool, repo storage.Repositor
 	}
 
 	fmt.Println("Database cleared successfully.")
+	fmt.Printf("Removed %d repositories.\n", stats.TotalRepositories)
+
+	if opts.CacheDir != "" {
+		return purgeFileCache(ctx, opts.CacheDir)
+	}
+
+	return nil
+}
+
+// purgeFileCache removes every cached file in dir. Subdirectories, such as the
+// Python environment for embeddings, are kept.
+func purgeFileCache(ctx context.Context, dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		fmt.Println("File cache is already empty.")
+		return nil
+	}
+
+	fileCache, err := cache.NewFileCache(dir, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open file cache: %w", err)
+	}
+
+	if err := fileCache.Clear(ctx); err != nil {
+		return fmt.Errorf("failed to purge file cache: %w", err)
+	}
+
+	fmt.Printf("File cache in %s purged.\n", dir)
 
 	return nil
 }
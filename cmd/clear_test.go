@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
@@ -37,6 +41,7 @@ func TestRunClear(t *testing.T) {
 				"• 10 repositories",
 				"• 5.50 MB of data",
 				"Database cleared successfully.",
+				"Removed 10 repositories.",
 			},
 		},
 		{
@@ -96,3 +101,43 @@ func TestRunClear(t *testing.T) {
 		})
 	}
 }
+
+func TestRunClearWithOptions(t *testing.T) {
+	stats := &storage.Stats{TotalRepositories: 3}
+
+	t.Run("declined confirmation keeps the cache", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		cached := filepath.Join(cacheDir, "entry.data")
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o600))
+
+		opts := ClearOptions{CacheDir: cacheDir}
+		require.NoError(t, RunClearWithOptions(context.Background(), opts, &MockRepository{stats: stats}, strings.NewReader("no\n")))
+
+		assert.FileExists(t, cached)
+	})
+
+	t.Run("confirmed clear purges cached files", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		cached := filepath.Join(cacheDir, "entry.data")
+		nested := filepath.Join(cacheDir, "python-env")
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o600))
+		require.NoError(t, os.Mkdir(nested, 0o755))
+
+		opts := ClearOptions{CacheDir: cacheDir}
+		require.NoError(t, RunClearWithOptions(context.Background(), opts, &MockRepository{stats: stats}, strings.NewReader("yes\n")))
+
+		assert.NoFileExists(t, cached)
+		assert.DirExists(t, nested, "subdirectories such as the Python environment should be kept")
+	})
+
+	t.Run("empty database still purges the cache", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		cached := filepath.Join(cacheDir, "entry.data")
+		require.NoError(t, os.WriteFile(cached, []byte("cached"), 0o600))
+
+		opts := ClearOptions{CacheDir: cacheDir}
+		require.NoError(t, RunClearWithOptions(context.Background(), opts, &MockRepository{stats: &storage.Stats{}}, strings.NewReader("")))
+
+		assert.NoFileExists(t, cached)
+	})
+}
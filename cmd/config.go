@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
@@ -13,10 +16,44 @@ import (
 
 func ConfigCommand() *cli.Command {
 	return &cli.Command{
-		Name:        "config",
-		Usage:       "Display the active configuration",
-		Description: `Show the current active configuration including all settings from file, environment variables, and command-line flags.`,
-		Action:      runConfig,
+		Name:  "config",
+		Usage: "Display, create or check the configuration",
+		Description: `Show the current active configuration including all settings from file, environment variables, and command-line flags.
+
+Examples:
+  gh star-search config init
+  gh star-search config show --json
+  gh star-search config validate`,
+		Commands: []*cli.Command{
+			{
+				Name:  "init",
+				Usage: "Write a config file with every setting at its default",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Overwrite an existing config file",
+					},
+				},
+				Action: runConfigInit,
+			},
+			{
+				Name:  "show",
+				Usage: "Display the active configuration (the default without a subcommand)",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the merged configuration as JSON, in the config file format",
+					},
+				},
+				Action: runConfigShow,
+			},
+			{
+				Name:   "validate",
+				Usage:  "Check the config files and environment overrides for errors",
+				Action: runConfigValidate,
+			},
+		},
+		Action: runConfig,
 	}
 }
 
@@ -24,6 +61,106 @@ func runConfig(ctx context.Context, _ *cli.Command) error {
 	return RunConfigWithConfig(getConfigFromContext(ctx))
 }
 
+func runConfigShow(ctx context.Context, cmd *cli.Command) error {
+	cfg := getConfigFromContext(ctx)
+
+	if !cmd.Bool("json") {
+		return RunConfigWithConfig(cfg)
+	}
+
+	jsonData, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config to JSON: %w", err)
+	}
+
+	fmt.Println(string(jsonData))
+
+	return nil
+}
+
+func runConfigInit(_ context.Context, cmd *cli.Command) error {
+	return initConfigFile(os.Stdout, cmd.Bool("force"))
+}
+
+// initConfigFile writes the default configuration to the user config file,
+// refusing to replace an existing one unless force is set
+func initConfigFile(out io.Writer, force bool) error {
+	configPath := config.UserConfigPath()
+
+	if _, err := os.Stat(configPath); err == nil && !force {
+		return errors.Newf(errors.ErrTypeValidation, "config file already exists: %s", configPath).
+			WithSuggestion("Pass --force to replace it with the defaults").
+			WithSuggestion("Run 'gh star-search config validate' to check it")
+	}
+
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeConfig, "failed to build default configuration")
+	}
+
+	if err := config.SaveConfig(cfg); err != nil {
+		return errors.Wrap(err, errors.ErrTypeFileSystem, "failed to write config file")
+	}
+
+	fmt.Fprintf(out, "Wrote default configuration to %s\n", configPath)
+	fmt.Fprintln(out, "Remove the settings you do not change so they keep tracking future defaults.")
+
+	return nil
+}
+
+func runConfigValidate(_ context.Context, _ *cli.Command) error {
+	return validateConfigSources(os.Stdout)
+}
+
+// validateConfigSources checks each config file for syntax errors and unknown
+// keys, then the merged configuration (with environment overrides) for invalid
+// values, reporting every issue found. Unlike the other commands it loads the
+// configuration itself, so it still runs when that configuration is invalid.
+func validateConfigSources(out io.Writer) error {
+	issues := 0
+
+	check := func(label, path string) {
+		if err := config.CheckFile(path); err != nil {
+			fmt.Fprintf(out, "%s %s: %v\n", label, path, err)
+			issues++
+
+			return
+		}
+
+		fmt.Fprintf(out, "%s %s: ok\n", label, path)
+	}
+
+	if userPath := config.UserConfigPath(); fileExists(userPath) {
+		check("User config", userPath)
+	} else {
+		fmt.Fprintf(out, "User config %s: not found, using defaults\n", userPath)
+	}
+
+	if projectPath := config.ProjectConfigPath(); projectPath != "" {
+		check("Project config", projectPath)
+	}
+
+	if _, err := config.LoadConfig(); err != nil {
+		fmt.Fprintf(out, "Effective configuration: %v\n", err)
+		issues++
+	} else {
+		fmt.Fprintln(out, "Effective configuration: ok")
+	}
+
+	if issues > 0 {
+		return errors.Newf(errors.ErrTypeConfig, "configuration has %d issue(s)", issues).
+			WithSuggestion("See OPERATIONS.md for every setting and its allowed values")
+	}
+
+	return nil
+}
+
+// fileExists reports whether path names an existing file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
 // RunConfigWithConfig displays the configuration (exported for testing)
 func RunConfigWithConfig(cfg *config.Config) error {
 	// Ensure we have a valid config
@@ -35,10 +172,21 @@ func RunConfigWithConfig(cfg *config.Config) error {
 	fmt.Println("====================")
 	fmt.Println("Active Configuration:")
 
+	if projectPath := config.ProjectConfigPath(); projectPath != "" {
+		fmt.Printf("Project Config: %s\n", projectPath)
+	}
+
 	// Database configuration
 	fmt.Println("\nDatabase:")
 	fmt.Printf("  Path: %s\n", cfg.Database.Path)
 	fmt.Printf("  Query Timeout: %s\n", cfg.Database.QueryTimeout)
+	fmt.Printf("  Max Connections: %d\n", cfg.Database.MaxConnections)
+	fmt.Printf("  Max Idle Connections: %d\n", cfg.Database.MaxIdleConns)
+	fmt.Printf("  Connection Max Lifetime: %s\n", cfg.Database.ConnMaxLifetime)
+	fmt.Printf("  Connection Max Idle Time: %s\n", cfg.Database.ConnMaxIdleTime)
+	fmt.Printf("  Single Connection: %t\n", cfg.Database.SingleConnection)
+	fmt.Printf("  Open Attempts: %d (backoff %s)\n", cfg.Database.OpenAttempts, cfg.Database.OpenBackoff)
+	fmt.Printf("  Lock Wait: %s\n", cfg.Database.LockWait)
 
 	// Cache configuration
 	fmt.Println("\nCache:")
@@ -60,6 +208,63 @@ func RunConfigWithConfig(cfg *config.Config) error {
 
 	fmt.Printf("  Add Source: %t\n", cfg.Logging.AddSource)
 
+	// Sync configuration
+	fmt.Println("\nSync:")
+	fmt.Printf("  Adaptive Batch Delay: %t\n", cfg.Sync.AdaptiveBatchDelay)
+	fmt.Printf("  Rate Limit Threshold: %d\n", cfg.Sync.RateLimitThreshold)
+	fmt.Printf("  Retry Attempts: %d\n", cfg.Sync.RetryAttempts)
+	fmt.Printf("  Search Requests Per Minute: %s\n", formatLimit(cfg.Sync.SearchRequestsPerMinute))
+	fmt.Printf("  GraphQL: %t\n", cfg.Sync.GraphQL)
+	fmt.Printf("  Max Requests: %s\n", formatLimit(cfg.Sync.MaxRequests))
+	fmt.Printf("  Max Bytes: %s\n", formatLimit(cfg.Sync.MaxBytes))
+
+	if cfg.Sync.ConfirmLargeSync > 0 {
+		fmt.Printf("  Confirm Large Sync: over %d repositories\n", cfg.Sync.ConfirmLargeSync)
+	} else {
+		fmt.Println("  Confirm Large Sync: disabled")
+	}
+
+	if cfg.Sync.DocsDedupThreshold > 0 {
+		fmt.Printf("  Docs Dedup Threshold: %.2f\n", cfg.Sync.DocsDedupThreshold)
+	} else {
+		fmt.Println("  Docs Dedup Threshold: disabled")
+	}
+
+	fmt.Printf("  Homepage Text: %t\n", cfg.Sync.HomepageText)
+
+	// Processor configuration
+	fmt.Println("\nProcessor:")
+	fmt.Printf("  Max File KB: %d\n", cfg.Processor.MaxFileKB)
+	fmt.Printf("  Max Total Tokens: %d\n", cfg.Processor.MaxTotalTokens)
+	fmt.Printf("  Max Tokens Per Chunk: %d\n", cfg.Processor.MaxTokensPerChunk)
+
+	// Formatter configuration
+	fmt.Println("\nFormatter:")
+	fmt.Printf("  Match Context Width: %d\n", cfg.Formatter.MatchContextWidth)
+	fmt.Printf("  Max Contributors: %d\n", cfg.Formatter.MaxContributors)
+	fmt.Printf("  Max Description Length: %d\n", cfg.Formatter.MaxDescriptionLength)
+
+	// Summarize configuration
+	fmt.Println("\nSummarize:")
+
+	if len(cfg.Summarize.Languages) == 0 {
+		fmt.Println("  Languages: all")
+	} else {
+		fmt.Printf("  Languages: %s\n", strings.Join(cfg.Summarize.Languages, ", "))
+	}
+
+	// Search configuration
+	fmt.Println("\nSearch:")
+	fmt.Printf("  Default Mode: %s\n", cfg.Search.DefaultMode)
+
+	if cfg.Search.ScoreExpression == "" {
+		fmt.Println("  Score Expression: default (star and recency boosts)")
+	} else {
+		fmt.Printf("  Score Expression: %s\n", cfg.Search.ScoreExpression)
+	}
+
+	fmt.Printf("  Min Score: %g\n", cfg.Search.MinScore)
+
 	// Debug configuration
 	fmt.Println("\nDebug:")
 	fmt.Printf("  Enabled: %t\n", cfg.Debug.Enabled)
@@ -81,3 +286,12 @@ func RunConfigWithConfig(cfg *config.Config) error {
 
 	return nil
 }
+
+// formatLimit renders a cap where 0 means no limit
+func formatLimit(limit int) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d", limit)
+}
@@ -3,9 +3,13 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/KyleKing/gh-star-search/internal/config"
 )
 
@@ -148,3 +152,100 @@ func TestRunConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestInitConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "nested", "config.json")
+	t.Setenv("GH_STAR_SEARCH_CONFIG", configPath)
+
+	var out bytes.Buffer
+	require.NoError(t, initConfigFile(&out, false))
+	assert.Contains(t, out.String(), configPath)
+
+	// The written file loads back as the defaults
+	require.NoError(t, config.CheckFile(configPath))
+
+	loaded, err := config.LoadConfig()
+	require.NoError(t, err)
+
+	defaults, err := config.DefaultConfig()
+	require.NoError(t, err)
+	assert.Equal(t, defaults, loaded)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"logging": {"level": "debug"}}`), 0o600))
+
+	err = initConfigFile(&out, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	require.NoError(t, initConfigFile(&out, true))
+
+	loaded, err = config.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "info", loaded.Logging.Level, "--force replaces the file")
+}
+
+func TestValidateConfigSources(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string // user config file; empty leaves it missing
+		env       map[string]string
+		wantErr   bool
+		wantLines []string
+	}{
+		{
+			name:      "no config file",
+			wantLines: []string{"not found, using defaults", "Effective configuration: ok"},
+		},
+		{
+			name:      "valid file",
+			content:   `{"logging": {"level": "debug"}}`,
+			wantLines: []string{"config.json: ok", "Effective configuration: ok"},
+		},
+		{
+			name:      "unknown key",
+			content:   `{"logging": {"levle": "debug"}}`,
+			wantErr:   true,
+			wantLines: []string{`unknown field "levle"`, "Effective configuration: ok"},
+		},
+		{
+			name:      "invalid value",
+			content:   `{"search": {"default_mode": "exact"}}`,
+			wantErr:   true,
+			wantLines: []string{"config.json: ok", "invalid search default mode: exact"},
+		},
+		{
+			name:      "invalid environment override",
+			env:       map[string]string{"GH_STAR_SEARCH_LOG_FORMAT": "yaml"},
+			wantErr:   true,
+			wantLines: []string{"invalid log format: yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			t.Setenv("GH_STAR_SEARCH_CONFIG", configPath)
+
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0o600))
+			}
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var out bytes.Buffer
+
+			err := validateConfigSources(&out)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, want := range tt.wantLines {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
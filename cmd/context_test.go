@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+)
+
+func TestWithConfig(t *testing.T) {
+	cfg, err := config.DefaultConfig()
+	require.NoError(t, err)
+
+	cfg.Database.Path = "/tmp/alternate.db"
+
+	ctx := WithConfig(context.Background(), cfg)
+
+	assert.Same(t, cfg, getConfigFromContext(ctx), "commands see the configuration the root command loaded")
+}
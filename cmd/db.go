@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func DBCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "db",
+		Usage: "Maintain the local database",
+		Description: `Maintenance operations on the stored data that do not need GitHub.
+
+Examples:
+  gh star-search db trim-chunks --max 20`,
+		Commands: []*cli.Command{
+			{
+				Name:  "trim-chunks",
+				Usage: "Keep at most N content chunks per repository, dropping the lowest-priority ones",
+				Description: `Applies sync's --prune-chunks-over to repositories that are already stored,
+without re-fetching them. README, package manifest and changelog chunks are
+kept ahead of docs and code.`,
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:     "max",
+						Usage:    "Maximum content chunks to keep per repository",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return withChunkTrimmer(ctx, func(repo chunkTrimmer) error {
+						return runTrimChunks(ctx, os.Stdout, repo, int(cmd.Int("max")))
+					})
+				},
+			},
+		},
+	}
+}
+
+// chunkTrimmer is the part of the DuckDB repository db trim-chunks uses
+type chunkTrimmer interface {
+	TrimChunks(ctx context.Context, maxChunks int) (int, error)
+}
+
+// withChunkTrimmer opens and migrates the configured database and passes it
+// to fn
+func withChunkTrimmer(ctx context.Context, fn func(chunkTrimmer) error) error {
+	cfg := getConfigFromContext(ctx)
+
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&cfg.Database)
+	if err != nil {
+		return databaseOpenError(err)
+	}
+	defer repo.Close()
+
+	if err := repo.Initialize(ctx); err != nil {
+		return schemaError(err)
+	}
+
+	return fn(repo)
+}
+
+// runTrimChunks caps every stored repository at maxChunks content chunks and
+// reports how many were deleted
+func runTrimChunks(ctx context.Context, out io.Writer, repo chunkTrimmer, maxChunks int) error {
+	if maxChunks <= 0 {
+		return errors.Newf(errors.ErrTypeValidation, "--max must be positive, got %d", maxChunks).
+			WithSuggestion("Pass the number of chunks to keep per repository, e.g. --max 20")
+	}
+
+	deleted, err := repo.TrimChunks(ctx, maxChunks)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to trim content chunks")
+	}
+
+	fmt.Fprintf(out, "Trimmed %d content chunk(s) to at most %d per repository.\n", deleted, maxChunks)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChunkTrimmer struct {
+	maxChunks int
+	deleted   int
+}
+
+func (s *stubChunkTrimmer) TrimChunks(_ context.Context, maxChunks int) (int, error) {
+	s.maxChunks = maxChunks
+	return s.deleted, nil
+}
+
+func TestRunTrimChunks(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubChunkTrimmer{deleted: 7}
+
+	var out bytes.Buffer
+
+	require.NoError(t, runTrimChunks(ctx, &out, repo, 20))
+	assert.Equal(t, 20, repo.maxChunks)
+	assert.Equal(t, "Trimmed 7 content chunk(s) to at most 20 per repository.\n", out.String())
+
+	repo.maxChunks = 0
+	err := runTrimChunks(ctx, &out, repo, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max must be positive")
+	assert.Zero(t, repo.maxChunks, "nothing is trimmed on invalid input")
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func DiffCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Compare a stored repository with its live state on GitHub",
+		Description: `Fetch one repository from GitHub and print each field that differs from the
+stored row (stars, forks, size, description, language, topics, license). Nothing
+is written, so this answers "is my index stale for this repo?" without a sync.
+
+Examples:
+  gh star-search diff cli/cli`,
+		ArgsUsage: "<owner/repo>",
+		Action:    runDiff,
+	}
+}
+
+func runDiff(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if args.Len() != 1 {
+		return errors.New(errors.ErrTypeValidation, "expected exactly one repository")
+	}
+
+	fullName := args.First()
+	if err := validateRepositoryName(fullName); err != nil {
+		return err
+	}
+
+	repo, err := initializeStorage(getConfigFromContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize storage")
+	}
+	defer repo.Close()
+
+	githubClient, err := github.NewClient()
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeAuth, "failed to create GitHub client").
+			WithSuggestion("Run 'gh auth login' to authenticate")
+	}
+
+	return RunDiffWithClients(ctx, githubClient, repo, fullName, os.Stdout)
+}
+
+// fieldDiff is one field whose stored value no longer matches GitHub
+type fieldDiff struct {
+	Field  string
+	Stored string
+	Live   string
+}
+
+// RunDiffWithClients writes a field-by-field comparison of the stored row for
+// fullName against the repository as GitHub currently reports it
+func RunDiffWithClients(
+	ctx context.Context,
+	client github.Client,
+	repo storage.Repository,
+	fullName string,
+	w io.Writer,
+) error {
+	stored, err := repo.GetRepository(ctx, fullName)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, "repository is not stored").
+			WithSuggestion("Run 'gh star-search sync' to add it")
+	}
+
+	live, err := client.GetRepository(ctx, fullName)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeGitHubAPI, "failed to fetch repository from GitHub")
+	}
+
+	fmt.Fprintf(w, "Repository: %s\n", stored.FullName)
+	fmt.Fprintf(w, "Last synced: %s\n", stored.LastSynced.Format(time.DateTime))
+
+	if live.UpdatedAt.After(stored.LastSynced) {
+		fmt.Fprintf(w, "Updated on GitHub: %s (after last sync, content may have changed)\n",
+			live.UpdatedAt.Format(time.DateTime))
+	}
+
+	diffs := repositoryFieldDiffs(*live, stored)
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "\nNo field differences: the stored metadata matches GitHub.")
+		return nil
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tSTORED\tLIVE")
+	fmt.Fprintln(tw, "-----\t------\t----")
+
+	for _, d := range diffs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Field, d.Stored, d.Live)
+	}
+
+	return tw.Flush()
+}
+
+// repositoryFieldDiffs lists the fields sync compares when deciding whether a
+// repository needs an update, in the order getUpdateReason reports them
+func repositoryFieldDiffs(live github.Repository, stored *storage.StoredRepo) []fieldDiff {
+	var (
+		s     SyncService
+		diffs []fieldDiff
+	)
+
+	if githubIDChanged(live, stored) {
+		diffs = append(diffs, fieldDiff{
+			"github_id", strconv.FormatInt(stored.GitHubID, 10), strconv.FormatInt(live.ID, 10),
+		})
+	}
+
+	if live.StargazersCount != stored.StargazersCount {
+		diffs = append(diffs, fieldDiff{
+			"stars", strconv.Itoa(stored.StargazersCount), strconv.Itoa(live.StargazersCount),
+		})
+	}
+
+	if live.ForksCount != stored.ForksCount {
+		diffs = append(diffs, fieldDiff{
+			"forks", strconv.Itoa(stored.ForksCount), strconv.Itoa(live.ForksCount),
+		})
+	}
+
+	if live.Size != stored.SizeKB {
+		diffs = append(diffs, fieldDiff{
+			"size", fmt.Sprintf("%d KB", stored.SizeKB), fmt.Sprintf("%d KB", live.Size),
+		})
+	}
+
+	if live.Description != stored.Description {
+		diffs = append(diffs, fieldDiff{
+			"description", getStringOrNA(stored.Description), getStringOrNA(live.Description),
+		})
+	}
+
+	if live.Language != stored.Language {
+		diffs = append(diffs, fieldDiff{
+			"language", getStringOrNA(stored.Language), getStringOrNA(live.Language),
+		})
+	}
+
+	if !s.topicsEqual(live.Topics, stored.Topics) {
+		diffs = append(diffs, fieldDiff{
+			"topics",
+			getStringOrNA(strings.Join(stored.Topics, ", ")),
+			getStringOrNA(strings.Join(live.Topics, ", ")),
+		})
+	}
+
+	if s.licenseChanged(live.License, stored.LicenseName, stored.LicenseSPDXID) {
+		liveLicense := ""
+		if live.License != nil {
+			liveLicense = formatLicense(live.License.Name, live.License.SPDXID)
+		}
+
+		diffs = append(diffs, fieldDiff{
+			"license",
+			getStringOrNA(formatLicense(stored.LicenseName, stored.LicenseSPDXID)),
+			getStringOrNA(liveLicense),
+		})
+	}
+
+	return diffs
+}
+
+// formatLicense renders a license as "Name (SPDX)", or whichever part is set
+func formatLicense(name, spdxID string) string {
+	switch {
+	case name != "" && spdxID != "":
+		return fmt.Sprintf("%s (%s)", name, spdxID)
+	case name != "":
+		return name
+	default:
+		return spdxID
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunDiffWithClients(t *testing.T) {
+	lastSynced := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := &MockRepository{repos: []storage.StoredRepo{
+		{
+			FullName:        "owner/current",
+			StargazersCount: 10,
+			Language:        "Go",
+			Topics:          []string{"cli"},
+			LicenseName:     "MIT License",
+			LicenseSPDXID:   "MIT",
+			LastSynced:      lastSynced,
+		},
+		{
+			FullName:        "owner/stale",
+			StargazersCount: 10,
+			ForksCount:      2,
+			Language:        "Go",
+			Topics:          []string{"cli"},
+			LastSynced:      lastSynced,
+		},
+		{FullName: "owner/unstarred", LastSynced: lastSynced},
+	}}
+
+	client := &MockGitHubClient{
+		starredRepos: []github.Repository{
+			{
+				FullName:        "owner/current",
+				StargazersCount: 10,
+				Language:        "Go",
+				Topics:          []string{"cli"},
+				License:         &github.License{Name: "MIT License", SPDXID: "MIT"},
+				UpdatedAt:       lastSynced.Add(-time.Hour),
+			},
+			{
+				FullName:        "owner/stale",
+				StargazersCount: 25,
+				ForksCount:      2,
+				Language:        "Rust",
+				Topics:          []string{"cli", "tui"},
+				License:         &github.License{Name: "Apache License 2.0", SPDXID: "Apache-2.0"},
+				UpdatedAt:       lastSynced.Add(time.Hour),
+			},
+		},
+		errors: map[string]error{},
+	}
+
+	tests := []struct {
+		name        string
+		repo        string
+		contains    []string
+		notContains []string
+		errType     errors.ErrorType
+	}{
+		{
+			name:        "matching repository",
+			repo:        "owner/current",
+			contains:    []string{"Repository: owner/current", "No field differences"},
+			notContains: []string{"FIELD", "Updated on GitHub"},
+		},
+		{
+			name: "stale repository",
+			repo: "owner/stale",
+			contains: []string{
+				"Updated on GitHub: 2024-01-01 01:00:00",
+				"stars     10",
+				"language  Go",
+				"topics    cli",
+				"cli, tui",
+				"license   N/A",
+				"Apache License 2.0 (Apache-2.0)",
+			},
+			notContains: []string{"forks", "No field differences"},
+		},
+		{
+			name:    "not stored",
+			repo:    "owner/missing",
+			errType: errors.ErrTypeNotFound,
+		},
+		{
+			name:    "not on GitHub",
+			repo:    "owner/unstarred",
+			errType: errors.ErrTypeGitHubAPI,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := RunDiffWithClients(context.Background(), client, mockRepo, tt.repo, &buf)
+
+			if tt.errType != "" {
+				if !errors.IsType(err, tt.errType) {
+					t.Fatalf("expected %s error, got %v", tt.errType, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("output should not contain %q:\n%s", unwanted, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/python"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// doctorCheckTimeout bounds each check, so an unreachable API reports a
+// failure instead of hanging the checklist
+const doctorCheckTimeout = 15 * time.Second
+
+func DoctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Check GitHub authentication, the database, the cache and the summarization backend",
+		Description: `Run a checklist of what sync and search depend on and print pass or fail for
+each, with hints on how to fix failures:
+
+  - GitHub authentication: the GitHub CLI credentials can fetch the current user
+  - Database: the database file opens and every schema migration is applied
+  - Cache directory: the cache directory is writable
+  - Summarization backend: uv, which runs the local summarization and
+    embedding models, is installed and responds
+
+Exits non-zero when any check fails.`,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runDoctor(ctx, os.Stdout, doctorChecks(getConfigFromContext(ctx)), jsonOutput(cmd))
+		},
+	}
+}
+
+// doctorCheck is one item of the doctor checklist
+type doctorCheck struct {
+	Name string
+	Run  func(ctx context.Context) doctorResult
+}
+
+// doctorResult is the outcome of a check: what was found when it passes, or
+// the failure with hints on how to fix it
+type doctorResult struct {
+	Detail string
+	Err    error
+	Hints  []string
+}
+
+// doctorChecks returns the checks run by the doctor command for cfg
+func doctorChecks(cfg *config.Config) []doctorCheck {
+	return []doctorCheck{
+		{Name: "GitHub authentication", Run: checkGitHubAuth},
+		{Name: "Database", Run: func(ctx context.Context) doctorResult {
+			return checkDatabase(ctx, &cfg.Database)
+		}},
+		{Name: "Cache directory", Run: func(context.Context) doctorResult {
+			return checkCacheDirectory(config.ExpandPath(cfg.Cache.Directory))
+		}},
+		{Name: "Summarization backend", Run: checkSummarizationBackend},
+	}
+}
+
+// doctorJSONCheck is the JSON shape of a check result under --json
+type doctorJSONCheck struct {
+	Name   string   `json:"name"`
+	OK     bool     `json:"ok"`
+	Detail string   `json:"detail,omitempty"`
+	Error  string   `json:"error,omitempty"`
+	Hints  []string `json:"hints,omitempty"`
+}
+
+// runDoctor runs every check, printing a pass or fail line for each with the
+// hints of failures (or a JSON array of results when asJSON is set), and
+// returns an error when any check failed
+func runDoctor(ctx context.Context, out io.Writer, checks []doctorCheck, asJSON bool) error {
+	failed := 0
+	jsonChecks := make([]doctorJSONCheck, 0, len(checks))
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, doctorCheckTimeout)
+		result := check.Run(checkCtx)
+		cancel()
+
+		if asJSON {
+			jsonCheck := doctorJSONCheck{Name: check.Name, OK: result.Err == nil, Detail: result.Detail, Hints: result.Hints}
+			if result.Err != nil {
+				failed++
+				jsonCheck.Error = result.Err.Error()
+			}
+
+			jsonChecks = append(jsonChecks, jsonCheck)
+
+			continue
+		}
+
+		if result.Err != nil {
+			failed++
+
+			fmt.Fprintf(out, "✗ %s: %v\n", check.Name, result.Err)
+
+			for _, hint := range result.Hints {
+				fmt.Fprintf(out, "    → %s\n", hint)
+			}
+
+			continue
+		}
+
+		fmt.Fprintf(out, "✓ %s: %s\n", check.Name, result.Detail)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(jsonChecks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal check results: %w", err)
+		}
+
+		fmt.Fprintln(out, string(data))
+	}
+
+	if failed > 0 {
+		return errors.Newf(errors.ErrTypeValidation, "%d of %d checks failed", failed, len(checks))
+	}
+
+	if !asJSON {
+		fmt.Fprintln(out, "\nAll checks passed.")
+	}
+
+	return nil
+}
+
+// checkGitHubAuth fetches the authenticated user with the GitHub CLI credentials
+func checkGitHubAuth(ctx context.Context) doctorResult {
+	hints := []string{
+		"Run 'gh auth status' to see which account and host the GitHub CLI uses",
+		"Run 'gh auth login' to sign in again",
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return doctorResult{Err: fmt.Errorf("no usable GitHub CLI credentials: %w", err), Hints: hints}
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+
+	if err := client.DoWithContext(ctx, "GET", "user", nil, &user); err != nil {
+		return doctorResult{Err: fmt.Errorf("failed to fetch the current user: %w", err), Hints: hints}
+	}
+
+	return doctorResult{Detail: "signed in as " + user.Login}
+}
+
+// checkDatabase opens the database without migrating it and reports pending
+// migrations. A missing database fails rather than being created.
+func checkDatabase(ctx context.Context, cfg *config.DatabaseConfig) doctorResult {
+	path := config.ExpandPath(cfg.Path)
+
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return doctorResult{
+				Err:   fmt.Errorf("no database at %s", path),
+				Hints: []string{"Run 'gh star-search sync' to create it"},
+			}
+		}
+
+		return doctorResult{Err: err, Hints: []string{"Check the permissions of " + path}}
+	}
+
+	repo, err := storage.NewDuckDBRepositoryFromConfig(cfg)
+	if err != nil {
+		openErr := databaseOpenError(err)
+
+		return doctorResult{Err: fmt.Errorf("%s: %w", openErr.Message, err), Hints: openErr.Suggestions}
+	}
+	defer repo.Close()
+
+	statuses, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		return doctorResult{
+			Err:   fmt.Errorf("failed to read the schema version: %w", err),
+			Hints: []string{"The file may not be a gh star-search database; check --db-path"},
+		}
+	}
+
+	version, pending := 0, 0
+
+	for _, status := range statuses {
+		if status.Applied {
+			version = max(version, status.Version)
+		} else {
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		return doctorResult{
+			Err: fmt.Errorf("schema version %d has %d pending migration(s)", version, pending),
+			Hints: []string{
+				"Run 'gh star-search migrate up' to apply them",
+			},
+		}
+	}
+
+	return doctorResult{Detail: fmt.Sprintf("%s (schema version %d)", path, version)}
+}
+
+// checkCacheDirectory creates dir if needed and writes a temporary file to it
+func checkCacheDirectory(dir string) doctorResult {
+	hints := []string{
+		"Fix the permissions of " + dir,
+		"Or pass --cache-dir (GH_STAR_SEARCH_CACHE_DIR) to use a writable directory",
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return doctorResult{Err: fmt.Errorf("failed to create %s: %w", dir, err), Hints: hints}
+	}
+
+	file, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return doctorResult{Err: fmt.Errorf("%s is not writable: %w", dir, err), Hints: hints}
+	}
+
+	file.Close()
+	os.Remove(file.Name())
+
+	return doctorResult{Detail: dir + " is writable"}
+}
+
+// checkSummarizationBackend finds uv, which runs the summarization and
+// embedding models, and asks it for its version
+func checkSummarizationBackend(ctx context.Context) doctorResult {
+	hints := []string{
+		"Install uv: https://docs.astral.sh/uv/getting-started/installation/",
+		"Only summarize, embed and vector search need it; sync and fuzzy search work without it",
+	}
+
+	uvPath, err := python.FindUV()
+	if err != nil {
+		return doctorResult{Err: stderrors.New("uv not found in PATH"), Hints: hints}
+	}
+
+	output, err := exec.CommandContext(ctx, uvPath, "--version").Output()
+	if err != nil {
+		return doctorResult{Err: fmt.Errorf("%s does not respond: %w", uvPath, err), Hints: hints}
+	}
+
+	return doctorResult{Detail: strings.TrimSpace(string(output))}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunDoctor(t *testing.T) {
+	pass := doctorCheck{Name: "Passing", Run: func(context.Context) doctorResult {
+		return doctorResult{Detail: "all good"}
+	}}
+	fail := doctorCheck{Name: "Failing", Run: func(context.Context) doctorResult {
+		return doctorResult{Err: stderrors.New("broken"), Hints: []string{"fix it"}}
+	}}
+
+	var out bytes.Buffer
+
+	require.NoError(t, runDoctor(context.Background(), &out, []doctorCheck{pass}, false))
+	assert.Equal(t, "✓ Passing: all good\n\nAll checks passed.\n", out.String())
+
+	out.Reset()
+
+	err := runDoctor(context.Background(), &out, []doctorCheck{fail, pass}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 checks failed")
+	assert.Equal(t, "✗ Failing: broken\n    → fix it\n✓ Passing: all good\n", out.String(),
+		"every check runs after a failure")
+
+	out.Reset()
+
+	require.Error(t, runDoctor(context.Background(), &out, []doctorCheck{fail, pass}, true))
+
+	var checks []doctorJSONCheck
+	require.NoError(t, json.Unmarshal(out.Bytes(), &checks))
+	assert.Equal(t, []doctorJSONCheck{
+		{Name: "Failing", Error: "broken", Hints: []string{"fix it"}},
+		{Name: "Passing", OK: true, Detail: "all good"},
+	}, checks)
+}
+
+func TestCheckDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := &config.DatabaseConfig{QueryTimeout: "30s"}
+
+	cfg.Path = filepath.Join(dir, "missing.db")
+	result := checkDatabase(ctx, cfg)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Hints[0], "sync")
+	assert.NoFileExists(t, cfg.Path, "a missing database is not created")
+
+	cfg.Path = filepath.Join(dir, "stale.db")
+	repo, err := storage.NewDuckDBRepository(cfg.Path)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	result = checkDatabase(ctx, cfg)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "pending migration")
+
+	repo, err = storage.NewDuckDBRepository(cfg.Path)
+	require.NoError(t, err)
+	require.NoError(t, repo.Initialize(ctx))
+	require.NoError(t, repo.Close())
+
+	result = checkDatabase(ctx, cfg)
+	require.NoError(t, result.Err)
+
+	latest, err := storage.LatestSchemaVersion()
+	require.NoError(t, err)
+	assert.Contains(t, result.Detail, fmt.Sprintf("schema version %d", latest))
+}
+
+func TestCheckCacheDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	result := checkCacheDirectory(dir)
+	require.NoError(t, result.Err)
+	assert.DirExists(t, dir, "a missing cache directory is created")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the probe file is removed")
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	result = checkCacheDirectory(file)
+	require.Error(t, result.Err)
+	assert.NotEmpty(t, result.Hints)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+)
+
+func EmbedCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "embed",
+		Usage: "Generate vector embeddings for stored repositories",
+		Description: `Embed each stored repository that has no embedding yet, using the local
+embedding model (managed by uv). The embedded text is the repository name,
+summary, description and topics. Embeddings power 'query --mode vector' and
+'query --near'; 'sync --embed' runs the same step after a sync.
+
+--chunks also embeds the content chunks (README sections, docs) stored by
+sync, one batch per repository, for 'query --chunks'.
+
+Examples:
+  gh star-search embed
+  gh star-search embed --force   # re-embed every repository
+  gh star-search embed --chunks`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Re-embed every repository, not just those without an embedding",
+			},
+			&cli.BoolFlag{
+				Name:  "chunks",
+				Usage: "Also embed stored content chunks for 'query --chunks'",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+		},
+		Action: runEmbed,
+	}
+}
+
+func runEmbed(ctx context.Context, cmd *cli.Command) error {
+	cfg := getConfigFromContext(ctx)
+	applyLockWait(cfg, cmd)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	err = generateEmbeddings(ctx, repo, cfg, cmd.Bool("force"))
+	if err == nil && cmd.Bool("chunks") {
+		err = generateChunkEmbeddings(ctx, repo, cfg, cmd.Bool("force"))
+	}
+
+	if stderrors.Is(err, ErrPartialFailure) {
+		return err
+	}
+
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeInternal, "failed to generate embeddings").
+			WithSuggestion("Embedding runs a local model through uv; install it from https://docs.astral.sh/uv/")
+	}
+
+	return nil
+}
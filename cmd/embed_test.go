@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+// fakeEmbeddingProvider returns a fixed vector, or an error for inputs containing failOn
+type fakeEmbeddingProvider struct {
+	vector     []float32
+	dimensions int
+	failOn     string
+}
+
+func (p *fakeEmbeddingProvider) GenerateEmbedding(_ context.Context, text string) ([]float32, error) {
+	if p.failOn != "" && strings.Contains(text, p.failOn) {
+		return nil, errors.New("model error")
+	}
+
+	return p.vector, nil
+}
+
+func (p *fakeEmbeddingProvider) GetDimensions() int { return p.dimensions }
+func (p *fakeEmbeddingProvider) IsEnabled() bool    { return true }
+func (p *fakeEmbeddingProvider) GetName() string    { return "fake" }
+
+func TestEmbedRepositories(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, name := range []string{"user/ok", "user/broken"} {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(testutil.WithFullName(name)), nil,
+		)))
+	}
+
+	provider := &fakeEmbeddingProvider{
+		vector:     []float32{0.1, 0.2, 0.3},
+		dimensions: 3,
+		failOn:     "user/broken",
+	}
+
+	successful, failed := embedRepositories(ctx, repo, provider, []string{"user/ok", "user/broken", "user/missing"})
+	assert.Equal(t, 1, successful)
+	assert.Equal(t, 2, failed)
+
+	stored, err := repo.GetRepository(ctx, "user/ok")
+	require.NoError(t, err)
+	assert.Len(t, stored.RepoEmbedding, 3)
+
+	pending, err := repo.GetRepositoriesNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/broken"}, pending)
+}
+
+func TestEmbedRepositories_RejectsWrongDimensions(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(testutil.WithFullName("user/ok")), nil,
+	)))
+
+	provider := &fakeEmbeddingProvider{vector: []float32{0.1, 0.2}, dimensions: 384}
+
+	successful, failed := embedRepositories(ctx, repo, provider, []string{"user/ok"})
+	assert.Equal(t, 0, successful)
+	assert.Equal(t, 1, failed)
+
+	stored, err := repo.GetRepository(ctx, "user/ok")
+	require.NoError(t, err)
+	assert.Empty(t, stored.RepoEmbedding)
+}
+
+// fakeChunkEmbedder returns a fixed vector per text, or fails for batches
+// containing failOn
+type fakeChunkEmbedder struct {
+	vector []float32
+	failOn string
+	calls  int
+}
+
+func (e *fakeChunkEmbedder) GenerateEmbeddings(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if e.failOn != "" && text == e.failOn {
+			return nil, errors.New("model error")
+		}
+
+		vectors[i] = e.vector
+	}
+
+	return vectors, nil
+}
+
+func (e *fakeChunkEmbedder) GetDimensions() int { return len(e.vector) }
+
+func TestEmbedChunks(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	chunksByRepo := map[string][]string{
+		"user/ok":     {"install", "usage"},
+		"user/broken": {"boom"},
+	}
+	for name, contents := range chunksByRepo {
+		chunks := make([]processor.ContentChunk, len(contents))
+		for i, content := range contents {
+			chunks[i] = processor.ContentChunk{Source: "README.md", Type: "readme", Content: content}
+		}
+
+		require.NoError(t, repo.UpsertRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(testutil.WithFullName(name)), nil,
+		), storage.UpsertOptions{ReplaceChunks: true, Chunks: chunks}))
+	}
+
+	pending, err := repo.GetChunksNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+
+	embedder := &fakeChunkEmbedder{vector: []float32{0.1, 0.2, 0.3}, failOn: "boom"}
+
+	successful, failed := embedChunks(ctx, repo, embedder, pending)
+	assert.Equal(t, 1, successful)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 2, embedder.calls, "chunks are embedded in one batch per repository")
+
+	pending, err = repo.GetChunksNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "user/broken", pending[0].FullName)
+}
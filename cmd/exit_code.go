@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// Process exit codes, so scripts can tell failure modes apart, e.g. retry later
+// after a rate limit but alert on an authentication failure
+const (
+	ExitOK             = 0
+	ExitError          = 1 // Any failure without a more specific code
+	ExitAuth           = 2 // GitHub authentication missing or rejected
+	ExitRateLimited    = 3 // GitHub rate limit hit and not waited out
+	ExitDatabaseLocked = 4 // Database held by another gh star-search process
+	ExitPartial        = 5 // Command finished, but some repositories failed
+)
+
+// ErrPartialFailure is returned by commands that completed but failed to
+// process some repositories; it maps to ExitPartial
+var ErrPartialFailure = stderrors.New("partial failure")
+
+// ExitCode returns the process exit code for an error returned by a command
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case stderrors.Is(err, storage.ErrDatabaseLocked):
+		return ExitDatabaseLocked
+	case errors.IsType(err, errors.ErrTypeAuth) || github.IsAuthError(err):
+		return ExitAuth
+	case errors.IsType(err, errors.ErrTypeRateLimit) || github.IsRateLimitError(err):
+		return ExitRateLimited
+	case stderrors.Is(err, ErrPartialFailure):
+		return ExitPartial
+	default:
+		return ExitError
+	}
+}
+
+// partialFailure returns ErrPartialFailure when any repositories failed to
+// complete action (e.g. "process"), else nil
+func partialFailure(failed int, action string) error {
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d repositories failed to %s", ErrPartialFailure, failed, action)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestExitCode(t *testing.T) {
+	rateLimited := &api.HTTPError{StatusCode: http.StatusForbidden, Headers: http.Header{}}
+	rateLimited.Headers.Set("X-RateLimit-Remaining", "0")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: ExitOK},
+		{name: "generic error", err: stderrors.New("boom"), want: ExitError},
+		{name: "validation error", err: errors.New(errors.ErrTypeValidation, "bad flag"), want: ExitError},
+		{name: "database locked", err: databaseOpenError(storage.ErrDatabaseLocked), want: ExitDatabaseLocked},
+		{
+			name: "wrapped database locked",
+			err:  fmt.Errorf("failed to initialize sync service: %w", databaseOpenError(storage.ErrDatabaseLocked)),
+			want: ExitDatabaseLocked,
+		},
+		{name: "structured auth error", err: errors.New(errors.ErrTypeAuth, "no token"), want: ExitAuth},
+		{
+			name: "GitHub 401",
+			err:  fmt.Errorf("failed to fetch starred repositories: %w", &api.HTTPError{StatusCode: http.StatusUnauthorized}),
+			want: ExitAuth,
+		},
+		{name: "structured rate limit error", err: errors.New(errors.ErrTypeRateLimit, "slow down"), want: ExitRateLimited},
+		{name: "GitHub rate limit", err: fmt.Errorf("sync: %w", rateLimited), want: ExitRateLimited},
+		{name: "partial failure", err: partialFailure(3, "process"), want: ExitPartial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartialFailure(t *testing.T) {
+	if err := partialFailure(0, "process"); err != nil {
+		t.Errorf("partialFailure(0) = %v, want nil", err)
+	}
+
+	err := partialFailure(2, "embed")
+	if !stderrors.Is(err, ErrPartialFailure) {
+		t.Fatalf("partialFailure(2) = %v, want ErrPartialFailure", err)
+	}
+
+	if want := "partial failure: 2 repositories failed to embed"; err.Error() != want {
+		t.Errorf("partialFailure(2) = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// exportFormat identifies the header line that starts every export
+const exportFormat = "gh-star-search-export"
+
+// exportHeader is the first line of an export. SchemaVersion is the database
+// schema the records were read from, so import can refuse newer exports.
+type exportHeader struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+}
+
+// exportPageSize is how many repositories are read from the database at a time,
+// so memory stays flat no matter how many repositories are starred
+const exportPageSize = 100
+
+func ExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export the local database as newline-delimited JSON",
+		Description: `Write every stored repository as one JSON object per line (JSONL), including
+metrics, contributors, summaries and embeddings. Repositories are read a page
+at a time and streamed out, so large indexes export in constant memory.
+
+The first line is a header with the database schema version, checked by
+'gh star-search import' when restoring the export.
+
+Examples:
+  gh star-search export > stars.jsonl
+  gh star-search export --output stars.jsonl.gz --gzip
+  gh star-search export | jq -r 'select(.language == "Go") | .full_name'`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Write to this file instead of stdout",
+			},
+			&cli.BoolFlag{
+				Name:  "gzip",
+				Usage: "Compress the output with gzip",
+			},
+		},
+		Action: runExport,
+	}
+}
+
+func runExport(ctx context.Context, cmd *cli.Command) error {
+	output := cmd.String("output")
+	if output == "" && cmd.Bool("gzip") && stdoutIsTerminal() {
+		return errors.New(errors.ErrTypeValidation, "refusing to write gzip data to a terminal").
+			WithSuggestion("Pass --output <file> or redirect stdout")
+	}
+
+	repo, err := openStorage(ctx, getConfigFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	count, err := writeExport(ctx, repo, output, cmd.Bool("gzip"))
+	if err != nil {
+		return err
+	}
+
+	// Keep stdout clean for the exported data
+	fmt.Fprintf(os.Stderr, "Exported %d repositories\n", count)
+
+	return nil
+}
+
+// writeExport exports to path, or stdout when path is empty, optionally gzipped
+func writeExport(ctx context.Context, repo storage.Repository, path string, compress bool) (int, error) {
+	var (
+		out  io.Writer = os.Stdout
+		file *os.File
+	)
+
+	if path != "" {
+		var err error
+
+		file, err = os.Create(path)
+		if err != nil {
+			return 0, errors.Wrap(err, errors.ErrTypeFileSystem, "failed to create export file")
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(out)
+		out = gz
+	}
+
+	count, err := exportRepositories(ctx, repo, out)
+	if err != nil {
+		return count, errors.Wrap(err, errors.ErrTypeDatabase, "failed to export repositories")
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return count, errors.Wrap(err, errors.ErrTypeFileSystem, "failed to finish gzip output")
+		}
+	}
+
+	if file != nil {
+		if err := file.Close(); err != nil {
+			return count, errors.Wrap(err, errors.ErrTypeFileSystem, "failed to write export file")
+		}
+	}
+
+	return count, nil
+}
+
+// exportRepositories streams the header and then every stored repository to w
+// as JSONL, paging through the database by name so the order is stable
+func exportRepositories(ctx context.Context, repo storage.Repository, w io.Writer) (int, error) {
+	schemaVersion, err := storage.LatestSchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	count := 0
+
+	header := exportHeader{Format: exportFormat, SchemaVersion: schemaVersion, ExportedAt: time.Now().UTC()}
+	if err := encoder.Encode(header); err != nil {
+		return 0, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		repos, err := repo.ListRepositoriesSorted(ctx, storage.ListOptions{
+			Limit:  exportPageSize,
+			Offset: offset,
+			Sort:   storage.ListSortName,
+		})
+		if err != nil {
+			return count, err
+		}
+
+		for i := range repos {
+			if err := encoder.Encode(&repos[i]); err != nil {
+				return count, fmt.Errorf("failed to write %s: %w", repos[i].FullName, err)
+			}
+
+			count++
+		}
+
+		if len(repos) < exportPageSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// newExportMock returns a mock holding n repositories, enough to span several export pages
+func newExportMock(n int) *MockRepository {
+	repos := make([]storage.StoredRepo, n)
+	for i := range repos {
+		repos[i] = storage.StoredRepo{
+			FullName:     fmt.Sprintf("user/repo%03d", i),
+			Purpose:      "summary",
+			Contributors: []storage.Contributor{{Login: "alice", Contributions: 3}},
+		}
+	}
+
+	return &MockRepository{repos: repos}
+}
+
+// decodeExport parses JSONL into the exported repositories, checking the header line
+func decodeExport(t *testing.T, r io.Reader) []storage.StoredRepo {
+	t.Helper()
+
+	var repos []storage.StoredRepo
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		t.Fatal("export is missing the header line")
+	}
+
+	var header exportHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatalf("header is not JSON: %v\n%s", err, scanner.Text())
+	}
+
+	latest, _ := storage.LatestSchemaVersion()
+	if header.Format != exportFormat || header.SchemaVersion != latest {
+		t.Errorf("header = %+v, want format %q and schema version %d", header, exportFormat, latest)
+	}
+
+	for scanner.Scan() {
+		var repo storage.StoredRepo
+		if err := json.Unmarshal(scanner.Bytes(), &repo); err != nil {
+			t.Fatalf("line is not a JSON repository: %v\n%s", err, scanner.Text())
+		}
+
+		repos = append(repos, repo)
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	return repos
+}
+
+func TestExportRepositories(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+	}{
+		{name: "empty database", count: 0},
+		{name: "partial page", count: 7},
+		{name: "exact page", count: exportPageSize},
+		{name: "several pages", count: 2*exportPageSize + 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newExportMock(tt.count)
+
+			var buf bytes.Buffer
+
+			count, err := exportRepositories(context.Background(), mock, &buf)
+			if err != nil {
+				t.Fatalf("exportRepositories() error = %v", err)
+			}
+
+			if count != tt.count {
+				t.Errorf("exportRepositories() = %d, want %d", count, tt.count)
+			}
+
+			repos := decodeExport(t, &buf)
+			if len(repos) != tt.count {
+				t.Fatalf("exported %d lines, want %d", len(repos), tt.count)
+			}
+
+			for i, repo := range repos {
+				if repo.FullName != mock.repos[i].FullName {
+					t.Errorf("line %d = %s, want %s", i, repo.FullName, mock.repos[i].FullName)
+				}
+
+				if repo.Purpose != "summary" || len(repo.Contributors) != 1 {
+					t.Errorf("line %d lost summary or contributors: %+v", i, repo)
+				}
+			}
+
+			if tt.count > 0 && mock.listOptions.Sort != storage.ListSortName {
+				t.Errorf("export sorted by %q, want name for a stable order", mock.listOptions.Sort)
+			}
+		})
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{name: "plain file", compress: false},
+		{name: "gzip file", compress: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "stars.jsonl")
+
+			count, err := writeExport(context.Background(), newExportMock(3), path, tt.compress)
+			if err != nil {
+				t.Fatalf("writeExport() error = %v", err)
+			}
+
+			if count != 3 {
+				t.Errorf("writeExport() = %d, want 3", count)
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("failed to open export: %v", err)
+			}
+			defer file.Close()
+
+			var r io.Reader = file
+
+			if tt.compress {
+				gz, err := gzip.NewReader(file)
+				if err != nil {
+					t.Fatalf("export is not gzip: %v", err)
+				}
+				defer gz.Close()
+
+				r = gz
+			}
+
+			if repos := decodeExport(t, r); len(repos) != 3 {
+				t.Errorf("exported %d lines, want 3", len(repos))
+			}
+		})
+	}
+}
+
+func TestWriteExport_CreateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "stars.jsonl")
+
+	if _, err := writeExport(context.Background(), newExportMock(1), path, false); err == nil {
+		t.Error("writeExport() should fail when the file cannot be created")
+	}
+}
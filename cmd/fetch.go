@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+)
+
+func FetchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "fetch",
+		Usage: "Fetch and print a single file from a repository (debugging)",
+		Description: `Fetch one file through the GitHub contents API, decode it the same way sync
+does, and print it. Chunking and storage are bypassed, so this shows exactly what
+GitHub returns for a path. Binary (non-UTF-8) content is shown as a hex dump.
+
+Examples:
+  gh star-search fetch cli/cli README.md
+  gh star-search fetch --raw cli/cli docs/logo.png > logo.png`,
+		ArgsUsage: "<owner/repo> <path>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "Write only the decoded bytes, without the header or hex dump",
+			},
+		},
+		Action: runFetch,
+	}
+}
+
+func runFetch(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) != 2 {
+		return errors.New(errors.ErrTypeValidation, "expected a repository and a file path")
+	}
+
+	fullName := args[0]
+	if err := validateRepositoryName(fullName); err != nil {
+		return err
+	}
+
+	githubClient, err := github.NewClient()
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeAuth, "failed to create GitHub client").
+			WithSuggestion("Run 'gh auth login' to authenticate")
+	}
+
+	return RunFetchWithClient(ctx, githubClient, fullName, args[1], cmd.Bool("raw"), os.Stdout)
+}
+
+// RunFetchWithClient fetches a single file and writes it to w: a short header
+// followed by the text, or a hex dump for non-UTF-8 content. With raw set only
+// the decoded bytes are written.
+func RunFetchWithClient(
+	ctx context.Context,
+	client github.Client,
+	fullName, path string,
+	raw bool,
+	w io.Writer,
+) error {
+	path = strings.TrimPrefix(path, "/")
+
+	contents, err := client.GetRepositoryContent(ctx, github.Repository{FullName: fullName}, []string{path})
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeGitHubAPI, "failed to fetch content")
+	}
+
+	if len(contents) == 0 {
+		return errors.Newf(errors.ErrTypeNotFound, "%s not found in %s", path, fullName).
+			WithSuggestion("Paths are case-sensitive and relative to the repository root")
+	}
+
+	file := contents[0]
+
+	decoded, err := processor.DecodeContent(file)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeValidation, "failed to decode content")
+	}
+
+	if raw {
+		_, err = w.Write(decoded)
+		return err
+	}
+
+	encoding := file.Encoding
+	if encoding == "" {
+		encoding = "none"
+	}
+
+	isText := utf8.Valid(decoded)
+
+	fmt.Fprintf(w, "Path: %s\n", file.Path)
+	fmt.Fprintf(w, "Size: %d bytes (%d decoded)\n", file.Size, len(decoded))
+	fmt.Fprintf(w, "Encoding: %s\n", encoding)
+	fmt.Fprintf(w, "SHA: %s\n", file.SHA)
+
+	if isText {
+		fmt.Fprintln(w, "UTF-8: valid")
+	} else {
+		fmt.Fprintln(w, "UTF-8: invalid (sync skips this file; showing hex dump)")
+	}
+
+	fmt.Fprintln(w, "---")
+
+	if !isText {
+		_, err = io.WriteString(w, hex.Dump(decoded))
+		return err
+	}
+
+	_, err = w.Write(decoded)
+	if err == nil && len(decoded) > 0 && decoded[len(decoded)-1] != '\n' {
+		_, err = fmt.Fprintln(w)
+	}
+
+	return err
+}
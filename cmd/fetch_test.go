@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestRunFetchWithClient(t *testing.T) {
+	binary := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}
+
+	client := &MockGitHubClient{
+		content: map[string][]github.Content{
+			"owner/text": {testutil.NewTestContent("README.md", "# Title\nBody")},
+			"owner/binary": {{
+				Path:     "logo.png",
+				Encoding: "base64",
+				Content:  base64.StdEncoding.EncodeToString(binary),
+				Size:     len(binary),
+			}},
+		},
+		errors: map[string]error{},
+	}
+
+	tests := []struct {
+		name        string
+		repo        string
+		raw         bool
+		contains    []string
+		notContains []string
+		exact       string
+		errType     errors.ErrorType
+	}{
+		{
+			name:     "text with header",
+			repo:     "owner/text",
+			contains: []string{"Path: README.md", "Encoding: base64", "UTF-8: valid", "---\n# Title\nBody\n"},
+		},
+		{
+			name:  "raw text",
+			repo:  "owner/text",
+			raw:   true,
+			exact: "# Title\nBody",
+		},
+		{
+			name:        "binary shown as hex",
+			repo:        "owner/binary",
+			contains:    []string{"UTF-8: invalid", "89 50 4e 47 ff 00"},
+			notContains: []string{"PNG\xff"},
+		},
+		{
+			name:  "raw binary",
+			repo:  "owner/binary",
+			raw:   true,
+			exact: string(binary),
+		},
+		{
+			name:    "missing path",
+			repo:    "owner/empty",
+			errType: errors.ErrTypeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := RunFetchWithClient(context.Background(), client, tt.repo, "/README.md", tt.raw, &buf)
+			if tt.errType != "" {
+				if !errors.IsType(err, tt.errType) {
+					t.Fatalf("RunFetchWithClient() error = %v, want type %s", err, tt.errType)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RunFetchWithClient() unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			if tt.exact != "" && output != tt.exact {
+				t.Errorf("output = %q, want %q", output, tt.exact)
+			}
+
+			for _, expected := range tt.contains {
+				if !strings.Contains(output, expected) {
+					t.Errorf("output does not contain %q\nOutput: %s", expected, output)
+				}
+			}
+
+			for _, unexpected := range tt.notContains {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("output unexpectedly contains %q\nOutput: %s", unexpected, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// filterFlags are the structured filter flags shared by query, list and saved
+// searches. verb completes each usage line, e.g. "return" or "list".
+func filterFlags(verb string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "topic",
+			Usage: fmt.Sprintf("Only %s repositories with this GitHub topic (repeat to require several)", verb),
+		},
+		&cli.StringFlag{
+			Name:  "language",
+			Usage: fmt.Sprintf("Only %s repositories whose primary language is this (case-insensitive)", verb),
+		},
+		&cli.IntFlag{
+			Name:  "min-stars",
+			Usage: fmt.Sprintf("Only %s repositories with at least this many stars", verb),
+		},
+		&cli.IntFlag{
+			Name:  "max-stars",
+			Usage: fmt.Sprintf("Only %s repositories with at most this many stars", verb),
+		},
+		&cli.StringFlag{
+			Name:  "updated-after",
+			Usage: fmt.Sprintf("Only %s repositories updated on or after this date (YYYY-MM-DD or RFC3339)", verb),
+		},
+		&cli.StringSliceFlag{
+			Name:  "license",
+			Usage: fmt.Sprintf("Only %s repositories under this SPDX license, e.g. MIT (repeat to allow several)", verb),
+		},
+	}
+}
+
+// searchFilterFlags are the result filters and matching options of query that
+// saved searches and the query history keep with the query
+func searchFilterFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.FloatFlag{
+			Name:  "min-score",
+			Usage: "Drop results scoring below this (boosted BM25 score in fuzzy mode, cosine similarity in vector mode; default: search.min_score)",
+		},
+		&cli.StringFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Only return repositories with this local tag",
+		},
+		&cli.StringFlag{
+			Name:    "keyword",
+			Aliases: []string{"k"},
+			Usage:   "Only return repositories with this README-derived keyword",
+		},
+		&cli.StringFlag{
+			Name:  "content-language",
+			Usage: "Only return repositories whose README is in this language (ISO 639-1 code, e.g. en, zh)",
+		},
+		&cli.StringFlag{
+			Name:  "readme-format",
+			Usage: "Only return repositories whose README is markdown, rst or text; 'none' for repositories without a README",
+		},
+		&cli.IntFlag{
+			Name:    "max-per-owner",
+			Aliases: []string{"max-repos-per-owner"},
+			Usage:   "Keep at most N results from any one owner, filling the limit with other owners (0 for no cap)",
+		},
+		&cli.BoolFlag{
+			Name:  "case-sensitive",
+			Usage: "Only return results containing every term with exact case and accents (fuzzy mode only)",
+		},
+		&cli.BoolFlag{
+			Name:  "chunks",
+			Usage: "Match README sections and docs instead of whole repositories, showing the best match (vector similarity; run 'embed --chunks' first)",
+		},
+	}
+}
+
+// parseFilterFlags builds a storage filter from the flags in filterFlags
+func parseFilterFlags(cmd *cli.Command) (storage.Filter, error) {
+	filter := storage.Filter{
+		Topics:   normalizeTopics(cmd.StringSlice("topic")),
+		Language: strings.TrimSpace(cmd.String("language")),
+		MinStars: int(cmd.Int("min-stars")),
+		MaxStars: int(cmd.Int("max-stars")),
+		Licenses: normalizeLicenses(cmd.StringSlice("license")),
+	}
+
+	if filter.MinStars < 0 || filter.MaxStars < 0 {
+		return storage.Filter{}, errors.New(errors.ErrTypeValidation, "--min-stars and --max-stars must not be negative")
+	}
+
+	if filter.MaxStars > 0 && filter.MinStars > filter.MaxStars {
+		return storage.Filter{}, errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("--min-stars (%d) is greater than --max-stars (%d)", filter.MinStars, filter.MaxStars))
+	}
+
+	if value := strings.TrimSpace(cmd.String("updated-after")); value != "" {
+		updatedAfter, err := parseFilterDate(value)
+		if err != nil {
+			return storage.Filter{}, err
+		}
+
+		filter.UpdatedAfter = updatedAfter
+	}
+
+	return filter, nil
+}
+
+// parseSearchFilterFlags builds the search filters from the flags in
+// searchFilterFlags and filterFlags. MinScore stays nil unless --min-score is
+// given, so search.min_score applies.
+func parseSearchFilterFlags(cmd *cli.Command) (storage.SearchFilters, error) {
+	filter, err := parseFilterFlags(cmd)
+	if err != nil {
+		return storage.SearchFilters{}, err
+	}
+
+	filters := storage.SearchFilters{
+		Tag:             strings.TrimSpace(cmd.String("tag")),
+		Filter:          filter,
+		Keyword:         strings.TrimSpace(cmd.String("keyword")),
+		ContentLanguage: strings.TrimSpace(cmd.String("content-language")),
+		ReadmeFormat:    strings.ToLower(strings.TrimSpace(cmd.String("readme-format"))),
+		MaxPerOwner:     int(cmd.Int("max-per-owner")),
+		CaseSensitive:   cmd.Bool("case-sensitive"),
+		Chunks:          cmd.Bool("chunks"),
+	}
+
+	if cmd.IsSet("min-score") {
+		minScore := cmd.Float("min-score")
+		filters.MinScore = &minScore
+	}
+
+	if err := validateSearchFilters(filters); err != nil {
+		return storage.SearchFilters{}, err
+	}
+
+	return filters, nil
+}
+
+// validateSearchFilters checks the values of filters that do not depend on the
+// search mode
+func validateSearchFilters(filters storage.SearchFilters) error {
+	if filters.MinScore != nil && *filters.MinScore < 0 {
+		return errors.New(errors.ErrTypeValidation, "--min-score must not be negative")
+	}
+
+	if err := validateReadmeFormat(filters.ReadmeFormat); err != nil {
+		return err
+	}
+
+	if filters.MaxPerOwner < 0 {
+		return errors.New(errors.ErrTypeValidation, "--max-per-owner must not be negative")
+	}
+
+	return nil
+}
+
+// parseFilterDate accepts an RFC3339 timestamp or a YYYY-MM-DD date, read as
+// midnight UTC
+func parseFilterDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, errors.New(errors.ErrTypeValidation, fmt.Sprintf("invalid date '%s'", value)).
+		WithSuggestion("Use YYYY-MM-DD (e.g. 2024-01-31) or RFC3339 (e.g. 2024-01-31T12:00:00Z)")
+}
+
+// normalizeTopics lowercases and trims --topic values, dropping empty ones.
+// GitHub topics are always lowercase.
+func normalizeTopics(values []string) []string {
+	var topics []string
+
+	for _, value := range values {
+		if topic := strings.ToLower(strings.TrimSpace(value)); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	return topics
+}
+
+// normalizeLicenses trims --license values to their canonical SPDX casing,
+// dropping empty ones
+func normalizeLicenses(values []string) []string {
+	var licenses []string
+
+	for _, value := range values {
+		if license := storage.CanonicalSPDX(value); license != "" {
+			licenses = append(licenses, license)
+		}
+	}
+
+	return licenses
+}
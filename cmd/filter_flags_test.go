@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestParseFilterFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    storage.Filter
+		wantErr string
+	}{
+		{name: "no flags", want: storage.Filter{}},
+		{
+			name: "every flag",
+			args: []string{
+				"--topic", " CLI ", "--topic", "go", "--language", "Rust",
+				"--min-stars", "10", "--max-stars", "10", "--updated-after", "2024-01-31",
+			},
+			want: storage.Filter{
+				Topics:       []string{"cli", "go"},
+				Language:     "Rust",
+				MinStars:     10,
+				MaxStars:     10,
+				UpdatedAfter: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "RFC3339 timestamp",
+			args: []string{"--updated-after", "2024-01-31T12:30:00+02:00"},
+			want: storage.Filter{UpdatedAfter: time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)},
+		},
+		{name: "minimum above maximum", args: []string{"--min-stars", "11", "--max-stars", "10"}, wantErr: "greater than --max-stars"},
+		{name: "negative stars", args: []string{"--min-stars", "-1"}, wantErr: "must not be negative"},
+		{name: "unparseable date", args: []string{"--updated-after", "31/01/2024"}, wantErr: "invalid date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got      storage.Filter
+				parseErr error
+			)
+
+			app := &cli.Command{
+				Name:  "app",
+				Flags: filterFlags("list"),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					got, parseErr = parseFilterFlags(cmd)
+					return nil
+				},
+			}
+
+			require.NoError(t, app.Run(context.Background(), append([]string{"app"}, tt.args...)))
+
+			if tt.wantErr != "" {
+				require.Error(t, parseErr)
+				assert.Contains(t, parseErr.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, parseErr)
+			assert.Equal(t, tt.want.Topics, got.Topics)
+			assert.Equal(t, tt.want.Language, got.Language)
+			assert.Equal(t, tt.want.MinStars, got.MinStars)
+			assert.Equal(t, tt.want.MaxStars, got.MaxStars)
+			assert.True(t, tt.want.UpdatedAfter.Equal(got.UpdatedAfter),
+				"UpdatedAfter = %v, want %v", got.UpdatedAfter, tt.want.UpdatedAfter)
+		})
+	}
+}
+
+func TestNormalizeTopics(t *testing.T) {
+	assert.Equal(t, []string{"cli", "go"}, normalizeTopics([]string{" CLI ", "", "go"}))
+	assert.Nil(t, normalizeTopics(nil))
+}
@@ -1,12 +1,9 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 
-	"github.com/urfave/cli/v3"
-
 	"github.com/KyleKing/gh-star-search/cmd"
 )
 
@@ -17,24 +14,5 @@ var (
 )
 
 func main() {
-	app := &cli.Command{
-		Name:    "gh-star-search",
-		Usage:   "Search your starred GitHub repositories using natural language",
-		Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
-		Commands: []*cli.Command{
-			cmd.SyncCommand(),
-			cmd.ListCommand(),
-			cmd.InfoCommand(),
-			cmd.StatsCommand(),
-			cmd.ClearCommand(),
-			cmd.QueryCommand(),
-			cmd.RelatedCommand(),
-			cmd.ConfigCommand(),
-		},
-	}
-
-	if err := app.Run(context.Background(), os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
+	os.Exit(cmd.Execute(os.Args, fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date)))
 }
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// DefaultHistoryLimit is the default number of history entries to display
+const DefaultHistoryLimit = 20
+
+func HistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "Show past search queries and re-run them",
+		Description: `Display the local log of query invocations (query text, mode, result count, and time).
+Use --rerun with an entry ID to execute a past query again.
+
+Examples:
+  gh star-search history --queries
+  gh star-search history --rerun 12`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "queries",
+				Value: true,
+				Usage: "Show the query history",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Value:   DefaultHistoryLimit,
+				Usage:   "Maximum number of history entries to display",
+			},
+			&cli.IntFlag{
+				Name:  "rerun",
+				Usage: "Re-execute the query with the given history ID",
+			},
+			&cli.BoolFlag{
+				Name:  "no-history",
+				Usage: "Do not record the re-executed query in the history",
+			},
+		},
+		Action: runHistory,
+	}
+}
+
+func runHistory(ctx context.Context, cmd *cli.Command) error {
+	cfg := getConfigFromContext(ctx)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if rerunID := cmd.Int("rerun"); rerunID > 0 {
+		entry, err := repo.GetQueryHistoryEntry(ctx, int64(rerunID))
+		// Release the database before the query opens its own connection
+		repo.Close()
+
+		if err != nil {
+			return errors.Wrap(err, errors.ErrTypeNotFound, "failed to load query history entry").
+				WithSuggestion("Run 'gh star-search history --queries' to list entry IDs")
+		}
+
+		return rerunQuery(ctx, cmd, *entry)
+	}
+	defer repo.Close()
+
+	if !cmd.Bool("queries") {
+		return nil
+	}
+
+	return RunHistoryWithStorage(ctx, int(cmd.Int("limit")), repo)
+}
+
+// RunHistoryWithStorage prints the most recent query history entries
+func RunHistoryWithStorage(ctx context.Context, limit int, repo storage.Repository) error {
+	if limit <= 0 {
+		return errors.New(errors.ErrTypeValidation, "limit must be positive")
+	}
+
+	entries, err := repo.ListQueryHistory(ctx, limit)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to list query history")
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No queries recorded yet.")
+		return nil
+	}
+
+	fmt.Printf("%-5s  %-16s  %-6s  %-7s  %s\n", "ID", "Executed", "Mode", "Results", "Query")
+
+	for _, entry := range entries {
+		fmt.Printf("%-5d  %-16s  %-6s  %-7d  %s\n",
+			entry.ID,
+			entry.ExecutedAt.Format("2006-01-02 15:04"),
+			entry.Mode,
+			entry.ResultCount,
+			entry.Query,
+		)
+	}
+
+	return nil
+}
+
+// rerunQuery executes a past query with its original mode, limit and filters
+func rerunQuery(ctx context.Context, cmd *cli.Command, entry storage.QueryHistoryEntry) error {
+	if err := validateQuery(entry.Query); err != nil {
+		return err
+	}
+
+	if err := validateQueryFlags(entry.Mode, entry.Limit, false, false); err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-running query #%d: %q (mode: %s, limit: %d)\n\n",
+		entry.ID, entry.Query, entry.Mode, entry.Limit)
+
+	return executeQuery(ctx, getConfigFromContext(ctx), queryRequest{
+		Query:     entry.Query,
+		Mode:      entry.Mode,
+		Limit:     entry.Limit,
+		Filters:   entry.Filters,
+		NoHistory: cmd.Bool("no-history"),
+	})
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  []storage.QueryHistoryEntry
+		limit    int
+		wantErr  bool
+		contains []string
+		excludes []string
+		// wantFirst is expected in the first row after the header
+		wantFirst string
+	}{
+		{
+			name:     "empty history",
+			limit:    DefaultHistoryLimit,
+			contains: []string{"No queries recorded yet."},
+		},
+		{
+			name: "lists newest first",
+			history: []storage.QueryHistoryEntry{
+				{Query: "web framework", Mode: "fuzzy", Limit: 10, ResultCount: 4},
+				{Query: "machine learning", Mode: "vector", Limit: 5, ResultCount: 2},
+			},
+			limit:     DefaultHistoryLimit,
+			contains:  []string{"ID", "Query", "web framework", "machine learning", "vector"},
+			wantFirst: "machine learning",
+		},
+		{
+			name: "respects limit",
+			history: []storage.QueryHistoryEntry{
+				{Query: "web framework", Mode: "fuzzy", Limit: 10},
+				{Query: "machine learning", Mode: "vector", Limit: 5},
+			},
+			limit:    1,
+			contains: []string{"machine learning"},
+			excludes: []string{"web framework"},
+		},
+		{
+			name:    "invalid limit",
+			limit:   0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{}
+			for _, entry := range tt.history {
+				_ = mockRepo.RecordQuery(context.Background(), entry)
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := RunHistoryWithStorage(context.Background(), tt.limit, mockRepo)
+
+			w.Close()
+
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+
+			_, _ = buf.ReadFrom(r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunHistoryWithStorage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			for _, expected := range tt.contains {
+				if !strings.Contains(output, expected) {
+					t.Errorf("output does not contain %q\nOutput: %s", expected, output)
+				}
+			}
+
+			for _, unexpected := range tt.excludes {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("output unexpectedly contains %q\nOutput: %s", unexpected, output)
+				}
+			}
+
+			if tt.wantFirst != "" {
+				lines := strings.Split(output, "\n")
+				if len(lines) < 2 || !strings.Contains(lines[1], tt.wantFirst) {
+					t.Errorf("expected first row to contain %q\nOutput: %s", tt.wantFirst, output)
+				}
+			}
+		})
+	}
+}
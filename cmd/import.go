@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// ImportStats counts the outcome of an import
+type ImportStats struct {
+	Added   int // Repositories not previously stored
+	Updated int // Stored repositories replaced under --overwrite
+	Skipped int // Stored repositories left untouched under --merge
+}
+
+func ImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Restore repositories from a JSONL export",
+		ArgsUsage: "<file>",
+		Description: `Read a file written by 'gh star-search export' (plain or gzipped, "-" for
+stdin) and store each repository with its metrics, summary and embedding,
+without contacting GitHub. Use it to move an index between machines.
+
+By default (--merge) repositories already in the database are kept as they
+are. --overwrite replaces them with the exported records. Exports from a newer
+schema than this build supports are refused.
+
+Examples:
+  gh star-search import stars.jsonl
+  gh star-search import --overwrite stars.jsonl.gz
+  ssh laptop gh star-search export | gh star-search import -`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "merge",
+				Usage: "Skip repositories that are already stored (default)",
+			},
+			&cli.BoolFlag{
+				Name:  "overwrite",
+				Usage: "Replace stored repositories with the exported records",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+		},
+		Action: runImport,
+	}
+}
+
+func runImport(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New(errors.ErrTypeValidation, "import takes exactly one file").
+			WithSuggestion("Pass the export file, or - to read stdin")
+	}
+
+	if cmd.Bool("merge") && cmd.Bool("overwrite") {
+		return errors.New(errors.ErrTypeValidation, "--merge and --overwrite cannot be combined")
+	}
+
+	input, err := openImportInput(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	cfg := getConfigFromContext(ctx)
+	applyLockWait(cfg, cmd)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	stats, err := importRepositories(ctx, repo, input, cmd.Bool("overwrite"))
+	if err != nil {
+		return err
+	}
+
+	if err := repo.RebuildFTSIndex(ctx); err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to rebuild search index")
+	}
+
+	fmt.Printf("Imported %d new repositories, updated %d, skipped %d already stored\n",
+		stats.Added, stats.Updated, stats.Skipped)
+
+	return nil
+}
+
+// openImportInput opens path, or stdin for "-", transparently decompressing gzip
+func openImportInput(path string) (io.ReadCloser, error) {
+	var file io.ReadCloser = os.Stdin
+
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrTypeFileSystem, "failed to open import file")
+		}
+
+		file = f
+	}
+
+	buffered := bufio.NewReader(file)
+
+	// gzip streams start with the magic bytes 1f 8b
+	if magic, _ := buffered.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrap(err, errors.ErrTypeValidation, "failed to read gzip import file")
+		}
+
+		return readCloser{Reader: gz, closers: []io.Closer{gz, file}}, nil
+	}
+
+	return readCloser{Reader: buffered, closers: []io.Closer{file}}, nil
+}
+
+// readCloser closes every wrapped reader, innermost first
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r readCloser) Close() error {
+	var first error
+
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// importRepositories stores each exported repository read from r. Existing
+// repositories are skipped unless overwrite is set. Each repository is written
+// in its own transaction, so an interrupted import can be resumed by running it
+// again.
+func importRepositories(
+	ctx context.Context,
+	repo storage.Repository,
+	r io.Reader,
+	overwrite bool,
+) (*ImportStats, error) {
+	existing, err := storedRepositoryNames(ctx, repo)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeDatabase, "failed to list stored repositories")
+	}
+
+	stats := &ImportStats{}
+	reader := bufio.NewReader(r)
+
+	for lineNumber := 1; ; lineNumber++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return stats, errors.Wrap(readErr, errors.ErrTypeFileSystem, "failed to read import file")
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if lineNumber == 1 {
+				isHeader, err := checkExportHeader(line)
+				if err != nil {
+					return stats, err
+				}
+
+				if isHeader {
+					continue
+				}
+
+				fmt.Fprintln(os.Stderr, "Warning: import file has no export header; schema compatibility is not checked")
+			}
+
+			if err := importLine(ctx, repo, line, overwrite, existing, stats); err != nil {
+				return stats, errors.Wrapf(err, errors.ErrTypeValidation, "failed to import line %d", lineNumber)
+			}
+		}
+
+		if readErr == io.EOF {
+			return stats, nil
+		}
+	}
+}
+
+// checkExportHeader reports whether line is an export header, and fails when the
+// export was written by a newer schema than this build knows
+func checkExportHeader(line []byte) (bool, error) {
+	var header exportHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Format != exportFormat {
+		return false, nil //nolint:nilerr // Not a header; the line is parsed as a record
+	}
+
+	latest, err := storage.LatestSchemaVersion()
+	if err != nil {
+		return true, errors.Wrap(err, errors.ErrTypeInternal, "failed to read the schema version")
+	}
+
+	if header.SchemaVersion > latest {
+		return true, errors.Newf(errors.ErrTypeValidation,
+			"export uses schema version %d, newer than the %d supported by this build",
+			header.SchemaVersion, latest).
+			WithSuggestion("Upgrade gh star-search to the version that wrote the export")
+	}
+
+	return true, nil
+}
+
+// importLine stores one exported repository
+func importLine(
+	ctx context.Context,
+	repo storage.Repository,
+	line []byte,
+	overwrite bool,
+	existing map[string]bool,
+	stats *ImportStats,
+) error {
+	var stored storage.StoredRepo
+	if err := json.Unmarshal(line, &stored); err != nil {
+		return fmt.Errorf("invalid repository record: %w", err)
+	}
+
+	if err := validateRepositoryName(stored.FullName); err != nil {
+		return err
+	}
+
+	key := strings.ToLower(stored.FullName)
+	if existing[key] && !overwrite {
+		stats.Skipped++
+		return nil
+	}
+
+	opts := storage.UpsertOptions{Embedding: stored.RepoEmbedding, KeepSyncTime: true}
+	if metrics, ok := storedMetrics(stored); ok {
+		opts.Metrics = &metrics
+	}
+
+	if stored.Purpose != "" {
+		opts.Summary = &storage.RepositorySummary{
+			Purpose:     stored.Purpose,
+			GeneratedAt: stored.SummaryGeneratedAt,
+			Version:     stored.SummaryVersion,
+		}
+	}
+
+	if err := repo.UpsertRepository(ctx, storedToProcessedRepo(stored), opts); err != nil {
+		return err
+	}
+
+	if existing[key] {
+		stats.Updated++
+	} else {
+		stats.Added++
+		existing[key] = true
+	}
+
+	return nil
+}
+
+// storedRepositoryNames returns the lowercased names of every stored repository
+func storedRepositoryNames(ctx context.Context, repo storage.Repository) (map[string]bool, error) {
+	names := make(map[string]bool)
+
+	for offset := 0; ; offset += exportPageSize {
+		repos, err := repo.ListRepositories(ctx, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range repos {
+			names[strings.ToLower(r.FullName)] = true
+		}
+
+		if len(repos) < exportPageSize {
+			return names, nil
+		}
+	}
+}
+
+// storedToProcessedRepo converts an exported repository back into the form the
+// storage layer writes, keeping its sync time and content fields. The content
+// hash is left empty: exports carry no chunks, so the next sync must treat the
+// content as changed and chunk it.
+func storedToProcessedRepo(stored storage.StoredRepo) processor.ProcessedRepo {
+	ghRepo := storedToGitHubRepository(&stored)
+	ghRepo.ID = stored.GitHubID
+
+	return processor.ProcessedRepo{
+		Repository:      ghRepo,
+		ProcessedAt:     stored.LastSynced,
+		ContentLanguage: stored.ContentLanguage,
+		ReadmePath:      stored.ReadmePath,
+		ReadmeFormat:    stored.ReadmeFormat,
+	}
+}
+
+// storedMetrics returns the exported metrics, or false when the repository
+// never had metrics fetched (every count unknown and no languages or contributors)
+func storedMetrics(stored storage.StoredRepo) (storage.RepositoryMetrics, bool) {
+	metrics := storage.RepositoryMetrics{
+		OpenIssuesOpen:  stored.OpenIssuesOpen,
+		OpenIssuesTotal: stored.OpenIssuesTotal,
+		OpenPRsOpen:     stored.OpenPRsOpen,
+		OpenPRsTotal:    stored.OpenPRsTotal,
+		Commits30d:      stored.Commits30d,
+		Commits1y:       stored.Commits1y,
+		CommitsTotal:    stored.CommitsTotal,
+		Languages:       stored.Languages,
+		Contributors:    stored.Contributors,
+		Homepage:        stored.Homepage,
+	}
+
+	known := len(metrics.Languages) > 0 || len(metrics.Contributors) > 0
+
+	for _, count := range []int{
+		metrics.OpenIssuesOpen, metrics.OpenIssuesTotal, metrics.OpenPRsOpen, metrics.OpenPRsTotal,
+		metrics.Commits30d, metrics.Commits1y, metrics.CommitsTotal,
+	} {
+		known = known || count >= 0
+	}
+
+	return metrics, known
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+// seedImportDB returns a database holding two repositories, one with metrics,
+// an earlier summary and an embedding
+func seedImportDB(t *testing.T) (*storage.DuckDBRepository, func()) {
+	t.Helper()
+
+	repo, cleanup := storage.NewTestDB(t)
+	ctx := context.Background()
+
+	full := testutil.NewTestProcessedRepo(testutil.NewTestRepository(
+		testutil.WithFullName("user/full"), testutil.WithStars(42), testutil.WithGitHubID(7)), nil)
+	metrics := &storage.RepositoryMetrics{
+		OpenIssuesOpen: 3, OpenIssuesTotal: 10, OpenPRsOpen: 1, OpenPRsTotal: 4,
+		Commits30d: 5, Commits1y: 50, CommitsTotal: 500,
+		Languages:    map[string]int64{"Go": 1000},
+		Contributors: []storage.Contributor{{Login: "alice", Contributions: 3}},
+	}
+
+	summarizedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := repo.UpsertRepository(ctx, full, storage.UpsertOptions{
+		Metrics: metrics, Embedding: []float32{0.1, 0.2, 0.3},
+		Summary: &storage.RepositorySummary{Purpose: "Does everything", GeneratedAt: &summarizedAt, Version: 2},
+	}); err != nil {
+		t.Fatalf("failed to seed repository: %v", err)
+	}
+
+	bare := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName("user/bare")), nil)
+	if err := repo.UpsertRepository(ctx, bare, storage.UpsertOptions{}); err != nil {
+		t.Fatalf("failed to seed repository: %v", err)
+	}
+
+	return repo, cleanup
+}
+
+func TestImportRepositories_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	source, cleanupSource := seedImportDB(t)
+	defer cleanupSource()
+
+	var export bytes.Buffer
+	if _, err := exportRepositories(ctx, source, &export); err != nil {
+		t.Fatalf("exportRepositories() error = %v", err)
+	}
+
+	target, cleanupTarget := storage.NewTestDB(t)
+	defer cleanupTarget()
+
+	stats, err := importRepositories(ctx, target, &export, false)
+	if err != nil {
+		t.Fatalf("importRepositories() error = %v", err)
+	}
+
+	if *stats != (ImportStats{Added: 2}) {
+		t.Errorf("stats = %+v, want 2 added", *stats)
+	}
+
+	for _, name := range []string{"user/full", "user/bare"} {
+		want, err := source.GetRepository(ctx, name)
+		if err != nil {
+			t.Fatalf("GetRepository(%s) on source: %v", name, err)
+		}
+
+		got, err := target.GetRepository(ctx, name)
+		if err != nil {
+			t.Fatalf("GetRepository(%s) after import: %v", name, err)
+		}
+
+		if got.StargazersCount != want.StargazersCount || got.GitHubID != want.GitHubID ||
+			got.Purpose != want.Purpose || got.SummaryVersion != want.SummaryVersion ||
+			!equalTimePtr(got.SummaryGeneratedAt, want.SummaryGeneratedAt) ||
+			got.CommitsTotal != want.CommitsTotal ||
+			len(got.Contributors) != len(want.Contributors) ||
+			len(got.RepoEmbedding) != len(want.RepoEmbedding) ||
+			!got.LastSynced.Equal(want.LastSynced) {
+			t.Errorf("%s imported as %+v, want %+v", name, got, want)
+		}
+
+		if got.ContentHash != "" {
+			t.Errorf("%s imported with content hash %q; exports carry no chunks, so sync must re-chunk it",
+				name, got.ContentHash)
+		}
+	}
+}
+
+// equalTimePtr reports whether a and b are both nil or the same instant
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func TestImportRepositories_Modes(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite bool
+		wantStats ImportStats
+		wantStars int
+	}{
+		{name: "merge keeps stored", overwrite: false, wantStats: ImportStats{Added: 1, Skipped: 1}, wantStars: 42},
+		{name: "overwrite replaces stored", overwrite: true, wantStats: ImportStats{Added: 1, Updated: 1}, wantStars: 99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			repo, cleanup := seedImportDB(t)
+			defer cleanup()
+
+			lines := []storage.StoredRepo{
+				{FullName: "user/full", StargazersCount: 99, OpenIssuesOpen: -1, OpenIssuesTotal: -1,
+					OpenPRsOpen: -1, OpenPRsTotal: -1, Commits30d: -1, Commits1y: -1, CommitsTotal: -1},
+				{FullName: "user/new", StargazersCount: 1},
+			}
+
+			var input bytes.Buffer
+			for _, line := range lines {
+				data, _ := json.Marshal(line)
+				input.Write(append(data, '\n'))
+			}
+
+			stats, err := importRepositories(ctx, repo, &input, tt.overwrite)
+			if err != nil {
+				t.Fatalf("importRepositories() error = %v", err)
+			}
+
+			if *stats != tt.wantStats {
+				t.Errorf("stats = %+v, want %+v", *stats, tt.wantStats)
+			}
+
+			stored, err := repo.GetRepository(ctx, "user/full")
+			if err != nil {
+				t.Fatalf("GetRepository() error = %v", err)
+			}
+
+			if stored.StargazersCount != tt.wantStars {
+				t.Errorf("stars = %d, want %d", stored.StargazersCount, tt.wantStars)
+			}
+		})
+	}
+}
+
+func TestImportRepositories_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "newer schema",
+			input:   `{"format":"gh-star-search-export","schema_version":9999}` + "\n",
+			wantErr: "newer than",
+		},
+		{
+			name:    "malformed record",
+			input:   `{"format":"gh-star-search-export","schema_version":1}` + "\n{not json\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "missing owner",
+			input:   `{"full_name":"repo"}` + "\n",
+			wantErr: "owner/name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := importRepositories(context.Background(), &MockRepository{}, strings.NewReader(tt.input), false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("importRepositories() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenImportInput_Gzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stars.jsonl.gz")
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte("hello\n"))
+	gz.Close()
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	input, err := openImportInput(path)
+	if err != nil {
+		t.Fatalf("openImportInput() error = %v", err)
+	}
+	defer input.Close()
+
+	data := new(bytes.Buffer)
+	if _, err := data.ReadFrom(input); err != nil || data.String() != "hello\n" {
+		t.Errorf("read %q (%v), want decompressed content", data.String(), err)
+	}
+}
@@ -65,6 +65,10 @@ func RunInfoWithStorage(ctx context.Context, repoName string, repo storage.Repos
 	fmt.Printf("Updated: %s\n", storedRepo.UpdatedAt.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Last Synced: %s\n", storedRepo.LastSynced.Format("2006-01-02 15:04:05"))
 
+	if storedRepo.StarredAt != nil {
+		fmt.Printf("Starred: %s\n", storedRepo.StarredAt.Format("2006-01-02 15:04:05"))
+	}
+
 	if storedRepo.LicenseName != "" {
 		fmt.Printf("License: %s", storedRepo.LicenseName)
 
@@ -79,6 +83,14 @@ func RunInfoWithStorage(ctx context.Context, repoName string, repo storage.Repos
 		fmt.Printf("Topics: %s\n", strings.Join(storedRepo.Topics, ", "))
 	}
 
+	if len(storedRepo.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(storedRepo.Tags, ", "))
+	}
+
+	if len(storedRepo.Keywords) > 0 {
+		fmt.Printf("Keywords: %s\n", strings.Join(storedRepo.Keywords, ", "))
+	}
+
 	return nil
 }
 
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+)
+
+// JSONFlag switches commands to machine-readable output and errors to JSON on
+// stderr. It belongs on the root command so every subcommand accepts it.
+func JSONFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print machine-readable JSON: results, stats and summaries on stdout, errors on stderr",
+	}
+}
+
+// jsonOutput reports whether --json was given, on the command or the root
+func jsonOutput(cmd *cli.Command) bool {
+	return cmd.Bool("json")
+}
+
+// formatFlag returns the value of a --format flag, or "json" under --json.
+// Asking for another format explicitly alongside --json is an error.
+func formatFlag(cmd *cli.Command) (string, error) {
+	format := cmd.String("format")
+	if !jsonOutput(cmd) {
+		return format, nil
+	}
+
+	if cmd.IsSet("format") && format != "json" {
+		return "", errors.Newf(errors.ErrTypeValidation, "--json cannot be combined with --format %s", format)
+	}
+
+	return "json", nil
+}
+
+// jsonError is the JSON shape of an error printed under --json
+type jsonError struct {
+	Error       string   `json:"error"`
+	Type        string   `json:"type,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	ExitCode    int      `json:"exit_code"`
+}
+
+// WriteJSONError writes err to w as a single JSON object, including the type
+// and suggestions of structured errors and the process exit code
+func WriteJSONError(w io.Writer, err error) {
+	out := jsonError{Error: err.Error(), ExitCode: ExitCode(err)}
+
+	var structErr *errors.Error
+	if stderrors.As(err, &structErr) {
+		out.Error = structErr.Message
+		out.Type = string(structErr.Type)
+		out.Suggestions = structErr.Suggestions
+	}
+
+	data, marshalErr := json.Marshal(out)
+	if marshalErr != nil {
+		data = []byte(fmt.Sprintf(`{"error": %q}`, err.Error()))
+	}
+
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+)
+
+func TestFormatFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", args: []string{"app", "list"}, want: "table"},
+		{name: "explicit format", args: []string{"app", "list", "--format", "csv"}, want: "csv"},
+		{name: "global json", args: []string{"app", "--json", "list"}, want: "json"},
+		{name: "json after the subcommand", args: []string{"app", "list", "--json"}, want: "json"},
+		{name: "json with format json", args: []string{"app", "--json", "list", "--format", "json"}, want: "json"},
+		{name: "json with another format", args: []string{"app", "--json", "list", "--format", "csv"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got string
+				err error
+			)
+
+			app := &cli.Command{
+				Name:  "app",
+				Flags: []cli.Flag{JSONFlag()},
+				Commands: []*cli.Command{{
+					Name:  "list",
+					Flags: []cli.Flag{&cli.StringFlag{Name: "format", Value: "table"}},
+					Action: func(_ context.Context, cmd *cli.Command) error {
+						got, err = formatFlag(cmd)
+						return nil
+					},
+				}},
+			}
+
+			require.NoError(t, app.Run(context.Background(), tt.args))
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var out bytes.Buffer
+
+	WriteJSONError(&out, errors.New(errors.ErrTypeAuth, "not logged in").WithSuggestion("Run 'gh auth login'"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"error":       "not logged in",
+		"type":        "auth",
+		"suggestions": []any{"Run 'gh auth login'"},
+		"exit_code":   float64(ExitAuth),
+	}, got)
+
+	out.Reset()
+	WriteJSONError(&out, fmt.Errorf("plain failure"))
+	assert.JSONEq(t, `{"error": "plain failure", "exit_code": 1}`, out.String())
+}
+
+func TestWriteJSONSummary(t *testing.T) {
+	var out bytes.Buffer
+
+	service := &SyncService{summaryOut: &out}
+	stats := &SyncStats{TotalRepos: 10, ProcessedRepos: 3, NewRepos: 2, UpdatedRepos: 1, ErrorRepos: 1,
+		ProcessingTime: 1500 * time.Millisecond}
+	stats.AddSkipped(skipUpToDate, 6)
+
+	service.printSyncSummary(stats)
+
+	var got syncJSONSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got), "stdout holds only the JSON summary")
+	assert.Equal(t, 10, got.TotalRepos)
+	assert.Equal(t, 2, got.Added)
+	assert.Equal(t, 1, got.Failed)
+	assert.Equal(t, 6, got.Skipped)
+	assert.Equal(t, map[string]int{string(skipUpToDate): 6}, got.SkipReasons)
+	assert.InDelta(t, 1.5, got.DurationSeconds, 1e-9)
+}
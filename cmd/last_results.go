@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/query"
+)
+
+// lastResultsFile is the most recent query's result list within the cache
+// directory, so other commands can refer to a result by its rank
+const lastResultsFile = "last-results.json"
+
+// lastResultsData is the on-disk form of the last query's results
+type lastResultsData struct {
+	Query      string    `json:"query"`
+	ExecutedAt time.Time `json:"executed_at"`
+	FullNames  []string  `json:"full_names"` // In rank order
+}
+
+// saveLastResults records the ranked result names of a query, replacing the
+// previous query's
+func saveLastResults(cacheDir, queryString string, results []query.Result) error {
+	saved := lastResultsData{
+		Query:      queryString,
+		ExecutedAt: time.Now(),
+		FullNames:  make([]string, len(results)),
+	}
+
+	for i, result := range results {
+		saved.FullNames[i] = result.Repository.FullName
+	}
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to encode last results: %w", err)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(cacheDir, lastResultsFile), data, 0o600)
+}
+
+// lastResultAt returns the full name ranked rank (1-based) by the last query
+func lastResultAt(cacheDir string, rank int) (string, error) {
+	data, err := os.ReadFile(filepath.Join(cacheDir, lastResultsFile))
+	if os.IsNotExist(err) {
+		return "", errors.New(errors.ErrTypeNotFound, "no previous search results").
+			WithSuggestion("Run 'gh star-search query <search-string>' first, or pass owner/repo")
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read last results: %w", err)
+	}
+
+	var saved lastResultsData
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return "", fmt.Errorf("failed to parse last results: %w", err)
+	}
+
+	if rank < 1 || rank > len(saved.FullNames) {
+		return "", errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("the last search (%q) returned %d result(s); %d is out of range",
+				saved.Query, len(saved.FullNames), rank))
+	}
+
+	return saved.FullNames[rank-1], nil
+}
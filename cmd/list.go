@@ -2,25 +2,45 @@ package cmd
 
 import (
 	"context"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"text/template"
+	"unicode/utf8"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
+// ListOutputOptions controls header and delimiter handling for table, CSV and TSV output,
+// or replaces the format with a report template
+type ListOutputOptions struct {
+	NoHeader     bool
+	Delimiter    rune   // Zero keeps the format default (aligned columns for table, comma for CSV, tab for TSV)
+	TemplateFile string // Template path or name in the templates directory; overrides the format
+}
+
 func ListCommand() *cli.Command {
 	return &cli.Command{
-		Name:        "list",
-		Usage:       "List all repositories in the local database",
-		Description: `Display all repositories in the local database with basic information.`,
-		Flags: []cli.Flag{
+		Name:  "list",
+		Usage: "List all repositories in the local database",
+		Description: `Display all repositories in the local database with basic information.
+
+Sorting and filtering are done by the database, so paging through a large
+index with --limit and --offset stays cheap for any --sort.
+
+Examples:
+  gh star-search list --sort updated
+  gh star-search list --topic cli --topic go
+  gh star-search list --language go --min-stars 100 --max-stars 5000
+  gh star-search list --updated-after 2024-06-01 --sort updated`,
+		Flags: append([]cli.Flag{
 			&cli.IntFlag{
 				Name:    "limit",
 				Aliases: []string{"l"},
@@ -33,33 +53,109 @@ func ListCommand() *cli.Command {
 				Value:   0,
 				Usage:   "Number of repositories to skip",
 			},
+			&cli.StringFlag{
+				Name:    "sort",
+				Aliases: []string{"s"},
+				Value:   string(storage.ListSortStars),
+				Usage:   "Sort by stars, forks, updated or name",
+			},
+			&cli.StringFlag{
+				Name:  "order",
+				Usage: "Sort order, asc or desc (default: asc for name, desc otherwise)",
+			},
 			&cli.StringFlag{
 				Name:    "format",
 				Aliases: []string{"f"},
 				Value:   "table",
-				Usage:   "Output format (table, json, csv)",
+				Usage:   "Output format (table, short, json, csv, tsv)",
 			},
-		},
+			&cli.BoolFlag{
+				Name:  "no-header",
+				Usage: "Omit the header row from table, csv and tsv output",
+			},
+			&cli.StringFlag{
+				Name:  "delimiter",
+				Usage: `Single-character field delimiter for table, csv and tsv output (use "\t" or "tab" for TSV)`,
+			},
+			&cli.StringFlag{
+				Name:  "output-template-file",
+				Usage: "Render output with a text/template file (path, or name in ~/.config/gh-star-search/templates/)",
+			},
+		}, filterFlags("list")...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			limit := int(cmd.Int("limit"))
-			offset := int(cmd.Int("offset"))
-			format := cmd.String("format")
+			format, err := formatFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			listOpts, err := parseListOptions(cmd.String("sort"), cmd.String("order"))
+			if err != nil {
+				return err
+			}
+
+			listOpts.Limit = int(cmd.Int("limit"))
+			listOpts.Offset = int(cmd.Int("offset"))
+			listOpts.Filter, err = parseFilterFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			delimiter, err := parseDelimiter(cmd.String("delimiter"))
+			if err != nil {
+				return err
+			}
+
+			opts := ListOutputOptions{
+				NoHeader:     cmd.Bool("no-header"),
+				Delimiter:    delimiter,
+				TemplateFile: cmd.String("output-template-file"),
+			}
 
-			return runList(ctx, limit, offset, format)
+			return runList(ctx, listOpts, format, opts)
 		},
 	}
 }
 
-func runList(ctx context.Context, limit, offset int, format string) error {
-	return RunListWithStorage(ctx, limit, offset, format, nil)
+func runList(ctx context.Context, listOpts storage.ListOptions, format string, opts ListOutputOptions) error {
+	return RunListWithOptions(ctx, listOpts, format, opts, nil)
 }
 
+// RunListWithStorage lists repositories using the default output options
 func RunListWithStorage(
 	ctx context.Context,
 	limit, offset int,
 	format string,
 	repo storage.Repository,
 ) error {
+	return RunListWithOptions(
+		ctx, storage.ListOptions{Limit: limit, Offset: offset}, format, ListOutputOptions{}, repo)
+}
+
+// RunListWithOptions lists a sorted page of repositories with explicit header and delimiter options
+func RunListWithOptions(
+	ctx context.Context,
+	listOpts storage.ListOptions,
+	format string,
+	opts ListOutputOptions,
+	repo storage.Repository,
+) error {
+	format = strings.ToLower(format)
+	if (format == "json" || format == "short" || opts.TemplateFile != "") && (opts.NoHeader || opts.Delimiter != 0) {
+		return errors.New(errors.ErrTypeValidation,
+			"--no-header and --delimiter only apply to table, csv and tsv formats")
+	}
+
+	var tmpl *template.Template
+
+	if opts.TemplateFile != "" {
+		var err error
+
+		tmpl, err = loadOutputTemplate(opts.TemplateFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Initialize storage if not provided (for testing)
 	if repo == nil {
 		var err error
@@ -75,36 +171,110 @@ func RunListWithStorage(
 	}
 
 	// Get repositories
-	repos, err := repo.ListRepositories(ctx, limit, offset)
+	repos, err := repo.ListRepositoriesSorted(ctx, listOpts)
 	if err != nil {
 		return fmt.Errorf("failed to list repositories: %w", err)
 	}
 
 	if len(repos) == 0 {
+		if format == "json" {
+			return outputJSON([]storage.StoredRepo{})
+		}
+
+		if !listOpts.Filter.IsZero() {
+			fmt.Println("No repositories match the given filters.")
+			return nil
+		}
+
 		fmt.Println("No repositories found. Run 'gh star-search sync' to populate the database.")
+
 		return nil
 	}
 
+	if tmpl != nil {
+		return renderOutputTemplate(tmpl, formatter.TemplateData{Repositories: repos})
+	}
+
 	// Format output
-	switch strings.ToLower(format) {
+	switch format {
 	case "json":
 		return outputJSON(repos)
-	case "csv":
-		return outputCSV(repos)
+	case "csv", "tsv":
+		comma := opts.Delimiter
+		if comma == 0 {
+			comma = ','
+			if format == "tsv" {
+				comma = '\t'
+			}
+		}
+
+		return outputDelimited(repos, comma, opts.NoHeader)
+	case "short":
+		return outputShort(ctx, repos, listOpts.Offset)
 	case "table":
 		fallthrough
 	default:
-		return outputTable(repos)
+		if opts.Delimiter != 0 {
+			return outputDelimited(repos, opts.Delimiter, opts.NoHeader)
+		}
+
+		return outputTable(repos, opts)
 	}
 }
 
-func outputTable(repos []storage.StoredRepo) error {
+// parseListOptions validates the --sort and --order flags. Only the fields in
+// storage.ListSorts are accepted, since they select an ORDER BY column.
+func parseListOptions(sort, order string) (storage.ListOptions, error) {
+	listSort := storage.ListSort(strings.ToLower(sort))
+	if !slices.Contains(storage.ListSorts(), listSort) {
+		return storage.ListOptions{}, errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("invalid sort field: %q", sort)).
+			WithSuggestion("Use one of: stars, forks, updated, name")
+	}
+
+	sortOrder := storage.SortOrder(strings.ToLower(order))
+	if sortOrder != "" && sortOrder != storage.SortAscending && sortOrder != storage.SortDescending {
+		return storage.ListOptions{}, errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("invalid sort order: %q", order)).
+			WithSuggestion("Use asc or desc")
+	}
+
+	return storage.ListOptions{Sort: listSort, Order: sortOrder}, nil
+}
+
+// parseDelimiter converts the --delimiter flag value into a single rune.
+// An empty value returns zero, meaning the format default.
+func parseDelimiter(value string) (rune, error) {
+	switch value {
+	case "":
+		return 0, nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("delimiter must be a single character, got %q", value))
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(value)
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' || delimiter == utf8.RuneError {
+		return 0, errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("delimiter %q is not allowed", value))
+	}
+
+	return delimiter, nil
+}
+
+func outputTable(repos []storage.StoredRepo, opts ListOutputOptions) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	defer w.Flush()
 
 	// Header
-	fmt.Fprintln(w, "NAME\tLANGUAGE\tSTARS\tFORKS\tUPDATED\tDESCRIPTION")
-	fmt.Fprintln(w, "----\t--------\t-----\t-----\t-------\t-----------")
+	if !opts.NoHeader {
+		fmt.Fprintln(w, "NAME\tLANGUAGE\tSTARS\tFORKS\tUPDATED\tDESCRIPTION")
+		fmt.Fprintln(w, "----\t--------\t-----\t-----\t-------\t-----------")
+	}
 
 	// Rows
 	for _, repo := range repos {
@@ -131,44 +301,40 @@ func outputTable(repos []storage.StoredRepo) error {
 	return nil
 }
 
-func outputJSON(repos []storage.StoredRepo) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
-	return encoder.Encode(repos)
-}
-
-func outputCSV(repos []storage.StoredRepo) error {
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
-
-	// Header
-	if err := writer.Write(
-		[]string{"Name", "Language", "Stars", "Forks", "Updated", "Description"},
-	); err != nil {
-		return err
+// outputDelimited writes the formatter's CSV columns separated by comma, without
+// padding or truncation, so the output can be consumed by spreadsheets and tools
+// like awk and cut
+func outputDelimited(repos []storage.StoredRepo, comma rune, noHeader bool) error {
+	if !noHeader {
+		fmt.Println(formatter.DelimitedHeader(comma))
 	}
 
-	// Rows
 	for _, repo := range repos {
-		language := repo.Language
-		if language == "" {
-			language = notAvailable
-		}
+		fmt.Println(formatter.FormatDelimited(repo, comma))
+	}
 
-		record := []string{
-			repo.FullName,
-			language,
-			strconv.Itoa(repo.StargazersCount),
-			strconv.Itoa(repo.ForksCount),
-			repo.UpdatedAt.Format("2006-01-02"),
-			repo.Description,
-		}
+	return nil
+}
 
-		if err := writer.Write(record); err != nil {
-			return err
+// outputShort prints each repository in the short form used by query results,
+// numbered by its position in the full listing
+func outputShort(ctx context.Context, repos []storage.StoredRepo, offset int) error {
+	f := formatter.NewFormatter(getConfigFromContext(ctx).Formatter)
+
+	for i, repo := range repos {
+		if i > 0 {
+			fmt.Println()
 		}
+
+		fmt.Printf("%d. %s\n", offset+i+1, f.FormatRepository(repo, formatter.FormatShort))
 	}
 
 	return nil
 }
+
+func outputJSON(repos []storage.StoredRepo) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(repos)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -90,7 +92,7 @@ func TestRunList(t *testing.T) {
 			offset:   0,
 			format:   "csv",
 			wantErr:  false,
-			contains: []string{"Name,Language,Stars", "user/repo1,Go,100"},
+			contains: []string{"full_name,language,stars", "user/repo1,Go,100,10,,2023-01-01,Test repository 1"},
 		},
 	}
 
@@ -166,7 +168,7 @@ func TestOutputTable(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := outputTable(repos)
+	err := outputTable(repos, ListOutputOptions{})
 
 	// Restore stdout and get output
 	w.Close()
@@ -197,3 +199,223 @@ func TestOutputTable(t *testing.T) {
 		}
 	}
 }
+
+func TestRunListWithOptions(t *testing.T) {
+	repos := []storage.StoredRepo{
+		{
+			FullName:        "user/repo1",
+			Language:        "Go",
+			StargazersCount: 100,
+			ForksCount:      10,
+			UpdatedAt:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Description:     "Tabs\tand\nnewlines",
+		},
+	}
+
+	templatePath := filepath.Join(t.TempDir(), "report.tmpl")
+
+	templateBody := "{{range .Repositories}}- [{{.FullName}}]({{repoURL .FullName}}) {{.StargazersCount}}\n{{end}}"
+	if err := os.WriteFile(templatePath, []byte(templateBody), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		opts        ListOutputOptions
+		wantErr     bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "table without header",
+			format:      "table",
+			opts:        ListOutputOptions{NoHeader: true},
+			contains:    []string{"user/repo1"},
+			notContains: []string{"NAME", "----"},
+		},
+		{
+			name:     "table with tab delimiter",
+			format:   "table",
+			opts:     ListOutputOptions{Delimiter: '\t'},
+			contains: []string{"full_name\tlanguage", "user/repo1\tGo\t100\t10\t\t2023-01-01\t\"Tabs\tand\nnewlines\"\n"},
+		},
+		{
+			name:     "tsv format",
+			format:   "tsv",
+			contains: []string{"full_name\tlanguage", "user/repo1\tGo\t100"},
+		},
+		{
+			name:        "tsv without header",
+			format:      "tsv",
+			opts:        ListOutputOptions{NoHeader: true},
+			contains:    []string{"user/repo1\tGo"},
+			notContains: []string{"full_name"},
+		},
+		{
+			name:        "csv with semicolon and no header",
+			format:      "csv",
+			opts:        ListOutputOptions{NoHeader: true, Delimiter: ';'},
+			contains:    []string{"user/repo1;Go;100"},
+			notContains: []string{"full_name"},
+		},
+		{
+			name:        "template overrides format",
+			format:      "table",
+			opts:        ListOutputOptions{TemplateFile: templatePath},
+			contains:    []string{"- [user/repo1](https://github.com/user/repo1) 100\n"},
+			notContains: []string{"NAME"},
+		},
+		{
+			name:    "template rejects delimiter",
+			format:  "table",
+			opts:    ListOutputOptions{TemplateFile: templatePath, Delimiter: ';'},
+			wantErr: true,
+		},
+		{
+			name:    "missing template",
+			format:  "table",
+			opts:    ListOutputOptions{TemplateFile: filepath.Join(t.TempDir(), "missing.tmpl")},
+			wantErr: true,
+		},
+		{
+			name:        "short format numbers each repository",
+			format:      "short",
+			contains:    []string{"1. user/repo1  (link: https://github.com/user/repo1)\nGitHub Description: "},
+			notContains: []string{"NAME"},
+		},
+		{
+			name:    "short rejects no-header",
+			format:  "short",
+			opts:    ListOutputOptions{NoHeader: true},
+			wantErr: true,
+		},
+		{
+			name:    "json rejects delimiter",
+			format:  "json",
+			opts:    ListOutputOptions{Delimiter: ';'},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := RunListWithOptions(
+				context.Background(), storage.ListOptions{Limit: 50}, tt.format, tt.opts, &MockRepository{repos: repos},
+			)
+
+			w.Close()
+
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+
+			_, _ = buf.ReadFrom(r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunListWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, expected := range tt.contains {
+				if !strings.Contains(output, expected) {
+					t.Errorf("output does not contain %q\nOutput: %s", expected, output)
+				}
+			}
+
+			for _, unexpected := range tt.notContains {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("output unexpectedly contains %q\nOutput: %s", unexpected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    rune
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: `\t`, want: '\t'},
+		{value: "tab", want: '\t'},
+		{value: "|", want: '|'},
+		{value: "→", want: '→'},
+		{value: "::", wantErr: true},
+		{value: `"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDelimiter(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDelimiter(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseDelimiter(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseListOptions(t *testing.T) {
+	tests := []struct {
+		sort    string
+		order   string
+		want    storage.ListOptions
+		wantErr bool
+	}{
+		{sort: "stars", want: storage.ListOptions{Sort: storage.ListSortStars}},
+		{sort: "Forks", order: "ASC", want: storage.ListOptions{Sort: storage.ListSortForks, Order: storage.SortAscending}},
+		{sort: "updated", order: "desc", want: storage.ListOptions{Sort: storage.ListSortUpdated, Order: storage.SortDescending}},
+		{sort: "name", want: storage.ListOptions{Sort: storage.ListSortName}},
+		{sort: "stargazers_count", wantErr: true},
+		{sort: "stars", order: "up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort+"/"+tt.order, func(t *testing.T) {
+			got, err := parseListOptions(tt.sort, tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseListOptions(%q, %q) error = %v, wantErr %v", tt.sort, tt.order, err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseListOptions(%q, %q) = %+v, want %+v", tt.sort, tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunListWithOptions_PassesSortToStorage(t *testing.T) {
+	mock := &MockRepository{repos: []storage.StoredRepo{{FullName: "user/repo1"}}}
+	listOpts := storage.ListOptions{
+		Limit: 5, Offset: 2, Sort: storage.ListSortName, Order: storage.SortDescending,
+		Filter: storage.Filter{Topics: []string{"cli", "go"}},
+	}
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := RunListWithOptions(context.Background(), listOpts, "table", ListOutputOptions{}, mock)
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("RunListWithOptions() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(mock.listOptions, listOpts) {
+		t.Errorf("storage received %+v, want %+v", mock.listOptions, listOpts)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func MigrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Show or apply database schema migrations",
+		Description: `Every command that opens the database applies pending migrations first, so
+this is only needed to inspect the schema or to upgrade it on purpose, e.g.
+right after installing a new version.
+
+Migrations are forward-only: each one is idempotent DDL with no down step,
+so there is no rollback. To undo a schema change, restore an export with
+'gh star-search import' or rebuild with 'gh star-search rebuild'.
+
+Examples:
+  gh star-search migrate status
+  gh star-search migrate status --format json
+  gh star-search migrate up`,
+		Commands: []*cli.Command{
+			{
+				Name:  "status",
+				Usage: "List every migration with whether and when it was applied (the default)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Value:   "table",
+						Usage:   "Output format (table, json)",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					format, err := formatFlag(cmd)
+					if err != nil {
+						return err
+					}
+
+					return withMigrator(ctx, func(repo migrator) error {
+						return runMigrateStatus(ctx, os.Stdout, repo, format)
+					})
+				},
+			},
+			{
+				Name:  "up",
+				Usage: "Apply every pending migration",
+				Action: func(ctx context.Context, _ *cli.Command) error {
+					return withMigrator(ctx, func(repo migrator) error {
+						return runMigrateUp(ctx, os.Stdout, repo)
+					})
+				},
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			format := "table"
+			if jsonOutput(cmd) {
+				format = "json"
+			}
+
+			return withMigrator(ctx, func(repo migrator) error {
+				return runMigrateStatus(ctx, os.Stdout, repo, format)
+			})
+		},
+	}
+}
+
+// migrator is the part of the DuckDB repository the migrate command uses
+type migrator interface {
+	Initialize(ctx context.Context) error
+	MigrationStatus(ctx context.Context) ([]storage.MigrationStatus, error)
+}
+
+// withMigrator opens the configured database without migrating it and passes
+// it to fn
+func withMigrator(ctx context.Context, fn func(migrator) error) error {
+	cfg := getConfigFromContext(ctx)
+
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&cfg.Database)
+	if err != nil {
+		return databaseOpenError(err)
+	}
+	defer repo.Close()
+
+	return fn(repo)
+}
+
+// runMigrateStatus prints each migration with when it was applied, or
+// "pending", as a table or JSON
+func runMigrateStatus(ctx context.Context, out io.Writer, repo migrator, format string) error {
+	if format != "table" && format != "json" {
+		return errors.Newf(errors.ErrTypeValidation, "invalid format %q", format).
+			WithSuggestion("Use --format table or --format json")
+	}
+
+	statuses, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to read migration status")
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal migration status: %w", err)
+		}
+
+		fmt.Fprintln(out, string(data))
+
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED")
+	fmt.Fprintln(tw, "-------\t----\t-------")
+
+	pending := 0
+
+	for _, status := range statuses {
+		applied := "pending"
+		if status.Applied {
+			applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+		} else {
+			pending++
+		}
+
+		fmt.Fprintf(tw, "%03d\t%s\t%s\n", status.Version, status.Name, applied)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if pending > 0 {
+		fmt.Fprintf(out, "\n%d pending migration(s); run 'gh star-search migrate up' to apply them.\n", pending)
+	}
+
+	return nil
+}
+
+// runMigrateUp applies pending migrations and lists the ones it applied
+func runMigrateUp(ctx context.Context, out io.Writer, repo migrator) error {
+	before, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to read migration status")
+	}
+
+	if err := repo.Initialize(ctx); err != nil {
+		return schemaError(err)
+	}
+
+	applied := 0
+
+	for _, status := range before {
+		if !status.Applied {
+			fmt.Fprintf(out, "Applied %03d %s\n", status.Version, status.Name)
+			applied++
+		}
+	}
+
+	if applied == 0 {
+		fmt.Fprintln(out, "Schema is up to date.")
+	}
+
+	return nil
+}
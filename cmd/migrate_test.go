@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestMigrateUpAndStatus(t *testing.T) {
+	ctx := context.Background()
+
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+
+	defer repo.Close()
+
+	latest, err := storage.LatestSchemaVersion()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	require.NoError(t, runMigrateStatus(ctx, &out, repo, "table"))
+	assert.Regexp(t, `001\s+initial_schema\s+pending`, out.String())
+	assert.Contains(t, out.String(), "run 'gh star-search migrate up'")
+
+	out.Reset()
+	require.NoError(t, runMigrateUp(ctx, &out, repo))
+	assert.Contains(t, out.String(), "Applied 001 initial_schema")
+	assert.Equal(t, latest, bytes.Count(out.Bytes(), []byte("Applied ")), "every migration is applied once")
+
+	out.Reset()
+	require.NoError(t, runMigrateUp(ctx, &out, repo))
+	assert.Equal(t, "Schema is up to date.\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runMigrateStatus(ctx, &out, repo, "json"))
+
+	var statuses []storage.MigrationStatus
+	require.NoError(t, json.Unmarshal(out.Bytes(), &statuses))
+	require.Len(t, statuses, latest)
+
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "migration %d", status.Version)
+		assert.NotNil(t, status.AppliedAt)
+	}
+
+	err = runMigrateStatus(ctx, &out, repo, "yaml")
+	require.Error(t, err)
+}
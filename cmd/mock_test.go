@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/KyleKing/gh-star-search/internal/processor"
@@ -11,9 +13,15 @@ import (
 
 // MockRepository implements storage.Repository for testing
 type MockRepository struct {
-	repos  []storage.StoredRepo
-	stats  *storage.Stats
-	closed bool
+	repos   []storage.StoredRepo
+	stats   *storage.Stats
+	sizes   *storage.SizeBreakdown
+	history []storage.QueryHistoryEntry
+	saved   []storage.SavedSearch
+	tags    map[string][]string
+	closed  bool
+
+	listOptions storage.ListOptions // Last options passed to ListRepositoriesSorted
 }
 
 func (m *MockRepository) Initialize(_ context.Context) error {
@@ -28,6 +36,10 @@ func (m *MockRepository) UpdateRepository(_ context.Context, _ processor.Process
 	return nil
 }
 
+func (m *MockRepository) UpsertRepository(_ context.Context, _ processor.ProcessedRepo, _ storage.UpsertOptions) error {
+	return nil
+}
+
 func (m *MockRepository) DeleteRepository(_ context.Context, _ string) error {
 	return nil
 }
@@ -39,6 +51,14 @@ func (m *MockRepository) SearchRepositories(
 	return nil, nil
 }
 
+func (m *MockRepository) SearchFilteredRepositories(
+	_ context.Context,
+	_ string,
+	_ storage.Filter,
+) ([]storage.SearchResult, error) {
+	return nil, nil
+}
+
 func (m *MockRepository) GetRepository(
 	_ context.Context,
 	fullName string,
@@ -69,6 +89,61 @@ func (m *MockRepository) ListRepositories(
 	return m.repos[start:end], nil
 }
 
+func (m *MockRepository) ListRepositoriesSorted(
+	ctx context.Context,
+	opts storage.ListOptions,
+) ([]storage.StoredRepo, error) {
+	m.listOptions = opts
+
+	return m.ListRepositories(ctx, opts.Limit, opts.Offset)
+}
+
+func (m *MockRepository) FilterRepositories(
+	_ context.Context,
+	filter storage.Filter,
+) ([]storage.SearchResult, error) {
+	var results []storage.SearchResult
+
+	for _, repo := range m.repos {
+		if hasAllTopics(repo, filter.Topics) {
+			results = append(results, storage.SearchResult{Repository: repo, Score: 1.0})
+		}
+	}
+
+	return results, nil
+}
+
+func (m *MockRepository) SearchByContributor(
+	_ context.Context,
+	login string,
+) ([]storage.SearchResult, error) {
+	var results []storage.SearchResult
+
+	for _, repo := range m.repos {
+		for _, contributor := range repo.Contributors {
+			if strings.EqualFold(contributor.Login, login) {
+				results = append(results, storage.SearchResult{
+					Repository: repo,
+					Score:      float64(contributor.Contributions),
+				})
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// hasAllTopics reports whether repo carries every topic
+func hasAllTopics(repo storage.StoredRepo, topics []string) bool {
+	for _, topic := range topics {
+		if !slices.Contains(repo.Topics, topic) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (m *MockRepository) GetStats(_ context.Context) (*storage.Stats, error) {
 	if m.stats != nil {
 		return m.stats, nil
@@ -83,6 +158,14 @@ func (m *MockRepository) GetStats(_ context.Context) (*storage.Stats, error) {
 	}, nil
 }
 
+func (m *MockRepository) GetSizeBreakdown(_ context.Context, _ int) (*storage.SizeBreakdown, error) {
+	if m.sizes != nil {
+		return m.sizes, nil
+	}
+
+	return &storage.SizeBreakdown{}, nil
+}
+
 func (m *MockRepository) Clear(_ context.Context) error {
 	m.repos = []storage.StoredRepo{}
 	return nil
@@ -107,6 +190,10 @@ func (m *MockRepository) GetRepositoriesNeedingSummaryUpdate(
 	return []string{}, nil
 }
 
+func (m *MockRepository) GetRepositoriesNeedingEmbedding(_ context.Context, _ bool) ([]string, error) {
+	return []string{}, nil
+}
+
 func (m *MockRepository) UpdateRepositoryEmbedding(_ context.Context, _ string, _ []float32) error {
 	return nil
 }
@@ -127,10 +214,168 @@ func (m *MockRepository) RebuildFTSIndex(_ context.Context) error {
 	return nil
 }
 
-func (m *MockRepository) SearchByEmbedding(_ context.Context, _ []float32, _ int, _ float64) ([]storage.SearchResult, error) {
+func (m *MockRepository) SearchByEmbedding(_ context.Context, _ []float32, limit int, _ float64) ([]storage.SearchResult, error) {
+	// Repositories with embeddings are returned in stored order
+	var results []storage.SearchResult
+	for _, repo := range m.repos {
+		if len(repo.RepoEmbedding) == 0 || len(results) >= limit {
+			continue
+		}
+
+		results = append(results, storage.SearchResult{Repository: repo, Score: 0.5})
+	}
+
+	return results, nil
+}
+
+func (m *MockRepository) GetChunksNeedingEmbedding(_ context.Context, _ bool) ([]storage.StoredChunk, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) UpdateChunkEmbedding(_ context.Context, _ string, _ []float32) error {
+	return nil
+}
+
+func (m *MockRepository) SearchChunksByEmbedding(
+	_ context.Context, _ []float32, _ int, _ float64,
+) ([]storage.SearchResult, error) {
 	return nil, nil
 }
 
 func (m *MockRepository) GetRelatedCounts(_ context.Context, _ string) (int, int, error) {
 	return 0, 0, nil
 }
+
+func (m *MockRepository) RecordQuery(_ context.Context, entry storage.QueryHistoryEntry) error {
+	entry.ID = int64(len(m.history) + 1)
+	entry.ExecutedAt = time.Now()
+	m.history = append(m.history, entry)
+
+	return nil
+}
+
+func (m *MockRepository) ListQueryHistory(
+	_ context.Context,
+	limit int,
+) ([]storage.QueryHistoryEntry, error) {
+	var entries []storage.QueryHistoryEntry
+
+	for i := len(m.history) - 1; i >= 0 && len(entries) < limit; i-- {
+		entries = append(entries, m.history[i])
+	}
+
+	return entries, nil
+}
+
+func (m *MockRepository) GetQueryHistoryEntry(
+	_ context.Context,
+	id int64,
+) (*storage.QueryHistoryEntry, error) {
+	for _, entry := range m.history {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("query history entry not found: %d", id)
+}
+
+func (m *MockRepository) SaveSearch(_ context.Context, search storage.SavedSearch) error {
+	for i := range m.saved {
+		if m.saved[i].Name == search.Name {
+			m.saved[i] = search
+			return nil
+		}
+	}
+
+	m.saved = append(m.saved, search)
+
+	return nil
+}
+
+func (m *MockRepository) GetSavedSearch(
+	_ context.Context,
+	name string,
+) (*storage.SavedSearch, error) {
+	for _, search := range m.saved {
+		if search.Name == name {
+			return &search, nil
+		}
+	}
+
+	return nil, fmt.Errorf("saved search not found: %s", name)
+}
+
+func (m *MockRepository) ListSavedSearches(_ context.Context) ([]storage.SavedSearch, error) {
+	return m.saved, nil
+}
+
+func (m *MockRepository) DeleteSavedSearch(_ context.Context, name string) error {
+	for i, search := range m.saved {
+		if search.Name == name {
+			m.saved = append(m.saved[:i], m.saved[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("saved search not found: %s", name)
+}
+
+func (m *MockRepository) RenameRepository(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *MockRepository) BackfillGitHubIDs(_ context.Context, _ map[string]int64) (int, error) {
+	return 0, nil
+}
+
+func (m *MockRepository) ListKeywordTerms(_ context.Context) (map[string]map[string]int, error) {
+	return map[string]map[string]int{}, nil
+}
+
+func (m *MockRepository) UpdateKeywords(_ context.Context, selected map[string][]string) (int, error) {
+	return len(selected), nil
+}
+
+func (m *MockRepository) AddTag(_ context.Context, fullName, tag string) error {
+	if _, err := m.GetRepository(context.Background(), fullName); err != nil {
+		return err
+	}
+
+	if m.tags == nil {
+		m.tags = make(map[string][]string)
+	}
+
+	if !slices.Contains(m.tags[fullName], tag) {
+		m.tags[fullName] = append(m.tags[fullName], tag)
+	}
+
+	return nil
+}
+
+func (m *MockRepository) RemoveTag(_ context.Context, fullName, tag string) error {
+	i := slices.Index(m.tags[fullName], tag)
+	if i < 0 {
+		return fmt.Errorf("tag %q not found on %s", tag, fullName)
+	}
+
+	m.tags[fullName] = slices.Delete(m.tags[fullName], i, i+1)
+
+	return nil
+}
+
+func (m *MockRepository) GetTags(_ context.Context, fullName string) ([]string, error) {
+	return m.tags[fullName], nil
+}
+
+func (m *MockRepository) ListTaggedRepositories(_ context.Context, tag string) ([]string, error) {
+	var names []string
+
+	for name, tags := range m.tags {
+		if slices.Contains(tags, tag) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
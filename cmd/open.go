@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func OpenCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "open",
+		Usage: "Open a starred repository's GitHub page in the browser",
+		Description: `Open the GitHub page of a repository in the local database. The repository is
+given as owner/name, or as a result number from the last query.
+
+The browser is chosen by $BROWSER when set, otherwise by the system opener
+(open, xdg-open or the Windows URL handler). The URL is always printed, so
+on a headless machine it can be copied instead.
+
+Examples:
+  gh star-search open cli/cli
+  gh star-search query "terminal ui" && gh star-search open 2
+  gh star-search open --homepage charmbracelet/bubbletea`,
+		ArgsUsage: "<repository|result-number>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "homepage",
+				Usage: "Open the repository's homepage instead of its GitHub page",
+			},
+		},
+		Action: runOpen,
+	}
+}
+
+func runOpen(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) != 1 {
+		return errors.New(errors.ErrTypeValidation, "expected exactly one repository or result number")
+	}
+
+	cfg := getConfigFromContext(ctx)
+
+	fullName, err := resolveOpenTarget(config.ExpandPath(cfg.Cache.Directory), strings.TrimSpace(args[0]))
+	if err != nil {
+		return err
+	}
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	return openRepository(ctx, os.Stdout, repo, fullName, cmd.Bool("homepage"))
+}
+
+// resolveOpenTarget turns the open argument into a full name: a number is a
+// rank in the last query's results, anything else must be owner/name
+func resolveOpenTarget(cacheDir, arg string) (string, error) {
+	if rank, err := strconv.Atoi(arg); err == nil {
+		return lastResultAt(cacheDir, rank)
+	}
+
+	if err := validateRepositoryName(arg); err != nil {
+		return "", err
+	}
+
+	return arg, nil
+}
+
+// openRepository looks up fullName and opens its GitHub page, or its homepage
+// when homepage is set. The URL is printed whether or not a browser starts.
+func openRepository(
+	ctx context.Context,
+	out io.Writer,
+	repo storage.Repository,
+	fullName string,
+	homepage bool,
+) error {
+	stored, err := repo.GetRepository(ctx, fullName)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, fmt.Sprintf("repository %s is not in the local database", fullName)).
+			WithSuggestion("Only starred repositories that have been synced can be opened")
+	}
+
+	url := "https://github.com/" + stored.FullName
+
+	if homepage {
+		if stored.Homepage == "" {
+			return errors.New(errors.ErrTypeNotFound, fmt.Sprintf("%s has no homepage", stored.FullName)).
+				WithSuggestion("Open its GitHub page without --homepage")
+		}
+
+		url = stored.Homepage
+	}
+
+	fmt.Fprintln(out, url)
+
+	if err := launchBrowser(ctx, url); err != nil {
+		fmt.Fprintf(out, "Could not open a browser (%v); copy the URL above instead.\n", err)
+	}
+
+	return nil
+}
+
+// launchBrowser opens url in the user's browser. It is a variable so tests can
+// replace it.
+var launchBrowser = func(ctx context.Context, url string) error {
+	name, args := browserCommand(os.Getenv("BROWSER"), runtime.GOOS)
+
+	//nolint:gosec // the command is the user's $BROWSER or the fixed system opener
+	if err := exec.CommandContext(ctx, name, append(args, url)...).Start(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// browserCommand returns the program and leading arguments that open a URL:
+// $BROWSER when set, otherwise the opener for goos
+func browserCommand(browserEnv, goos string) (string, []string) {
+	if fields := strings.Fields(browserEnv); len(fields) > 0 {
+		return fields[0], fields[1:]
+	}
+
+	switch goos {
+	case "darwin":
+		return "open", nil
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler"}
+	default:
+		return "xdg-open", nil
+	}
+}
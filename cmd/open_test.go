@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// stubBrowser replaces launchBrowser for a test, recording opened URLs
+func stubBrowser(t *testing.T, err error) *[]string {
+	t.Helper()
+
+	var opened []string
+
+	original := launchBrowser
+	launchBrowser = func(_ context.Context, url string) error {
+		opened = append(opened, url)
+		return err
+	}
+
+	t.Cleanup(func() { launchBrowser = original })
+
+	return &opened
+}
+
+func TestOpenRepository(t *testing.T) {
+	mock := &MockRepository{repos: []storage.StoredRepo{
+		{FullName: "cli/cli", Homepage: "https://cli.github.com"},
+		{FullName: "user/bare"},
+	}}
+	ctx := context.Background()
+
+	t.Run("github page", func(t *testing.T) {
+		opened := stubBrowser(t, nil)
+
+		var out bytes.Buffer
+		require.NoError(t, openRepository(ctx, &out, mock, "cli/cli", false))
+		assert.Equal(t, []string{"https://github.com/cli/cli"}, *opened)
+		assert.Equal(t, "https://github.com/cli/cli\n", out.String())
+	})
+
+	t.Run("homepage", func(t *testing.T) {
+		opened := stubBrowser(t, nil)
+
+		var out bytes.Buffer
+		require.NoError(t, openRepository(ctx, &out, mock, "cli/cli", true))
+		assert.Equal(t, []string{"https://cli.github.com"}, *opened)
+	})
+
+	t.Run("missing homepage", func(t *testing.T) {
+		opened := stubBrowser(t, nil)
+
+		err := openRepository(ctx, &bytes.Buffer{}, mock, "user/bare", true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has no homepage")
+		assert.Empty(t, *opened)
+	})
+
+	t.Run("not starred", func(t *testing.T) {
+		stubBrowser(t, nil)
+
+		err := openRepository(ctx, &bytes.Buffer{}, mock, "other/repo", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not in the local database")
+	})
+
+	t.Run("headless prints the URL", func(t *testing.T) {
+		stubBrowser(t, fmt.Errorf("exec: \"xdg-open\": executable file not found in $PATH"))
+
+		var out bytes.Buffer
+		require.NoError(t, openRepository(ctx, &out, mock, "cli/cli", false))
+		assert.Contains(t, out.String(), "https://github.com/cli/cli\n")
+		assert.Contains(t, out.String(), "Could not open a browser")
+	})
+}
+
+func TestResolveOpenTarget(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	_, err := resolveOpenTarget(cacheDir, "1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no previous search results")
+
+	require.NoError(t, saveLastResults(cacheDir, "terminal ui", []query.Result{
+		{Repository: storage.StoredRepo{FullName: "charmbracelet/bubbletea"}},
+		{Repository: storage.StoredRepo{FullName: "rivo/tview"}},
+	}))
+
+	name, err := resolveOpenTarget(cacheDir, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "rivo/tview", name)
+
+	_, err = resolveOpenTarget(cacheDir, "3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+
+	name, err = resolveOpenTarget(cacheDir, "cli/cli")
+	require.NoError(t, err)
+	assert.Equal(t, "cli/cli", name)
+
+	_, err = resolveOpenTarget(cacheDir, "not-a-repo")
+	require.Error(t, err)
+}
+
+func TestBrowserCommand(t *testing.T) {
+	tests := []struct {
+		browserEnv string
+		goos       string
+		wantName   string
+		wantArgs   []string
+	}{
+		{goos: "darwin", wantName: "open"},
+		{goos: "linux", wantName: "xdg-open"},
+		{goos: "windows", wantName: "rundll32", wantArgs: []string{"url.dll,FileProtocolHandler"}},
+		{browserEnv: "firefox --new-tab", goos: "linux", wantName: "firefox", wantArgs: []string{"--new-tab"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.browserEnv, func(t *testing.T) {
+			name, args := browserCommand(tt.browserEnv, tt.goos)
+			assert.Equal(t, tt.wantName, name)
+			assert.ElementsMatch(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/urfave/cli/v3"
@@ -13,9 +16,12 @@ import (
 	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/embedding"
 	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
+	"github.com/KyleKing/gh-star-search/internal/processor"
 	"github.com/KyleKing/gh-star-search/internal/python"
 	"github.com/KyleKing/gh-star-search/internal/query"
 	"github.com/KyleKing/gh-star-search/internal/related"
+	"github.com/KyleKing/gh-star-search/internal/scoring"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
@@ -32,8 +38,9 @@ const (
 
 func QueryCommand() *cli.Command {
 	return &cli.Command{
-		Name:  "query",
-		Usage: "Search starred repositories using fuzzy or vector search",
+		Name:    "query",
+		Aliases: []string{"search"},
+		Usage:   "Search starred repositories using fuzzy or vector search",
 		Description: `Search your starred repositories using a query string. Supports two search modes:
 - fuzzy: Full-text search with BM25 scoring (default)
 - vector: Semantic similarity search using embeddings
@@ -42,14 +49,21 @@ Examples:
   gh star-search query "web framework"
   gh star-search query --mode vector "machine learning"
   gh star-search query --limit 5 --long "golang http"
-  gh star-search query --related "react components"`,
+  gh star-search query --related "react components"
+  gh star-search query --tag "evaluate for work" "cli"
+  gh star-search query --topic cli --topic go "terminal"
+  gh star-search query --language rust --min-stars 1000 --updated-after 2024-01-01 "parser"
+  gh star-search query --keyword tokenizer "parser"
+  gh star-search query --near kubernetes/kubernetes
+  gh star-search query --chunks "retry with exponential backoff"
+  gh star-search query --contributor alice
+  gh star-search search --min-score 1.5 "terminal ui"`,
 		ArgsUsage: "<search-string>",
-		Flags: []cli.Flag{
+		Flags: slices.Concat([]cli.Flag{
 			&cli.StringFlag{
 				Name:    "mode",
 				Aliases: []string{"m"},
-				Value:   "fuzzy",
-				Usage:   "Search mode: fuzzy or vector",
+				Usage:   "Search mode: fuzzy or vector (default: search.default_mode, fuzzy unless configured)",
 			},
 			&cli.IntFlag{
 				Name:    "limit",
@@ -76,7 +90,32 @@ Examples:
 				Aliases: []string{"r"},
 				Usage:   "Include related repositories in results",
 			},
-		},
+			&cli.StringFlag{
+				Name:  "near",
+				Usage: "Find repositories similar to owner/repo using stored embeddings (no search string)",
+			},
+			&cli.StringFlag{
+				Name:  "contributor",
+				Usage: "Find repositories this GitHub user is a top contributor to, most contributions first (no search string)",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Print one record per result instead of long/short output (csv, tsv)",
+			},
+			&cli.StringFlag{
+				Name:  "output-template-file",
+				Usage: "Render results with a text/template file (path, or name in ~/.config/gh-star-search/templates/)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-history",
+				Usage: "Do not record this query in the local query history",
+			},
+			&cli.BoolFlag{
+				Name:  "explain-plan",
+				Usage: "Show the DuckDB query plan for the search instead of running it (fuzzy mode only)",
+			},
+		}, searchFilterFlags(), filterFlags("return")),
 		Action: runQuery,
 	}
 }
@@ -87,38 +126,184 @@ func runQuery(ctx context.Context, cmd *cli.Command) error {
 
 	// Parse arguments
 	args := cmd.Args().Slice()
-	if len(args) != 1 {
-		return errors.New(errors.ErrTypeValidation, "expected exactly one search string argument")
+	near := strings.TrimSpace(cmd.String("near"))
+	contributor := strings.TrimPrefix(strings.TrimSpace(cmd.String("contributor")), "@")
+	mode := cmd.String("mode")
+	if !cmd.IsSet("mode") {
+		mode = defaultQueryMode(configFromContext)
 	}
 
-	// Validate query string
-	queryString := strings.TrimSpace(args[0])
-	if err := validateQuery(queryString); err != nil {
+	var queryString string
+
+	switch {
+	case near != "" && contributor != "":
+		return errors.New(errors.ErrTypeValidation, "--near and --contributor cannot be combined")
+	case contributor != "":
+		if len(args) != 0 {
+			return errors.New(errors.ErrTypeValidation, "--contributor does not take a search string argument")
+		}
+
+		// Results are ranked by contribution count rather than a text or vector score
+		if cmd.IsSet("mode") || cmd.IsSet("min-score") || cmd.Bool("case-sensitive") || cmd.Bool("explain-plan") ||
+			cmd.Bool("chunks") {
+			return errors.New(errors.ErrTypeValidation,
+				"--contributor cannot be combined with --mode, --min-score, --case-sensitive, --explain-plan or --chunks")
+		}
+
+		mode = "fuzzy"
+	case near != "":
+		if len(args) != 0 {
+			return errors.New(errors.ErrTypeValidation, "--near does not take a search string argument")
+		}
+
+		if err := validateRepositoryName(near); err != nil {
+			return err
+		}
+
+		if cmd.IsSet("mode") && mode != "vector" {
+			return errors.New(errors.ErrTypeValidation, "--near always uses vector similarity")
+		}
+
+		mode = "vector"
+	default:
+		if len(args) != 1 {
+			return errors.New(errors.ErrTypeValidation, "expected exactly one search string argument")
+		}
+
+		// Validate query string
+		queryString = strings.TrimSpace(args[0])
+		if err := validateQuery(queryString); err != nil {
+			return err
+		}
+
+		if cmd.Bool("chunks") {
+			if cmd.IsSet("mode") && mode != "vector" {
+				return errors.New(errors.ErrTypeValidation, "--chunks always uses vector similarity")
+			}
+
+			mode = "vector"
+		}
+	}
+
+	filters, err := parseSearchFilterFlags(cmd)
+	if err != nil {
 		return err
 	}
 
-	// Get flag values
-	queryMode := cmd.String("mode")
-	queryLimit := int(cmd.Int("limit"))
-	queryLong := cmd.Bool("long")
-	queryShort := cmd.Bool("short")
-	queryRelated := cmd.Bool("related")
+	req := queryRequest{
+		Query:        queryString,
+		Near:         near,
+		Contributor:  contributor,
+		Mode:         mode,
+		Limit:        int(cmd.Int("limit")),
+		Filters:      filters,
+		Long:         cmd.Bool("long"),
+		Short:        cmd.Bool("short"),
+		Related:      cmd.Bool("related"),
+		TemplateFile: cmd.String("output-template-file"),
+		NoHistory:    cmd.Bool("no-history"),
+		ExplainPlan:  cmd.Bool("explain-plan"),
+	}
+
+	format, err := formatFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	req.Format = strings.ToLower(format)
 
 	// Validate and normalize flags
-	if err := validateQueryFlags(queryMode, queryLimit, queryLong, queryShort); err != nil {
+	if err := validateQueryFlags(req.Mode, req.Limit, req.Long, req.Short); err != nil {
 		return err
 	}
 
+	if req.ExplainPlan && req.Mode != "fuzzy" {
+		return errors.New(errors.ErrTypeValidation, "--explain-plan is only supported in fuzzy mode")
+	}
+
+	if req.Filters.CaseSensitive && req.Mode != "fuzzy" {
+		return errors.New(errors.ErrTypeValidation, "--case-sensitive is only supported in fuzzy mode")
+	}
+
+	switch req.Format {
+	case "", "json", "csv", "tsv":
+	default:
+		return errors.Newf(errors.ErrTypeValidation, "invalid format: %q", format).
+			WithSuggestion("Use csv or tsv, or --json")
+	}
+
+	if req.Format != "" && (req.Long || req.Short || req.Related || req.ExplainPlan || req.TemplateFile != "") {
+		return errors.New(errors.ErrTypeValidation,
+			"--json and --format cannot be combined with --long, --short, --related, --explain-plan or --output-template-file")
+	}
+
+	return executeQuery(ctx, configFromContext, req)
+}
+
+// queryRequest holds the validated inputs for a single search invocation
+type queryRequest struct {
+	Query        string
+	Near         string // Seed repository for a similarity search; Query is empty when set
+	Contributor  string // GitHub login for a contributor search; Query is empty when set
+	Mode         string
+	Limit        int
+	Filters      storage.SearchFilters // Filters and matching options, recorded with the query
+	Long         bool
+	Short        bool
+	Related      bool
+	TemplateFile string // Report template path or name; replaces long/short output
+	NoHistory    bool
+	ExplainPlan  bool
+	Format       string // json, csv or tsv for one record per result instead of long/short output
+}
+
+// minScore returns the --min-score of the request, or search.min_score when
+// none was given. Contributor results have no match score, so nothing is dropped.
+func (req queryRequest) minScore(cfg *config.Config) float64 {
+	switch {
+	case req.Filters.MinScore != nil:
+		return *req.Filters.MinScore
+	case req.Contributor != "":
+		return 0
+	default:
+		return defaultMinScore(cfg)
+	}
+}
+
+// executeQuery runs a validated search and prints the results
+func executeQuery(ctx context.Context, configFromContext *config.Config, req queryRequest) error {
+	queryString := req.Query
+	queryMode := req.Mode
+	queryLimit := req.Limit
+	queryLong := req.Long
+	queryShort := req.Short
+	queryRelated := req.Related
+
+	var tmpl *template.Template
+
+	if req.TemplateFile != "" {
+		var err error
+
+		tmpl, err = loadOutputTemplate(req.TemplateFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Initialize repository
-	repo, err := storage.NewDuckDBRepository(configFromContext.Database.Path)
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&configFromContext.Database)
 	if err != nil {
-		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database")
+		return databaseOpenError(err)
 	}
 	defer repo.Close()
 
 	// Check if database exists and is initialized
 	if err := repo.Initialize(ctx); err != nil {
-		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database schema")
+		return schemaError(err)
+	}
+
+	if req.ExplainPlan {
+		return displayQueryPlan(ctx, repo, queryString)
 	}
 
 	slog.Debug("Executing query",
@@ -126,50 +311,97 @@ func runQuery(ctx context.Context, cmd *cli.Command) error {
 		slog.String("mode", queryMode),
 		slog.Int("limit", queryLimit))
 
-	// Initialize embedding manager (nil if not configured/enabled)
-	embConfig := embedding.DefaultConfig()
-	var uvPath, projectDir string
-	if queryMode == "vector" {
-		embConfig.Enabled = true
-		uvPath, err = python.FindUV()
+	// Set search options
+	searchOpts := query.SearchOptions{
+		Limit:           queryLimit,
+		MinScore:        req.minScore(configFromContext),
+		Tag:             req.Filters.Tag,
+		Filter:          req.Filters.Filter,
+		Keyword:         req.Filters.Keyword,
+		ContentLanguage: req.Filters.ContentLanguage,
+		ReadmeFormat:    req.Filters.ReadmeFormat,
+		MaxPerOwner:     req.Filters.MaxPerOwner,
+		CaseSensitive:   req.Filters.CaseSensitive,
+		Chunks:          req.Filters.Chunks,
+	}
+
+	var results []query.Result
+
+	switch {
+	case req.Near != "":
+		results, err = searchNear(ctx, repo, req.Near, searchOpts)
 		if err != nil {
-			return errors.Wrap(err, errors.ErrTypeValidation, "vector search requires uv")
+			return err
 		}
-		cacheDir := config.ExpandPath(configFromContext.Cache.Directory)
-		projectDir, err = python.EnsureEnvironment(ctx, uvPath, cacheDir)
+	case req.Contributor != "":
+		results, err = query.NewSearchEngine(repo, nil).SearchByContributor(ctx, req.Contributor, searchOpts)
+		if err != nil {
+			return errors.Wrap(err, errors.ErrTypeDatabase, "contributor search failed")
+		}
+	default:
+		results, err = searchQuery(ctx, configFromContext, repo, queryString, queryMode, searchOpts)
 		if err != nil {
-			return errors.Wrap(err, errors.ErrTypeValidation, "failed to prepare Python environment")
+			return err
 		}
 	}
-	embManager, err := embedding.NewManager(embConfig, uvPath, projectDir)
-	if err != nil {
-		slog.Warn("Failed to initialize embedding manager", slog.String("error", err.Error()))
+
+	// Record the query in the local history (best effort); similarity and
+	// contributor searches have no query string to re-run
+	if !req.NoHistory && req.Near == "" && req.Contributor == "" {
+		entry := storage.QueryHistoryEntry{
+			Query:       queryString,
+			Mode:        queryMode,
+			Limit:       queryLimit,
+			ResultCount: len(results),
+			Filters:     req.Filters,
+		}
+		if err := repo.RecordQuery(ctx, entry); err != nil {
+			slog.Warn("Failed to record query history", slog.String("error", err.Error()))
+		}
 	}
 
-	// Initialize search engine
-	searchEngine := query.NewSearchEngine(repo, embManager)
+	// Remember the ranking so 'open N' can refer to a result (best effort)
+	label := queryString
+	if req.Near != "" {
+		label = "--near " + req.Near
+	} else if req.Contributor != "" {
+		label = "--contributor " + req.Contributor
+	}
 
-	// Create query object
-	searchQuery := query.Query{
-		Raw:  queryString,
-		Mode: query.Mode(queryMode),
+	if err := saveLastResults(config.ExpandPath(configFromContext.Cache.Directory), label, results); err != nil {
+		slog.Warn("Failed to save search results", slog.String("error", err.Error()))
 	}
 
-	// Set search options
-	searchOpts := query.SearchOptions{
-		Limit:    queryLimit,
-		MinScore: 0.0, // No minimum score filter for now
+	if tmpl != nil {
+		repos := make([]storage.StoredRepo, len(results))
+		for i, result := range results {
+			repos[i] = result.Repository
+		}
+
+		return renderOutputTemplate(tmpl, formatter.TemplateData{
+			Query:        queryString,
+			Results:      results,
+			Repositories: repos,
+		})
 	}
 
-	// Execute search
-	results, err := searchEngine.Search(ctx, searchQuery, searchOpts)
-	if err != nil {
-		return errors.Wrap(err, errors.ErrTypeDatabase, "search execution failed")
+	if req.Format != "" {
+		format := formatter.OutputFormat(req.Format)
+		if header := formatter.FormatHeader(format); header != "" {
+			fmt.Println(header)
+		}
+
+		f := formatter.NewFormatter(configFromContext.Formatter)
+		for _, result := range results {
+			fmt.Println(f.FormatResult(result, format))
+		}
+
+		return nil
 	}
 
 	// Display results
 	if len(results) == 0 {
-		fmt.Println("No results found.")
+		fmt.Println(noResultsMessage(ctx, repo))
 		return nil
 	}
 
@@ -189,11 +421,13 @@ func runQuery(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Display results
+	fmtCfg := formatter.WithDefaults(configFromContext.Formatter)
+
 	for i, result := range results {
 		if longForm {
-			displayLongFormResult(i+1, result, queryRelated)
+			displayLongFormResult(i+1, result, queryString, fmtCfg)
 		} else {
-			displayShortFormResult(i+1, result)
+			displayShortFormResult(i+1, result, fmtCfg)
 		}
 
 		if i < len(results)-1 {
@@ -235,6 +469,125 @@ func runQuery(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// noResultsMessage explains an empty result set, pointing at sync when nothing is stored yet
+func noResultsMessage(ctx context.Context, repo storage.Repository) string {
+	if stats, err := repo.GetStats(ctx); err == nil && stats.TotalRepositories == 0 {
+		return "No repositories found. Run 'gh star-search sync' to populate the database."
+	}
+
+	return "No results found."
+}
+
+// searchQuery runs a fuzzy or vector search for a query string
+func searchQuery(
+	ctx context.Context,
+	configFromContext *config.Config,
+	repo storage.Repository,
+	queryString, queryMode string,
+	searchOpts query.SearchOptions,
+) ([]query.Result, error) {
+	var err error
+
+	// Initialize embedding manager (nil if not configured/enabled)
+	embConfig := embedding.DefaultConfig()
+	var uvPath, projectDir string
+	if queryMode == "vector" {
+		embConfig.Enabled = true
+		uvPath, err = python.FindUV()
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrTypeValidation, "vector search requires uv")
+		}
+		cacheDir := config.ExpandPath(configFromContext.Cache.Directory)
+		projectDir, err = python.EnsureEnvironment(ctx, uvPath, cacheDir)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrTypeValidation, "failed to prepare Python environment")
+		}
+	}
+	embManager, err := embedding.NewManager(embConfig, uvPath, projectDir)
+	if err != nil {
+		slog.Warn("Failed to initialize embedding manager", slog.String("error", err.Error()))
+	}
+
+	// Initialize search engine
+	var engineOpts []query.EngineOption
+	if source := configFromContext.Search.ScoreExpression; source != "" {
+		expr, err := scoring.Parse(source)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrTypeConfig, "invalid search score expression").
+				WithSuggestion("Check search.score_expression in your configuration")
+		}
+
+		engineOpts = append(engineOpts, query.WithScoreExpression(expr))
+	}
+
+	searchEngine := query.NewSearchEngine(repo, embManager, engineOpts...)
+
+	// Create query object
+	searchQuery := query.Query{
+		Raw:  queryString,
+		Mode: query.Mode(queryMode),
+	}
+
+	// Execute search
+	results, err := searchEngine.Search(ctx, searchQuery, searchOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeDatabase, "search execution failed")
+	}
+
+	return results, nil
+}
+
+// searchNear finds repositories similar to fullName using its stored embedding
+func searchNear(
+	ctx context.Context,
+	repo storage.Repository,
+	fullName string,
+	searchOpts query.SearchOptions,
+) ([]query.Result, error) {
+	seed, err := repo.GetRepository(ctx, fullName)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeValidation,
+			fmt.Sprintf("repository '%s' not found in your starred repositories", fullName))
+	}
+
+	results, err := query.NewSearchEngine(repo, nil).SearchSimilar(ctx, *seed, searchOpts)
+	if stderrors.Is(err, query.ErrNoEmbedding) {
+		return nil, errors.Newf(errors.ErrTypeValidation, "repository '%s' has no embedding", fullName).
+			WithSuggestion("Run 'gh star-search sync --embed' to generate embeddings")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeDatabase, "similarity search failed")
+	}
+
+	return results, nil
+}
+
+// displayQueryPlan prints the EXPLAIN output and parsed operators for a fuzzy search
+func displayQueryPlan(ctx context.Context, repo *storage.DuckDBRepository, queryString string) error {
+	plan, err := repo.ExplainTextSearch(ctx, queryString)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to explain search query").
+			WithSuggestion("Run 'gh star-search sync' to build the search index")
+	}
+
+	fmt.Println(plan.Raw)
+	fmt.Println("Operations:")
+
+	for i, op := range plan.Operations {
+		estimate := "?"
+		if op.EstimatedRows >= 0 {
+			estimate = strconv.Itoa(op.EstimatedRows)
+		}
+
+		fmt.Printf("  %d. %s (estimated rows: %s)\n", i+1, op.Name, estimate)
+	}
+
+	fmt.Printf("Uses index scan: %t\n", plan.UsesIndex())
+
+	return nil
+}
+
 // validateQuery validates the search query string
 func validateQuery(query string) error {
 	if len(query) < MinQueryLength {
@@ -264,6 +617,24 @@ func validateQuery(query string) error {
 	return nil
 }
 
+// defaultQueryMode returns the configured search.default_mode, falling back to fuzzy
+func defaultQueryMode(cfg *config.Config) string {
+	if cfg == nil || cfg.Search.DefaultMode == "" {
+		return "fuzzy"
+	}
+
+	return cfg.Search.DefaultMode
+}
+
+// defaultMinScore returns the configured search.min_score, or 0 without a config
+func defaultMinScore(cfg *config.Config) float64 {
+	if cfg == nil {
+		return 0
+	}
+
+	return cfg.Search.MinScore
+}
+
 // validateQueryFlags validates and normalizes command flags
 func validateQueryFlags(queryMode string, queryLimit int, queryLong, queryShort bool) error {
 	// Validate mode
@@ -288,8 +659,19 @@ func validateQueryFlags(queryMode string, queryLimit int, queryLong, queryShort
 	return nil
 }
 
+// validateReadmeFormat checks the --readme-format filter value
+func validateReadmeFormat(format string) error {
+	switch format {
+	case "", processor.ReadmeFormatMarkdown, processor.ReadmeFormatRST, processor.ReadmeFormatText, query.ReadmeFormatNone:
+		return nil
+	default:
+		return errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("invalid README format '%s'. Must be 'markdown', 'rst', 'text' or 'none'", format))
+	}
+}
+
 // displayLongFormResult displays a search result in long format
-func displayLongFormResult(rank int, result query.Result, _ bool) {
+func displayLongFormResult(rank int, result query.Result, queryString string, fmtCfg config.FormatterConfig) {
 	repo := result.Repository
 
 	// Header line with link
@@ -312,9 +694,9 @@ func displayLongFormResult(rank int, result query.Result, _ bool) {
 	fmt.Printf("GitHub External Description Link: %s\n", homepage)
 
 	// Numbers: issues, PRs, stars, forks
-	fmt.Printf("Numbers: %d/%d open issues, %d/%d open PRs, %d stars, %d forks\n",
-		repo.OpenIssuesOpen, repo.OpenIssuesTotal,
-		repo.OpenPRsOpen, repo.OpenPRsTotal,
+	fmt.Printf("Numbers: %s/%s open issues, %s/%s open PRs, %d stars, %d forks\n",
+		formatCount(repo.OpenIssuesOpen), formatCount(repo.OpenIssuesTotal),
+		formatCount(repo.OpenPRsOpen), formatCount(repo.OpenPRsTotal),
 		repo.StargazersCount, repo.ForksCount)
 
 	// Commits
@@ -322,9 +704,9 @@ func displayLongFormResult(rank int, result query.Result, _ bool) {
 	commits1y := repo.Commits1y
 	commitsTotal := repo.CommitsTotal
 
-	commits30dStr := formatCommitCount(commits30d)
-	commits1yStr := formatCommitCount(commits1y)
-	commitsTotalStr := formatCommitCount(commitsTotal)
+	commits30dStr := formatCount(commits30d)
+	commits1yStr := formatCount(commits1y)
+	commitsTotalStr := formatCount(commitsTotal)
 
 	fmt.Printf("Commits: %s in last 30 days, %s in last year, %s total\n",
 		commits30dStr, commits1yStr, commitsTotalStr)
@@ -345,14 +727,32 @@ func displayLongFormResult(rank int, result query.Result, _ bool) {
 
 	fmt.Printf("License: %s\n", license)
 
-	// Top 10 Contributors
-	contributors := formatContributors(repo.Contributors)
-	fmt.Printf("Top 10 Contributors: %s\n", contributors)
+	// Top N Contributors
+	contributors := formatContributors(repo.Contributors, fmtCfg.MaxContributors)
+	fmt.Printf("Top %d Contributors: %s\n", fmtCfg.MaxContributors, contributors)
 
 	// GitHub Topics
 	topics := formatTopics(repo.Topics)
 	fmt.Printf("GitHub Topics: %s\n", topics)
 
+	// Local tags
+	if len(repo.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(repo.Tags, ", "))
+	}
+
+	// README-derived keywords
+	if len(repo.Keywords) > 0 {
+		fmt.Printf("Keywords: %s\n", strings.Join(repo.Keywords, ", "))
+	}
+
+	if repo.ContentLanguage != "" {
+		fmt.Printf("Content Language: %s\n", repo.ContentLanguage)
+	}
+
+	if repo.ReadmePath != "" {
+		fmt.Printf("README: %s (%s)\n", repo.ReadmePath, repo.ReadmeFormat)
+	}
+
 	// Languages
 	languages := formatLanguages(repo.Languages)
 	fmt.Printf("Languages: %s\n", languages)
@@ -365,12 +765,20 @@ func displayLongFormResult(rank int, result query.Result, _ bool) {
 	lastSynced := formatAge(repo.LastSynced)
 	fmt.Printf("Last synced: %s\n", lastSynced)
 
+	if matched := formatter.NewFormatter(fmtCfg).FormatMatched(result.Matches, queryString); matched != "" {
+		fmt.Printf("Matched: %s\n", matched)
+	}
+
+	if chunk, ok := matchedChunk(result); ok {
+		fmt.Printf("Matched Chunk (%s): %s\n", chunk.Source, chunkSnippet(chunk, fmtCfg.MaxDescriptionLength))
+	}
+
 	// Score
 	fmt.Printf("Score: %.2f\n", result.Score)
 }
 
 // displayShortFormResult displays a search result in short format
-func displayShortFormResult(rank int, result query.Result) {
+func displayShortFormResult(rank int, result query.Result, fmtCfg config.FormatterConfig) {
 	repo := result.Repository
 
 	// First line: rank, name, stars, primary language, updated, score
@@ -385,21 +793,38 @@ func displayShortFormResult(rank int, result query.Result) {
 		rank, repo.FullName, repo.StargazersCount, primaryLang, updated, result.Score)
 
 	// Second line: truncated description
-	description := repo.Description
-	if len(description) > 80 {
-		description = description[:77] + "..."
+	description := truncateDescription(repo.Description, fmtCfg.MaxDescriptionLength)
+
+	fmt.Printf("   %s\n", description)
+
+	if len(repo.Tags) > 0 {
+		fmt.Printf("   Tags: %s\n", strings.Join(repo.Tags, ", "))
 	}
 
-	if description == "" {
-		description = "-"
+	if chunk, ok := matchedChunk(result); ok {
+		fmt.Printf("   Matched %s: %s\n", chunk.Source, chunkSnippet(chunk, fmtCfg.MaxDescriptionLength))
 	}
+}
 
-	fmt.Printf("   %s\n", description)
+// matchedChunk returns the content chunk a chunk-level search matched, if any
+func matchedChunk(result query.Result) (storage.Match, bool) {
+	for _, match := range result.Matches {
+		if match.Field == storage.MatchFieldChunk {
+			return match, true
+		}
+	}
+
+	return storage.Match{}, false
+}
+
+// chunkSnippet flattens a matched chunk onto one line, truncated to maxLen
+func chunkSnippet(chunk storage.Match, maxLen int) string {
+	return truncateDescription(strings.Join(strings.Fields(chunk.Content), " "), maxLen)
 }
 
 // Helper functions for formatting
 
-func formatCommitCount(count int) string {
+func formatCount(count int) string {
 	if count < 0 {
 		return "?"
 	}
@@ -447,15 +872,24 @@ func formatAge(timestamp time.Time) string {
 	return fmt.Sprintf("%d years ago", years)
 }
 
-func formatContributors(contributors []storage.Contributor) string {
+// truncateDescription shortens a description to maxLen runes, using "-" when empty
+func truncateDescription(description string, maxLen int) string {
+	if description == "" {
+		return "-"
+	}
+
+	return formatter.TruncateRunes(maxLen, description)
+}
+
+func formatContributors(contributors []storage.Contributor, limit int) string {
 	if len(contributors) == 0 {
 		return "-"
 	}
 
-	parts := make([]string, 0, 10)
+	parts := make([]string, 0, limit)
 
 	for i, contrib := range contributors {
-		if i >= 10 { // Limit to top 10
+		if i >= limit { // Limit to the top contributors
 			break
 		}
 
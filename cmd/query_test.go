@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
 func TestValidateQuery(t *testing.T) {
@@ -144,6 +152,37 @@ func TestValidateQueryFlags(t *testing.T) {
 	}
 }
 
+func TestDefaultQueryMode(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want string
+	}{
+		{name: "no config", cfg: nil, want: "fuzzy"},
+		{name: "unset", cfg: &config.Config{}, want: "fuzzy"},
+		{name: "vector", cfg: &config.Config{Search: config.SearchConfig{DefaultMode: "vector"}}, want: "vector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultQueryMode(tt.cfg); got != tt.want {
+				t.Errorf("defaultQueryMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultMinScore(t *testing.T) {
+	if got := defaultMinScore(nil); got != 0 {
+		t.Errorf("defaultMinScore(nil) = %g, want 0", got)
+	}
+
+	cfg := &config.Config{Search: config.SearchConfig{MinScore: 0.4}}
+	if got := defaultMinScore(cfg); got != 0.4 {
+		t.Errorf("defaultMinScore() = %g, want 0.4", got)
+	}
+}
+
 func TestFormatAge(t *testing.T) {
 	now := time.Now()
 
@@ -198,3 +237,159 @@ func TestFormatAge(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchNear(t *testing.T) {
+	repo := &MockRepository{repos: []storage.StoredRepo{
+		{FullName: "seed/repo", RepoEmbedding: []float32{0.1, 0.2}},
+		{FullName: "near/one", RepoEmbedding: []float32{0.1, 0.3}},
+		{FullName: "plain/repo"},
+		{FullName: "near/two", RepoEmbedding: []float32{0.2, 0.2}},
+	}}
+
+	tests := []struct {
+		name      string
+		fullName  string
+		wantRepos []string
+		errSubstr string
+	}{
+		{
+			name:      "excludes seed",
+			fullName:  "seed/repo",
+			wantRepos: []string{"near/one", "near/two"},
+		},
+		{
+			name:      "seed without embedding",
+			fullName:  "plain/repo",
+			errSubstr: "has no embedding",
+		},
+		{
+			name:      "unknown seed",
+			fullName:  "missing/repo",
+			errSubstr: "not found in your starred repositories",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := searchNear(context.Background(), repo, tt.fullName, query.SearchOptions{Limit: 10})
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("searchNear() error = %v, want substring %q", err, tt.errSubstr)
+				}
+
+				if !errors.IsType(err, errors.ErrTypeValidation) {
+					t.Errorf("searchNear() error type = %v, want validation", errors.GetType(err))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("searchNear() unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Repository.FullName)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.wantRepos, ",") {
+				t.Errorf("searchNear() = %v, want %v", got, tt.wantRepos)
+			}
+		})
+	}
+}
+
+func TestTruncateDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		maxLen      int
+		expected    string
+	}{
+		{name: "empty", description: "", maxLen: 10, expected: "-"},
+		{name: "fits", description: "short", maxLen: 10, expected: "short"},
+		{name: "truncated", description: "a much longer description", maxLen: 10, expected: "a much ..."},
+		{name: "multibyte", description: "日本語のとても長い説明文です", maxLen: 8, expected: "日本語のと..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateDescription(tt.description, tt.maxLen); got != tt.expected {
+				t.Errorf("truncateDescription() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNoResultsMessage(t *testing.T) {
+	ctx := context.Background()
+
+	empty := noResultsMessage(ctx, &MockRepository{})
+	if !strings.Contains(empty, "gh star-search sync") {
+		t.Errorf("noResultsMessage() for empty database = %q, want sync suggestion", empty)
+	}
+
+	populated := noResultsMessage(ctx, &MockRepository{repos: []storage.StoredRepo{{FullName: "a/b"}}})
+	if populated != "No results found." {
+		t.Errorf("noResultsMessage() for populated database = %q", populated)
+	}
+}
+
+func TestRunQueryContributorAndChunksValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		errSubstr string
+	}{
+		{name: "search string", args: []string{"--contributor", "alice", "cli"}, errSubstr: "does not take a search string"},
+		{name: "with near", args: []string{"--contributor", "alice", "--near", "cli/cli"}, errSubstr: "cannot be combined"},
+		{name: "with mode", args: []string{"--contributor", "alice", "--mode", "vector"}, errSubstr: "--mode"},
+		{name: "with min score", args: []string{"--contributor", "alice", "--min-score", "1"}, errSubstr: "--min-score"},
+		{name: "with chunks", args: []string{"--contributor", "alice", "--chunks"}, errSubstr: "--chunks"},
+		{name: "chunks in fuzzy mode", args: []string{"--chunks", "--mode", "fuzzy", "retry"}, errSubstr: "vector similarity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &cli.Command{Name: "app", Commands: []*cli.Command{QueryCommand()}}
+
+			err := app.Run(context.Background(), append([]string{"app", "query"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("query error = %v, want substring %q", err, tt.errSubstr)
+			}
+
+			if errors.GetType(err) != errors.ErrTypeValidation {
+				t.Errorf("query error type = %v, want validation", errors.GetType(err))
+			}
+		})
+	}
+}
+
+func TestRunQueryFormatValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		errSubstr string
+	}{
+		{name: "unknown format", args: []string{"--format", "xml", "retry"}, errSubstr: "invalid format"},
+		{name: "with long", args: []string{"--format", "csv", "--long", "retry"}, errSubstr: "--long"},
+		{name: "with template", args: []string{"--format", "tsv", "--output-template-file", "x", "retry"}, errSubstr: "--output-template-file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &cli.Command{Name: "app", Commands: []*cli.Command{QueryCommand()}}
+
+			err := app.Run(context.Background(), append([]string{"app", "query"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("query error = %v, want substring %q", err, tt.errSubstr)
+			}
+
+			if errors.GetType(err) != errors.ErrTypeValidation {
+				t.Errorf("query error type = %v, want validation", errors.GetType(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// MaxRandomCount caps how many repositories random picks at once
+const MaxRandomCount = 50
+
+func RandomCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "random",
+		Usage: "Show random starred repositories to rediscover",
+		Description: `Pick repositories at random from the local database, for rediscovering what
+you starred and forgot. The filter flags narrow the pool before picking.
+
+A single pick is shown in long form, several in short form.
+
+Examples:
+  gh star-search random
+  gh star-search random --count 5 --language go
+  gh star-search random --min-stars 1000 --topic cli`,
+		Flags: append([]cli.Flag{
+			&cli.IntFlag{
+				Name:    "count",
+				Aliases: []string{"n"},
+				Value:   1,
+				Usage:   fmt.Sprintf("Number of repositories to pick (1-%d)", MaxRandomCount),
+			},
+		}, filterFlags("pick")...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			count := int(cmd.Int("count"))
+			if count < 1 || count > MaxRandomCount {
+				return errors.New(errors.ErrTypeValidation,
+					fmt.Sprintf("--count must be between 1 and %d", MaxRandomCount))
+			}
+
+			filter, err := parseFilterFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			cfg := getConfigFromContext(ctx)
+
+			repo, err := openStorage(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer repo.Close()
+
+			return runRandom(ctx, os.Stdout, repo, cfg.Formatter, count, filter)
+		},
+	}
+}
+
+// runRandom prints count repositories matching filter, chosen at random
+func runRandom(
+	ctx context.Context,
+	out io.Writer,
+	repo storage.Repository,
+	fmtCfg config.FormatterConfig,
+	count int,
+	filter storage.Filter,
+) error {
+	repos, err := repo.ListRepositoriesSorted(ctx, storage.ListOptions{
+		Limit:  count,
+		Sort:   storage.ListSortRandom,
+		Filter: filter,
+	})
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to pick random repositories")
+	}
+
+	if len(repos) == 0 {
+		if !filter.IsZero() {
+			fmt.Fprintln(out, "No repositories match the given filters.")
+			return nil
+		}
+
+		fmt.Fprintln(out, "No repositories found. Run 'gh star-search sync' to populate the database.")
+
+		return nil
+	}
+
+	f := formatter.NewFormatter(fmtCfg)
+
+	if len(repos) == 1 {
+		fmt.Fprintln(out, f.FormatRepository(repos[0], formatter.FormatLong))
+		return nil
+	}
+
+	for i, r := range repos {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+
+		fmt.Fprintf(out, "%d. %s\n", i+1, f.FormatRepository(r, formatter.FormatShort))
+	}
+
+	return nil
+}
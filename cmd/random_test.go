@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunRandom(t *testing.T) {
+	ctx := context.Background()
+	filter := storage.Filter{Language: "Go", MinStars: 100}
+
+	t.Run("passes count, filter and random sort", func(t *testing.T) {
+		mock := &MockRepository{repos: []storage.StoredRepo{
+			{FullName: "user/one"},
+			{FullName: "user/two"},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, runRandom(ctx, &out, mock, config.FormatterConfig{}, 2, filter))
+
+		assert.Equal(t, storage.ListOptions{Limit: 2, Sort: storage.ListSortRandom, Filter: filter}, mock.listOptions)
+		assert.Contains(t, out.String(), "1. ")
+		assert.Contains(t, out.String(), "user/two")
+	})
+
+	t.Run("single pick uses long form", func(t *testing.T) {
+		mock := &MockRepository{repos: []storage.StoredRepo{{FullName: "user/one"}}}
+
+		var out bytes.Buffer
+		require.NoError(t, runRandom(ctx, &out, mock, config.FormatterConfig{}, 1, storage.Filter{}))
+
+		assert.Contains(t, out.String(), "user/one")
+		assert.NotContains(t, out.String(), "1. ")
+	})
+
+	t.Run("nothing matches the filter", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, runRandom(ctx, &out, &MockRepository{}, config.FormatterConfig{}, 1, filter))
+
+		assert.Equal(t, "No repositories match the given filters.\n", out.String())
+	})
+}
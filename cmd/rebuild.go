@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func RebuildCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rebuild",
+		Usage: "Rebuild the database from the local cache after it was lost",
+		Description: `Reconstruct repositories from the cache left by earlier syncs: the starred list
+(with repository metadata), extracted content, and activity metrics. GitHub is
+only called for pieces the cache does not have, so recovery after deleting or
+losing the database is much faster than a full re-sync.
+
+Repositories already in the database are left untouched. Run 'sync' afterwards
+to pick up stars added since the cache was written.
+
+Examples:
+  gh star-search rebuild --from-cache`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "from-cache",
+				Usage: "Rebuild from cached metadata and content, fetching only what is missing",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-progress",
+				Usage: "Print plain progress lines instead of spinners (automatic when output is not a terminal)",
+			},
+		},
+		Action: runRebuild,
+	}
+}
+
+// RebuildStats tracks where the rebuilt data came from
+type RebuildStats struct {
+	Total          int
+	Skipped        int // Already in the database
+	Restored       int
+	Failed         int
+	StarredCached  bool
+	ContentCached  int
+	ContentFetched int
+	MetricsCached  int
+	MetricsFetched int
+}
+
+func runRebuild(ctx context.Context, cmd *cli.Command) error {
+	if !cmd.Bool("from-cache") {
+		return errors.New(errors.ErrTypeValidation, "rebuild requires --from-cache").
+			WithSuggestion("Run 'gh star-search rebuild --from-cache', or 'gh star-search sync' for a full re-fetch")
+	}
+
+	cfg := getConfigFromContext(ctx)
+	verbose := cfg.Logging.Level == "debug" || cfg.Debug.Enabled
+
+	applyLockWait(cfg, cmd)
+
+	syncService, err := initializeSyncService(cfg, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to initialize sync service: %w", err)
+	}
+	defer syncService.storage.Close()
+
+	syncService.noProgress = cmd.Bool("no-progress")
+
+	if syncService.cache == nil {
+		return errors.New(errors.ErrTypeConfig, "no cache is available to rebuild from").
+			WithSuggestion("Set cache.directory (or GH_STAR_SEARCH_CACHE_DIR) to the directory used by earlier syncs")
+	}
+
+	if err := syncService.storage.Initialize(ctx); err != nil {
+		return schemaError(err)
+	}
+
+	stats, err := syncService.rebuildFromCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	printRebuildSummary(stats)
+
+	if stats.Restored == 0 {
+		return nil
+	}
+
+	// Keywords depend on the whole corpus, so refresh them after all repositories are stored
+	if err := syncService.refreshKeywords(ctx); err != nil {
+		fmt.Printf("\nWarning: Failed to refresh keywords: %v\n", err)
+	}
+
+	if err := syncService.storage.RebuildFTSIndex(ctx); err != nil {
+		return fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+
+	return nil
+}
+
+// rebuildFromCache restores every starred repository missing from the database,
+// preferring cached metadata, content and metrics over GitHub calls
+func (s *SyncService) rebuildFromCache(ctx context.Context) (*RebuildStats, error) {
+	stats := &RebuildStats{}
+
+	starredRepos, cached := s.cachedStarredRepos(ctx)
+	if cached {
+		stats.StarredCached = true
+	} else {
+		s.log().Debug("Starred list not cached, fetching from GitHub")
+
+		var err error
+
+		starredRepos, err = s.githubClient.GetStarredRepos(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch starred repositories: %w", err)
+		}
+
+		s.cacheStarredRepos(ctx, starredRepos)
+	}
+
+	stats.Total = len(starredRepos)
+
+	existingRepos, err := s.getExistingRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing repositories: %w", err)
+	}
+
+	progress := s.newProgress(len(starredRepos), "Rebuilding from cache")
+	if !s.verbose {
+		progress.Start()
+	}
+
+	var needMetrics []github.Repository
+
+	for _, repo := range starredRepos {
+		if !s.verbose {
+			progress.Update(repo.FullName)
+		}
+
+		if _, ok := existingRepos[repo.FullName]; ok {
+			stats.Skipped++
+			continue
+		}
+
+		if err := s.restoreRepository(ctx, repo, stats); err != nil {
+			s.log().Warn("Failed to restore repository", slog.String("repo", repo.FullName), slog.String("error", err.Error()))
+			stats.Failed++
+
+			continue
+		}
+
+		stats.Restored++
+
+		if metrics, ok := s.cachedMetrics(ctx, repo.FullName); ok {
+			if err := s.storage.UpdateRepositoryMetrics(ctx, repo.FullName, metrics); err != nil {
+				s.log().Warn("Failed to restore metrics",
+					slog.String("repo", repo.FullName), slog.String("error", err.Error()))
+			}
+
+			stats.MetricsCached++
+		} else {
+			needMetrics = append(needMetrics, repo)
+		}
+	}
+
+	if !s.verbose {
+		progress.Finish("Rebuild complete")
+	}
+
+	if len(needMetrics) > 0 {
+		fmt.Printf("Fetching metrics for %d repositories missing from the cache...\n", len(needMetrics))
+		s.fetchAndStoreMetrics(ctx, needMetrics)
+		stats.MetricsFetched = len(needMetrics)
+	}
+
+	return stats, nil
+}
+
+// restoreRepository processes and stores one repository, counting whether its
+// content came from the cache or had to be fetched
+func (s *SyncService) restoreRepository(ctx context.Context, repo github.Repository, stats *RebuildStats) error {
+	if _, err := s.cache.Get(ctx, processor.ContentCacheKey(repo)); err == nil {
+		stats.ContentCached++
+	} else {
+		stats.ContentFetched++
+	}
+
+	content, err := s.processor.ExtractContent(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to extract content: %w", err)
+	}
+
+	processed, err := s.processor.ProcessRepository(ctx, repo, content)
+	if err != nil {
+		return fmt.Errorf("failed to process repository: %w", err)
+	}
+
+	upsertOpts := storage.UpsertOptions{
+		KeywordTerms:  chunkKeywordTerms(processed.Chunks),
+		ReplaceChunks: true,
+		Chunks:        processed.Chunks,
+	}
+	if err := s.storage.UpsertRepository(ctx, *processed, upsertOpts); err != nil {
+		return fmt.Errorf("failed to store repository: %w", err)
+	}
+
+	return nil
+}
+
+func printRebuildSummary(stats *RebuildStats) {
+	starredSource := "GitHub"
+	if stats.StarredCached {
+		starredSource = "cache"
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("REBUILD SUMMARY")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Starred repositories: %d (from %s)\n", stats.Total, starredSource)
+	fmt.Printf("Restored: %d\n", stats.Restored)
+	fmt.Printf("Already present: %d\n", stats.Skipped)
+	fmt.Printf("Failed: %d\n", stats.Failed)
+	fmt.Printf("Content: %d from cache, %d fetched\n", stats.ContentCached, stats.ContentFetched)
+	fmt.Printf("Metrics: %d from cache, %d fetched\n", stats.MetricsCached, stats.MetricsFetched)
+	fmt.Println(strings.Repeat("=", 60))
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/cache"
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestRebuildFromCache(t *testing.T) {
+	ctx := context.Background()
+	cfg, _ := config.LoadConfig()
+
+	fileCache, err := cache.NewFileCache(t.TempDir(), 10, time.Hour)
+	require.NoError(t, err)
+
+	cachedRepo := testutil.NewTestRepository(testutil.WithFullName("user/cached"))
+	uncachedRepo := testutil.NewTestRepository(testutil.WithFullName("user/uncached"))
+	presentRepo := testutil.NewTestRepository(testutil.WithFullName("user/present"))
+	starred := []github.Repository{cachedRepo, uncachedRepo, presentRepo}
+
+	newMock := func() *testutil.MockGitHubClient {
+		return testutil.NewMockGitHubClient(
+			testutil.WithStarredRepos(starred),
+			testutil.WithContent(map[string][]github.Content{
+				"user/cached":   {testutil.NewTestContent("README.md", "Cached readme")},
+				"user/uncached": {testutil.NewTestContent("README.md", "Uncached readme")},
+			}),
+		)
+	}
+
+	// A previous sync left the starred list, one repository's content and its metrics in the cache
+	previous := newMock()
+	writer := &SyncService{
+		githubClient: previous,
+		processor:    processor.NewServiceWithCache(previous, fileCache),
+		cache:        fileCache,
+		config:       cfg,
+	}
+	writer.cacheStarredRepos(ctx, starred)
+	_, err = writer.processor.ExtractContent(ctx, cachedRepo)
+	require.NoError(t, err)
+	writer.cacheMetrics(ctx, "user/cached", storage.RepositoryMetrics{
+		OpenIssuesOpen: 3,
+		CommitsTotal:   42,
+		Contributors:   []storage.Contributor{{Login: "alice", Contributions: 10}},
+	})
+
+	// The database is lost; only user/present was re-added since
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/present")))
+
+	mockGitHub := newMock()
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewServiceWithCache(mockGitHub, fileCache),
+		storage:      repo,
+		cache:        fileCache,
+		config:       cfg,
+	}
+
+	stats, err := syncService.rebuildFromCache(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, &RebuildStats{
+		Total:          3,
+		Skipped:        1,
+		Restored:       2,
+		StarredCached:  true,
+		ContentCached:  1,
+		ContentFetched: 1,
+		MetricsCached:  1,
+		MetricsFetched: 1,
+	}, stats)
+
+	assert.Zero(t, mockGitHub.GetCallCount("GetStarredRepos"), "starred list should come from the cache")
+	assert.Equal(t, 1, mockGitHub.GetCallCount("GetRepositoryContent"), "only uncached content is fetched")
+
+	restored, err := repo.GetRepository(ctx, "user/cached")
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.OpenIssuesOpen)
+	assert.Equal(t, 42, restored.CommitsTotal)
+
+	_, err = repo.GetRepository(ctx, "user/uncached")
+	require.NoError(t, err)
+}
+
+func TestRebuildFromCache_StarredListMissing(t *testing.T) {
+	ctx := context.Background()
+	cfg, _ := config.LoadConfig()
+
+	fileCache, err := cache.NewFileCache(t.TempDir(), 10, time.Hour)
+	require.NoError(t, err)
+
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	mockGitHub := testutil.NewMockGitHubClient(
+		testutil.WithStarredRepos([]github.Repository{testutil.NewTestRepository(testutil.WithFullName("user/alpha"))}),
+		testutil.WithContent(map[string][]github.Content{
+			"user/alpha": {testutil.NewTestContent("README.md", "Alpha readme")},
+		}),
+	)
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewServiceWithCache(mockGitHub, fileCache),
+		storage:      repo,
+		cache:        fileCache,
+		config:       cfg,
+	}
+
+	stats, err := syncService.rebuildFromCache(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, stats.StarredCached)
+	assert.Equal(t, 1, stats.Restored)
+	assert.Equal(t, 1, mockGitHub.GetCallCount("GetStarredRepos"))
+
+	_, cached := syncService.cachedStarredRepos(ctx)
+	assert.True(t, cached, "fetched starred list should be cached for the next rebuild")
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+func RefreshCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "refresh",
+		Usage: "Refresh metadata and metrics of repositories not synced recently",
+		Description: `Re-fetch GitHub metadata (stars, description, topics, license) and activity
+metrics (issues, pull requests, commits, languages, contributors) for stored
+repositories last synced more than --stale-days ago. Content, summaries,
+embeddings and tags are untouched, and the starred list is not re-read, so
+this is a cheaper way to keep metrics current between full syncs.
+
+A repository counts as refreshed once its metrics are stored; one whose
+metrics could not be fetched stays stale and is retried by the next refresh.
+
+Examples:
+  gh star-search refresh
+  gh star-search refresh --stale-days 3
+  gh star-search refresh --metrics-only`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "stale-days",
+				Usage: "Refresh repositories last synced more than this many days ago (default: cache.metadata_stale_days; 0 refreshes all)",
+			},
+			&cli.BoolFlag{
+				Name:  "metrics-only",
+				Usage: "Only refresh activity metrics, leaving stars, description and topics as stored",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-progress",
+				Usage: "Print plain progress lines instead of spinners (automatic when output is not a terminal)",
+			},
+		},
+		Action: runRefresh,
+	}
+}
+
+// RefreshStats tracks the outcome of a metadata refresh
+type RefreshStats struct {
+	Total           int // Stale repositories found
+	MetadataUpdated int // Repositories whose GitHub metadata was re-fetched
+	MetricsUpdated  int // Repositories whose metrics were stored
+	Failed          int // Repositories left stale
+}
+
+func runRefresh(ctx context.Context, cmd *cli.Command) error {
+	cfg := getConfigFromContext(ctx)
+	verbose := cfg.Logging.Level == "debug" || cfg.Debug.Enabled
+
+	staleDays := cfg.Cache.MetadataStaleDays
+	if cmd.IsSet("stale-days") {
+		staleDays = int(cmd.Int("stale-days"))
+	}
+
+	if staleDays < 0 {
+		return errors.New(errors.ErrTypeValidation, "--stale-days must be zero or positive")
+	}
+
+	applyLockWait(cfg, cmd)
+
+	syncService, err := initializeSyncService(cfg, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to initialize sync service: %w", err)
+	}
+	defer syncService.storage.Close()
+
+	syncService.noProgress = cmd.Bool("no-progress")
+
+	if err := syncService.storage.Initialize(ctx); err != nil {
+		return schemaError(err)
+	}
+
+	stats, err := syncService.refreshStale(ctx, staleDays, cmd.Bool("metrics-only"))
+	if err != nil {
+		return err
+	}
+
+	if stats.MetadataUpdated > 0 {
+		if err := syncService.storage.RebuildFTSIndex(ctx); err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+	}
+
+	printRefreshSummary(stats, staleDays, cmd.Bool("metrics-only"))
+
+	return partialFailure(stats.Failed, "refresh")
+}
+
+// refreshStale re-fetches metadata (unless metricsOnly) and metrics for every
+// repository last synced more than staleDays ago, in batches of DefaultBatchSize
+func (s *SyncService) refreshStale(ctx context.Context, staleDays int, metricsOnly bool) (*RefreshStats, error) {
+	names, err := s.storage.GetRepositoriesNeedingMetricsUpdate(ctx, staleDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale repositories: %w", err)
+	}
+
+	stats := &RefreshStats{Total: len(names)}
+	if len(names) == 0 {
+		return stats, nil
+	}
+
+	progress := s.newProgress(len(names), "Refreshing metadata")
+	if !s.verbose {
+		progress.Start()
+	}
+
+	for start := 0; start < len(names); start += DefaultBatchSize {
+		batchNames := names[start:min(start+DefaultBatchSize, len(names))]
+
+		batch, failed := s.refreshBatchMetadata(ctx, batchNames, metricsOnly, progress)
+		stats.Failed += failed
+
+		if !metricsOnly {
+			stats.MetadataUpdated += len(batch)
+		}
+
+		stored := s.fetchAndStoreMetrics(ctx, batch)
+		stats.MetricsUpdated += stored
+		stats.Failed += len(batch) - stored
+	}
+
+	if !s.verbose {
+		progress.Finish("Metadata refresh complete")
+	}
+
+	return stats, nil
+}
+
+// refreshBatchMetadata refreshes the metadata of a batch of repositories with
+// a bounded pool of workers sized like sync's. It returns the repositories to
+// fetch metrics for, in batch order, and the number that failed.
+func (s *SyncService) refreshBatchMetadata(
+	ctx context.Context,
+	names []string,
+	metricsOnly bool,
+	progress *ProgressTracker,
+) ([]github.Repository, int) {
+	repos := make([]*github.Repository, len(names))
+	jobs := make(chan int, len(names))
+
+	for i := range names {
+		jobs <- i
+	}
+
+	close(jobs)
+
+	var wg sync.WaitGroup
+
+	for range s.calculateOptimalWorkers(len(names)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+
+				if !s.verbose {
+					progress.Update(names[i])
+				}
+
+				repo, err := s.refreshRepositoryMetadata(ctx, names[i], metricsOnly)
+				if err != nil {
+					s.log().Warn("Failed to refresh repository", slog.String("repo", names[i]), slog.String("error", err.Error()))
+				} else {
+					repos[i] = &repo
+				}
+
+				// Pace GitHub requests like sync's workers; metrics-only reads only the database
+				if delay := s.repoDelay(); !metricsOnly && delay > 0 {
+					time.Sleep(delay)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	batch := make([]github.Repository, 0, len(names))
+
+	for _, repo := range repos {
+		if repo != nil {
+			batch = append(batch, *repo)
+		}
+	}
+
+	return batch, len(names) - len(batch)
+}
+
+// refreshRepositoryMetadata returns the repository to fetch metrics for. Unless
+// metricsOnly is set, it first re-fetches the repository from GitHub and stores
+// its metadata, keeping the stored content tracking fields and sync time.
+func (s *SyncService) refreshRepositoryMetadata(
+	ctx context.Context,
+	name string,
+	metricsOnly bool,
+) (github.Repository, error) {
+	stored, err := s.storage.GetRepository(ctx, name)
+	if err != nil {
+		return github.Repository{}, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	if metricsOnly {
+		return storedToGitHubRepository(stored), nil
+	}
+
+	fresh, err := s.githubClient.GetRepository(ctx, name)
+	if err != nil {
+		return github.Repository{}, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+
+	// GitHub follows renames; moving the row is left to sync, which tracks renames by id
+	if !strings.EqualFold(fresh.FullName, name) {
+		return github.Repository{}, fmt.Errorf("renamed to %s; run 'gh star-search sync' to move it", fresh.FullName)
+	}
+
+	// Keep the stored name's case, which the row is keyed by
+	fresh.FullName = name
+
+	// last_synced only advances once metrics are stored, so a repository whose
+	// metrics fail stays stale
+	processed := storedToProcessedRepo(*stored)
+	processed.Repository = *fresh
+
+	if err := s.storage.UpdateRepository(ctx, processed); err != nil {
+		return github.Repository{}, fmt.Errorf("failed to store metadata: %w", err)
+	}
+
+	return *fresh, nil
+}
+
+// printRefreshSummary reports the outcome of a metadata refresh
+func printRefreshSummary(stats *RefreshStats, staleDays int, metricsOnly bool) {
+	if stats.Total == 0 {
+		fmt.Printf("No repositories last synced more than %d days ago\n", staleDays)
+		return
+	}
+
+	fmt.Printf("\nStale repositories (>%d days): %d\n", staleDays, stats.Total)
+
+	if !metricsOnly {
+		fmt.Printf("Metadata refreshed: %d\n", stats.MetadataUpdated)
+	}
+
+	fmt.Printf("Metrics refreshed: %d\n", stats.MetricsUpdated)
+
+	if stats.Failed > 0 {
+		fmt.Printf("Failed (still stale): %d\n", stats.Failed)
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func RefreshContentCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "refresh-content",
+		Usage: "Re-extract repository content without touching metadata or metrics",
+		Description: `Re-fetch and re-chunk the content of stored repositories and update their
+content hash. Metadata, activity metrics, summaries, and embeddings are preserved.
+Useful after changing content extraction rules.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "repo",
+				Aliases: []string{"r"},
+				Usage:   "Refresh content for a specific repository",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Bypass the content cache and update even when the content hash is unchanged",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-progress",
+				Usage: "Print plain progress lines instead of spinners (automatic when output is not a terminal)",
+			},
+		},
+		Action: runRefreshContent,
+	}
+}
+
+// RefreshContentStats tracks the outcome of a content refresh
+type RefreshContentStats struct {
+	Total     int
+	Updated   int
+	Unchanged int
+	Failed    int
+}
+
+func runRefreshContent(ctx context.Context, cmd *cli.Command) error {
+	specificRepo := cmd.String("repo")
+	force := cmd.Bool("force")
+
+	cfg := getConfigFromContext(ctx)
+	verbose := cfg.Logging.Level == "debug" || cfg.Debug.Enabled
+
+	applyLockWait(cfg, cmd)
+
+	syncService, err := initializeSyncService(cfg, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to initialize sync service: %w", err)
+	}
+	defer syncService.storage.Close()
+
+	syncService.noProgress = cmd.Bool("no-progress")
+
+	if err := syncService.storage.Initialize(ctx); err != nil {
+		return schemaError(err)
+	}
+
+	// Forced refreshes re-fetch content rather than reusing cached extractions
+	if force {
+		syncService.processor = processor.NewService(syncService.githubClient, processorOptions(cfg, syncService.storage)...)
+	}
+
+	stats, err := syncService.refreshContent(ctx, specificRepo, force)
+	if err != nil {
+		return err
+	}
+
+	printRefreshContentSummary(stats)
+
+	return partialFailure(stats.Failed, "refresh")
+}
+
+// refreshContent re-extracts content for stored repositories and updates only their
+// content hash. When repoName is empty, every stored repository is refreshed.
+func (s *SyncService) refreshContent(
+	ctx context.Context,
+	repoName string,
+	force bool,
+) (*RefreshContentStats, error) {
+	var targets []*storage.StoredRepo
+
+	if repoName != "" {
+		existing, err := s.storage.GetRepository(ctx, repoName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+
+		targets = append(targets, existing)
+	} else {
+		existingRepos, err := s.getExistingRepositories(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get existing repositories: %w", err)
+		}
+
+		for _, existing := range existingRepos {
+			targets = append(targets, existing)
+		}
+
+		sort.Slice(targets, func(i, j int) bool {
+			return targets[i].FullName < targets[j].FullName
+		})
+	}
+
+	stats := &RefreshContentStats{Total: len(targets)}
+
+	progress := s.newProgress(len(targets), "Refreshing content")
+	if !s.verbose {
+		progress.Start()
+	}
+
+	for _, existing := range targets {
+		if !s.verbose {
+			progress.Update(existing.FullName)
+		}
+
+		changed, err := s.refreshRepositoryContent(ctx, existing, force)
+		if err != nil {
+			s.log().Warn("Failed to refresh content",
+				slog.String("repo", existing.FullName), slog.String("error", err.Error()))
+			stats.Failed++
+
+			continue
+		}
+
+		if changed {
+			stats.Updated++
+		} else {
+			stats.Unchanged++
+		}
+	}
+
+	if !s.verbose {
+		progress.Finish("Content refresh complete")
+	}
+
+	return stats, nil
+}
+
+// refreshRepositoryContent re-extracts and re-chunks a single repository's content,
+// storing the new content hash and chunks together when it differs (or when forced)
+func (s *SyncService) refreshRepositoryContent(
+	ctx context.Context,
+	existing *storage.StoredRepo,
+	force bool,
+) (bool, error) {
+	repo := storedToGitHubRepository(existing)
+	repo.ID = existing.GitHubID
+
+	content, err := s.processor.ExtractContent(ctx, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract content: %w", err)
+	}
+
+	processed, err := s.processor.ProcessRepository(ctx, repo, content)
+	if err != nil {
+		return false, fmt.Errorf("failed to process repository: %w", err)
+	}
+
+	if !processed.ContentChangedSince(existing.ContentHash) && !force {
+		s.log().Debug("Content unchanged", slog.String("repo", existing.FullName))
+		return false, nil
+	}
+
+	processed.Chunks, _ = processor.LimitChunks(processed.Chunks, s.maxChunks)
+
+	// A content refresh is not a sync, so keep the last sync time
+	processed.ProcessedAt = existing.LastSynced
+
+	upsertOpts := storage.UpsertOptions{
+		KeywordTerms:  chunkKeywordTerms(processed.Chunks),
+		ReplaceChunks: true,
+		Chunks:        processed.Chunks,
+	}
+	if err := s.storage.UpsertRepository(ctx, *processed, upsertOpts); err != nil {
+		return false, fmt.Errorf("failed to update repository content: %w", err)
+	}
+
+	s.log().Debug("Updated content", slog.String("repo", existing.FullName), slog.Int("chunks", len(processed.Chunks)))
+
+	return true, nil
+}
+
+// storedToGitHubRepository rebuilds the GitHub repository fields needed for content extraction
+func storedToGitHubRepository(stored *storage.StoredRepo) github.Repository {
+	repo := github.Repository{
+		FullName:        stored.FullName,
+		Description:     stored.Description,
+		Homepage:        stored.Homepage,
+		Language:        stored.Language,
+		StargazersCount: stored.StargazersCount,
+		ForksCount:      stored.ForksCount,
+		UpdatedAt:       stored.UpdatedAt,
+		CreatedAt:       stored.CreatedAt,
+		Topics:          stored.Topics,
+		Size:            stored.SizeKB,
+		Archived:        stored.Archived,
+	}
+
+	if stored.LicenseName != "" || stored.LicenseSPDXID != "" {
+		repo.License = &github.License{
+			Name:   stored.LicenseName,
+			SPDXID: stored.LicenseSPDXID,
+		}
+	}
+
+	return repo
+}
+
+func printRefreshContentSummary(stats *RefreshContentStats) {
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("CONTENT REFRESH SUMMARY")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Total repositories: %d\n", stats.Total)
+	fmt.Printf("Content updated: %d\n", stats.Updated)
+	fmt.Printf("Unchanged: %d\n", stats.Unchanged)
+	fmt.Printf("Failed: %d\n", stats.Failed)
+	fmt.Println(strings.Repeat("=", 60))
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestSyncService_RefreshContent(t *testing.T) {
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if err := repo.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/refresh-repo")); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := storage.RepositoryMetrics{OpenIssuesOpen: 7, CommitsTotal: 99}
+	if err := repo.UpdateRepositoryMetrics(ctx, "user/refresh-repo", metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGitHub := &MockGitHubClient{
+		content: map[string][]github.Content{
+			"user/refresh-repo": {
+				{
+					Path:     "README.md",
+					Type:     "file",
+					Content:  "IyBSZWZyZXNoZWQgUkVBRE1FCgpOZXcgY29udGVudCBhZnRlciBjaGFuZ2luZyBleHRyYWN0aW9uLg==", // "# Refreshed README\n\nNew content after changing extraction."
+					Size:     60,
+					Encoding: "base64",
+				},
+			},
+		},
+	}
+
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+	}
+
+	tests := []struct {
+		name          string
+		force         bool
+		wantUpdated   int
+		wantUnchanged int
+	}{
+		{name: "content changed", wantUpdated: 1},
+		{name: "content unchanged", wantUnchanged: 1},
+		{name: "forced refresh", force: true, wantUpdated: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := syncService.refreshContent(ctx, "user/refresh-repo", tt.force)
+			if err != nil {
+				t.Fatalf("refreshContent() error = %v", err)
+			}
+
+			if stats.Updated != tt.wantUpdated || stats.Unchanged != tt.wantUnchanged {
+				t.Errorf("refreshContent() updated=%d unchanged=%d, want updated=%d unchanged=%d",
+					stats.Updated, stats.Unchanged, tt.wantUpdated, tt.wantUnchanged)
+			}
+
+			stored, err := repo.GetRepository(ctx, "user/refresh-repo")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stored.OpenIssuesOpen != 7 || stored.CommitsTotal != 99 {
+				t.Errorf("metrics not preserved: open issues=%d, commits=%d",
+					stored.OpenIssuesOpen, stored.CommitsTotal)
+			}
+		})
+	}
+
+	chunks, err := repo.GetChunksNeedingEmbedding(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) == 0 || !strings.Contains(chunks[0].Content, "Refreshed README") {
+		t.Errorf("refreshed chunks not stored with the new content hash: %+v", chunks)
+	}
+
+	if _, err := syncService.refreshContent(ctx, "user/missing-repo", false); err == nil {
+		t.Error("expected error for repository not in database")
+	}
+}
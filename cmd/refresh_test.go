@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestSyncService_RefreshStale(t *testing.T) {
+	longAgo := time.Now().AddDate(0, 0, -30)
+
+	tests := []struct {
+		name        string
+		metricsOnly bool
+		wantStats   RefreshStats
+		wantStars   int
+		wantFetches int // GetRepository calls
+	}{
+		{
+			name:        "metadata and metrics",
+			wantStats:   RefreshStats{Total: 2, MetadataUpdated: 1, MetricsUpdated: 1, Failed: 1},
+			wantStars:   999,
+			wantFetches: 2,
+		},
+		{
+			name:        "metrics only",
+			metricsOnly: true,
+			wantStats:   RefreshStats{Total: 2, MetricsUpdated: 2},
+			wantStars:   10,
+			wantFetches: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			repo, cleanup := storage.NewTestDB(t)
+			defer cleanup()
+
+			seed := func(name string, syncedAt time.Time) {
+				processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(
+					testutil.WithFullName(name), testutil.WithStars(10)), nil)
+				processed.ProcessedAt = syncedAt
+				require.NoError(t, repo.UpsertRepository(ctx, processed, storage.UpsertOptions{}))
+			}
+
+			seed("user/stale", longAgo)
+			seed("user/gone", longAgo.Add(time.Hour))
+			seed("user/fresh", time.Now())
+			require.NoError(t, repo.UpdateRepositorySummary(ctx, "user/stale", "Kept summary"))
+
+			mockGitHub := testutil.NewMockGitHubClient(testutil.WithStarredRepos([]github.Repository{
+				testutil.NewTestRepository(testutil.WithFullName("user/stale"), testutil.WithStars(999)),
+				testutil.NewTestRepository(testutil.WithFullName("user/fresh"), testutil.WithStars(999)),
+			}))
+
+			syncService := &SyncService{githubClient: mockGitHub, storage: repo, verbose: true}
+
+			stats, err := syncService.refreshStale(ctx, 14, tt.metricsOnly)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStats, *stats)
+			assert.Equal(t, tt.wantFetches, mockGitHub.GetCallCount("GetRepository"))
+
+			stale, err := repo.GetRepository(ctx, "user/stale")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStars, stale.StargazersCount)
+			assert.Equal(t, "Kept summary", stale.Purpose)
+			assert.WithinDuration(t, time.Now(), stale.LastSynced, time.Minute, "storing metrics marks it fresh")
+
+			fresh, err := repo.GetRepository(ctx, "user/fresh")
+			require.NoError(t, err)
+			assert.Equal(t, 10, fresh.StargazersCount, "recently synced repositories are not refreshed")
+
+			remaining, err := repo.GetRepositoriesNeedingMetricsUpdate(ctx, 14)
+			require.NoError(t, err)
+
+			if tt.metricsOnly {
+				assert.Empty(t, remaining)
+			} else {
+				assert.Equal(t, []string{"user/gone"}, remaining, "a failed refresh stays stale")
+			}
+		})
+	}
+}
+
+// slowGitHubClient delays GetRepository to stand in for API latency
+type slowGitHubClient struct {
+	*testutil.MockGitHubClient
+	latency time.Duration
+}
+
+func (c *slowGitHubClient) GetRepository(ctx context.Context, fullName string) (*github.Repository, error) {
+	time.Sleep(c.latency)
+	return c.MockGitHubClient.GetRepository(ctx, fullName)
+}
+
+// seedRefreshRepos stores n repositories and returns a mock client that
+// serves all but the skipped ones
+func seedRefreshRepos(tb testing.TB, repo *storage.DuckDBRepository, n int, skip map[int]bool) ([]string, *testutil.MockGitHubClient) {
+	tb.Helper()
+
+	names := make([]string, n)
+	starred := make([]github.Repository, 0, n)
+
+	for i := range n {
+		names[i] = fmt.Sprintf("user/repo-%02d", i)
+		ghRepo := testutil.NewTestRepository(testutil.WithFullName(names[i]), testutil.WithStars(i))
+
+		processed := testutil.NewTestProcessedRepo(ghRepo, nil)
+		require.NoError(tb, repo.UpsertRepository(context.Background(), processed, storage.UpsertOptions{}))
+
+		if !skip[i] {
+			ghRepo.StargazersCount = 1000 + i
+			starred = append(starred, ghRepo)
+		}
+	}
+
+	return names, testutil.NewMockGitHubClient(testutil.WithStarredRepos(starred))
+}
+
+func TestSyncService_RefreshBatchMetadataParallel(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	names, mockGitHub := seedRefreshRepos(t, repo, 12, map[int]bool{3: true, 7: true})
+
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		storage:      repo,
+		verbose:      true,
+		config:       &config.Config{Sync: config.SyncConfig{MaxWorkers: 4, RepoDelay: "0s"}},
+	}
+
+	batch, failed := syncService.refreshBatchMetadata(context.Background(), names, false, nil)
+	assert.Equal(t, 2, failed)
+	assert.Equal(t, 12, mockGitHub.GetCallCount("GetRepository"))
+	require.Len(t, batch, 10)
+
+	want := make([]string, 0, 10)
+
+	for i, name := range names {
+		if i != 3 && i != 7 {
+			want = append(want, name)
+		}
+	}
+
+	got := make([]string, 0, len(batch))
+	for _, r := range batch {
+		got = append(got, r.FullName)
+		assert.GreaterOrEqual(t, r.StargazersCount, 1000, "fresh metadata is returned")
+	}
+
+	assert.Equal(t, want, got, "results keep batch order")
+}
+
+// BenchmarkRefreshBatchMetadata compares one worker with the default pool for
+// a batch whose GitHub requests each take a few milliseconds
+func BenchmarkRefreshBatchMetadata(b *testing.B) {
+	ctx := context.Background()
+
+	repo, err := storage.NewDuckDBRepository(filepath.Join(b.TempDir(), "bench.db"))
+	require.NoError(b, err)
+	defer repo.Close()
+
+	require.NoError(b, repo.Initialize(ctx))
+
+	names, mockGitHub := seedRefreshRepos(b, repo, 40, nil)
+	client := &slowGitHubClient{MockGitHubClient: mockGitHub, latency: 5 * time.Millisecond}
+
+	for _, workers := range []int{1, MaxWorkerCap} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			syncService := &SyncService{
+				githubClient: client,
+				storage:      repo,
+				verbose:      true,
+				config:       &config.Config{Sync: config.SyncConfig{MaxWorkers: workers, RepoDelay: "0s"}},
+			}
+
+			for range b.N {
+				if _, failed := syncService.refreshBatchMetadata(ctx, names, false, nil); failed > 0 {
+					b.Fatalf("%d repositories failed to refresh", failed)
+				}
+			}
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
 	"github.com/KyleKing/gh-star-search/internal/related"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
@@ -64,15 +66,15 @@ func runRelated(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Initialize repository
-	repo, err := storage.NewDuckDBRepository(configFromContext.Database.Path)
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&configFromContext.Database)
 	if err != nil {
-		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database")
+		return databaseOpenError(err)
 	}
 	defer repo.Close()
 
 	// Check if database exists and is initialized
 	if err := repo.Initialize(ctx); err != nil {
-		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database schema")
+		return schemaError(err)
 	}
 
 	// Verify the target repository exists
@@ -105,8 +107,10 @@ func runRelated(ctx context.Context, cmd *cli.Command) error {
 	fmt.Printf("Repositories related to %s:\n\n", repoFullName)
 
 	// Display each related repository in short form with explanation
+	fmtCfg := formatter.WithDefaults(configFromContext.Formatter)
+
 	for i, rel := range relatedRepos {
-		displayRelatedRepository(i+1, rel, targetRepo)
+		displayRelatedRepository(i+1, rel, targetRepo, fmtCfg)
 
 		if i < len(relatedRepos)-1 {
 			fmt.Println() // Add spacing between results
@@ -133,7 +137,12 @@ func validateRepositoryName(repoName string) error {
 }
 
 // displayRelatedRepository displays a related repository result
-func displayRelatedRepository(rank int, rel related.Repository, _ *storage.StoredRepo) {
+func displayRelatedRepository(
+	rank int,
+	rel related.Repository,
+	_ *storage.StoredRepo,
+	fmtCfg config.FormatterConfig,
+) {
 	repo := rel.Repository
 
 	// First line: rank, name, stars, primary language, score
@@ -146,14 +155,7 @@ func displayRelatedRepository(rank int, rel related.Repository, _ *storage.Store
 		rank, repo.FullName, repo.StargazersCount, primaryLang, rel.Score)
 
 	// Second line: truncated description
-	description := repo.Description
-	if len(description) > 80 {
-		description = description[:77] + "..."
-	}
-
-	if description == "" {
-		description = "-"
-	}
+	description := truncateDescription(repo.Description, fmtCfg.MaxDescriptionLength)
 
 	fmt.Printf("   %s\n", description)
 
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
 	"github.com/KyleKing/gh-star-search/internal/related"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
@@ -178,7 +180,7 @@ func TestDisplayRelatedRepository(t *testing.T) {
 			os.Stdout = w
 
 			// Display the repository
-			displayRelatedRepository(tt.rank, tt.rel, targetRepo)
+			displayRelatedRepository(tt.rank, tt.rel, targetRepo, formatter.WithDefaults(config.FormatterConfig{}))
 
 			// Restore stdout and get output
 			w.Close()
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/logging"
+)
+
+// debugMode is set from the loaded configuration; it adds underlying causes
+// to printed errors
+var debugMode bool
+
+// Execute runs gh-star-search with args, starting with the program name, and
+// returns the process exit code. Errors are printed to stderr: as JSON under
+// --json, otherwise with their suggestions.
+func Execute(args []string, version string) int {
+	return execute(context.Background(), args, version, os.Stderr)
+}
+
+func execute(ctx context.Context, args []string, version string, stderr io.Writer) int {
+	root := newRootCommand(version)
+
+	err := root.Run(ctx, args)
+	if err == nil {
+		return ExitOK
+	}
+
+	var structErr *errors.Error
+
+	switch {
+	case root.Bool("json"):
+		WriteJSONError(stderr, err)
+	case stderrors.As(err, &structErr):
+		printStructuredError(stderr, structErr)
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+	}
+
+	return ExitCode(err)
+}
+
+// newRootCommand builds the gh-star-search command: the global flags, loading
+// the configuration and logging before any subcommand runs, and every
+// subcommand with --timings support.
+func newRootCommand(version string) *cli.Command {
+	var logCloser io.Closer
+
+	return &cli.Command{
+		Name:  "gh-star-search",
+		Usage: "Search your starred GitHub repositories using natural language",
+		Description: `gh-star-search is a GitHub CLI extension that ingests and indexes all repositories
+starred by the currently logged-in user. It enables natural language search queries
+against a local DuckDB database containing both structured metadata and unstructured
+content from your starred repositories.`,
+		Version: version,
+		Flags:   globalFlags(),
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			newCtx, closer, err := initializeGlobalConfig(ctx, cmd, version)
+			logCloser = closer
+
+			if err != nil && repairsConfig(cmd.Args().Slice()) {
+				// config init and config validate load the configuration
+				// themselves, so they still run when it is invalid
+				return ctx, nil
+			}
+
+			return newCtx, err
+		},
+		After: func(context.Context, *cli.Command) error {
+			if logCloser != nil {
+				return logCloser.Close()
+			}
+
+			return nil
+		},
+		Commands: WithTimings(Commands()),
+	}
+}
+
+// globalFlags returns the flags every subcommand inherits from the root
+func globalFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "config file path (default: ~/.config/gh-star-search/config.json)",
+		},
+		&cli.StringFlag{
+			Name:    "log-level",
+			Aliases: []string{"l"},
+			Usage:   "log level (debug, info, warn, error)",
+		},
+		&cli.StringFlag{
+			Name:  "log-format",
+			Usage: "log format (text, json); json makes sync logs machine-parseable",
+		},
+		&cli.BoolFlag{
+			Name:  "verbose",
+			Usage: "enable verbose output",
+		},
+		&cli.BoolFlag{
+			Name:  "debug",
+			Usage: "enable debug mode",
+		},
+		&cli.StringFlag{
+			Name:    "db-path",
+			Aliases: []string{"db"},
+			Usage:   "database file path for this invocation, e.g. a separate index per topic",
+		},
+		&cli.StringFlag{
+			Name:  "cache-dir",
+			Usage: "cache directory path",
+		},
+		TimingsFlag(),
+		JSONFlag(),
+	}
+}
+
+// Commands returns every subcommand of gh-star-search, as registered by
+// Execute for both entrypoints
+func Commands() []*cli.Command {
+	return []*cli.Command{
+		SyncCommand(),
+		RefreshCommand(),
+		RefreshContentCommand(),
+		RebuildCommand(),
+		FetchCommand(),
+		DiffCommand(),
+		SummarizeCommand(),
+		EmbedCommand(),
+		ListCommand(),
+		BrowseCommand(),
+		ExportCommand(),
+		ImportCommand(),
+		InfoCommand(),
+		OpenCommand(),
+		RandomCommand(),
+		StatsCommand(),
+		ClearCommand(),
+		MigrateCommand(),
+		DBCommand(),
+		QueryCommand(),
+		HistoryCommand(),
+		SearchesCommand(),
+		TagCommand(),
+		RelatedCommand(),
+		SimilarCommand(),
+		ConfigCommand(),
+		DoctorCommand(),
+	}
+}
+
+// initializeGlobalConfig loads the configuration with the root flags applied,
+// sets up logging and stores the configuration in the context. The returned
+// closer releases the log file, if any.
+func initializeGlobalConfig(ctx context.Context, root *cli.Command, version string) (context.Context, io.Closer, error) {
+	// Prepare flag overrides
+	flagOverrides := make(map[string]interface{})
+
+	if logLevel := root.String("log-level"); logLevel != "" {
+		flagOverrides["log-level"] = logLevel
+	}
+
+	if logFormat := root.String("log-format"); logFormat != "" {
+		flagOverrides["log-format"] = logFormat
+	}
+
+	if verbose := root.Bool("verbose"); verbose {
+		flagOverrides["verbose"] = verbose
+	}
+
+	if debug := root.Bool("debug"); debug {
+		flagOverrides["debug"] = debug
+	}
+
+	if dbPath := root.String("db-path"); dbPath != "" {
+		flagOverrides["db-path"] = dbPath
+	}
+
+	if cacheDir := root.String("cache-dir"); cacheDir != "" {
+		flagOverrides["cache-dir"] = cacheDir
+	}
+
+	// Set custom config file path if provided
+	if configFile := root.String("config"); configFile != "" {
+		os.Setenv("GH_STAR_SEARCH_CONFIG", configFile)
+	}
+
+	// Load configuration with overrides
+	cfg, err := config.LoadConfigWithOverrides(flagOverrides)
+	if err != nil {
+		return ctx, nil, errors.Wrap(err, errors.ErrTypeConfig, "failed to load configuration").
+			WithSuggestion("Run 'gh star-search config validate' to list every issue")
+	}
+
+	// Expand paths and ensure directories exist
+	cfg.ExpandAllPaths()
+
+	if err := cfg.EnsureDirectories(); err != nil {
+		return ctx, nil, errors.Wrap(
+			err,
+			errors.ErrTypeFileSystem,
+			"failed to create required directories",
+		)
+	}
+
+	// Keep stdout for the JSON document alone
+	if root.Bool("json") && cfg.Logging.Output == "stdout" {
+		cfg.Logging.Output = "stderr"
+	}
+
+	// Initialize logging with slog
+	logCloser, err := logging.SetupLogger(cfg.Logging)
+	if err != nil {
+		return ctx, nil, errors.Wrap(err, errors.ErrTypeConfig, "failed to initialize logging")
+	}
+
+	// Log startup information using slog
+	slog.Info("gh-star-search starting",
+		slog.String("version", version),
+		slog.String("config", cfg.Database.Path))
+
+	debugMode = cfg.Debug.Enabled
+	if debugMode {
+		slog.Debug("Debug mode enabled")
+		slog.Debug("Configuration loaded", slog.Any("config", cfg))
+	}
+
+	return WithConfig(ctx, cfg), logCloser, nil
+}
+
+// repairsConfig reports whether args invoke a config subcommand that works
+// without a valid configuration
+func repairsConfig(args []string) bool {
+	if len(args) < 2 || args[0] != "config" {
+		return false
+	}
+
+	return args[1] == "init" || args[1] == "validate"
+}
+
+// printStructuredError prints a user-friendly error message
+func printStructuredError(w io.Writer, err *errors.Error) {
+	fmt.Fprintf(w, "Error: %s\n", err.Message)
+
+	if len(err.Suggestions) > 0 {
+		fmt.Fprintf(w, "\nSuggestions:\n")
+
+		for _, suggestion := range err.Suggestions {
+			fmt.Fprintf(w, "  - %s\n", suggestion)
+		}
+	}
+
+	if err.Cause != nil && debugMode {
+		fmt.Fprintf(w, "\nUnderlying error: %v\n", err.Cause)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+)
+
+func TestCommands_UniqueNames(t *testing.T) {
+	seen := make(map[string]bool)
+
+	for _, command := range Commands() {
+		assert.False(t, seen[command.Name], "command %q registered twice", command.Name)
+		seen[command.Name] = true
+	}
+
+	for _, name := range []string{"db", "doctor", "migrate", "open", "random", "summarize", "browse", "similar"} {
+		assert.True(t, seen[name], "command %q is not registered", name)
+	}
+}
+
+func TestExecute_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GH_STAR_SEARCH_CONFIG", "")
+
+	t.Run("json", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := execute(context.Background(), []string{"gh-star-search", "--json", "similar"}, "dev", &stderr)
+		assert.Equal(t, ExitError, code)
+
+		var out jsonError
+		require.NoError(t, json.Unmarshal(stderr.Bytes(), &out))
+		assert.Equal(t, "expected exactly one repository argument", out.Error)
+		assert.Equal(t, string(errors.ErrTypeValidation), out.Type)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := execute(context.Background(), []string{"gh-star-search", "similar"}, "dev", &stderr)
+		assert.Equal(t, ExitError, code)
+		assert.Equal(t, "Error: expected exactly one repository argument\n", stderr.String())
+	})
+}
+
+func TestRootCommand_DBPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GH_STAR_SEARCH_CONFIG", "")
+
+	for _, flag := range []string{"--db", "--db-path"} {
+		t.Run(flag, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "topic.db")
+
+			var got string
+
+			root := newRootCommand("dev")
+			root.Commands = append(root.Commands, &cli.Command{
+				Name: "probe",
+				Action: func(ctx context.Context, _ *cli.Command) error {
+					got = getConfigFromContext(ctx).Database.Path
+					return nil
+				},
+			})
+
+			require.NoError(t, root.Run(context.Background(), []string{"gh-star-search", flag, dbPath, "probe"}))
+			assert.Equal(t, dbPath, got)
+		})
+	}
+}
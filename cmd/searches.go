@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func SearchesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "searches",
+		Usage: "Manage saved named searches",
+		Description: `Save frequently used query parameters under a name and run them later.
+The query filters (--tag, --topic, --language, --min-stars, --keyword, ...) are
+saved with the search and applied every time it runs.
+
+Examples:
+  gh star-search searches save rust-clis "rust cli" --limit 20
+  gh star-search searches save new-go-tools "cli" --language go --updated-after 2025-01-01
+  gh star-search searches run rust-clis
+  gh star-search searches list
+  gh star-search searches delete rust-clis`,
+		Commands: []*cli.Command{
+			{
+				Name:      "save",
+				Usage:     "Save a named search",
+				ArgsUsage: "<name> <search-string>",
+				Flags: slices.Concat([]cli.Flag{
+					&cli.StringFlag{
+						Name:    "mode",
+						Aliases: []string{"m"},
+						Usage:   "Search mode: fuzzy or vector (default: search.default_mode)",
+					},
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"l"},
+						Value:   DefaultQueryLimit,
+						Usage: fmt.Sprintf(
+							"Maximum number of results (%d-%d)",
+							MinQueryLimit,
+							MaxQueryLimit,
+						),
+					},
+				}, searchFilterFlags(), filterFlags("return")),
+				Action: runSaveSearch,
+			},
+			{
+				Name:      "run",
+				Usage:     "Run a saved search",
+				ArgsUsage: "<name>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "long",
+						Aliases: []string{"L"},
+						Usage:   "Use long-form output format",
+					},
+					&cli.BoolFlag{
+						Name:    "short",
+						Aliases: []string{"s"},
+						Usage:   "Use short-form output format",
+					},
+					&cli.BoolFlag{
+						Name:  "no-history",
+						Usage: "Do not record this query in the local query history",
+					},
+				},
+				Action: runSavedSearch,
+			},
+			{
+				Name:   "list",
+				Usage:  "List saved searches",
+				Action: runListSavedSearches,
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete a saved search",
+				ArgsUsage: "<name>",
+				Action:    runDeleteSavedSearch,
+			},
+		},
+	}
+}
+
+func runSaveSearch(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) != 2 {
+		return errors.New(errors.ErrTypeValidation, "expected a name and a search string")
+	}
+
+	cfg := getConfigFromContext(ctx)
+
+	filters, err := parseSearchFilterFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	search := storage.SavedSearch{
+		Name:    strings.TrimSpace(args[0]),
+		Query:   strings.TrimSpace(args[1]),
+		Mode:    cmd.String("mode"),
+		Limit:   int(cmd.Int("limit")),
+		Filters: filters,
+	}
+
+	switch {
+	case filters.Chunks:
+		// As for query, --chunks always uses vector similarity
+		if cmd.IsSet("mode") && search.Mode != "vector" {
+			return errors.New(errors.ErrTypeValidation, "--chunks always uses vector similarity")
+		}
+
+		search.Mode = "vector"
+	case !cmd.IsSet("mode"):
+		search.Mode = defaultQueryMode(cfg)
+	}
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	return RunSaveSearchWithStorage(ctx, search, repo)
+}
+
+// RunSaveSearchWithStorage validates and persists a saved search
+func RunSaveSearchWithStorage(
+	ctx context.Context,
+	search storage.SavedSearch,
+	repo storage.Repository,
+) error {
+	if err := validateSavedSearchName(search.Name); err != nil {
+		return err
+	}
+
+	if err := validateQuery(search.Query); err != nil {
+		return err
+	}
+
+	if err := validateQueryFlags(search.Mode, search.Limit, false, false); err != nil {
+		return err
+	}
+
+	if err := validateSearchFilters(search.Filters); err != nil {
+		return err
+	}
+
+	if search.Filters.CaseSensitive && search.Mode != "fuzzy" {
+		return errors.New(errors.ErrTypeValidation, "--case-sensitive is only supported in fuzzy mode")
+	}
+
+	if err := repo.SaveSearch(ctx, search); err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to save search")
+	}
+
+	fmt.Printf("Saved search %q\n", search.Name)
+
+	return nil
+}
+
+func runSavedSearch(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New(errors.ErrTypeValidation, "expected exactly one saved search name")
+	}
+
+	name := strings.TrimSpace(cmd.Args().First())
+	cfg := getConfigFromContext(ctx)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	search, err := repo.GetSavedSearch(ctx, name)
+	// Release the database before the query opens its own connection
+	repo.Close()
+
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, "failed to load saved search").
+			WithSuggestion("Run 'gh star-search searches list' to see saved searches")
+	}
+
+	req := queryRequest{
+		Query:     search.Query,
+		Mode:      search.Mode,
+		Limit:     search.Limit,
+		Filters:   search.Filters,
+		Long:      cmd.Bool("long"),
+		Short:     cmd.Bool("short"),
+		NoHistory: cmd.Bool("no-history"),
+	}
+
+	if err := validateQueryFlags(req.Mode, req.Limit, req.Long, req.Short); err != nil {
+		return err
+	}
+
+	return executeQuery(ctx, cfg, req)
+}
+
+func runListSavedSearches(ctx context.Context, _ *cli.Command) error {
+	repo, err := openStorage(ctx, getConfigFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	return RunListSavedSearchesWithStorage(ctx, repo)
+}
+
+// RunListSavedSearchesWithStorage prints all saved searches
+func RunListSavedSearchesWithStorage(ctx context.Context, repo storage.Repository) error {
+	searches, err := repo.ListSavedSearches(ctx)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to list saved searches")
+	}
+
+	if len(searches) == 0 {
+		fmt.Println("No saved searches.")
+		return nil
+	}
+
+	for _, search := range searches {
+		fmt.Printf("%s: %q (mode: %s, limit: %d)\n",
+			search.Name, search.Query, search.Mode, search.Limit)
+	}
+
+	return nil
+}
+
+func runDeleteSavedSearch(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New(errors.ErrTypeValidation, "expected exactly one saved search name")
+	}
+
+	name := strings.TrimSpace(cmd.Args().First())
+
+	repo, err := openStorage(ctx, getConfigFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	if err := repo.DeleteSavedSearch(ctx, name); err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, "failed to delete saved search")
+	}
+
+	fmt.Printf("Deleted saved search %q\n", name)
+
+	return nil
+}
+
+// validateSavedSearchName ensures a saved search name is a single non-empty token
+func validateSavedSearchName(name string) error {
+	if name == "" {
+		return errors.New(errors.ErrTypeValidation, "saved search name cannot be empty")
+	}
+
+	if strings.ContainsAny(name, " \t\n") {
+		return errors.New(errors.ErrTypeValidation,
+			fmt.Sprintf("saved search name '%s' must not contain whitespace", name))
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunSaveSearchWithStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  storage.SavedSearch
+		wantErr bool
+	}{
+		{
+			name:   "valid search",
+			search: storage.SavedSearch{Name: "rust-clis", Query: "rust cli", Mode: "fuzzy", Limit: 20},
+		},
+		{
+			name:    "empty name",
+			search:  storage.SavedSearch{Name: "", Query: "rust cli", Mode: "fuzzy", Limit: 20},
+			wantErr: true,
+		},
+		{
+			name:    "name with whitespace",
+			search:  storage.SavedSearch{Name: "rust clis", Query: "rust cli", Mode: "fuzzy", Limit: 20},
+			wantErr: true,
+		},
+		{
+			name:    "query too short",
+			search:  storage.SavedSearch{Name: "short", Query: "r", Mode: "fuzzy", Limit: 20},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			search:  storage.SavedSearch{Name: "bad-mode", Query: "rust cli", Mode: "exact", Limit: 20},
+			wantErr: true,
+		},
+		{
+			name: "with filters",
+			search: storage.SavedSearch{
+				Name: "go-clis", Query: "cli", Mode: "fuzzy", Limit: 20,
+				Filters: storage.SearchFilters{
+					Tag:    "work",
+					Filter: storage.Filter{Topics: []string{"cli"}, Language: "go", MinStars: 100},
+				},
+			},
+		},
+		{
+			name: "case sensitive in vector mode",
+			search: storage.SavedSearch{
+				Name: "exact", Query: "rust cli", Mode: "vector", Limit: 20,
+				Filters: storage.SearchFilters{CaseSensitive: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max per owner",
+			search: storage.SavedSearch{
+				Name: "diverse", Query: "rust cli", Mode: "fuzzy", Limit: 20,
+				Filters: storage.SearchFilters{MaxPerOwner: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "limit out of range",
+			search:  storage.SavedSearch{Name: "too-many", Query: "rust cli", Mode: "fuzzy", Limit: 500},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{}
+
+			err := RunSaveSearchWithStorage(context.Background(), tt.search, mockRepo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunSaveSearchWithStorage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if len(mockRepo.saved) != 0 {
+					t.Errorf("expected nothing saved, got %d searches", len(mockRepo.saved))
+				}
+
+				return
+			}
+
+			saved, err := mockRepo.GetSavedSearch(context.Background(), tt.search.Name)
+			if err != nil {
+				t.Fatalf("saved search not persisted: %v", err)
+			}
+
+			if saved.Query != tt.search.Query {
+				t.Errorf("saved query = %q, want %q", saved.Query, tt.search.Query)
+			}
+
+			if !reflect.DeepEqual(saved.Filters, tt.search.Filters) {
+				t.Errorf("saved filters = %+v, want %+v", saved.Filters, tt.search.Filters)
+			}
+		})
+	}
+}
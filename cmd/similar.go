@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
+	"github.com/KyleKing/gh-star-search/internal/search"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// MaxSimilarLimit caps how many similar repositories are shown
+const MaxSimilarLimit = 50
+
+func SimilarCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "similar",
+		Usage: "Find starred repositories that are near-duplicates of a repository",
+		Description: `Rank your other starred repositories by how alike they are to the given one,
+to spot redundant stars worth pruning. Similarity combines:
+- Topic overlap (Jaccard)
+- Primary language match
+- Embedding cosine similarity (when both repositories have embeddings)
+
+Examples:
+  gh star-search similar junegunn/fzf
+  gh star-search similar --limit 20 BurntSushi/ripgrep`,
+		ArgsUsage: "<repository>",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Value:   10,
+				Usage:   fmt.Sprintf("Maximum number of similar repositories to show (1-%d)", MaxSimilarLimit),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New(errors.ErrTypeValidation, "expected exactly one repository argument")
+			}
+
+			fullName := cmd.Args().First()
+			if err := validateRepositoryName(fullName); err != nil {
+				return err
+			}
+
+			limit := int(cmd.Int("limit"))
+			if limit < 1 || limit > MaxSimilarLimit {
+				return errors.New(errors.ErrTypeValidation,
+					fmt.Sprintf("--limit must be between 1 and %d", MaxSimilarLimit))
+			}
+
+			cfg := getConfigFromContext(ctx)
+
+			repo, err := openStorage(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer repo.Close()
+
+			return runSimilar(ctx, os.Stdout, repo, cfg.Formatter, fullName, limit)
+		},
+	}
+}
+
+// runSimilar prints up to limit stored repositories ranked by similarity to
+// fullName. Embedding similarities come from a vector search seeded with the
+// target's embedding, so they are only used when the target has one.
+func runSimilar(
+	ctx context.Context,
+	out io.Writer,
+	repo storage.Repository,
+	fmtCfg config.FormatterConfig,
+	fullName string,
+	limit int,
+) error {
+	target, err := repo.GetRepository(ctx, fullName)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeValidation,
+			fmt.Sprintf("repository '%s' not found in your starred repositories", fullName))
+	}
+
+	stored, err := repo.ListRepositoriesSorted(ctx, storage.ListOptions{Limit: math.MaxInt32})
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to list repositories")
+	}
+
+	cosines := make(map[string]float64)
+
+	if len(target.RepoEmbedding) > 0 {
+		// A minimum of -1 keeps every repository with an embedding
+		results, err := repo.SearchByEmbedding(ctx, target.RepoEmbedding, len(stored), -1)
+		if err != nil {
+			return errors.Wrap(err, errors.ErrTypeDatabase, "failed to search by embedding")
+		}
+
+		for _, r := range results {
+			cosines[r.Repository.FullName] = r.Score
+		}
+	}
+
+	byName := make(map[string]storage.StoredRepo, len(stored))
+	candidates := make([]search.SimilarCandidate, 0, len(stored))
+
+	for _, r := range stored {
+		cosine, ok := cosines[r.FullName]
+		byName[r.FullName] = r
+		candidates = append(candidates, search.SimilarCandidate{
+			FullName:     r.FullName,
+			Features:     search.RepoFeatures{Topics: r.Topics, Language: r.Language},
+			Cosine:       cosine,
+			HasEmbedding: ok,
+		})
+	}
+
+	ranked := search.RankSimilar(search.RepoFeatures{Topics: target.Topics, Language: target.Language},
+		target.FullName, candidates, limit)
+
+	if len(ranked) == 0 {
+		fmt.Fprintf(out, "No repositories similar to %s found\n", target.FullName)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Repositories similar to %s:\n\n", target.FullName)
+
+	fmtCfg = formatter.WithDefaults(fmtCfg)
+
+	for i, r := range ranked {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+
+		displaySimilarRepository(out, i+1, byName[r.FullName], *target, r.RepoScore, fmtCfg)
+	}
+
+	return nil
+}
+
+// displaySimilarRepository prints one ranked repository and what it shares
+// with the target
+func displaySimilarRepository(
+	out io.Writer,
+	rank int,
+	repo, target storage.StoredRepo,
+	score search.RepoScore,
+	fmtCfg config.FormatterConfig,
+) {
+	language := repo.Language
+	if language == "" {
+		language = "Unknown"
+	}
+
+	fmt.Fprintf(out, "%d. %s  ⭐ %d  %s  Score: %.2f\n",
+		rank, repo.FullName, repo.StargazersCount, language, score.Score)
+	fmt.Fprintf(out, "   %s\n", truncateDescription(repo.Description, fmtCfg.MaxDescriptionLength))
+
+	var reasons []string
+
+	if shared := sharedTopics(target.Topics, repo.Topics); len(shared) > 0 {
+		reasons = append(reasons, fmt.Sprintf("topics %.2f (%s)", score.Topics, strings.Join(shared, ", ")))
+	}
+
+	if score.Language > 0 {
+		reasons = append(reasons, "same language")
+	}
+
+	if score.HasEmbedding {
+		reasons = append(reasons, fmt.Sprintf("embedding %.2f", score.Embedding))
+	}
+
+	fmt.Fprintf(out, "   Similar: %s\n", strings.Join(reasons, " · "))
+}
+
+// sharedTopics returns the topics of b that a also has, ignoring case
+func sharedTopics(a, b []string) []string {
+	have := make(map[string]bool, len(a))
+	for _, topic := range a {
+		have[strings.ToLower(topic)] = true
+	}
+
+	var shared []string
+
+	for _, topic := range b {
+		if have[strings.ToLower(topic)] {
+			shared = append(shared, topic)
+		}
+	}
+
+	return shared
+}
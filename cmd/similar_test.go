@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunSimilar(t *testing.T) {
+	ctx := context.Background()
+	embedding := []float32{0.1, 0.2}
+
+	t.Run("ranks by topics, language and embedding", func(t *testing.T) {
+		mock := &MockRepository{repos: []storage.StoredRepo{
+			{FullName: "user/fzf", Language: "Go", Topics: []string{"cli", "fuzzy-finder"}, RepoEmbedding: embedding},
+			{FullName: "user/unrelated", Language: "Python", Topics: []string{"web"}},
+			{FullName: "user/skim", Language: "Rust", Topics: []string{"cli", "fuzzy-finder"}, RepoEmbedding: embedding},
+			{FullName: "user/peco", Language: "Go", Topics: []string{"cli"}},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, runSimilar(ctx, &out, mock, config.FormatterConfig{}, "user/fzf", 10))
+
+		got := out.String()
+		assert.Contains(t, got, "Repositories similar to user/fzf:")
+		assert.Contains(t, got, "1. user/skim")
+		assert.Contains(t, got, "Similar: topics 1.00 (cli, fuzzy-finder) · embedding 0.50")
+		assert.Contains(t, got, "2. user/peco")
+		assert.Contains(t, got, "Similar: topics 0.50 (cli) · same language")
+		assert.NotContains(t, got, "user/unrelated")
+		assert.NotContains(t, got, ". user/fzf")
+	})
+
+	t.Run("limit caps the list", func(t *testing.T) {
+		mock := &MockRepository{repos: []storage.StoredRepo{
+			{FullName: "user/a", Language: "Go"},
+			{FullName: "user/b", Language: "Go"},
+			{FullName: "user/c", Language: "Go"},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, runSimilar(ctx, &out, mock, config.FormatterConfig{}, "user/a", 1))
+
+		assert.Contains(t, out.String(), "1. user/b")
+		assert.NotContains(t, out.String(), "user/c")
+	})
+
+	t.Run("nothing similar", func(t *testing.T) {
+		mock := &MockRepository{repos: []storage.StoredRepo{
+			{FullName: "user/a", Language: "Go"},
+			{FullName: "user/b", Language: "Rust"},
+		}}
+
+		var out bytes.Buffer
+		require.NoError(t, runSimilar(ctx, &out, mock, config.FormatterConfig{}, "user/a", 10))
+
+		assert.Equal(t, "No repositories similar to user/a found\n", out.String())
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runSimilar(ctx, &out, &MockRepository{}, config.FormatterConfig{}, "user/missing", 10)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
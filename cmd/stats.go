@@ -2,30 +2,75 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/KyleKing/gh-star-search/internal/errors"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
 func StatsCommand() *cli.Command {
 	return &cli.Command{
-		Name:        "stats",
-		Usage:       "Display database statistics",
-		Description: `Show statistics about the local database including total repositories, last sync time, and database size.`,
-		Action: func(ctx context.Context, _ *cli.Command) error {
-			return runStats(ctx)
+		Name:  "stats",
+		Usage: "Display database statistics",
+		Description: `Show statistics about the local database including total repositories, last sync time, and database size.
+
+Use --sizes to see approximate rows and bytes per table and the largest repositories,
+which helps explain database growth. Use --format json for the full language
+and topic breakdowns in machine-readable form.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "sizes",
+				Usage: "Show approximate size breakdown by table and largest repositories",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   "table",
+				Usage:   "Output format (table, json)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			format, err := formatFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runStats(ctx, cmd.Bool("sizes"), format)
 		},
 	}
 }
 
-func runStats(ctx context.Context) error {
-	return runStatsWithStorage(ctx, nil)
+// largestRepositoriesShown limits the repositories listed by --sizes
+const largestRepositoriesShown = 10
+
+// statsJSON is the --format json document: the statistics plus, with --sizes,
+// the size breakdown
+type statsJSON struct {
+	*storage.Stats
+	Sizes *storage.SizeBreakdown `json:"sizes,omitempty"`
+}
+
+func runStats(ctx context.Context, sizes bool, format string) error {
+	return runStatsWithFormat(ctx, nil, sizes, format)
 }
 
-func runStatsWithStorage(ctx context.Context, repo storage.Repository) error {
+func runStatsWithStorage(ctx context.Context, repo storage.Repository, sizes bool) error {
+	return runStatsWithFormat(ctx, repo, sizes, "table")
+}
+
+func runStatsWithFormat(ctx context.Context, repo storage.Repository, sizes bool, format string) error {
+	format = strings.ToLower(format)
+	if format != "table" && format != "json" {
+		return errors.Newf(errors.ErrTypeValidation, "unsupported stats format %q", format).
+			WithSuggestion("Use --format table or --format json")
+	}
+
 	// Initialize storage if not provided (for testing)
 	if repo == nil {
 		var err error
@@ -46,6 +91,22 @@ func runStatsWithStorage(ctx context.Context, repo storage.Repository) error {
 		return fmt.Errorf("failed to get statistics: %w", err)
 	}
 
+	if format == "json" {
+		output := statsJSON{Stats: stats}
+
+		if sizes {
+			output.Sizes, err = repo.GetSizeBreakdown(ctx, largestRepositoriesShown)
+			if err != nil {
+				return fmt.Errorf("failed to get size breakdown: %w", err)
+			}
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(output)
+	}
+
 	// Display statistics
 	fmt.Printf("Database Statistics\n")
 	fmt.Printf("==================\n\n")
@@ -131,5 +192,48 @@ func runStatsWithStorage(ctx context.Context, repo storage.Repository) error {
 		}
 	}
 
+	if sizes {
+		breakdown, err := repo.GetSizeBreakdown(ctx, largestRepositoriesShown)
+		if err != nil {
+			return fmt.Errorf("failed to get size breakdown: %w", err)
+		}
+
+		displaySizeBreakdown(breakdown)
+	}
+
 	return nil
 }
+
+// displaySizeBreakdown prints approximate table sizes and the largest repositories
+func displaySizeBreakdown(breakdown *storage.SizeBreakdown) {
+	fmt.Printf("\nSize Breakdown (approximate):\n")
+
+	for _, table := range breakdown.Tables {
+		fmt.Printf("  %-20s %6d rows %10s\n", table.Table, table.Rows, formatBytes(table.Bytes))
+	}
+
+	if len(breakdown.LargestRepositories) > 0 {
+		fmt.Printf("\nLargest Repositories:\n")
+
+		for _, repo := range breakdown.LargestRepositories {
+			fmt.Printf("  %-40s %10s\n", repo.FullName, formatBytes(repo.Bytes))
+		}
+	}
+}
+
+// formatBytes renders a byte count with a binary unit suffix
+func formatBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -3,11 +3,13 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/KyleKing/gh-star-search/internal/errors"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
@@ -37,8 +39,11 @@ func TestRunStats(t *testing.T) {
 	tests := []struct {
 		name     string
 		stats    *storage.Stats
+		sizes    *storage.SizeBreakdown
+		showSize bool
 		wantErr  bool
 		contains []string
+		excludes []string
 	}{
 		{
 			name:    "full stats",
@@ -57,6 +62,29 @@ func TestRunStats(t *testing.T) {
 				"cli                   40 repos (26.7%)",
 				"web                   35 repos (23.3%)",
 			},
+			excludes: []string{"Size Breakdown"},
+		},
+		{
+			name:  "with sizes",
+			stats: testStats,
+			sizes: &storage.SizeBreakdown{
+				Tables: []storage.TableSize{
+					{Table: "query_history", Rows: 3, Bytes: 512},
+					{Table: "repositories", Rows: 150, Bytes: 3 * 1024 * 1024},
+				},
+				LargestRepositories: []storage.RepositorySize{
+					{FullName: "owner/big", Bytes: 20480},
+				},
+			},
+			showSize: true,
+			contains: []string{
+				"Size Breakdown (approximate):",
+				"query_history             3 rows      512 B",
+				"repositories            150 rows    3.0 MiB",
+				"Largest Repositories:",
+				"owner/big",
+				"20.0 KiB",
+			},
 		},
 		{
 			name: "empty stats",
@@ -86,10 +114,11 @@ func TestRunStats(t *testing.T) {
 			// Create mock storage
 			mockRepo := &MockRepository{
 				stats: tt.stats,
+				sizes: tt.sizes,
 			}
 
 			// Run the command with mock storage
-			err := runStatsWithStorage(context.Background(), mockRepo)
+			err := runStatsWithStorage(context.Background(), mockRepo, tt.showSize)
 
 			// Restore stdout and get output
 			w.Close()
@@ -113,6 +142,85 @@ func TestRunStats(t *testing.T) {
 					t.Errorf("runStats() output does not contain %q\nOutput: %s", expected, output)
 				}
 			}
+
+			for _, unexpected := range tt.excludes {
+				if strings.Contains(output, unexpected) {
+					t.Errorf("runStats() output unexpectedly contains %q\nOutput: %s", unexpected, output)
+				}
+			}
 		})
 	}
 }
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{2 * 1024 * 1024 * 1024, "2.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRunStatsWithFormat_JSON(t *testing.T) {
+	mockRepo := &MockRepository{
+		stats: &storage.Stats{
+			TotalRepositories: 3,
+			LanguageBreakdown: map[string]int{"Go": 2, "Rust": 1},
+			TopicBreakdown:    map[string]int{"cli": 2},
+		},
+		sizes: &storage.SizeBreakdown{
+			Tables: []storage.TableSize{{Table: "repositories", Rows: 3, Bytes: 2048}},
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStatsWithFormat(context.Background(), mockRepo, true, "JSON")
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+
+	_, _ = buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("runStatsWithFormat() error = %v", err)
+	}
+
+	var got struct {
+		TotalRepositories int                    `json:"total_repositories"`
+		LanguageBreakdown map[string]int         `json:"language_breakdown"`
+		TopicBreakdown    map[string]int         `json:"topic_breakdown"`
+		Sizes             *storage.SizeBreakdown `json:"sizes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+
+	if got.TotalRepositories != 3 || got.LanguageBreakdown["Go"] != 2 || got.TopicBreakdown["cli"] != 2 {
+		t.Errorf("unexpected stats: %+v", got)
+	}
+
+	if got.Sizes == nil || len(got.Sizes.Tables) != 1 {
+		t.Errorf("expected size breakdown with --sizes, got %+v", got.Sizes)
+	}
+
+	if err := runStatsWithFormat(context.Background(), mockRepo, false, "csv"); !errors.IsType(err, errors.ErrTypeValidation) {
+		t.Errorf("expected validation error for csv, got %v", err)
+	}
+}
@@ -1,22 +1,62 @@
 package cmd
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 
 	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
 // initializeStorage creates and initializes a storage repository
 func initializeStorage(cfg *config.Config) (storage.Repository, error) {
-	// Expand home directory in database path
-	dbPath := config.ExpandPath(cfg.Database.Path)
-
-	// Create DuckDB repository
-	repo, err := storage.NewDuckDBRepository(dbPath)
+	// Create DuckDB repository with the configured connection pool
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&cfg.Database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
 	return repo, nil
 }
+
+// openStorage creates a storage repository and applies any pending migrations.
+// The caller is responsible for closing the returned repository.
+func openStorage(ctx context.Context, cfg *config.Config) (storage.Repository, error) {
+	repo, err := initializeStorage(cfg)
+	if err != nil {
+		return nil, databaseOpenError(err)
+	}
+
+	if err := repo.Initialize(ctx); err != nil {
+		repo.Close()
+		return nil, schemaError(err)
+	}
+
+	return repo, nil
+}
+
+// databaseOpenError wraps a failure to open the database, calling out the
+// single-writer lock held by a concurrent invocation
+func databaseOpenError(err error) *errors.Error {
+	if stderrors.Is(err, storage.ErrDatabaseLocked) {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "database is in use by another gh star-search process").
+			WithSuggestion("Wait for the other command (for example a running sync) to finish, then retry").
+			WithSuggestion("Pass --wait <duration> to sync or refresh-content to wait for the lock")
+	}
+
+	return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database")
+}
+
+// schemaError wraps a failure to migrate the database schema, calling out a
+// database written by a newer gh star-search
+func schemaError(err error) *errors.Error {
+	if stderrors.Is(err, storage.ErrSchemaTooNew) {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "database was created by a newer version of gh star-search").
+			WithSuggestion("Upgrade with 'gh extension upgrade star-search'").
+			WithSuggestion("Or pass --db-path to use a different database")
+	}
+
+	return errors.Wrap(err, errors.ErrTypeDatabase, "failed to initialize database schema")
+}
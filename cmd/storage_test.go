@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestDatabaseOpenError(t *testing.T) {
+	locked := databaseOpenError(fmt.Errorf("failed to open database after 3 attempt(s): %w", storage.ErrDatabaseLocked))
+	assert.Equal(t, errors.ErrTypeDatabase, locked.Type)
+	assert.Contains(t, locked.Message, "in use by another gh star-search process")
+	assert.NotEmpty(t, locked.Suggestions)
+
+	other := databaseOpenError(fmt.Errorf("failed to open database: permission denied"))
+	assert.Equal(t, "failed to initialize database", other.Message)
+	assert.Empty(t, other.Suggestions)
+}
+
+func TestSchemaError(t *testing.T) {
+	tooNew := schemaError(fmt.Errorf("%w: database is at version 99", storage.ErrSchemaTooNew))
+	assert.Equal(t, errors.ErrTypeDatabase, tooNew.Type)
+	assert.Contains(t, tooNew.Message, "newer version")
+	assert.NotEmpty(t, tooNew.Suggestions)
+
+	other := schemaError(fmt.Errorf("failed to run migration 3: boom"))
+	assert.Equal(t, "failed to initialize database schema", other.Message)
+	assert.Empty(t, other.Suggestions)
+}
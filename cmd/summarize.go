@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+)
+
+func SummarizeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "summarize",
+		Usage: "Generate summaries for stored repositories",
+		Description: `Summarize each stored repository that has no summary yet, using the local
+summarizer (managed by uv; transformers with a heuristic fallback). The input
+is the repository name, description, homepage, topics and primary language.
+Repositories whose content language is not in summarize.languages are skipped.
+'sync --summarize' runs the same step after a sync.
+
+Examples:
+  gh star-search summarize
+  gh star-search summarize --force   # regenerate every summary`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Regenerate every summary, not just missing ones",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+		},
+		Action: runSummarize,
+	}
+}
+
+func runSummarize(ctx context.Context, cmd *cli.Command) error {
+	cfg := getConfigFromContext(ctx)
+	applyLockWait(cfg, cmd)
+
+	repo, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	// Debug logging prints each generated summary, as in sync
+	verbose := cfg.Logging.Level == "debug" || cfg.Debug.Enabled
+
+	err = generateSummaries(ctx, repo, cfg, cmd.Bool("force"), verbose)
+	if stderrors.Is(err, ErrPartialFailure) {
+		return err
+	}
+
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeInternal, "failed to generate summaries").
+			WithSuggestion("Summarization runs a local model through uv; install it from https://docs.astral.sh/uv/")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestSummarizeRepositories(t *testing.T) {
+	ctx := context.Background()
+
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	for _, name := range []string{"user/ok", "user/broken", "user/foreign"} {
+		processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName(name)), nil)
+		if name == "user/foreign" {
+			processed.ContentLanguage = "zh"
+		}
+
+		require.NoError(t, repo.UpsertRepository(ctx, processed, storage.UpsertOptions{}))
+	}
+
+	gen := &fakeSummaryGenerator{fail: map[string]bool{"user/broken": true}}
+	sumCfg := config.SummarizeConfig{Languages: []string{"en"}}
+
+	stats := summarizeRepositories(ctx, repo, gen, sumCfg,
+		[]string{"user/ok", "user/broken", "user/foreign", "user/missing"}, false)
+	assert.Equal(t, summarizeStats{Successful: 1, Failed: 2, Skipped: 1}, stats)
+	assert.Equal(t, []string{"user/ok", "user/broken"}, gen.calls)
+
+	stored, err := repo.GetRepository(ctx, "user/ok")
+	require.NoError(t, err)
+	assert.Equal(t, "Summary of user/ok", stored.Purpose)
+	assert.NotNil(t, stored.SummaryGeneratedAt)
+
+	pending, err := repo.GetRepositoriesNeedingSummaryUpdate(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/broken", "user/foreign"}, pending)
+
+	// --force selects every repository, including those already summarized
+	all, err := repo.GetRepositoriesNeedingSummaryUpdate(ctx, true)
+	require.NoError(t, err)
+	assert.Contains(t, all, "user/ok")
+}
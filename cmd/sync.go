@@ -3,7 +3,13 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +19,7 @@ import (
 
 	"github.com/KyleKing/gh-star-search/internal/cache"
 	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
 	"github.com/KyleKing/gh-star-search/internal/github"
 	"github.com/KyleKing/gh-star-search/internal/processor"
 	"github.com/KyleKing/gh-star-search/internal/storage"
@@ -23,10 +30,14 @@ const (
 	DefaultBatchSize = 10
 	// BatchDelaySeconds is the delay between batches to be respectful to APIs
 	BatchDelaySeconds = 2
+	// LowRateLimitBatchDelaySeconds is the delay between batches when the remaining quota is nearly exhausted
+	LowRateLimitBatchDelaySeconds = 10
 	// RepositoryRateLimitMs is the rate limit delay between processing individual repositories
 	RepositoryRateLimitMs = 100
-	// MaxWorkerCap is the maximum number of concurrent workers for API calls
+	// MaxWorkerCap is the maximum number of concurrent workers chosen automatically
 	MaxWorkerCap = 8
+	// MaxWorkerLimit is the most workers --workers or sync.max_workers can request
+	MaxWorkerLimit = 32
 )
 
 func SyncCommand() *cli.Command {
@@ -42,6 +53,15 @@ intelligent search capabilities.`,
 				Aliases: []string{"r"},
 				Usage:   "Sync a specific repository for fine-tuning",
 			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only process repositories starred after this date (YYYY-MM-DD or RFC3339); nothing is removed",
+			},
+			&cli.StringFlag{
+				Name:    "repos-from",
+				Aliases: []string{"repos-file"},
+				Usage:   "Sync only the starred repositories listed in a file (one owner/name per line); nothing is removed",
+			},
 
 			&cli.IntFlag{
 				Name:    "batch-size",
@@ -58,9 +78,69 @@ intelligent search capabilities.`,
 				Name:  "summarize",
 				Usage: "Generate AI summaries for repositories after sync",
 			},
+			&cli.IntFlag{
+				Name:  "summarize-preview",
+				Usage: "Summarize a sample of N repositories needing summaries, print them with an estimate for the rest, and exit without syncing or storing",
+			},
 			&cli.BoolFlag{
 				Name:  "embed",
-				Usage: "Generate vector embeddings for repositories after sync",
+				Usage: "Generate vector embeddings after sync for repositories without one (all of them with --force)",
+			},
+			&cli.BoolFlag{
+				Name:  "append-only",
+				Usage: "Only add newly starred repositories; never update or remove stored ones",
+			},
+			&cli.BoolFlag{
+				Name:  "exclude-archived",
+				Usage: "Skip archived repositories and remove stored ones that are now archived",
+			},
+			&cli.BoolFlag{
+				Name:  "exclude-forks",
+				Usage: "Skip forked repositories and remove stored forks",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Do not ask for confirmation before a large sync (see sync.confirm_large_sync) or removals",
+			},
+			&cli.BoolFlag{
+				Name:  "confirm-removals",
+				Usage: "List repositories that are no longer starred and ask before removing them",
+			},
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "Skip repositories already processed by an interrupted full sync (ignored with --force)",
+			},
+			&cli.IntFlag{
+				Name:  "prune-chunks-over",
+				Usage: "Keep at most N content chunks per repository, dropping the lowest-priority ones (0 keeps all)",
+			},
+			&cli.DurationFlag{
+				Name:  "wait",
+				Usage: "Wait up to this long for another gh star-search process to release the database (e.g. 10m)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-progress",
+				Usage: "Print plain progress lines instead of spinners (automatic when output is not a terminal)",
+			},
+			&cli.BoolFlag{
+				Name:  "verbose-cache",
+				Usage: "Report whether each repository's content came from the local cache or GitHub",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print what the sync would add, update and remove without fetching content or writing",
+			},
+			&cli.IntFlag{
+				Name: "workers",
+				Usage: fmt.Sprintf(
+					"Process this many repositories of a batch concurrently (1-%d; default: sync.max_workers, or automatic)",
+					MaxWorkerLimit,
+				),
+			},
+			&cli.DurationFlag{
+				Name:  "batch-delay",
+				Usage: "Pause between batches (default: sync.batch_delay, 2s); 0 for none",
 			},
 		},
 		Action: runSync,
@@ -69,11 +149,30 @@ intelligent search capabilities.`,
 
 // SyncService handles the synchronization of starred repositories
 type SyncService struct {
-	githubClient github.Client
-	processor    processor.Service
-	storage      storage.Repository
-	config       *config.Config
-	verbose      bool
+	githubClient    github.Client
+	processor       processor.Service
+	storage         storage.Repository
+	cache           cache.Cache // Optional; holds metadata for rebuild --from-cache
+	config          *config.Config
+	verbose         bool            // Print each step instead of progress bars; set at debug log level
+	logger          *slog.Logger    // Structured sync log; nil uses slog.Default
+	maxChunks       int             // Per-repository chunk cap; 0 keeps every chunk
+	appendOnly      bool            // Never update or remove stored repositories
+	budget          *github.Budget  // Optional; caps the sync's network use
+	checkpoint      *syncCheckpoint // Optional; records progress of a full sync
+	resume          bool            // Skip repositories recorded by an interrupted sync
+	excludeArchived bool            // Keep archived repositories out of the index
+	excludeForks    bool            // Keep forks out of the index
+	assumeYes       bool            // Skip the large-sync and removal confirmations
+	confirmRemoval  bool            // Ask before removing repositories that are no longer starred
+	verboseCache    bool            // Report the content cache outcome of each repository
+	failedRepos     int             // Repositories that failed to process; sync exits with ExitPartial
+	confirmInput    io.Reader       // Answers the large-sync confirmation; nil when not interactive
+	dryRun          bool            // Print the sync plan without fetching content or writing
+	since           time.Time       // Only process repositories starred after this; zero for all
+	summaryOut      io.Writer       // Receives the summary as JSON under --json; nil prints text
+	noProgress      bool            // Print plain progress lines instead of spinners
+	batchDelaySet   bool            // --batch-delay was given, so adaptive pacing keeps it even at its default
 }
 
 // SyncStats tracks synchronization statistics
@@ -82,6 +181,7 @@ type SyncStats struct {
 	NewRepos        int
 	UpdatedRepos    int
 	RemovedRepos    int
+	RenamedRepos    int
 	SkippedRepos    int
 	ErrorRepos      int
 	ProcessedRepos  int
@@ -90,30 +190,61 @@ type SyncStats struct {
 	ProcessingTime  time.Duration
 	ContentChanges  int
 	MetadataChanges int
-	mu              sync.Mutex // Protect concurrent access to stats
+	TrimmedChunks   int
+	BudgetSkipped   int // Repositories left unprocessed once the network budget ran out
+	SkipReasons     map[skipReason]int
+	Failures        map[string]string // Why each failed repository failed, by full name
+	mu              sync.Mutex        // Protect concurrent access to stats
 }
 
 // ProgressTracker tracks progress during sync operations
 type ProgressTracker struct {
 	total     int
 	processed int
-	spinner   *spinner.Spinner
+	message   string
+	spinner   *spinner.Spinner // Nil when printing plain progress lines
+	out       io.Writer        // Receives plain progress lines
+	eta       *etaEstimator
 	mu        sync.Mutex
 }
 
 // NewProgressTracker creates a new progress tracker
 func NewProgressTracker(total int, message string) *ProgressTracker {
-	sp := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
-	sp.Suffix = fmt.Sprintf(" %s (0/%d)", message, total)
+	return newProgressTrackerWithETA(total, message, newETAEstimator(total), false)
+}
 
-	return &ProgressTracker{
+// newProgressTrackerWithETA creates a progress tracker that reports the ETA of a
+// shared estimator, so a batch spinner can show time remaining for the whole sync.
+// When plain is set it prints a line per update instead of animating a spinner.
+func newProgressTrackerWithETA(total int, message string, eta *etaEstimator, plain bool) *ProgressTracker {
+	tracker := &ProgressTracker{
 		total:   total,
-		spinner: sp,
+		message: message,
+		out:     os.Stdout,
+		eta:     eta,
+	}
+
+	if !plain {
+		// Write to os.Stdout as it is now, so under --json the spinner follows
+		// progress output to stderr
+		tracker.spinner = spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriterFile(os.Stdout))
+		tracker.spinner.Suffix = fmt.Sprintf(" %s (0/%d)", message, total)
 	}
+
+	return tracker
 }
 
 // Start begins the progress tracking
 func (p *ProgressTracker) Start() {
+	p.mu.Lock()
+	p.eta.start()
+	p.mu.Unlock()
+
+	if p.spinner == nil {
+		fmt.Fprintf(p.out, "%s (0/%d)\n", p.message, p.total)
+		return
+	}
+
 	p.spinner.Start()
 }
 
@@ -123,18 +254,46 @@ func (p *ProgressTracker) Update(repoName string) {
 	defer p.mu.Unlock()
 
 	p.processed++
-	p.spinner.Suffix = fmt.Sprintf(" Processing %s (%d/%d)", repoName, p.processed, p.total)
+	p.eta.observe()
+
+	// Plain lines leave out the ETA so output from repeated runs diffs cleanly
+	if p.spinner == nil {
+		fmt.Fprintf(p.out, "  Processing %s (%d/%d)\n", repoName, p.processed, p.total)
+		return
+	}
+
+	suffix := fmt.Sprintf(" Processing %s (%d/%d)", repoName, p.processed, p.total)
+	if remaining, ok := p.eta.remaining(); ok {
+		suffix += ", " + formatETA(remaining)
+	}
+
+	p.spinner.Suffix = suffix
 }
 
 // Finish stops the progress tracker and shows completion
 func (p *ProgressTracker) Finish(message string) {
-	p.spinner.Stop()
-	fmt.Printf("✓ %s (%d/%d)\n", message, p.processed, p.total)
+	p.Stop()
+	fmt.Fprintf(p.out, "✓ %s (%d/%d)\n", message, p.processed, p.total)
 }
 
 // Stop stops the progress tracker without showing completion
 func (p *ProgressTracker) Stop() {
-	p.spinner.Stop()
+	if p.spinner != nil {
+		p.spinner.Stop()
+	}
+}
+
+// newProgress creates a progress tracker for total items, printing plain lines
+// instead of a spinner when plainProgress reports so
+func (s *SyncService) newProgress(total int, message string) *ProgressTracker {
+	return newProgressTrackerWithETA(total, message, newETAEstimator(total), s.plainProgress())
+}
+
+// plainProgress reports whether progress is printed as plain lines: with
+// --no-progress, or when stdout is not a terminal (piped to a file or in CI),
+// where spinner frames would garble the output
+func (s *SyncService) plainProgress() bool {
+	return s.noProgress || !stdoutIsTerminal()
 }
 
 // SafeIncrement safely increments a counter in SyncStats
@@ -149,6 +308,8 @@ func (s *SyncStats) SafeIncrement(field string) {
 		s.UpdatedRepos++
 	case "removed":
 		s.RemovedRepos++
+	case "renamed":
+		s.RenamedRepos++
 	case "skipped":
 		s.SkippedRepos++
 	case "error":
@@ -159,25 +320,120 @@ func (s *SyncStats) SafeIncrement(field string) {
 		s.ContentChanges++
 	case "metadata_changes":
 		s.MetadataChanges++
+	case "budget_skipped":
+		s.BudgetSkipped++
 	}
 }
 
+// AddTrimmedChunks safely adds to the count of chunks dropped by --prune-chunks-over
+func (s *SyncStats) AddTrimmedChunks(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.TrimmedChunks += n
+}
+
+// AddFailure safely records why a repository failed
+func (s *SyncStats) AddFailure(fullName string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Failures == nil {
+		s.Failures = make(map[string]string)
+	}
+
+	s.Failures[fullName] = err.Error()
+}
+
+// AddBudgetSkipped safely adds to the count of repositories deferred by the network budget
+func (s *SyncStats) AddBudgetSkipped(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.BudgetSkipped += n
+}
+
 func runSync(ctx context.Context, cmd *cli.Command) error {
 	// Parse flags
 	specificRepo := cmd.String("repo")
+	reposFrom := cmd.String("repos-from")
 	batchSize := int(cmd.Int("batch-size"))
 	force := cmd.Bool("force")
 	summarize := cmd.Bool("summarize")
 	embed := cmd.Bool("embed")
+	maxChunks := int(cmd.Int("prune-chunks-over"))
+	summarizePreview := int(cmd.Int("summarize-preview"))
 
-	// Get verbose setting from config
-	configFromContext := getConfigFromContext(ctx)
-	verbose := configFromContext.Logging.Level == "debug" || configFromContext.Debug.Enabled
+	if maxChunks < 0 {
+		return fmt.Errorf("--prune-chunks-over must be zero or positive")
+	}
 
-	// Load configuration
-	cfg, err := config.LoadConfigWithOverrides(nil)
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+	if summarizePreview < 0 {
+		return fmt.Errorf("--summarize-preview must be zero or positive")
+	}
+
+	dryRun := cmd.Bool("dry-run")
+	if dryRun && (specificRepo != "" || summarizePreview > 0) {
+		return errors.New(errors.ErrTypeValidation, "--dry-run cannot be combined with --repo or --summarize-preview")
+	}
+
+	if specificRepo != "" && reposFrom != "" {
+		return fmt.Errorf("--repo and --repos-from cannot be used together")
+	}
+
+	var summaryOut io.Writer
+
+	if jsonOutput(cmd) {
+		if specificRepo != "" || dryRun || summarizePreview > 0 {
+			return errors.New(errors.ErrTypeValidation,
+				"--json only applies to a full sync or --repos-from, not --repo, --dry-run or --summarize-preview")
+		}
+
+		// Progress goes to stderr; stdout carries only the JSON summary
+		stdout, restore := stdoutToStderr()
+		defer restore()
+
+		summaryOut = stdout
+	}
+
+	if cmd.Bool("resume") && (specificRepo != "" || reposFrom != "") {
+		return fmt.Errorf("--resume only applies to a full sync")
+	}
+
+	var since time.Time
+
+	if value := strings.TrimSpace(cmd.String("since")); value != "" {
+		if specificRepo != "" || reposFrom != "" {
+			return errors.New(errors.ErrTypeValidation, "--since only applies to a full sync")
+		}
+
+		var err error
+
+		since, err = parseFilterDate(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	var repoList []string
+
+	if reposFrom != "" {
+		var err error
+
+		repoList, err = readRepoList(reposFrom)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Use the configuration with the root flags (e.g. --db-path) applied
+	cfg := getConfigFromContext(ctx)
+	verbose := cfg.Logging.Level == "debug" || cfg.Debug.Enabled
+
+	applyLockWait(cfg, cmd)
+
+	if err := applySyncPacing(cfg, cmd); err != nil {
+		return err
 	}
 
 	// Initialize services
@@ -187,9 +443,31 @@ func runSync(ctx context.Context, cmd *cli.Command) error {
 	}
 	defer syncService.storage.Close()
 
+	syncService.maxChunks = maxChunks
+	syncService.appendOnly = cmd.Bool("append-only")
+	syncService.excludeArchived = cmd.Bool("exclude-archived")
+	syncService.excludeForks = cmd.Bool("exclude-forks")
+	syncService.assumeYes = cmd.Bool("yes")
+	syncService.confirmRemoval = cmd.Bool("confirm-removals")
+	syncService.verboseCache = cmd.Bool("verbose-cache")
+	syncService.dryRun = dryRun
+	syncService.since = since
+	syncService.summaryOut = summaryOut
+	syncService.noProgress = cmd.Bool("no-progress")
+	syncService.batchDelaySet = cmd.IsSet("batch-delay")
+
+	if stdoutIsTerminal() {
+		syncService.confirmInput = os.Stdin
+	}
+
 	// Initialize database
 	if err := syncService.storage.Initialize(ctx); err != nil {
-		return fmt.Errorf("failed to initialize database: %w", err)
+		return schemaError(err)
+	}
+
+	// Preview summaries of stored repositories without syncing or storing anything
+	if summarizePreview > 0 {
+		return syncService.runSummaryPreview(ctx, summarizePreview, force)
 	}
 
 	// Handle specific repository sync
@@ -197,12 +475,31 @@ func runSync(ctx context.Context, cmd *cli.Command) error {
 		if err := syncService.syncSpecificRepository(ctx, specificRepo); err != nil {
 			return err
 		}
+
+		if err := syncService.refreshKeywords(ctx); err != nil {
+			fmt.Printf("\nWarning: Failed to refresh keywords: %v\n", err)
+		}
+
 		return syncService.storage.RebuildFTSIndex(ctx)
 	}
 
-	// Perform full sync
-	if err := syncService.performFullSync(ctx, batchSize, force); err != nil {
-		return err
+	// Perform a listed or full sync
+	if repoList != nil {
+		if err := syncService.syncRepositoryList(ctx, repoList, batchSize, force); err != nil {
+			return err
+		}
+	} else {
+		syncService.checkpoint = newSyncCheckpoint(filepath.Join(cfg.Cache.Directory, syncCheckpointFile))
+		syncService.resume = cmd.Bool("resume")
+
+		if err := syncService.performFullSync(ctx, batchSize, force); err != nil {
+			return err
+		}
+	}
+
+	// A dry run only prints the plan; summaries, embeddings and the index are untouched
+	if dryRun {
+		return nil
 	}
 
 	// Generate summaries if requested
@@ -221,29 +518,56 @@ func runSync(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	// Keywords depend on the whole corpus, so refresh them after all repositories are stored
+	if err := syncService.refreshKeywords(ctx); err != nil {
+		fmt.Printf("\nWarning: Failed to refresh keywords: %v\n", err)
+	}
+
 	// Rebuild FTS index after all data changes
 	if err := syncService.storage.RebuildFTSIndex(ctx); err != nil {
 		return fmt.Errorf("failed to rebuild search index: %w", err)
 	}
 
-	return nil
+	return partialFailure(syncService.failedRepos, "process")
 }
 
-func initializeSyncService(cfg *config.Config, verbose bool) (*SyncService, error) {
-	// Initialize GitHub client
-	githubClient, err := github.NewClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+// applyLockWait lets --wait override the configured database lock wait
+func applyLockWait(cfg *config.Config, cmd *cli.Command) {
+	if wait := cmd.Duration("wait"); wait > 0 {
+		cfg.Database.LockWait = wait.String()
 	}
+}
 
-	// Initialize storage
-	dbPath := config.ExpandPath(cfg.Database.Path)
+// applySyncPacing lets --workers and --batch-delay override the configured
+// sync pacing. Worker counts above MaxWorkerLimit are clamped.
+func applySyncPacing(cfg *config.Config, cmd *cli.Command) error {
+	if cmd.IsSet("workers") {
+		workers := int(cmd.Int("workers"))
+		if workers < 1 {
+			return errors.New(errors.ErrTypeValidation, "--workers must be at least 1")
+		}
 
-	repo, err := storage.NewDuckDBRepository(dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create storage repository: %w", err)
+		cfg.Sync.MaxWorkers = workers
+	}
+
+	if cfg.Sync.MaxWorkers > MaxWorkerLimit {
+		fmt.Printf("Limiting workers to %d (requested %d)\n", MaxWorkerLimit, cfg.Sync.MaxWorkers)
+		cfg.Sync.MaxWorkers = MaxWorkerLimit
+	}
+
+	if cmd.IsSet("batch-delay") {
+		delay := cmd.Duration("batch-delay")
+		if delay < 0 {
+			return errors.New(errors.ErrTypeValidation, "--batch-delay must not be negative")
+		}
+
+		cfg.Sync.BatchDelay = delay.String()
 	}
 
+	return nil
+}
+
+func initializeSyncService(cfg *config.Config, verbose bool) (*SyncService, error) {
 	// Initialize cache
 	var fileCache *cache.FileCache
 
@@ -260,21 +584,71 @@ func initializeSyncService(cfg *config.Config, verbose bool) (*SyncService, erro
 		}
 	}
 
+	// Initialize GitHub client, sending conditional requests when ETags can be cached
+	var githubClient github.Client
+	var err error
+
+	clientOpts := []github.ClientOption{
+		github.WithRetryAttempts(cfg.Sync.RetryAttempts),
+		github.WithSearchRateLimit(cfg.Sync.SearchRequestsPerMinute),
+	}
+	if cfg.Sync.GraphQL {
+		clientOpts = append(clientOpts, github.WithGraphQL())
+	}
+
+	// Cap network use when a budget is configured, counting every HTTP request
+	budget := newSyncBudget(cfg.Sync)
+	if budget != nil {
+		clientOpts = append(clientOpts, github.WithBudget(budget))
+	}
+
+	if fileCache != nil {
+		githubClient, err = github.NewClientWithETagCache(fileCache, clientOpts...)
+	} else {
+		githubClient, err = github.NewClient(clientOpts...)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeAuth, "failed to create GitHub client").
+			WithSuggestion("Run 'gh auth login' to authenticate")
+	}
+
+	// Stop starting fetches once the budget is spent; the processor shares the wrapped client
+	if budget != nil {
+		githubClient = github.NewBudgetClient(githubClient, budget)
+	}
+
+	// Initialize storage
+	repo, err := storage.NewDuckDBRepositoryFromConfig(&cfg.Database)
+	if err != nil {
+		return nil, databaseOpenError(err)
+	}
+
 	// Initialize processor with cache
 	var processorService processor.Service
+	processorOpts := processorOptions(cfg, repo)
+
 	if fileCache != nil {
-		processorService = processor.NewServiceWithCache(githubClient, fileCache)
+		processorService = processor.NewServiceWithCache(githubClient, fileCache, processorOpts...)
 	} else {
-		processorService = processor.NewService(githubClient)
+		processorService = processor.NewService(githubClient, processorOpts...)
 	}
 
-	return &SyncService{
+	service := &SyncService{
 		githubClient: githubClient,
 		processor:    processorService,
 		storage:      repo,
 		config:       cfg,
 		verbose:      verbose,
-	}, nil
+		budget:       budget,
+	}
+
+	// Leave the interface nil rather than holding a typed nil pointer
+	if fileCache != nil {
+		service.cache = fileCache
+	}
+
+	return service, nil
 }
 
 func (s *SyncService) performFullSync(ctx context.Context, batchSize int, force bool) error {
@@ -282,10 +656,10 @@ func (s *SyncService) performFullSync(ctx context.Context, batchSize int, force
 		StartTime: time.Now(),
 	}
 
-	s.logVerbose("Starting full sync of starred repositories...")
+	s.log().Debug("Starting full sync of starred repositories")
 
 	// Create progress tracker for fetching repositories
-	fetchProgress := NewProgressTracker(1, "Fetching starred repositories")
+	fetchProgress := s.newProgress(1, "Fetching starred repositories")
 	fetchProgress.Start()
 
 	// Fetch all starred repositories
@@ -298,25 +672,79 @@ func (s *SyncService) performFullSync(ctx context.Context, batchSize int, force
 	stats.TotalRepos = len(starredRepos)
 	fetchProgress.Finish(fmt.Sprintf("Found %d starred repositories", stats.TotalRepos))
 
+	if !s.dryRun {
+		s.cacheStarredRepos(ctx, starredRepos)
+	}
+
 	// Get existing repositories from database for incremental sync
-	s.logVerbose("Loading existing repositories from database...")
+	s.log().Debug("Loading existing repositories from database")
 
 	existingRepos, err := s.getExistingRepositories(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get existing repositories: %w", err)
 	}
 
-	s.logVerbose(fmt.Sprintf("Found %d existing repositories in database", len(existingRepos)))
+	s.log().Debug("Loaded existing repositories", slog.Int("repos", len(existingRepos)))
+
+	// Move renamed repositories before diffing so they are not removed and re-added
+	if err := s.applyRenames(ctx, starredRepos, existingRepos, stats); err != nil {
+		return err
+	}
+
+	if err := s.backfillGitHubIDs(ctx, starredRepos, existingRepos); err != nil {
+		return err
+	}
 
 	// Determine sync operations with enhanced change detection
 	operations := s.determineSyncOperations(starredRepos, existingRepos, force)
+	skippedUpdates, skippedRemovals := s.applyAppendOnly(operations)
+	skippedSince := s.applySince(operations)
+	resumed := s.applyCheckpoint(starredRepos, operations, force)
+	stats.AddSkipped(skipUpToDate, len(operations.upToDate))
+	stats.AddSkipped(skipAppendOnly, skippedUpdates)
+	stats.AddSkipped(skipBeforeSince, skippedSince)
+	stats.AddSkipped(skipCheckpoint, resumed)
+	stats.addExclusions(operations)
 
 	fmt.Printf("\nSync Plan:\n")
 	fmt.Printf("  New repositories: %d\n", len(operations.toAdd))
 	fmt.Printf("  Updated repositories: %d\n", len(operations.toUpdate))
 	fmt.Printf("  Removed repositories: %d\n", len(operations.toRemove))
+	printExclusionPlan(operations)
+
+	if s.appendOnly {
+		printAppendOnlyPlan(skippedUpdates, skippedRemovals)
+	}
+
+	if !s.since.IsZero() {
+		printSincePlan(s.since, skippedSince)
+	}
+
+	if resumed > 0 {
+		fmt.Printf("  Resumed: skipping %d repositories completed before the interruption\n", resumed)
+	}
+
 	fmt.Printf("  Total to process: %d\n", len(operations.toAdd)+len(operations.toUpdate))
 
+	if s.dryRun {
+		s.printDryRunPlan(os.Stdout, operations, existingRepos, force)
+		return nil
+	}
+
+	allToProcess := make([]github.Repository, 0, len(operations.toAdd)+len(operations.toUpdate))
+	allToProcess = append(allToProcess, operations.toAdd...)
+	allToProcess = append(allToProcess, operations.toUpdate...)
+
+	confirmed, err := s.confirmLargeSync(ctx, allToProcess)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Println("Sync canceled.")
+		return nil
+	}
+
 	// Remove unstarred repositories
 	if len(operations.toRemove) > 0 {
 		if err := s.removeRepositories(ctx, operations.toRemove, stats); err != nil {
@@ -325,9 +753,6 @@ func (s *SyncService) performFullSync(ctx context.Context, batchSize int, force
 	}
 
 	// Process repositories in batches with enhanced progress tracking
-	allToProcess := make([]github.Repository, 0, len(operations.toAdd)+len(operations.toUpdate))
-	allToProcess = append(allToProcess, operations.toAdd...)
-	allToProcess = append(allToProcess, operations.toUpdate...)
 
 	if len(allToProcess) > 0 {
 		if err := s.processRepositoriesInBatchesWithForceAndMonitor(
@@ -349,12 +774,14 @@ func (s *SyncService) performFullSync(ctx context.Context, batchSize int, force
 	stats.ProcessingTime = stats.EndTime.Sub(stats.StartTime)
 
 	s.printSyncSummary(stats)
+	s.finishCheckpoint(stats)
+	s.failedRepos += stats.ErrorRepos
 
 	return nil
 }
 
 func (s *SyncService) syncSpecificRepository(ctx context.Context, repoName string) error {
-	s.logVerbose("Syncing specific repository: " + repoName)
+	s.log().Debug("Syncing specific repository", slog.String("repo", repoName))
 
 	// Fetch the specific repository
 	starredRepos, err := s.githubClient.GetStarredRepos(ctx, "")
@@ -375,6 +802,13 @@ func (s *SyncService) syncSpecificRepository(ctx context.Context, repoName strin
 		return fmt.Errorf("repository %s not found in starred repositories", repoName)
 	}
 
+	if s.appendOnly {
+		if _, err := s.storage.GetRepository(ctx, repoName); err == nil {
+			fmt.Printf("Skipping %s: already stored and --append-only is set\n", repoName)
+			return nil
+		}
+	}
+
 	return s.processRepository(ctx, *targetRepo, true)
 }
 
@@ -382,6 +816,8 @@ type syncOperations struct {
 	toAdd    []github.Repository
 	toUpdate []github.Repository
 	toRemove []string
+	upToDate []string           // Repositories skipped without fetching
+	excluded map[skipReason]int // Repositories filtered out by --exclude-* flags
 }
 
 func (s *SyncService) determineSyncOperations(
@@ -401,27 +837,30 @@ func (s *SyncService) determineSyncOperations(
 		starredMap[repo.FullName] = repo
 	}
 
-	s.logVerbose("Analyzing repository changes...")
+	s.log().Debug("Analyzing repository changes")
 
 	// Determine additions and updates
 	for _, repo := range starredRepos {
 		existing, exists := existingRepos[repo.FullName]
 
-		if !exists {
+		if reason, excluded := s.exclusionReason(repo); excluded {
+			s.recordExclusion(ops, repo, reason, exists)
+		} else if !exists {
 			// New repository
 			ops.toAdd = append(ops.toAdd, repo)
-			s.logVerbose("  NEW: " + repo.FullName)
+			s.log().Debug("New repository", slog.String("repo", repo.FullName))
 		} else if force {
 			// Force update
 			ops.toUpdate = append(ops.toUpdate, repo)
-			s.logVerbose("  FORCE UPDATE: " + repo.FullName)
+			s.log().Debug("Forcing update", slog.String("repo", repo.FullName))
 		} else if s.needsUpdate(repo, existing) {
 			// Repository needs update
 			ops.toUpdate = append(ops.toUpdate, repo)
 			reason := s.getUpdateReason(repo, existing)
-			s.logVerbose(fmt.Sprintf("  UPDATE: %s (%s)", repo.FullName, reason))
+			s.log().Debug("Repository needs update", slog.String("repo", repo.FullName), slog.String("reason", reason))
 		} else {
-			s.logVerbose(fmt.Sprintf("  SKIP: %s (up to date)", repo.FullName))
+			ops.upToDate = append(ops.upToDate, repo.FullName)
+			s.logSkip(repo.FullName, skipUpToDate, false)
 		}
 	}
 
@@ -429,7 +868,7 @@ func (s *SyncService) determineSyncOperations(
 	for fullName := range existingRepos {
 		if _, stillStarred := starredMap[fullName]; !stillStarred {
 			ops.toRemove = append(ops.toRemove, fullName)
-			s.logVerbose(fmt.Sprintf("  REMOVE: %s (no longer starred)", fullName))
+			s.log().Debug("Removing repository", slog.String("repo", fullName), slog.String("reason", "no longer starred"))
 		}
 	}
 
@@ -438,7 +877,8 @@ func (s *SyncService) determineSyncOperations(
 
 func (s *SyncService) needsUpdate(repo github.Repository, existing *storage.StoredRepo) bool {
 	// Check if repository was updated since last sync
-	return repo.UpdatedAt.After(existing.LastSynced) ||
+	return githubIDChanged(repo, existing) ||
+		repo.UpdatedAt.After(existing.LastSynced) ||
 		repo.StargazersCount != existing.StargazersCount ||
 		repo.ForksCount != existing.ForksCount ||
 		repo.Size != existing.SizeKB ||
@@ -452,6 +892,13 @@ func (s *SyncService) needsUpdate(repo github.Repository, existing *storage.Stor
 func (s *SyncService) getUpdateReason(repo github.Repository, existing *storage.StoredRepo) string {
 	reasons := []string{}
 
+	if githubIDChanged(repo, existing) {
+		reasons = append(
+			reasons,
+			fmt.Sprintf("replaced: github id %d → %d", existing.GitHubID, repo.ID),
+		)
+	}
+
 	if repo.UpdatedAt.After(existing.LastSynced) {
 		reasons = append(reasons, "repository updated")
 	}
@@ -580,9 +1027,19 @@ func (s *SyncService) removeRepositories(
 		return nil
 	}
 
+	confirmed, err := s.confirmRemovals(toRemove)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Kept %d unstarred repositories; the next sync will offer them for removal again\n", len(toRemove))
+		return nil
+	}
+
 	fmt.Printf("\nRemoving %d unstarred repositories...\n", len(toRemove))
 
-	progress := NewProgressTracker(len(toRemove), "Removing repositories")
+	progress := s.newProgress(len(toRemove), "Removing repositories")
 	progress.Start()
 
 	for _, fullName := range toRemove {
@@ -596,10 +1053,10 @@ func (s *SyncService) removeRepositories(
 		progress.Update(fullName)
 
 		if err := s.storage.DeleteRepository(ctx, fullName); err != nil {
-			s.logVerbose(fmt.Sprintf("Failed to remove %s: %v", fullName, err))
+			s.log().Warn("Failed to remove repository", slog.String("repo", fullName), slog.String("error", err.Error()))
 			stats.SafeIncrement("error")
 		} else {
-			s.logVerbose("Removed: " + fullName)
+			s.log().Debug("Removed repository", slog.String("repo", fullName))
 			stats.SafeIncrement("removed")
 		}
 	}
@@ -655,6 +1112,9 @@ func (s *SyncService) processRepositoriesInBatchesWithForceAndMonitor(
 		isNewRepo[repo.FullName] = true
 	}
 
+	// One estimator spans all batches so the ETA covers the whole sync
+	eta := newETAEstimator(len(repos))
+
 	for i := 0; i < len(repos); i += batchSize {
 		select {
 		case <-ctx.Done():
@@ -670,11 +1130,22 @@ func (s *SyncService) processRepositoriesInBatchesWithForceAndMonitor(
 		batch := repos[i:end]
 		batchNum := (i / batchSize) + 1
 
+		if s.budgetExhausted() {
+			remaining := len(repos) - i
+			fmt.Printf("\nNetwork budget exhausted; leaving %d repositories for the next sync\n", remaining)
+
+			stats.AddBudgetSkipped(remaining)
+
+			return nil
+		}
+
 		fmt.Printf("\n--- Batch %d/%d ---\n", batchNum, totalBatches)
 
-		progress := NewProgressTracker(
+		progress := newProgressTrackerWithETA(
 			len(batch),
 			fmt.Sprintf("Processing batch %d/%d", batchNum, totalBatches),
+			eta,
+			s.plainProgress(),
 		)
 		progress.Start()
 
@@ -686,18 +1157,72 @@ func (s *SyncService) processRepositoriesInBatchesWithForceAndMonitor(
 		progress.Finish(fmt.Sprintf("Completed batch %d/%d", batchNum, totalBatches))
 
 		// Fetch and store metrics for the batch
-		s.fetchAndStoreMetrics(ctx, batch)
+		if !s.budgetExhausted() {
+			s.fetchAndStoreMetrics(ctx, batch)
+		}
 
-		// Small delay between batches to be respectful to APIs
-		if batchNum < totalBatches {
-			s.logVerbose("Waiting between batches...")
-			time.Sleep(BatchDelaySeconds * time.Second)
+		// The batch is fully stored, so a resumed sync can skip it
+		if err := s.checkpoint.save(); err != nil {
+			s.log().Warn("Failed to save sync checkpoint", slog.String("error", err.Error()))
+		}
+
+		// Delay between batches to be respectful to APIs, adapted to the remaining quota
+		if batchNum < totalBatches && !s.budgetExhausted() {
+			if delay := s.batchDelay(ctx); delay > 0 {
+				s.log().Debug("Waiting between batches", slog.Duration("delay", delay))
+				time.Sleep(delay)
+			}
 		}
 	}
 
 	return nil
 }
 
+// batchDelay returns how long to wait between batches. With adaptive delays enabled
+// the wait is skipped while the remaining rate limit is above the configured
+// threshold and lengthened when the quota is nearly exhausted. A delay set with
+// --batch-delay or sync.batch_delay is a floor: it is never skipped.
+func (s *SyncService) batchDelay(ctx context.Context) time.Duration {
+	defaultDelay := BatchDelaySeconds * time.Second
+	if s.config != nil {
+		defaultDelay = parseSyncDelay(s.config.Sync.BatchDelay, defaultDelay)
+	}
+
+	var floor time.Duration
+	if s.batchDelaySet || defaultDelay != BatchDelaySeconds*time.Second {
+		floor = defaultDelay
+	}
+
+	if s.config == nil || !s.config.Sync.AdaptiveBatchDelay {
+		return defaultDelay
+	}
+
+	reporter, ok := s.githubClient.(github.RateLimitReporter)
+	if !ok {
+		return defaultDelay
+	}
+
+	rateLimit, err := reporter.GetRateLimit(ctx)
+	if err != nil {
+		s.log().Debug("Could not check rate limit, using default delay", slog.String("error", err.Error()))
+		return defaultDelay
+	}
+
+	s.log().Debug("Rate limit",
+		slog.Int("remaining", rateLimit.Remaining), slog.Int("limit", rateLimit.Limit), slog.Time("reset", rateLimit.Reset))
+
+	threshold := s.config.Sync.RateLimitThreshold
+
+	switch {
+	case rateLimit.Remaining >= threshold:
+		return floor
+	case rateLimit.Remaining < threshold/4:
+		return max(LowRateLimitBatchDelaySeconds*time.Second, defaultDelay)
+	default:
+		return defaultDelay
+	}
+}
+
 func (s *SyncService) processBatch(
 	ctx context.Context,
 	batch []github.Repository,
@@ -749,8 +1274,15 @@ func (s *SyncService) processBatch(
 	for processedCount+errorCount < len(batch) {
 		select {
 		case result := <-results:
-			if result != nil {
+			if result != nil && result.OverBudget {
+				stats.SafeIncrement("budget_skipped")
+			} else if result != nil && result.Skipped {
+				stats.SafeIncrement("processed")
+				stats.AddTrimmedChunks(result.TrimmedChunks)
+				stats.AddSkipped(skipContentUnchanged, 1)
+			} else if result != nil {
 				stats.SafeIncrement("processed")
+				stats.AddTrimmedChunks(result.TrimmedChunks)
 
 				// Track the type of operation based on result
 				if result.IsNew {
@@ -772,8 +1304,12 @@ func (s *SyncService) processBatch(
 
 		case err := <-errors:
 			if err != nil {
-				s.logVerbose(fmt.Sprintf("Worker error: %v", err))
+				s.log().Debug("Worker error", slog.String("error", err.Error()))
 				stats.SafeIncrement("error")
+
+				if failure, ok := err.(*repoError); ok {
+					stats.AddFailure(failure.fullName, failure.err)
+				}
 			}
 
 			errorCount++
@@ -808,6 +1344,12 @@ func (s *SyncService) processWorker(
 
 			progress.Update(repo.FullName)
 
+			// Stop starting new fetches once the budget is spent
+			if s.budgetExhausted() {
+				results <- &ProcessResult{OverBudget: true}
+				continue
+			}
+
 			// Get existing repository to track changes
 			existing, _ := s.storage.GetRepository(ctx, repo.FullName)
 
@@ -818,19 +1360,26 @@ func (s *SyncService) processWorker(
 				false,
 				forceUpdate,
 			)
-			if err != nil {
-				s.logVerbose(fmt.Sprintf("Failed to process %s: %v", repo.FullName, err))
-				errors <- err
+			if err != nil && isBudgetError(err) {
+				s.log().Debug("Deferred repository: network budget exhausted", slog.String("repo", repo.FullName))
+				results <- &ProcessResult{OverBudget: true}
+			} else if err != nil {
+				s.log().Warn("Failed to process repository", slog.String("repo", repo.FullName), slog.String("error", err.Error()))
+				errors <- &repoError{fullName: repo.FullName, err: err}
 			} else {
 				// Enhance result with additional metadata
 				if result != nil {
 					result.IsNew = isNewRepo[repo.FullName]
 				}
+
+				s.checkpoint.markDone(repo.FullName)
 				results <- result
 			}
 
 			// Rate limiting - small delay between repositories
-			time.Sleep(RepositoryRateLimitMs * time.Millisecond)
+			if delay := s.repoDelay(); delay > 0 {
+				time.Sleep(delay)
+			}
 
 		case <-ctx.Done():
 			errors <- ctx.Err()
@@ -840,25 +1389,34 @@ func (s *SyncService) processWorker(
 }
 
 // fetchAndStoreMetrics fetches enriched metrics (contributors, languages, commits,
-// issues, PRs) for a batch of repos and stores them in the database.
-func (s *SyncService) fetchAndStoreMetrics(ctx context.Context, batch []github.Repository) {
+// issues, PRs) for a batch of repos and stores them in the database. It returns
+// the number of repositories whose metrics were stored.
+func (s *SyncService) fetchAndStoreMetrics(ctx context.Context, batch []github.Repository) int {
 	executor := github.NewBatchExecutor(s.githubClient, 4, 8)
 	ghMetrics := executor.FetchRepositoryMetrics(ctx, batch)
+	stored := 0
 
 	for _, repo := range batch {
 		gm, ok := ghMetrics[repo.FullName]
-		if !ok || gm == nil {
+		if !ok || gm == nil || metricsHitBudget(gm) {
 			continue
 		}
 
 		sm := s.convertMetrics(gm, repo.Homepage)
 		if err := s.storage.UpdateRepositoryMetrics(ctx, repo.FullName, sm); err != nil {
-			s.logVerbose(fmt.Sprintf("Failed to update metrics for %s: %v", repo.FullName, err))
+			s.log().Warn("Failed to update metrics", slog.String("repo", repo.FullName), slog.String("error", err.Error()))
+			continue
 		}
+
+		s.cacheMetrics(ctx, repo.FullName, sm)
+		stored++
 	}
+
+	return stored
 }
 
 // convertMetrics converts github.RepositoryMetrics to storage.RepositoryMetrics.
+// Counts whose fetch failed become storage.UnknownCount so they are stored as NULL.
 func (s *SyncService) convertMetrics(gm *github.RepositoryMetrics, homepage string) storage.RepositoryMetrics {
 	sm := storage.RepositoryMetrics{
 		OpenIssuesOpen:  gm.OpenIssues,
@@ -869,6 +1427,14 @@ func (s *SyncService) convertMetrics(gm *github.RepositoryMetrics, homepage stri
 		Homepage:        homepage,
 	}
 
+	if gm.FetchFailed("issues") {
+		sm.OpenIssuesOpen, sm.OpenIssuesTotal = storage.UnknownCount, storage.UnknownCount
+	}
+
+	if gm.FetchFailed("prs") {
+		sm.OpenPRsOpen, sm.OpenPRsTotal = storage.UnknownCount, storage.UnknownCount
+	}
+
 	// Convert contributors
 	for _, c := range gm.Contributors {
 		sm.Contributors = append(sm.Contributors, storage.Contributor{
@@ -877,27 +1443,69 @@ func (s *SyncService) convertMetrics(gm *github.RepositoryMetrics, homepage stri
 		})
 	}
 
-	// Convert commit activity
-	if gm.CommitActivity != nil {
-		sm.CommitsTotal = gm.CommitActivity.Total
+	// Convert commit activity; a negative total means GitHub is still computing stats
+	if gm.CommitActivity == nil || gm.CommitActivity.Total < 0 {
+		sm.Commits30d = storage.UnknownCount
+		sm.Commits1y = storage.UnknownCount
+		sm.CommitsTotal = storage.UnknownCount
+	} else {
 		now := time.Now()
-		for _, week := range gm.CommitActivity.Weeks {
-			weekTime := time.Unix(week.Week, 0)
-			daysSince := now.Sub(weekTime).Hours() / 24
-			if daysSince <= 30 {
-				sm.Commits30d += week.Commits
-			}
-			if daysSince <= 365 {
-				sm.Commits1y += week.Commits
-			}
-		}
+		sm.CommitsTotal = gm.CommitActivity.Total
+		sm.Commits30d = gm.CommitActivity.CommitsSince(now.AddDate(0, 0, -30))
+		sm.Commits1y = gm.CommitActivity.CommitsSince(now.AddDate(-1, 0, 0))
 	}
 
 	return sm
 }
 
-// calculateOptimalWorkers determines the optimal number of worker goroutines
+// repoDelay returns how long each worker pauses after a repository
+func (s *SyncService) repoDelay() time.Duration {
+	defaultDelay := RepositoryRateLimitMs * time.Millisecond
+	if s.config == nil {
+		return defaultDelay
+	}
+
+	return parseSyncDelay(s.config.Sync.RepoDelay, defaultDelay)
+}
+
+// parseSyncDelay parses a configured delay, falling back to defaultDelay for
+// values that are empty or invalid (which config validation rejects)
+func parseSyncDelay(value string, defaultDelay time.Duration) time.Duration {
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay < 0 {
+		return defaultDelay
+	}
+
+	return delay
+}
+
+// processorOptions configures content processing from the sync settings.
+// Stored chunks are reused for unchanged files when repo can look them up.
+func processorOptions(cfg *config.Config, repo storage.Repository) []processor.ServiceOption {
+	opts := []processor.ServiceOption{
+		processor.WithDocsDedupThreshold(cfg.Sync.DocsDedupThreshold),
+		processor.WithHomepageText(cfg.Sync.HomepageText),
+		processor.WithLimits(processor.Limits{
+			MaxFileKB:         cfg.Processor.MaxFileKB,
+			MaxTotalTokens:    cfg.Processor.MaxTotalTokens,
+			MaxTokensPerChunk: cfg.Processor.MaxTokensPerChunk,
+		}),
+	}
+
+	if store, ok := repo.(processor.ChunkStore); ok {
+		opts = append(opts, processor.WithChunkStore(store))
+	}
+
+	return opts
+}
+
+// calculateOptimalWorkers determines the optimal number of worker goroutines.
+// A configured worker count is used as is, up to MaxWorkerLimit and the batch size.
 func (s *SyncService) calculateOptimalWorkers(batchSize int) int {
+	if s.config != nil && s.config.Sync.MaxWorkers > 0 {
+		return max(1, minInt(minInt(s.config.Sync.MaxWorkers, MaxWorkerLimit), batchSize))
+	}
+
 	// Base the number of workers on CPU count and batch size
 	cpuCount := runtime.NumCPU()
 
@@ -928,6 +1536,22 @@ type ProcessResult struct {
 	MetadataChanged bool
 	Skipped         bool
 	IsNew           bool // Added for parallel processing tracking
+	TrimmedChunks   int  // Chunks dropped by the per-repository chunk cap
+	OverBudget      bool // Not fetched because the sync's network budget ran out
+}
+
+// repoError is a processing failure of one repository
+type repoError struct {
+	fullName string
+	err      error
+}
+
+func (e *repoError) Error() string {
+	return fmt.Sprintf("%s: %v", e.fullName, e.err)
+}
+
+func (e *repoError) Unwrap() error {
+	return e.err
 }
 
 func (s *SyncService) processRepository(
@@ -964,17 +1588,21 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 	if showDetails {
 		fmt.Printf("Processing repository: %s\n", repo.FullName)
 	} else {
-		s.logVerbose("Processing: " + repo.FullName)
+		s.log().Debug("Processing repository", slog.String("repo", repo.FullName))
 	}
 
 	result := &ProcessResult{}
 
 	// Extract content
-	content, err := s.processor.ExtractContent(ctx, repo)
+	extractCtx, cacheOutcome := processor.WithCacheOutcome(ctx)
+
+	content, err := s.processor.ExtractContent(extractCtx, repo)
 	if err != nil {
 		return result, fmt.Errorf("failed to extract content: %w", err)
 	}
 
+	s.logCacheOutcome(repo.FullName, cacheOutcome)
+
 	if showDetails {
 		fmt.Printf("  Extracted %d content files\n", len(content))
 	}
@@ -985,8 +1613,24 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 		return result, fmt.Errorf("failed to process repository: %w", err)
 	}
 
+	processed.Chunks, result.TrimmedChunks = processor.LimitChunks(processed.Chunks, s.maxChunks)
+
+	for _, docs := range slices.Sorted(maps.Keys(processed.DuplicateDocs)) {
+		if showDetails {
+			fmt.Printf("  Dropped %s of %s as a duplicate of %s\n", docs, repo.FullName, processed.DuplicateDocs[docs])
+		} else {
+			s.log().Debug("Dropped duplicate docs", slog.String("repo", repo.FullName),
+				slog.String("path", docs), slog.String("duplicate_of", processed.DuplicateDocs[docs]))
+		}
+	}
+
 	if showDetails {
 		fmt.Printf("  Generated %d content chunks\n", len(processed.Chunks))
+
+		if result.TrimmedChunks > 0 {
+			fmt.Printf("  Trimmed %d lower-priority chunks\n", result.TrimmedChunks)
+		}
+
 		fmt.Printf("  Content hash: %s\n", processed.ContentHash)
 	}
 
@@ -998,9 +1642,15 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 		}
 	}
 
+	upsertOpts := storage.UpsertOptions{
+		KeywordTerms:  chunkKeywordTerms(processed.Chunks),
+		ReplaceChunks: true,
+		Chunks:        processed.Chunks,
+	}
+
 	// Store or update repository with detailed change tracking
 	if existing == nil {
-		if err := s.storage.StoreRepository(ctx, *processed); err != nil {
+		if err := s.storage.UpsertRepository(ctx, *processed, upsertOpts); err != nil {
 			return result, fmt.Errorf("failed to store repository: %w", err)
 		}
 
@@ -1009,11 +1659,11 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 		}
 	} else {
 		// Enhanced change detection with content hash comparison
-		contentChanged := existing.ContentHash != processed.ContentHash
+		contentChanged := processed.ContentChangedSince(existing.ContentHash)
 		metadataChanged := s.hasMetadataChanged(existing, processed)
 
 		if contentChanged || metadataChanged || forceUpdate {
-			if err := s.storage.UpdateRepository(ctx, *processed); err != nil {
+			if err := s.storage.UpsertRepository(ctx, *processed, upsertOpts); err != nil {
 				return result, fmt.Errorf("failed to update repository: %w", err)
 			}
 
@@ -1041,8 +1691,8 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 					if contentChanged {
 						fmt.Printf(
 							"    Content hash: %s → %s\n",
-							existing.ContentHash[:8],
-							processed.ContentHash[:8],
+							shortHash(existing.ContentHash),
+							shortHash(processed.ContentHash),
 						)
 					}
 
@@ -1054,9 +1704,7 @@ func (s *SyncService) processRepositoryWithChangeTrackingAndForce(
 		} else {
 			result.Skipped = true
 
-			if showDetails {
-				fmt.Printf("  Skipped (no changes detected)\n")
-			}
+			s.logSkip(repo.FullName, skipContentUnchanged, showDetails)
 		}
 	}
 
@@ -1077,6 +1725,15 @@ func (s *SyncService) hasMetadataChanged(
 		s.licenseChanged(processed.Repository.License, existing.LicenseName, existing.LicenseSPDXID)
 }
 
+// shortHash abbreviates a content hash for display, "none" when there is none
+func shortHash(hash string) string {
+	if hash == "" {
+		return "none"
+	}
+
+	return hash[:min(len(hash), 8)]
+}
+
 // logMetadataChanges logs detailed metadata changes for verbose output
 func (s *SyncService) logMetadataChanges(
 	existing *storage.StoredRepo,
@@ -1108,6 +1765,22 @@ func (s *SyncService) logMetadataChanges(
 }
 
 func (s *SyncService) printSyncSummary(stats *SyncStats) {
+	s.log().Info("Sync finished",
+		slog.Int("total", stats.TotalRepos),
+		slog.Int("processed", stats.ProcessedRepos),
+		slog.Int("new", stats.NewRepos),
+		slog.Int("updated", stats.UpdatedRepos),
+		slog.Int("removed", stats.RemovedRepos),
+		slog.Int("renamed", stats.RenamedRepos),
+		slog.Int("skipped", stats.SkippedRepos),
+		slog.Int("failed", stats.ErrorRepos),
+		slog.Duration("duration", stats.ProcessingTime))
+
+	if s.summaryOut != nil {
+		s.writeJSONSummary(stats)
+		return
+	}
+
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Println("SYNC SUMMARY")
 	fmt.Println(strings.Repeat("=", 60))
@@ -1116,7 +1789,13 @@ func (s *SyncService) printSyncSummary(stats *SyncStats) {
 	fmt.Printf("New repositories added: %d\n", stats.NewRepos)
 	fmt.Printf("Repositories updated: %d\n", stats.UpdatedRepos)
 	fmt.Printf("Repositories removed: %d\n", stats.RemovedRepos)
+
+	if stats.RenamedRepos > 0 {
+		fmt.Printf("Repositories renamed: %d\n", stats.RenamedRepos)
+	}
+
 	fmt.Printf("Repositories skipped: %d\n", stats.SkippedRepos)
+	printSkipReasons(stats)
 	fmt.Printf("Failed repositories: %d\n", stats.ErrorRepos)
 
 	if stats.UpdatedRepos > 0 {
@@ -1125,6 +1804,18 @@ func (s *SyncService) printSyncSummary(stats *SyncStats) {
 		fmt.Printf("  Metadata changes: %d\n", stats.MetadataChanges)
 	}
 
+	if stats.TrimmedChunks > 0 {
+		fmt.Printf("Content chunks trimmed: %d\n", stats.TrimmedChunks)
+	}
+
+	s.printBudgetUsage(stats)
+
+	if reporter, ok := s.githubClient.(github.NotModifiedReporter); ok && reporter.NotModifiedCount() > 0 {
+		fmt.Printf("Unchanged GitHub responses served from cache: %d\n", reporter.NotModifiedCount())
+	}
+
+	s.printCacheStats()
+
 	fmt.Printf("\nTiming:\n")
 	fmt.Printf("  Total processing time: %v\n", stats.ProcessingTime)
 
@@ -1157,10 +1848,15 @@ func (s *SyncService) printSyncSummary(stats *SyncStats) {
 	}
 }
 
-func (s *SyncService) logVerbose(message string) {
-	if s.verbose {
-		fmt.Printf("[VERBOSE] %s\n", message)
+// log returns the structured logger for sync events: debug for each
+// repository and stage, warn for failures, info for the summary. Progress bars
+// and summaries meant for people are printed separately.
+func (s *SyncService) log() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
 	}
+
+	return slog.Default()
 }
 
 // contextKey is a type for context keys to avoid string collisions
@@ -1170,6 +1866,12 @@ const (
 	configContextKey contextKey = "config"
 )
 
+// WithConfig returns ctx carrying cfg, which commands read through
+// getConfigFromContext instead of loading the configuration again
+func WithConfig(ctx context.Context, cfg *config.Config) context.Context {
+	return context.WithValue(ctx, configContextKey, cfg)
+}
+
 // getConfigFromContext retrieves the configuration from the command context
 func getConfigFromContext(ctx context.Context) *config.Config {
 	if cfg, ok := ctx.Value(configContextKey).(*config.Config); ok {
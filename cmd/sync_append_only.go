@@ -0,0 +1,28 @@
+package cmd
+
+import "fmt"
+
+// applyAppendOnly drops updates and removals from operations when the sync is
+// append-only, so existing rows keep the snapshot taken when they were first
+// stored. It returns how many updates and removals were skipped.
+func (s *SyncService) applyAppendOnly(operations *syncOperations) (int, int) {
+	if !s.appendOnly {
+		return 0, 0
+	}
+
+	for _, repo := range operations.toUpdate {
+		s.logSkip(repo.FullName, skipAppendOnly, false)
+	}
+
+	skippedUpdates, skippedRemovals := len(operations.toUpdate), len(operations.toRemove)
+	operations.toUpdate = nil
+	operations.toRemove = nil
+
+	return skippedUpdates, skippedRemovals
+}
+
+// printAppendOnlyPlan notes in the sync plan what --append-only left untouched
+func printAppendOnlyPlan(skippedUpdates, skippedRemovals int) {
+	fmt.Printf("  Append-only: skipping %d updates and %d removals (existing repositories are never changed)\n",
+		skippedUpdates, skippedRemovals)
+}
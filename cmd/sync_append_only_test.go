@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestSyncService_ApplyAppendOnly(t *testing.T) {
+	newOperations := func() *syncOperations {
+		return &syncOperations{
+			toAdd:    []github.Repository{{FullName: "user/new"}},
+			toUpdate: []github.Repository{{FullName: "user/changed"}, {FullName: "user/other"}},
+			toRemove: []string{"user/unstarred"},
+		}
+	}
+
+	operations := newOperations()
+	updates, removals := (&SyncService{}).applyAppendOnly(operations)
+
+	if updates != 0 || removals != 0 || len(operations.toUpdate) != 2 || len(operations.toRemove) != 1 {
+		t.Errorf("applyAppendOnly() changed operations without --append-only")
+	}
+
+	operations = newOperations()
+	updates, removals = (&SyncService{appendOnly: true}).applyAppendOnly(operations)
+
+	if updates != 2 || removals != 1 {
+		t.Errorf("applyAppendOnly() skipped %d updates and %d removals, want 2 and 1", updates, removals)
+	}
+
+	if len(operations.toAdd) != 1 || len(operations.toUpdate) != 0 || len(operations.toRemove) != 0 {
+		t.Errorf("applyAppendOnly() left %+v, want only additions", operations)
+	}
+}
+
+func TestSyncAppendOnly_PreservesSnapshots(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if err := repo.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	starred := func(name string, stars int) github.Repository {
+		return github.Repository{
+			FullName:        name,
+			Description:     "Repository " + name,
+			StargazersCount: stars,
+			CreatedAt:       time.Now().Add(-24 * time.Hour),
+			UpdatedAt:       time.Now().Add(-time.Hour),
+		}
+	}
+
+	mockGitHub := &MockGitHubClient{
+		starredRepos: []github.Repository{starred("user/kept", 10), starred("user/unstarred", 20)},
+		content:      map[string][]github.Content{},
+	}
+
+	syncService := createTestSyncService(mockGitHub, processor.NewService(mockGitHub), repo)
+
+	if err := syncService.performFullSync(ctx, 10, false); err != nil {
+		t.Fatalf("Initial sync failed: %v", err)
+	}
+
+	// The kept repository gains stars, one is unstarred and one is newly starred
+	mockGitHub.starredRepos = []github.Repository{starred("user/kept", 99), starred("user/added", 5)}
+	syncService.appendOnly = true
+
+	if err := syncService.performFullSync(ctx, 10, false); err != nil {
+		t.Fatalf("Append-only sync failed: %v", err)
+	}
+
+	kept, err := repo.GetRepository(ctx, "user/kept")
+	if err != nil {
+		t.Fatalf("Failed to get user/kept: %v", err)
+	}
+
+	if kept.StargazersCount != 10 {
+		t.Errorf("Expected user/kept to keep its first-star snapshot of 10 stars, got %d", kept.StargazersCount)
+	}
+
+	if _, err := repo.GetRepository(ctx, "user/unstarred"); err != nil {
+		t.Errorf("Expected user/unstarred to be kept by an append-only sync: %v", err)
+	}
+
+	if _, err := repo.GetRepository(ctx, "user/added"); err != nil {
+		t.Errorf("Expected user/added to be stored: %v", err)
+	}
+}
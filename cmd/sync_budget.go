@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// newSyncBudget returns the network budget configured for a sync, or nil when unlimited
+func newSyncBudget(cfg config.SyncConfig) *github.Budget {
+	if cfg.MaxRequests <= 0 && cfg.MaxBytes <= 0 {
+		return nil
+	}
+
+	return github.NewBudget(int64(cfg.MaxRequests), int64(cfg.MaxBytes))
+}
+
+// budgetExhausted reports whether the sync has spent its network budget
+func (s *SyncService) budgetExhausted() bool {
+	return s.budget != nil && s.budget.Exhausted()
+}
+
+// isBudgetError reports whether err came from the budget refusing a request
+func isBudgetError(err error) bool {
+	return stderrors.Is(err, github.ErrBudgetExhausted)
+}
+
+// metricsHitBudget reports whether any metric fetch was refused by the budget.
+// Such metrics are not stored, so known counts are not overwritten with unknowns.
+func metricsHitBudget(metrics *github.RepositoryMetrics) bool {
+	for _, err := range metrics.Failed {
+		if isBudgetError(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printBudgetUsage reports how much of the network budget the sync spent
+func (s *SyncService) printBudgetUsage(stats *SyncStats) {
+	if s.budget == nil {
+		return
+	}
+
+	fmt.Printf("Network budget used: %d requests, %d bytes\n", s.budget.Requests(), s.budget.Bytes())
+
+	if stats.BudgetSkipped > 0 {
+		fmt.Printf("Deferred by network budget: %d (run sync again to continue)\n", stats.BudgetSkipped)
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// reservingClient draws one request from a budget per call, and one per path
+// for content, standing in for the HTTP transport of a client created WithBudget
+type reservingClient struct {
+	github.Client
+	budget *github.Budget
+}
+
+func (c *reservingClient) GetStarredRepos(ctx context.Context, username string) ([]github.Repository, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetStarredRepos(ctx, username)
+}
+
+func (c *reservingClient) GetRepository(ctx context.Context, fullName string) (*github.Repository, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetRepository(ctx, fullName)
+}
+
+func (c *reservingClient) GetRepositoryContent(
+	ctx context.Context,
+	repo github.Repository,
+	paths []string,
+) ([]github.Content, error) {
+	if err := c.budget.Reserve(int64(len(paths))); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetRepositoryContent(ctx, repo, paths)
+}
+
+func (c *reservingClient) GetRepositoryMetadata(ctx context.Context, repo github.Repository) (*github.Metadata, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetRepositoryMetadata(ctx, repo)
+}
+
+func (c *reservingClient) GetContributors(
+	ctx context.Context,
+	fullName string,
+	topN int,
+) ([]github.Contributor, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetContributors(ctx, fullName, topN)
+}
+
+func (c *reservingClient) GetTopics(ctx context.Context, fullName string) ([]string, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetTopics(ctx, fullName)
+}
+
+func (c *reservingClient) GetLanguages(ctx context.Context, fullName string) (map[string]int64, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetLanguages(ctx, fullName)
+}
+
+func (c *reservingClient) GetCommitActivity(ctx context.Context, fullName string) (*github.CommitActivity, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	return c.Client.GetCommitActivity(ctx, fullName)
+}
+
+func (c *reservingClient) GetPullCounts(ctx context.Context, fullName string) (int, int, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return 0, 0, err
+	}
+
+	return c.Client.GetPullCounts(ctx, fullName)
+}
+
+func (c *reservingClient) GetIssueCounts(ctx context.Context, fullName string) (int, int, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return 0, 0, err
+	}
+
+	return c.Client.GetIssueCounts(ctx, fullName)
+}
+
+func (c *reservingClient) GetHomepageText(ctx context.Context, url string) (string, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return "", err
+	}
+
+	return c.Client.GetHomepageText(ctx, url)
+}
+
+// newBudgetTestService returns a sync service whose client draws from budget,
+// an empty database and three starred repositories to process
+func newBudgetTestService(
+	t *testing.T,
+	budget *github.Budget,
+) (*SyncService, *storage.DuckDBRepository, []github.Repository) {
+	t.Helper()
+
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if err := repo.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGitHub := &MockGitHubClient{content: map[string][]github.Content{}}
+	for i := range 3 {
+		mockGitHub.starredRepos = append(mockGitHub.starredRepos, github.Repository{
+			FullName:  fmt.Sprintf("user/repo-%d", i),
+			CreatedAt: time.Now().Add(-24 * time.Hour),
+			UpdatedAt: time.Now().Add(-time.Hour),
+		})
+	}
+
+	client := github.NewBudgetClient(&reservingClient{Client: mockGitHub, budget: budget}, budget)
+	syncService := createTestSyncService(client, processor.NewService(client), repo)
+	syncService.budget = budget
+
+	return syncService, repo, mockGitHub.starredRepos
+}
+
+func TestSyncBudget_StopsStartingFetches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Measure what a single repository costs with an unlimited budget
+	unlimited := github.NewBudget(0, 0)
+	syncService, _, starred := newBudgetTestService(t, unlimited)
+	operations := &syncOperations{toAdd: starred[:1]}
+
+	if err := syncService.processRepositoriesInBatches(ctx, starred[:1], 1, &SyncStats{}, operations); err != nil {
+		t.Fatal(err)
+	}
+
+	perRepo := unlimited.Requests()
+	if perRepo == 0 {
+		t.Fatal("expected processing a repository to make requests")
+	}
+
+	// Enough for exactly one repository
+	budget := github.NewBudget(perRepo, 0)
+	syncService, repo, starred := newBudgetTestService(t, budget)
+
+	stats := &SyncStats{}
+	operations = &syncOperations{toAdd: starred}
+
+	if err := syncService.processRepositoriesInBatches(ctx, starred, 1, stats, operations); err != nil {
+		t.Fatalf("Budgeted sync should finish cleanly: %v", err)
+	}
+
+	if stats.ProcessedRepos != 1 || stats.BudgetSkipped != 2 || stats.ErrorRepos != 0 {
+		t.Errorf("Expected 1 processed, 2 deferred and 0 errors, got %d, %d and %d",
+			stats.ProcessedRepos, stats.BudgetSkipped, stats.ErrorRepos)
+	}
+
+	if budget.Requests() > perRepo {
+		t.Errorf("Budget overspent: %d requests, cap %d", budget.Requests(), perRepo)
+	}
+
+	stored, err := repo.ListRepositories(ctx, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stored) != 1 {
+		t.Errorf("Expected 1 stored repository, got %d", len(stored))
+	}
+}
+
+func TestNewSyncBudget(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.SyncConfig
+		want bool
+	}{
+		{"unlimited", config.SyncConfig{}, false},
+		{"request cap", config.SyncConfig{MaxRequests: 100}, true},
+		{"byte cap", config.SyncConfig{MaxBytes: 1 << 20}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newSyncBudget(tt.cfg) != nil; got != tt.want {
+				t.Errorf("newSyncBudget() returned a budget = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
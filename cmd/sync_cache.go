@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+const (
+	// starredReposCacheKey holds the full starred list (with repository metadata)
+	// from the last full sync, so rebuild --from-cache can skip the listing calls
+	starredReposCacheKey = "sync:starred_repos"
+	// metricsCacheKeyPrefix prefixes the cached metrics of each repository
+	metricsCacheKeyPrefix = "sync:metrics:"
+)
+
+// metadataCacheTTL keeps sync metadata for the configured metadata staleness window
+func (s *SyncService) metadataCacheTTL() time.Duration {
+	return time.Duration(s.config.Cache.MetadataStaleDays) * 24 * time.Hour
+}
+
+// cacheStarredRepos saves the starred list for later recovery. Failures are
+// logged only; the cache is an optimization.
+func (s *SyncService) cacheStarredRepos(ctx context.Context, repos []github.Repository) {
+	s.cacheJSON(ctx, starredReposCacheKey, repos)
+}
+
+// cachedStarredRepos returns the starred list saved by the last full sync
+func (s *SyncService) cachedStarredRepos(ctx context.Context) ([]github.Repository, bool) {
+	var repos []github.Repository
+	if !s.loadCachedJSON(ctx, starredReposCacheKey, &repos) {
+		return nil, false
+	}
+
+	return repos, true
+}
+
+// cacheMetrics saves the converted metrics of one repository
+func (s *SyncService) cacheMetrics(ctx context.Context, fullName string, metrics storage.RepositoryMetrics) {
+	s.cacheJSON(ctx, metricsCacheKeyPrefix+fullName, metrics)
+}
+
+// cachedMetrics returns the metrics saved when fullName was last synced
+func (s *SyncService) cachedMetrics(ctx context.Context, fullName string) (storage.RepositoryMetrics, bool) {
+	var metrics storage.RepositoryMetrics
+
+	return metrics, s.loadCachedJSON(ctx, metricsCacheKeyPrefix+fullName, &metrics)
+}
+
+func (s *SyncService) cacheJSON(ctx context.Context, key string, value any) {
+	if s.cache == nil {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = s.cache.Set(ctx, key, data, s.metadataCacheTTL())
+	}
+
+	if err != nil {
+		s.log().Debug("Failed to cache", slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+func (s *SyncService) loadCachedJSON(ctx context.Context, key string, target any) bool {
+	if s.cache == nil {
+		return false
+	}
+
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, target) == nil
+}
+
+// logCacheOutcome reports under --verbose-cache whether a repository's content
+// was served from the content cache or fetched from GitHub
+func (s *SyncService) logCacheOutcome(fullName string, outcome *processor.CacheOutcome) {
+	if !s.verboseCache {
+		return
+	}
+
+	switch {
+	case !outcome.Checked:
+		fmt.Printf("  %s: content cache disabled\n", fullName)
+	case outcome.Hit:
+		fmt.Printf("  %s: content cache hit\n", fullName)
+	default:
+		fmt.Printf("  %s: content cache miss, fetched from GitHub\n", fullName)
+	}
+}
+
+// printCacheStats prints the content cache totals of the sync. Each hit is a
+// content fetch that did not go to GitHub.
+func (s *SyncService) printCacheStats() {
+	reporter, ok := s.processor.(processor.CacheStatsReporter)
+	if !ok {
+		return
+	}
+
+	stats := reporter.ContentCacheStats()
+	if stats.Lookups() == 0 {
+		return
+	}
+
+	fmt.Printf("Content cache: %d hits, %d misses (%.1f%% hit rate)\n",
+		stats.Hits, stats.Misses, float64(stats.Hits)/float64(stats.Lookups())*100)
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// syncCheckpointFile is the checkpoint's name within the cache directory
+const syncCheckpointFile = "sync-checkpoint.json"
+
+// syncCheckpoint records the repositories a full sync has finished, so a sync
+// interrupted part way can resume without reprocessing them. It is saved after
+// each batch (once the batch's metrics are stored) and removed when a sync
+// completes. A nil checkpoint records nothing.
+type syncCheckpoint struct {
+	path      string
+	mu        sync.Mutex
+	startedAt time.Time
+	done      map[string]bool
+}
+
+// syncCheckpointData is the on-disk form of a checkpoint
+type syncCheckpointData struct {
+	StartedAt time.Time `json:"started_at"`
+	Completed []string  `json:"completed"`
+}
+
+func newSyncCheckpoint(path string) *syncCheckpoint {
+	return &syncCheckpoint{
+		path:      path,
+		startedAt: time.Now(),
+		done:      make(map[string]bool),
+	}
+}
+
+// load replaces the checkpoint's state with the file left by an earlier sync,
+// reporting false when there is none
+func (c *syncCheckpoint) load() (bool, error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to read sync checkpoint: %w", err)
+	}
+
+	var saved syncCheckpointData
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return false, fmt.Errorf("failed to parse sync checkpoint %s: %w", c.path, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.startedAt = saved.StartedAt
+	c.done = make(map[string]bool, len(saved.Completed))
+
+	for _, fullName := range saved.Completed {
+		c.done[fullName] = true
+	}
+
+	return true, nil
+}
+
+// retain forgets completed repositories that are no longer starred, so a
+// checkpoint from before the star list changed only skips current work
+func (c *syncCheckpoint) retain(starredRepos []github.Repository) {
+	starred := make(map[string]bool, len(starredRepos))
+	for _, repo := range starredRepos {
+		starred[repo.FullName] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for fullName := range c.done {
+		if !starred[fullName] {
+			delete(c.done, fullName)
+		}
+	}
+}
+
+// markDone records that fullName was processed
+func (c *syncCheckpoint) markDone(fullName string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[fullName] = true
+}
+
+// isDone reports whether fullName was processed
+func (c *syncCheckpoint) isDone(fullName string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.done[fullName]
+}
+
+// save writes the checkpoint, replacing the previous file atomically
+func (c *syncCheckpoint) save() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+
+	saved := syncCheckpointData{StartedAt: c.startedAt, Completed: make([]string, 0, len(c.done))}
+	for fullName := range c.done {
+		saved.Completed = append(saved.Completed, fullName)
+	}
+
+	c.mu.Unlock()
+
+	sort.Strings(saved.Completed)
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write sync checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write sync checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// remove deletes the checkpoint file once a sync has completed
+func (c *syncCheckpoint) remove() error {
+	if c == nil {
+		return nil
+	}
+
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove sync checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// applyCheckpoint starts this sync's checkpoint. With --resume (and without
+// --force) repositories completed by the interrupted sync are dropped from
+// operations; it returns how many were dropped. Otherwise the checkpoint starts
+// empty, overwriting any earlier one.
+func (s *SyncService) applyCheckpoint(
+	starredRepos []github.Repository,
+	operations *syncOperations,
+	force bool,
+) int {
+	if s.checkpoint == nil {
+		return 0
+	}
+
+	resumed := 0
+
+	if s.resume && !force {
+		found, err := s.checkpoint.load()
+
+		switch {
+		case err != nil:
+			fmt.Printf("Warning: %v; starting a full sync\n", err)
+
+			s.checkpoint = newSyncCheckpoint(s.checkpoint.path)
+		case !found:
+			fmt.Println("No sync checkpoint found; starting a full sync")
+		default:
+			s.checkpoint.retain(starredRepos)
+
+			operations.toAdd, resumed = s.dropCompleted(operations.toAdd, resumed)
+			operations.toUpdate, resumed = s.dropCompleted(operations.toUpdate, resumed)
+		}
+	}
+
+	if err := s.checkpoint.save(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	return resumed
+}
+
+// dropCompleted removes repositories already in the checkpoint, adding the
+// number removed to dropped
+func (s *SyncService) dropCompleted(repos []github.Repository, dropped int) ([]github.Repository, int) {
+	kept := repos[:0]
+
+	for _, repo := range repos {
+		if s.checkpoint.isDone(repo.FullName) {
+			s.logSkip(repo.FullName, skipCheckpoint, false)
+
+			dropped++
+
+			continue
+		}
+
+		kept = append(kept, repo)
+	}
+
+	return kept, dropped
+}
+
+// finishCheckpoint removes the checkpoint after a complete sync. It is kept when
+// repositories failed or were deferred by the network budget, so 'sync --resume'
+// picks up only what is left.
+func (s *SyncService) finishCheckpoint(stats *SyncStats) {
+	if s.checkpoint == nil {
+		return
+	}
+
+	if stats.ErrorRepos > 0 || stats.BudgetSkipped > 0 {
+		if err := s.checkpoint.save(); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+
+		fmt.Println("Sync checkpoint kept; run 'gh star-search sync --resume' to continue")
+
+		return
+	}
+
+	if err := s.checkpoint.remove(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestSyncCheckpoint_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", syncCheckpointFile)
+
+	checkpoint := newSyncCheckpoint(path)
+	checkpoint.markDone("user/b")
+	checkpoint.markDone("user/a")
+	require.NoError(t, checkpoint.save())
+
+	loaded := newSyncCheckpoint(path)
+	found, err := loaded.load()
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, loaded.isDone("user/a"))
+	assert.True(t, loaded.isDone("user/b"))
+
+	loaded.retain([]github.Repository{{FullName: "user/b"}})
+	assert.False(t, loaded.isDone("user/a"), "unstarred repositories are forgotten")
+	assert.True(t, loaded.isDone("user/b"))
+
+	require.NoError(t, loaded.remove())
+	found, err = newSyncCheckpoint(path).load()
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	_, err = newSyncCheckpoint(path).load()
+	assert.Error(t, err)
+
+	var nilCheckpoint *syncCheckpoint
+	nilCheckpoint.markDone("user/a")
+	assert.NoError(t, nilCheckpoint.save())
+}
+
+func TestSyncService_ApplyCheckpoint(t *testing.T) {
+	starred := []github.Repository{{FullName: "user/done"}, {FullName: "user/new"}, {FullName: "user/changed"}}
+
+	tests := []struct {
+		name        string
+		resume      bool
+		force       bool
+		wantResumed int
+		wantAdd     []string
+		wantUpdate  []string
+	}{
+		{
+			name:        "resume skips completed",
+			resume:      true,
+			wantResumed: 2,
+			wantAdd:     []string{"user/new"},
+			wantUpdate:  []string{},
+		},
+		{
+			name:       "force ignores checkpoint",
+			resume:     true,
+			force:      true,
+			wantAdd:    []string{"user/done", "user/new"},
+			wantUpdate: []string{"user/changed"},
+		},
+		{
+			name:       "without resume",
+			wantAdd:    []string{"user/done", "user/new"},
+			wantUpdate: []string{"user/changed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), syncCheckpointFile)
+
+			previous := newSyncCheckpoint(path)
+			previous.markDone("user/done")
+			previous.markDone("user/changed")
+			previous.markDone("user/unstarred")
+			require.NoError(t, previous.save())
+
+			s := &SyncService{checkpoint: newSyncCheckpoint(path), resume: tt.resume}
+			operations := &syncOperations{
+				toAdd:    []github.Repository{starred[0], starred[1]},
+				toUpdate: []github.Repository{starred[2]},
+			}
+
+			resumed := s.applyCheckpoint(starred, operations, tt.force)
+			assert.Equal(t, tt.wantResumed, resumed)
+
+			names := func(repos []github.Repository) []string {
+				out := []string{}
+				for _, repo := range repos {
+					out = append(out, repo.FullName)
+				}
+
+				return out
+			}
+
+			assert.Equal(t, tt.wantAdd, names(operations.toAdd))
+			assert.Equal(t, tt.wantUpdate, names(operations.toUpdate))
+
+			// The checkpoint on disk now belongs to this sync
+			saved := newSyncCheckpoint(path)
+			_, err := saved.load()
+			require.NoError(t, err)
+			assert.False(t, saved.isDone("user/unstarred"))
+			assert.Equal(t, tt.resume && !tt.force, saved.isDone("user/done"))
+		})
+	}
+}
+
+func TestSyncResume_SkipsCompletedRepositories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx := context.Background()
+	require.NoError(t, repo.Initialize(ctx))
+
+	starred := func(name string) github.Repository {
+		return github.Repository{
+			FullName:    name,
+			Description: "Repository " + name,
+			CreatedAt:   time.Now().Add(-24 * time.Hour),
+			UpdatedAt:   time.Now().Add(-time.Hour),
+		}
+	}
+
+	mockGitHub := &MockGitHubClient{
+		starredRepos: []github.Repository{starred("user/done"), starred("user/pending")},
+		content:      map[string][]github.Content{},
+	}
+
+	checkpointPath := filepath.Join(t.TempDir(), syncCheckpointFile)
+	interrupted := newSyncCheckpoint(checkpointPath)
+	interrupted.markDone("user/done")
+	require.NoError(t, interrupted.save())
+
+	syncService := createTestSyncService(mockGitHub, processor.NewService(mockGitHub), repo)
+	syncService.checkpoint = newSyncCheckpoint(checkpointPath)
+	syncService.resume = true
+
+	require.NoError(t, syncService.performFullSync(ctx, 10, false))
+
+	_, err = repo.GetRepository(ctx, "user/pending")
+	require.NoError(t, err)
+
+	_, err = repo.GetRepository(ctx, "user/done")
+	assert.Error(t, err, "completed repository is not reprocessed")
+
+	_, err = os.Stat(checkpointPath)
+	assert.True(t, os.IsNotExist(err), "checkpoint is removed after a complete sync")
+}
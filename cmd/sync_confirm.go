@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+)
+
+const (
+	// alwaysFetchedMetrics are the metric requests made for every repository
+	// (contributors and commit activity)
+	alwaysFetchedMetrics = 2
+	// prefetchableMetrics are the metric requests skipped when the GraphQL
+	// starred query already returned them (topics, languages, pull requests, issues)
+	prefetchableMetrics = 4
+)
+
+// syncEstimate is the pre-flight cost of processing a set of repositories
+type syncEstimate struct {
+	Repositories int
+	Requests     int // Upper bound on GitHub API requests
+}
+
+// estimateSyncCost bounds the GitHub requests needed to process repos: one per
+// priority file plus the per-repository metrics. Missing files still cost a
+// request, so this is an upper bound; cached and unchanged responses cost less.
+func estimateSyncCost(repos []github.Repository) syncEstimate {
+	contentRequests := len(processor.PriorityPaths())
+
+	estimate := syncEstimate{Repositories: len(repos)}
+
+	for _, repo := range repos {
+		estimate.Requests += contentRequests + alwaysFetchedMetrics
+
+		if repo.Prefetched == nil {
+			estimate.Requests += prefetchableMetrics
+		}
+	}
+
+	return estimate
+}
+
+// stdoutIsTerminal reports whether output goes to an interactive terminal
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmLargeSync asks before processing more repositories than
+// sync.confirm_large_sync, showing the estimated cost. It proceeds without asking
+// when the threshold is 0, --yes is set, or the sync is not interactive (no
+// confirmInput), and reports false only when the user declines.
+func (s *SyncService) confirmLargeSync(ctx context.Context, repos []github.Repository) (bool, error) {
+	threshold := 0
+	if s.config != nil {
+		threshold = s.config.Sync.ConfirmLargeSync
+	}
+
+	if threshold == 0 || len(repos) <= threshold || s.assumeYes || s.confirmInput == nil {
+		return true, nil
+	}
+
+	estimate := estimateSyncCost(repos)
+
+	fmt.Printf("\nThis sync will process %d repositories (confirmation threshold: %d).\n",
+		estimate.Repositories, threshold)
+	fmt.Printf("Estimated GitHub API requests: up to %d\n", estimate.Requests)
+
+	if reporter, ok := s.githubClient.(github.RateLimitReporter); ok {
+		if limit, err := reporter.GetRateLimit(ctx); err == nil {
+			fmt.Printf("Rate limit remaining: %d of %d (resets %s)\n",
+				limit.Remaining, limit.Limit, limit.Reset.Format("15:04"))
+
+			if estimate.Requests > limit.Remaining {
+				fmt.Println("The estimate exceeds the remaining quota; the sync will slow down or stop when it runs out.")
+			}
+		}
+	}
+
+	fmt.Print("Continue? [y/N]: ")
+
+	return readConfirmation(s.confirmInput)
+}
+
+// confirmRemovals asks before removing repositories that are no longer
+// starred, listing each one, when --confirm-removals is set. --yes confirms
+// without asking. A sync that cannot ask (no confirmInput) keeps them, since
+// the user opted into confirming removals.
+func (s *SyncService) confirmRemovals(toRemove []string) (bool, error) {
+	if !s.confirmRemoval || s.assumeYes {
+		return true, nil
+	}
+
+	fmt.Printf("\n%d repositories are no longer starred and will be removed:\n", len(toRemove))
+
+	for _, fullName := range toRemove {
+		fmt.Printf("  - %s\n", fullName)
+	}
+
+	if s.confirmInput == nil {
+		fmt.Println("Not removing them: output is not interactive. Pass --yes to remove without asking.")
+		return false, nil
+	}
+
+	fmt.Print("Remove them? [y/N]: ")
+
+	return readConfirmation(s.confirmInput)
+}
+
+// readConfirmation reads one answer line; only "y" or "yes" confirm
+func readConfirmation(r io.Reader) (bool, error) {
+	response, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestEstimateSyncCost(t *testing.T) {
+	repos := []github.Repository{
+		{FullName: "user/rest"},
+		{FullName: "user/graphql", Prefetched: &github.PrefetchedMetrics{}},
+	}
+
+	perRepo := len(processor.PriorityPaths()) + alwaysFetchedMetrics
+
+	estimate := estimateSyncCost(repos)
+	assert.Equal(t, 2, estimate.Repositories)
+	assert.Equal(t, 2*perRepo+prefetchableMetrics, estimate.Requests)
+}
+
+func TestSyncService_ConfirmLargeSync(t *testing.T) {
+	repos := []github.Repository{{FullName: "user/a"}, {FullName: "user/b"}, {FullName: "user/c"}}
+
+	tests := []struct {
+		name      string
+		threshold int
+		assumeYes bool
+		input     *string
+		want      bool
+	}{
+		{name: "under threshold", threshold: 3, input: ptr("n\n"), want: true},
+		{name: "disabled", threshold: 0, input: ptr("n\n"), want: true},
+		{name: "--yes", threshold: 2, assumeYes: true, input: ptr("n\n"), want: true},
+		{name: "not interactive", threshold: 2, want: true},
+		{name: "confirmed", threshold: 2, input: ptr("Yes\n"), want: true},
+		{name: "declined", threshold: 2, input: ptr("no\n"), want: false},
+		{name: "empty answer", threshold: 2, input: ptr("\n"), want: false},
+		{name: "closed input", threshold: 2, input: ptr(""), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SyncService{
+				githubClient: &MockGitHubClient{},
+				config:       &config.Config{Sync: config.SyncConfig{ConfirmLargeSync: tt.threshold}},
+				assumeYes:    tt.assumeYes,
+			}
+
+			if tt.input != nil {
+				s.confirmInput = strings.NewReader(*tt.input)
+			}
+
+			confirmed, err := s.confirmLargeSync(context.Background(), repos)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, confirmed)
+		})
+	}
+}
+
+func TestSyncService_ConfirmRemovals(t *testing.T) {
+	toRemove := []string{"user/a", "user/b"}
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		assumeYes bool
+		input     *string
+		want      bool
+	}{
+		{name: "disabled", input: ptr("n\n"), want: true},
+		{name: "--yes", enabled: true, assumeYes: true, input: ptr("n\n"), want: true},
+		{name: "not interactive", enabled: true, want: false},
+		{name: "confirmed", enabled: true, input: ptr("y\n"), want: true},
+		{name: "declined", enabled: true, input: ptr("n\n"), want: false},
+		{name: "closed input", enabled: true, input: ptr(""), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SyncService{confirmRemoval: tt.enabled, assumeYes: tt.assumeYes}
+
+			if tt.input != nil {
+				s.confirmInput = strings.NewReader(*tt.input)
+			}
+
+			confirmed, err := s.confirmRemovals(toRemove)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, confirmed)
+		})
+	}
+}
+
+func TestSyncService_RemoveRepositoriesDeclined(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(testutil.WithFullName("user/unstarred")), nil,
+	)))
+
+	s := &SyncService{
+		storage:        repo,
+		confirmRemoval: true,
+		confirmInput:   strings.NewReader("n\n"),
+		noProgress:     true,
+	}
+	stats := &SyncStats{}
+
+	require.NoError(t, s.removeRepositories(ctx, []string{"user/unstarred"}, stats))
+	assert.Zero(t, stats.RemovedRepos)
+
+	_, err := repo.GetRepository(ctx, "user/unstarred")
+	require.NoError(t, err, "a declined removal should keep the repository")
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// printDryRunPlan lists every repository a sync would add, update or remove,
+// with the reason for each update, in place of processing them
+func (s *SyncService) printDryRunPlan(
+	w io.Writer,
+	operations *syncOperations,
+	existingRepos map[string]*storage.StoredRepo,
+	force bool,
+) {
+	fmt.Fprintln(w, "\nDry run: no content is fetched and nothing is written.")
+
+	if len(operations.toAdd) > 0 {
+		fmt.Fprintf(w, "\nWould add (%d):\n", len(operations.toAdd))
+
+		for _, name := range sortedRepoNames(operations.toAdd) {
+			fmt.Fprintf(w, "  + %s\n", name)
+		}
+	}
+
+	if len(operations.toUpdate) > 0 {
+		fmt.Fprintf(w, "\nWould update (%d):\n", len(operations.toUpdate))
+
+		updates := slices.Clone(operations.toUpdate)
+		slices.SortFunc(updates, func(a, b github.Repository) int {
+			return strings.Compare(a.FullName, b.FullName)
+		})
+
+		for _, repo := range updates {
+			reason := "forced"
+			if existing, ok := existingRepos[repo.FullName]; ok && !force {
+				reason = s.getUpdateReason(repo, existing)
+			}
+
+			fmt.Fprintf(w, "  ~ %s (%s)\n", repo.FullName, reason)
+		}
+	}
+
+	if len(operations.toRemove) > 0 {
+		fmt.Fprintf(w, "\nWould remove (%d):\n", len(operations.toRemove))
+
+		removals := slices.Clone(operations.toRemove)
+		slices.Sort(removals)
+
+		for _, name := range removals {
+			fmt.Fprintf(w, "  - %s\n", name)
+		}
+	}
+
+	toProcess := make([]github.Repository, 0, len(operations.toAdd)+len(operations.toUpdate))
+	toProcess = append(toProcess, operations.toAdd...)
+	toProcess = append(toProcess, operations.toUpdate...)
+
+	if len(toProcess) == 0 && len(operations.toRemove) == 0 {
+		fmt.Fprintln(w, "\nNothing to change - all up to date!")
+		return
+	}
+
+	fmt.Fprintf(w, "\nEstimated GitHub requests: up to %d\n", estimateSyncCost(toProcess).Requests)
+}
+
+// sortedRepoNames returns the full names of repos in alphabetical order
+func sortedRepoNames(repos []github.Repository) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.FullName
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestPerformFullSync_DryRunWritesNothing(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, stored := range []github.Repository{
+		testutil.NewTestRepository(testutil.WithFullName("user/changed"), testutil.WithStars(10)),
+		testutil.NewTestRepository(testutil.WithFullName("user/unstarred")),
+		testutil.NewTestRepository(testutil.WithFullName("user/old-name"), testutil.WithGitHubID(42)),
+	} {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(stored, nil)))
+	}
+
+	mockGitHub := testutil.NewMockGitHubClient(testutil.WithStarredRepos([]github.Repository{
+		testutil.NewTestRepository(testutil.WithFullName("user/changed"), testutil.WithStars(20)),
+		testutil.NewTestRepository(testutil.WithFullName("org/new-name"), testutil.WithGitHubID(42)),
+		testutil.NewTestRepository(testutil.WithFullName("user/brand-new")),
+	}))
+
+	cfg, _ := config.LoadConfig()
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+		config:       cfg,
+		dryRun:       true,
+	}
+
+	require.NoError(t, syncService.performFullSync(ctx, DefaultBatchSize, false))
+
+	for _, name := range []string{"user/changed", "user/unstarred", "user/old-name"} {
+		_, err := repo.GetRepository(ctx, name)
+		require.NoError(t, err, "%s should still be stored", name)
+	}
+
+	_, err := repo.GetRepository(ctx, "user/brand-new")
+	require.Error(t, err, "new repositories should not be stored")
+
+	changed, err := repo.GetRepository(ctx, "user/changed")
+	require.NoError(t, err)
+	assert.Equal(t, 10, changed.StargazersCount)
+}
+
+func TestPrintDryRunPlan(t *testing.T) {
+	existing := map[string]*storage.StoredRepo{
+		"user/changed": {FullName: "user/changed", StargazersCount: 10},
+	}
+	operations := &syncOperations{
+		toAdd:    []github.Repository{{FullName: "user/zeta"}, {FullName: "user/alpha"}},
+		toUpdate: []github.Repository{{FullName: "user/changed", StargazersCount: 20}},
+		toRemove: []string{"user/unstarred-b", "user/unstarred-a"},
+	}
+
+	var out bytes.Buffer
+	(&SyncService{}).printDryRunPlan(&out, operations, existing, false)
+
+	plan := out.String()
+	assert.Contains(t, plan, "Would add (2):\n  + user/alpha\n  + user/zeta\n")
+	assert.Contains(t, plan, "  ~ user/changed (stars: 10 → 20)\n")
+	assert.Contains(t, plan, "Would remove (2):\n  - user/unstarred-a\n  - user/unstarred-b\n")
+	assert.Contains(t, plan, "Estimated GitHub requests")
+
+	out.Reset()
+	(&SyncService{}).printDryRunPlan(&out, operations, existing, true)
+	assert.Contains(t, out.String(), "  ~ user/changed (forced)\n")
+
+	out.Reset()
+	(&SyncService{}).printDryRunPlan(&out, &syncOperations{}, nil, false)
+	assert.Contains(t, out.String(), "Nothing to change")
+}
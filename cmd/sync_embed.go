@@ -8,25 +8,25 @@ import (
 	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/embedding"
 	"github.com/KyleKing/gh-star-search/internal/python"
+	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
-// generateEmbeddings generates vector embeddings for repositories
-func (s *SyncService) generateEmbeddings(ctx context.Context, _ bool) error {
-	s.logVerbose("\nGenerating repository embeddings...")
+// generateEmbeddings generates vector embeddings for repositories without one,
+// or for every repository when force is set
+func (s *SyncService) generateEmbeddings(ctx context.Context, force bool) error {
+	s.log().Debug("Generating repository embeddings")
 
-	// Get all repositories (we need to check which ones have embeddings)
-	// TODO: Add a method to get repositories needing embeddings
-	repos, err := s.storage.ListRepositories(ctx, 1000, 0)
+	return generateEmbeddings(ctx, s.storage, s.config, force)
+}
+
+// generateEmbeddings embeds the repositories that need it and prints a summary.
+// It is shared by 'sync --embed' and the embed command.
+func generateEmbeddings(ctx context.Context, repo storage.Repository, cfg *config.Config, force bool) error {
+	needEmbedding, err := repo.GetRepositoriesNeedingEmbedding(ctx, force)
 	if err != nil {
 		return fmt.Errorf("failed to get repositories: %w", err)
 	}
 
-	// Filter repositories that need embeddings
-	var needEmbedding []string
-	for _, repo := range repos {
-		needEmbedding = append(needEmbedding, repo.FullName)
-	}
-
 	if len(needEmbedding) == 0 {
 		fmt.Println("All repositories have embeddings - no updates needed")
 		return nil
@@ -34,46 +34,78 @@ func (s *SyncService) generateEmbeddings(ctx context.Context, _ bool) error {
 
 	fmt.Printf("\nGenerating embeddings for %d repositories...\n", len(needEmbedding))
 
-	// Prepare Python environment
+	embProvider, err := newEmbeddingProvider(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	successful, failed := embedRepositories(ctx, repo, embProvider, needEmbedding)
+
+	// Print summary
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("EMBEDDING GENERATION COMPLETE")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Total repositories: %d\n", len(needEmbedding))
+	fmt.Printf("Successfully embedded: %d\n", successful)
+	fmt.Printf("Failed: %d\n", failed)
+
+	if failed > 0 {
+		fmt.Printf("\n%d repositories failed to embed\n", failed)
+	} else {
+		fmt.Println("\nAll repositories embedded successfully!")
+	}
+
+	return partialFailure(failed, "embed")
+}
+
+// newEmbeddingProvider prepares the Python environment and starts the local
+// embedding provider
+func newEmbeddingProvider(ctx context.Context, cfg *config.Config) (embedding.Provider, error) {
 	uvPath, err := python.FindUV()
 	if err != nil {
-		return fmt.Errorf("embedding generation requires uv: %w", err)
+		return nil, fmt.Errorf("embedding generation requires uv: %w", err)
 	}
 
-	cacheDir := config.ExpandPath(s.config.Cache.Directory)
+	cacheDir := config.ExpandPath(cfg.Cache.Directory)
+
 	projectDir, err := python.EnsureEnvironment(ctx, uvPath, cacheDir)
 	if err != nil {
-		return fmt.Errorf("failed to prepare Python environment: %w", err)
+		return nil, fmt.Errorf("failed to prepare Python environment: %w", err)
 	}
 
-	// Initialize embedding provider
-	embConfig := embedding.Config{
-		Provider:   "local",
-		Model:      "intfloat/e5-small-v2",
-		Dimensions: 384,
-		Enabled:    true,
-		Options:    make(map[string]string),
-	}
+	embConfig := embedding.DefaultConfig()
+	embConfig.Enabled = true
 
 	embProvider, err := embedding.NewProvider(embConfig, uvPath, projectDir)
 	if err != nil {
-		return fmt.Errorf("failed to initialize embedding provider: %w", err)
+		return nil, fmt.Errorf("failed to initialize embedding provider: %w", err)
 	}
 
 	if !embProvider.IsEnabled() {
-		return fmt.Errorf("embedding provider is not enabled")
+		return nil, fmt.Errorf("embedding provider is not enabled")
 	}
 
-	// Track statistics
+	return embProvider, nil
+}
+
+// embedRepositories generates and stores an embedding for each named repository,
+// returning how many succeeded and failed. Vectors whose length differs from the
+// provider's dimensions are rejected, since vector search casts stored
+// embeddings to a fixed-size array.
+func embedRepositories(
+	ctx context.Context,
+	repo storage.Repository,
+	embProvider embedding.Provider,
+	fullNames []string,
+) (int, int) {
 	successful := 0
 	failed := 0
 
-	// Process each repository
-	for i, repoName := range needEmbedding {
-		fmt.Printf("  [%d/%d] %s: ", i+1, len(needEmbedding), repoName)
+	for i, repoName := range fullNames {
+		fmt.Printf("  [%d/%d] %s: ", i+1, len(fullNames), repoName)
 
 		// Get repository details
-		repo, err := s.storage.GetRepository(ctx, repoName)
+		stored, err := repo.GetRepository(ctx, repoName)
 		if err != nil {
 			fmt.Printf("Failed to get repository: %v\n", err)
 			failed++
@@ -81,7 +113,7 @@ func (s *SyncService) generateEmbeddings(ctx context.Context, _ bool) error {
 		}
 
 		// Build text to embed from repository metadata
-		text := buildEmbeddingInput(repo.FullName, repo.Description, repo.Purpose, repo.Topics)
+		text := buildEmbeddingInput(stored.FullName, stored.Description, stored.Purpose, stored.Topics)
 
 		// Generate embedding
 		embVec, err := embProvider.GenerateEmbedding(ctx, text)
@@ -91,8 +123,14 @@ func (s *SyncService) generateEmbeddings(ctx context.Context, _ bool) error {
 			continue
 		}
 
+		if len(embVec) != embProvider.GetDimensions() {
+			fmt.Printf("Embedding has %d dimensions, expected %d\n", len(embVec), embProvider.GetDimensions())
+			failed++
+			continue
+		}
+
 		// Store embedding
-		if err := s.storage.UpdateRepositoryEmbedding(ctx, repoName, embVec); err != nil {
+		if err := repo.UpdateRepositoryEmbedding(ctx, repoName, embVec); err != nil {
 			fmt.Printf("Failed to store embedding: %v\n", err)
 			failed++
 			continue
@@ -102,21 +140,7 @@ func (s *SyncService) generateEmbeddings(ctx context.Context, _ bool) error {
 		successful++
 	}
 
-	// Print summary
-	fmt.Println("\n" + strings.Repeat("=", 60))
-	fmt.Println("EMBEDDING GENERATION COMPLETE")
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Printf("Total repositories: %d\n", len(needEmbedding))
-	fmt.Printf("Successfully embedded: %d\n", successful)
-	fmt.Printf("Failed: %d\n", failed)
-
-	if failed > 0 {
-		fmt.Printf("\n%d repositories failed to embed\n", failed)
-	} else {
-		fmt.Println("\nAll repositories embedded successfully!")
-	}
-
-	return nil
+	return successful, failed
 }
 
 // buildEmbeddingInput creates text input for embedding from repository metadata
@@ -143,3 +167,126 @@ func buildEmbeddingInput(fullName, description, purpose string, topics []string)
 
 	return strings.Join(parts, ". ")
 }
+
+// chunkEmbedder embeds several texts in one call; satisfied by *embedding.LocalProvider
+type chunkEmbedder interface {
+	GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+	GetDimensions() int
+}
+
+// generateChunkEmbeddings embeds the stored content chunks without an
+// embedding, or every chunk when force is set, and prints a summary. Chunk
+// embeddings power 'query --chunks'.
+func generateChunkEmbeddings(ctx context.Context, repo storage.Repository, cfg *config.Config, force bool) error {
+	chunks, err := repo.GetChunksNeedingEmbedding(ctx, force)
+	if err != nil {
+		return fmt.Errorf("failed to get content chunks: %w", err)
+	}
+
+	if len(chunks) == 0 {
+		fmt.Println("All content chunks have embeddings - no updates needed")
+		return nil
+	}
+
+	fmt.Printf("\nGenerating embeddings for %d content chunks...\n", len(chunks))
+
+	embProvider, err := newEmbeddingProvider(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	embedder, ok := embProvider.(chunkEmbedder)
+	if !ok {
+		return fmt.Errorf("embedding provider %s does not support batch embedding", embProvider.GetName())
+	}
+
+	successful, failed := embedChunks(ctx, repo, embedder, chunks)
+
+	// Print summary
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("CHUNK EMBEDDING COMPLETE")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Total repositories: %d\n", successful+failed)
+	fmt.Printf("Successfully embedded: %d\n", successful)
+	fmt.Printf("Failed: %d\n", failed)
+
+	return partialFailure(failed, "embed")
+}
+
+// embedChunks embeds the chunks of each repository in one batch and stores
+// them, returning how many repositories succeeded and failed. chunks must be
+// grouped by repository, as GetChunksNeedingEmbedding returns them.
+func embedChunks(
+	ctx context.Context,
+	repo storage.Repository,
+	embedder chunkEmbedder,
+	chunks []storage.StoredChunk,
+) (int, int) {
+	var groups [][]storage.StoredChunk
+
+	for i, chunk := range chunks {
+		if i == 0 || chunk.FullName != chunks[i-1].FullName {
+			groups = append(groups, nil)
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], chunk)
+	}
+
+	successful := 0
+	failed := 0
+
+	for i, group := range groups {
+		fmt.Printf("  [%d/%d] %s: ", i+1, len(groups), group[0].FullName)
+
+		texts := make([]string, len(group))
+		for j, chunk := range group {
+			texts[j] = chunk.Content
+		}
+
+		vectors, err := embedder.GenerateEmbeddings(ctx, texts)
+		if err != nil {
+			fmt.Printf("Failed to generate embeddings: %v\n", err)
+			failed++
+			continue
+		}
+
+		if len(vectors) != len(group) {
+			fmt.Printf("Got %d embeddings for %d chunks\n", len(vectors), len(group))
+			failed++
+			continue
+		}
+
+		if err := storeChunkEmbeddings(ctx, repo, group, vectors, embedder.GetDimensions()); err != nil {
+			fmt.Printf("Failed to store embeddings: %v\n", err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("%d chunks embedded\n", len(group))
+		successful++
+	}
+
+	return successful, failed
+}
+
+// storeChunkEmbeddings stores one vector per chunk, rejecting vectors whose
+// length differs from dimensions as embedRepositories does
+func storeChunkEmbeddings(
+	ctx context.Context,
+	repo storage.Repository,
+	chunks []storage.StoredChunk,
+	vectors [][]float32,
+	dimensions int,
+) error {
+	for i, chunk := range chunks {
+		if len(vectors[i]) != dimensions {
+			return fmt.Errorf("chunk %d has %d dimensions, expected %d", chunk.Index, len(vectors[i]), dimensions)
+		}
+
+		if err := repo.UpdateChunkEmbedding(ctx, chunk.ID, vectors[i]); err != nil {
+			return fmt.Errorf("chunk %d: %w", chunk.Index, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// etaWindowSize is the number of recent completions averaged for the ETA
+	etaWindowSize = 50
+	// etaMinSamples is the number of completions required before an ETA is shown
+	etaMinSamples = 3
+)
+
+// etaEstimator predicts the remaining time of a sync from a rolling average of
+// per-item durations. Durations are measured between completions, so time spent
+// between batches (metrics fetches, delays) is included in the average.
+type etaEstimator struct {
+	total       int
+	completed   int
+	completions []time.Time // start time followed by the most recent completion times
+	now         func() time.Time
+}
+
+// newETAEstimator creates an estimator for the given number of items
+func newETAEstimator(total int) *etaEstimator {
+	return &etaEstimator{
+		total: total,
+		now:   time.Now,
+	}
+}
+
+// start records the starting time; later calls are ignored so one estimator can span batches
+func (e *etaEstimator) start() {
+	if len(e.completions) == 0 {
+		e.completions = append(e.completions, e.now())
+	}
+}
+
+// observe records the completion of one item
+func (e *etaEstimator) observe() {
+	e.start()
+
+	e.completed++
+	e.completions = append(e.completions, e.now())
+
+	if len(e.completions) > etaWindowSize+1 {
+		e.completions = e.completions[len(e.completions)-etaWindowSize-1:]
+	}
+}
+
+// remaining returns the estimated time left, or false when there is not enough data
+func (e *etaEstimator) remaining() (time.Duration, bool) {
+	if e.completed < etaMinSamples || e.completed >= e.total {
+		return 0, false
+	}
+
+	oldest := e.completions[0]
+	newest := e.completions[len(e.completions)-1]
+	perItem := newest.Sub(oldest) / time.Duration(len(e.completions)-1)
+
+	return perItem * time.Duration(e.total-e.completed), true
+}
+
+// formatETA renders a remaining duration, e.g. "~4m remaining"
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m remaining"
+	case d < time.Hour:
+		return fmt.Sprintf("~%dm remaining", int((d+time.Minute-1)/time.Minute))
+	default:
+		minutes := int((d + time.Minute - 1) / time.Minute)
+		return fmt.Sprintf("~%dh%02dm remaining", minutes/60, minutes%60)
+	}
+}
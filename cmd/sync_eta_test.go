@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns a controllable time source for the ETA estimator
+func fakeClock() (func() time.Time, func(time.Duration)) {
+	current := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return func() time.Time { return current }, func(d time.Duration) { current = current.Add(d) }
+}
+
+func TestETAEstimator(t *testing.T) {
+	t.Run("no estimate before minimum samples", func(t *testing.T) {
+		now, advance := fakeClock()
+		eta := newETAEstimator(100)
+		eta.now = now
+		eta.start()
+
+		for range etaMinSamples - 1 {
+			advance(time.Second)
+			eta.observe()
+		}
+
+		_, ok := eta.remaining()
+		assert.False(t, ok)
+	})
+
+	t.Run("steady rate", func(t *testing.T) {
+		now, advance := fakeClock()
+		eta := newETAEstimator(100)
+		eta.now = now
+		eta.start()
+
+		for range 10 {
+			advance(2 * time.Second)
+			eta.observe()
+		}
+
+		remaining, ok := eta.remaining()
+		assert.True(t, ok)
+		assert.Equal(t, 180*time.Second, remaining)
+	})
+
+	t.Run("adapts to rate changes", func(t *testing.T) {
+		now, advance := fakeClock()
+		eta := newETAEstimator(1000)
+		eta.now = now
+		eta.start()
+
+		for range etaWindowSize {
+			advance(10 * time.Second)
+			eta.observe()
+		}
+
+		for range etaWindowSize {
+			advance(time.Second)
+			eta.observe()
+		}
+
+		remaining, ok := eta.remaining()
+		assert.True(t, ok)
+		assert.Equal(t, 900*time.Second, remaining, "only the recent window should count")
+	})
+
+	t.Run("no estimate once complete", func(t *testing.T) {
+		now, advance := fakeClock()
+		eta := newETAEstimator(etaMinSamples)
+		eta.now = now
+		eta.start()
+
+		for range etaMinSamples {
+			advance(time.Second)
+			eta.observe()
+		}
+
+		_, ok := eta.remaining()
+		assert.False(t, ok)
+	})
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected string
+	}{
+		{"under a minute", 30 * time.Second, "<1m remaining"},
+		{"rounds up minutes", 3*time.Minute + 10*time.Second, "~4m remaining"},
+		{"hours", 90 * time.Minute, "~1h30m remaining"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatETA(tt.input))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+const (
+	// skipArchived: the repository is archived and --exclude-archived is set
+	skipArchived skipReason = "archived, --exclude-archived"
+	// skipFork: the repository is a fork and --exclude-forks is set
+	skipFork skipReason = "fork, --exclude-forks"
+)
+
+// exclusionReason reports whether the --exclude-* flags keep repo out of the index
+func (s *SyncService) exclusionReason(repo github.Repository) (skipReason, bool) {
+	switch {
+	case s.excludeArchived && repo.Archived:
+		return skipArchived, true
+	case s.excludeForks && repo.Fork:
+		return skipFork, true
+	default:
+		return "", false
+	}
+}
+
+// recordExclusion counts an excluded repository in operations. A stored
+// repository is queued for removal so the index drops it.
+func (s *SyncService) recordExclusion(ops *syncOperations, repo github.Repository, reason skipReason, stored bool) {
+	if ops.excluded == nil {
+		ops.excluded = make(map[skipReason]int)
+	}
+
+	ops.excluded[reason]++
+
+	if stored {
+		ops.toRemove = append(ops.toRemove, repo.FullName)
+		s.log().Debug("Removing repository", slog.String("repo", repo.FullName), slog.String("reason", string(reason)))
+
+		return
+	}
+
+	s.logSkip(repo.FullName, reason, false)
+}
+
+// addExclusions adds the repositories excluded by --exclude-* flags to the skip counts
+func (s *SyncStats) addExclusions(ops *syncOperations) {
+	for reason, n := range ops.excluded {
+		s.AddSkipped(reason, n)
+	}
+}
+
+// printExclusionPlan notes in the sync plan how many repositories the --exclude-* flags filtered out
+func printExclusionPlan(ops *syncOperations) {
+	if n := ops.excluded[skipArchived]; n > 0 {
+		fmt.Printf("  Excluded archived repositories: %d\n", n)
+	}
+
+	if n := ops.excluded[skipFork]; n > 0 {
+		fmt.Printf("  Excluded forks: %d\n", n)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestDetermineSyncOperations_Exclusions(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	starredRepos := []github.Repository{
+		{FullName: "user/active", UpdatedAt: baseTime},
+		{FullName: "user/new-archive", Archived: true, UpdatedAt: baseTime},
+		{FullName: "user/old-archive", Archived: true, UpdatedAt: baseTime},
+		{FullName: "user/new-fork", Fork: true, UpdatedAt: baseTime},
+		{FullName: "user/archived-fork", Archived: true, Fork: true, UpdatedAt: baseTime},
+	}
+	existingRepos := map[string]*storage.StoredRepo{
+		"user/old-archive": {FullName: "user/old-archive", LastSynced: baseTime},
+	}
+
+	names := func(repos []github.Repository) []string {
+		out := []string{}
+		for _, repo := range repos {
+			out = append(out, repo.FullName)
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		service      *SyncService
+		wantAdd      []string
+		wantRemove   []string
+		wantExcluded map[skipReason]int
+	}{
+		{
+			name:       "no filters",
+			service:    &SyncService{},
+			wantAdd:    []string{"user/active", "user/new-archive", "user/new-fork", "user/archived-fork"},
+			wantRemove: []string{},
+		},
+		{
+			name:         "exclude archived",
+			service:      &SyncService{excludeArchived: true},
+			wantAdd:      []string{"user/active", "user/new-fork"},
+			wantRemove:   []string{"user/old-archive"},
+			wantExcluded: map[skipReason]int{skipArchived: 3},
+		},
+		{
+			name:         "exclude forks",
+			service:      &SyncService{excludeForks: true},
+			wantAdd:      []string{"user/active", "user/new-archive"},
+			wantRemove:   []string{},
+			wantExcluded: map[skipReason]int{skipFork: 2},
+		},
+		{
+			name:         "exclude both",
+			service:      &SyncService{excludeArchived: true, excludeForks: true},
+			wantAdd:      []string{"user/active"},
+			wantRemove:   []string{"user/old-archive"},
+			wantExcluded: map[skipReason]int{skipArchived: 3, skipFork: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := tt.service.determineSyncOperations(starredRepos, existingRepos, false)
+
+			assert.Equal(t, tt.wantAdd, names(ops.toAdd))
+			assert.Equal(t, tt.wantRemove, ops.toRemove)
+			assert.Equal(t, tt.wantExcluded, ops.excluded)
+
+			stats := &SyncStats{}
+			stats.addExclusions(ops)
+
+			total := 0
+			for _, n := range tt.wantExcluded {
+				total += n
+			}
+
+			assert.Equal(t, total, stats.SkippedRepos)
+		})
+	}
+}
+
+func TestListedRemovals(t *testing.T) {
+	listed := []github.Repository{{FullName: "user/archived"}, {FullName: "user/kept"}}
+
+	assert.Equal(t, []string{"user/archived"},
+		listedRemovals([]string{"user/archived", "user/not-listed"}, listed))
+	assert.Empty(t, listedRemovals([]string{"user/not-listed"}, listed))
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// syncJSONSummary is the sync summary printed on stdout under --json
+type syncJSONSummary struct {
+	TotalRepos       int               `json:"total_repos"`
+	Processed        int               `json:"processed"`
+	Added            int               `json:"added"`
+	Updated          int               `json:"updated"`
+	Removed          int               `json:"removed"`
+	Renamed          int               `json:"renamed"`
+	Skipped          int               `json:"skipped"`
+	SkipReasons      map[string]int    `json:"skip_reasons"`
+	Failed           int               `json:"failed"`
+	Failures         map[string]string `json:"failures,omitempty"`
+	ContentChanges   int               `json:"content_changes"`
+	MetadataChanges  int               `json:"metadata_changes"`
+	TrimmedChunks    int               `json:"trimmed_chunks"`
+	DeferredByBudget int               `json:"deferred_by_budget"`
+	DurationSeconds  float64           `json:"duration_seconds"`
+}
+
+// writeJSONSummary writes stats to s.summaryOut as a single JSON object
+func (s *SyncService) writeJSONSummary(stats *SyncStats) {
+	reasons := make(map[string]int, len(stats.SkipReasons))
+	for reason, count := range stats.SkipReasons {
+		reasons[string(reason)] = count
+	}
+
+	data, err := json.Marshal(syncJSONSummary{
+		TotalRepos:       stats.TotalRepos,
+		Processed:        stats.ProcessedRepos,
+		Added:            stats.NewRepos,
+		Updated:          stats.UpdatedRepos,
+		Removed:          stats.RemovedRepos,
+		Renamed:          stats.RenamedRepos,
+		Skipped:          stats.SkippedRepos,
+		SkipReasons:      reasons,
+		Failed:           stats.ErrorRepos,
+		Failures:         stats.Failures,
+		ContentChanges:   stats.ContentChanges,
+		MetadataChanges:  stats.MetadataChanges,
+		TrimmedChunks:    stats.TrimmedChunks,
+		DeferredByBudget: stats.BudgetSkipped,
+		DurationSeconds:  stats.ProcessingTime.Seconds(),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to encode sync summary: %v\n", err)
+		return
+	}
+
+	fmt.Fprintln(s.summaryOut, string(data))
+}
+
+// stdoutToStderr sends everything printed to os.Stdout to stderr until restore
+// is called, so progress output does not mix with a JSON document on stdout.
+// It returns the original stdout for that document.
+func stdoutToStderr() (stdout *os.File, restore func()) {
+	stdout = os.Stdout
+	os.Stdout = os.Stderr
+
+	return stdout, func() { os.Stdout = stdout }
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/keywords"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+)
+
+// chunkKeywordTerms extracts candidate keyword counts from processed content chunks
+func chunkKeywordTerms(chunks []processor.ContentChunk) map[string]int {
+	var text strings.Builder
+
+	for _, chunk := range chunks {
+		text.WriteString(chunk.Content)
+		text.WriteString("\n")
+	}
+
+	return keywords.TermFrequencies(text.String(), keywords.MaxTermsPerDocument)
+}
+
+// refreshKeywords recomputes README-derived keywords for every repository with
+// TF-IDF over the stored candidate terms of the whole corpus. IDF changes as
+// repositories are added, so all rows are recomputed rather than only synced
+// ones; only those whose keywords changed are written, in one transaction.
+func (s *SyncService) refreshKeywords(ctx context.Context) error {
+	s.log().Debug("Refreshing README-derived keywords")
+
+	corpus, err := s.storage.ListKeywordTerms(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load keyword terms: %w", err)
+	}
+
+	selected := keywords.TopKeywords(corpus, keywords.DefaultKeywordCount)
+
+	updated, err := s.storage.UpdateKeywords(ctx, selected)
+	if err != nil {
+		return fmt.Errorf("failed to store keywords: %w", err)
+	}
+
+	s.log().Debug("Updated keywords", slog.Int("repos", len(selected)), slog.Int("changed", updated))
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// logRecords decodes the JSON log lines written to buf
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestSyncService_StructuredLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := &SyncService{
+		logger:     slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		summaryOut: io.Discard,
+	}
+
+	starred := []github.Repository{{FullName: "user/new"}, {FullName: "user/kept"}}
+	existing := map[string]*storage.StoredRepo{
+		"user/kept": {FullName: "user/kept"},
+		"user/gone": {FullName: "user/gone"},
+	}
+
+	s.determineSyncOperations(starred, existing, true)
+	s.printSyncSummary(&SyncStats{TotalRepos: 2, ProcessedRepos: 2, NewRepos: 1, UpdatedRepos: 1, RemovedRepos: 1})
+
+	records := logRecords(t, &buf)
+
+	perRepo := make(map[string]string)
+
+	for _, record := range records {
+		if name, ok := record["repo"].(string); ok {
+			assert.Equal(t, "DEBUG", record["level"], "per-repository events log at debug")
+			perRepo[name] = record["msg"].(string)
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"user/new":  "New repository",
+		"user/kept": "Forcing update",
+		"user/gone": "Removing repository",
+	}, perRepo)
+
+	summary := records[len(records)-1]
+	assert.Equal(t, "INFO", summary["level"])
+	assert.Equal(t, "Sync finished", summary["msg"])
+	assert.InDelta(t, 1, summary["new"], 0)
+	assert.InDelta(t, 1, summary["removed"], 0)
+}
+
+func TestSyncService_LogHonorsLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := &SyncService{
+		logger:     slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		summaryOut: io.Discard,
+	}
+
+	s.logSkip("user/repo", skipUpToDate, false)
+	assert.Empty(t, buf.String(), "debug events are dropped at info level")
+
+	s.printSyncSummary(&SyncStats{})
+	assert.Contains(t, buf.String(), `level=INFO msg="Sync finished"`)
+}
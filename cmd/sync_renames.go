@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// githubIDChanged reports whether the repository at this full name is a different
+// GitHub repository than the stored one (e.g. deleted and recreated, or a transfer
+// freed the name for another repository)
+func githubIDChanged(repo github.Repository, existing *storage.StoredRepo) bool {
+	return repo.ID != 0 && existing.GitHubID != 0 && repo.ID != existing.GitHubID
+}
+
+// backfillGitHubIDs stores the numeric id for existing rows synced before it was
+// tracked, including repositories that are otherwise up to date and skipped
+func (s *SyncService) backfillGitHubIDs(
+	ctx context.Context,
+	repos []github.Repository,
+	existingRepos map[string]*storage.StoredRepo,
+) error {
+	ids := make(map[string]int64)
+
+	for _, repo := range repos {
+		existing, exists := existingRepos[repo.FullName]
+		if exists && existing.GitHubID == 0 && repo.ID != 0 {
+			ids[repo.FullName] = repo.ID
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// A dry run only needs the ids in memory for rename detection
+	if !s.dryRun {
+		updated, err := s.storage.BackfillGitHubIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to backfill GitHub ids: %w", err)
+		}
+
+		s.log().Debug("Backfilled GitHub ids", slog.Int("repos", updated))
+	}
+
+	for fullName, id := range ids {
+		existingRepos[fullName].GitHubID = id
+	}
+
+	return nil
+}
+
+// applyRenames detects repositories renamed or transferred on GitHub by matching the
+// stable numeric id, and moves their stored rows to the new full name so locally
+// managed data (tags, summaries, embeddings, keywords) survives. existingRepos is
+// updated in place so the following sync plan sees the renamed rows as existing.
+func (s *SyncService) applyRenames(
+	ctx context.Context,
+	repos []github.Repository,
+	existingRepos map[string]*storage.StoredRepo,
+	stats *SyncStats,
+) error {
+	byGitHubID := make(map[int64]*storage.StoredRepo)
+
+	for _, existing := range existingRepos {
+		if existing.GitHubID != 0 {
+			byGitHubID[existing.GitHubID] = existing
+		}
+	}
+
+	current := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		current[repo.FullName] = true
+	}
+
+	for _, repo := range repos {
+		if repo.ID == 0 {
+			continue
+		}
+
+		if _, exists := existingRepos[repo.FullName]; exists {
+			continue
+		}
+
+		existing, found := byGitHubID[repo.ID]
+		if !found || current[existing.FullName] {
+			continue
+		}
+
+		oldFullName := existing.FullName
+
+		if s.dryRun {
+			fmt.Printf("  Would rename: %s -> %s\n", oldFullName, repo.FullName)
+		} else {
+			if err := s.storage.RenameRepository(ctx, oldFullName, repo.FullName); err != nil {
+				return fmt.Errorf("failed to rename %s to %s: %w", oldFullName, repo.FullName, err)
+			}
+
+			fmt.Printf("  Renamed: %s -> %s\n", oldFullName, repo.FullName)
+			stats.SafeIncrement("renamed")
+		}
+
+		delete(existingRepos, oldFullName)
+		existing.FullName = repo.FullName
+		existingRepos[repo.FullName] = existing
+	}
+
+	return nil
+}
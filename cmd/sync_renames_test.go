@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestPerformFullSync_RenamedRepository(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	original := testutil.NewTestRepository(testutil.WithFullName("user/old-name"), testutil.WithGitHubID(42))
+	require.NoError(t, repo.UpsertRepository(ctx, testutil.NewTestProcessedRepo(original, nil), storage.UpsertOptions{
+		Embedding: []float32{0.5, 0.5},
+	}))
+	require.NoError(t, repo.AddTag(ctx, "user/old-name", "evaluate for work"))
+
+	renamed := testutil.NewTestRepository(
+		testutil.WithFullName("org/new-name"),
+		testutil.WithGitHubID(42),
+		testutil.WithDescription("Moved to an organization"),
+	)
+	mockGitHub := testutil.NewMockGitHubClient(
+		testutil.WithStarredRepos([]github.Repository{renamed}),
+		testutil.WithContent(map[string][]github.Content{
+			"org/new-name": {testutil.NewTestContent("README.md", "Renamed repo")},
+		}),
+	)
+
+	cfg, _ := config.LoadConfig()
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+		config:       cfg,
+	}
+
+	require.NoError(t, syncService.performFullSync(ctx, DefaultBatchSize, false))
+
+	_, err := repo.GetRepository(ctx, "user/old-name")
+	require.Error(t, err, "old name should no longer exist")
+
+	stored, err := repo.GetRepository(ctx, "org/new-name")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), stored.GitHubID)
+	assert.Equal(t, []string{"evaluate for work"}, stored.Tags, "tags should follow the rename")
+	assert.Equal(t, []float32{0.5, 0.5}, stored.RepoEmbedding, "embedding should follow the rename")
+
+	stats, err := repo.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalRepositories)
+}
+
+func TestApplyRenames_IgnoresUnrelatedRepositories(t *testing.T) {
+	existing := map[string]*storage.StoredRepo{
+		"user/kept":    {FullName: "user/kept", GitHubID: 1},
+		"user/no-id":   {FullName: "user/no-id"},
+		"user/removed": {FullName: "user/removed", GitHubID: 3},
+	}
+
+	repos := []github.Repository{
+		{ID: 1, FullName: "user/kept"},
+		{ID: 2, FullName: "user/brand-new"},
+		{FullName: "user/unknown-id"},
+	}
+
+	syncService := &SyncService{storage: &MockRepository{}}
+
+	require.NoError(t, syncService.applyRenames(context.Background(), repos, existing, &SyncStats{}))
+
+	assert.Len(t, existing, 3)
+	assert.Contains(t, existing, "user/removed", "unstarred repositories are not treated as renames")
+}
+
+func TestPerformFullSync_BackfillsGitHubIDs(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/legacy")))
+
+	stored, err := repo.GetRepository(ctx, "user/legacy")
+	require.NoError(t, err)
+	require.Zero(t, stored.GitHubID)
+
+	// Same metadata as stored, so the repository is skipped but still backfilled
+	starred := testutil.NewTestRepository(
+		testutil.WithFullName("user/legacy"),
+		testutil.WithGitHubID(99),
+		testutil.WithUpdatedAt(stored.UpdatedAt),
+	)
+	mockGitHub := testutil.NewMockGitHubClient(testutil.WithStarredRepos([]github.Repository{starred}))
+
+	cfg, _ := config.LoadConfig()
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+		config:       cfg,
+	}
+
+	require.NoError(t, syncService.performFullSync(ctx, DefaultBatchSize, false))
+
+	stored, err = repo.GetRepository(ctx, "user/legacy")
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), stored.GitHubID)
+}
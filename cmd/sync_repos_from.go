@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// readRepoList reads "owner/name" lines from a file. Blank lines and lines
+// starting with '#' are ignored, and duplicates are dropped.
+func readRepoList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository list: %w", err)
+	}
+	defer file.Close()
+
+	var names []string
+
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := validateRepositoryName(line); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid repository %q: %w", path, lineNum, line, err)
+		}
+
+		if seen[line] {
+			continue
+		}
+
+		seen[line] = true
+		names = append(names, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repository list: %w", err)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("repository list %s is empty", path)
+	}
+
+	return names, nil
+}
+
+// syncRepositoryList fetches and processes exactly the listed repositories via the
+// single-repository endpoint. The starred set is not diffed, so nothing is removed;
+// instead each listed repository is checked to be starred when the client can
+// check stars. It ends with the outcome of each listed repository.
+func (s *SyncService) syncRepositoryList(
+	ctx context.Context,
+	repoNames []string,
+	batchSize int,
+	force bool,
+) error {
+	stats := &SyncStats{
+		StartTime:  time.Now(),
+		TotalRepos: len(repoNames),
+	}
+
+	s.log().Debug("Syncing listed repositories", slog.Int("repos", len(repoNames)))
+
+	fetchProgress := s.newProgress(len(repoNames), "Fetching listed repositories")
+	fetchProgress.Start()
+
+	repos := make([]github.Repository, 0, len(repoNames))
+	fetchedAs := make(map[string]string, len(repoNames))
+	checker, checkStars := s.githubClient.(github.StarChecker)
+
+	for _, name := range repoNames {
+		repo, err := s.githubClient.GetRepository(ctx, name)
+		if err == nil && checkStars {
+			err = requireStarred(ctx, checker, repo.FullName)
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				fetchProgress.Stop()
+				return ctx.Err()
+			}
+
+			stats.SafeIncrement("error")
+			stats.AddFailure(name, err)
+		} else {
+			repos = append(repos, *repo)
+			fetchedAs[name] = repo.FullName
+		}
+
+		fetchProgress.Update(name)
+	}
+
+	fetchProgress.Finish(fmt.Sprintf("Fetched %d of %d listed repositories", len(repos), len(repoNames)))
+
+	for _, name := range repoNames {
+		if reason, failed := stats.Failures[name]; failed {
+			fmt.Printf("  Warning: skipping %s: %s\n", name, reason)
+		}
+	}
+
+	existingRepos, err := s.getExistingRepositories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get existing repositories: %w", err)
+	}
+
+	if err := s.applyRenames(ctx, repos, existingRepos, stats); err != nil {
+		return err
+	}
+
+	if err := s.backfillGitHubIDs(ctx, repos, existingRepos); err != nil {
+		return err
+	}
+
+	operations := s.determineSyncOperations(repos, existingRepos, force)
+	// Repositories outside the list are not part of this sync and must not be removed;
+	// only listed repositories dropped by an --exclude-* flag are
+	operations.toRemove = listedRemovals(operations.toRemove, repos)
+	skippedUpdates, skippedRemovals := s.applyAppendOnly(operations)
+	stats.AddSkipped(skipUpToDate, len(operations.upToDate))
+	stats.AddSkipped(skipAppendOnly, skippedUpdates)
+	stats.addExclusions(operations)
+
+	fmt.Printf("\nSync Plan:\n")
+	fmt.Printf("  New repositories: %d\n", len(operations.toAdd))
+	fmt.Printf("  Updated repositories: %d\n", len(operations.toUpdate))
+
+	if len(operations.toRemove) > 0 {
+		fmt.Printf("  Removed repositories: %d\n", len(operations.toRemove))
+	}
+
+	printExclusionPlan(operations)
+
+	if s.appendOnly {
+		printAppendOnlyPlan(skippedUpdates, skippedRemovals)
+	}
+
+	fmt.Printf("  Total to process: %d\n", len(operations.toAdd)+len(operations.toUpdate))
+
+	if s.dryRun {
+		s.printDryRunPlan(os.Stdout, operations, existingRepos, force)
+		return nil
+	}
+
+	allToProcess := make([]github.Repository, 0, len(operations.toAdd)+len(operations.toUpdate))
+	allToProcess = append(allToProcess, operations.toAdd...)
+	allToProcess = append(allToProcess, operations.toUpdate...)
+
+	confirmed, err := s.confirmLargeSync(ctx, allToProcess)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Println("Sync canceled.")
+		return nil
+	}
+
+	if len(operations.toRemove) > 0 {
+		if err := s.removeRepositories(ctx, operations.toRemove, stats); err != nil {
+			return fmt.Errorf("failed to remove repositories: %w", err)
+		}
+	}
+
+	if len(allToProcess) > 0 {
+		if err := s.processRepositoriesInBatchesWithForceAndMonitor(
+			ctx,
+			allToProcess,
+			batchSize,
+			stats,
+			operations,
+			force,
+			nil,
+		); err != nil {
+			return fmt.Errorf("failed to process repositories: %w", err)
+		}
+	} else {
+		fmt.Println("\nNo listed repositories need processing - all up to date!")
+	}
+
+	stats.EndTime = time.Now()
+	stats.ProcessingTime = stats.EndTime.Sub(stats.StartTime)
+
+	printRepoListOutcome(os.Stdout, repoNames, fetchedAs, stats.Failures)
+	s.printSyncSummary(stats)
+	s.failedRepos += stats.ErrorRepos
+
+	return nil
+}
+
+// requireStarred returns an error unless the authenticated user starred fullName
+func requireStarred(ctx context.Context, checker github.StarChecker, fullName string) error {
+	starred, err := checker.IsStarred(ctx, fullName)
+	if err != nil {
+		return err
+	}
+
+	if !starred {
+		return errors.New("not starred")
+	}
+
+	return nil
+}
+
+// printRepoListOutcome prints whether each listed repository synced (or was
+// already up to date) or failed, and why. failures holds fetch failures by
+// listed name and processing failures by the fetched name, which differs from
+// the listed one for a renamed repository.
+func printRepoListOutcome(out io.Writer, repoNames []string, fetchedAs, failures map[string]string) {
+	fmt.Fprintln(out, "\nListed repositories:")
+
+	for _, name := range repoNames {
+		fetched, ok := fetchedAs[name]
+		if !ok {
+			fmt.Fprintf(out, "  ✗ %s: %s\n", name, failures[name])
+			continue
+		}
+
+		if reason, failed := failures[fetched]; failed {
+			fmt.Fprintf(out, "  ✗ %s: %s\n", name, reason)
+			continue
+		}
+
+		if fetched != name {
+			fmt.Fprintf(out, "  ✓ %s (now %s)\n", name, fetched)
+			continue
+		}
+
+		fmt.Fprintf(out, "  ✓ %s\n", name)
+	}
+}
+
+// listedRemovals keeps only the removals of repositories in the list, dropping
+// stored repositories that are merely absent from it
+func listedRemovals(toRemove []string, listed []github.Repository) []string {
+	inList := make(map[string]bool, len(listed))
+	for _, repo := range listed {
+		inList[repo.FullName] = true
+	}
+
+	var kept []string
+
+	for _, fullName := range toRemove {
+		if inList[fullName] {
+			kept = append(kept, fullName)
+		}
+	}
+
+	return kept
+}
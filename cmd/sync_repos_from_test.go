@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func writeRepoList(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestReadRepoList(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expected      []string
+		errorContains string
+	}{
+		{
+			name:     "skips blanks, comments, and duplicates",
+			content:  "# work repos\nuser/alpha\n\n  user/beta  \nuser/alpha\n",
+			expected: []string{"user/alpha", "user/beta"},
+		},
+		{
+			name:          "rejects invalid names with line number",
+			content:       "user/alpha\nnot-a-repo\n",
+			errorContains: ":2: invalid repository \"not-a-repo\"",
+		},
+		{
+			name:          "rejects empty list",
+			content:       "# nothing here\n",
+			errorContains: "is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := readRepoList(writeRepoList(t, tt.content))
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readRepoList(filepath.Join(t.TempDir(), "missing.txt"))
+		require.Error(t, err)
+	})
+}
+
+func TestSyncRepositoryList(t *testing.T) {
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/unlisted")))
+
+	listed := testutil.NewTestRepository(testutil.WithFullName("user/listed"))
+	unstarred := testutil.NewTestRepository(testutil.WithFullName("user/unstarred"))
+	mockGitHub := testutil.NewMockGitHubClient(
+		testutil.WithStarredRepos([]github.Repository{listed}),
+		testutil.WithUnstarredRepos([]github.Repository{unstarred}),
+		testutil.WithContent(map[string][]github.Content{
+			"user/listed": {testutil.NewTestContent("README.md", "Listed repo")},
+		}),
+	)
+
+	cfg, _ := config.LoadConfig()
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+		config:       cfg,
+	}
+
+	err := syncService.syncRepositoryList(ctx, []string{"user/listed", "user/missing", "user/unstarred"}, DefaultBatchSize, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, syncService.failedRepos)
+
+	_, err = repo.GetRepository(ctx, "user/listed")
+	require.NoError(t, err, "listed repository should be stored")
+
+	_, err = repo.GetRepository(ctx, "user/unstarred")
+	require.Error(t, err, "a listed repository that is not starred should not be stored")
+
+	_, err = repo.GetRepository(ctx, "user/unlisted")
+	require.NoError(t, err, "repositories outside the list should not be removed")
+
+	assert.Zero(t, mockGitHub.GetCallCount("GetStarredRepos"), "starred set should not be fetched")
+}
+
+func TestPrintRepoListOutcome(t *testing.T) {
+	var out bytes.Buffer
+
+	printRepoListOutcome(&out,
+		[]string{"user/ok", "user/old-name", "user/missing", "user/broken"},
+		map[string]string{"user/ok": "user/ok", "user/old-name": "org/new-name", "user/broken": "user/broken"},
+		map[string]string{"user/missing": "not starred", "user/broken": "failed to extract content"},
+	)
+
+	assert.Equal(t, `
+Listed repositories:
+  ✓ user/ok
+  ✓ user/old-name (now org/new-name)
+  ✗ user/missing: not starred
+  ✗ user/broken: failed to extract content
+`, out.String())
+}
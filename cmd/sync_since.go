@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// applySince drops additions and updates of repositories starred before
+// s.since, and all removals, since a sync limited to recent stars says nothing
+// about older ones. Repositories without a star time are dropped too. It
+// returns how many additions and updates were skipped.
+func (s *SyncService) applySince(operations *syncOperations) int {
+	if s.since.IsZero() {
+		return 0
+	}
+
+	keep := func(repos []github.Repository) ([]github.Repository, int) {
+		kept := make([]github.Repository, 0, len(repos))
+
+		for _, repo := range repos {
+			if repo.StarredAt.After(s.since) {
+				kept = append(kept, repo)
+				continue
+			}
+
+			s.logSkip(repo.FullName, skipBeforeSince, false)
+		}
+
+		return kept, len(repos) - len(kept)
+	}
+
+	var skippedAdds, skippedUpdates int
+
+	operations.toAdd, skippedAdds = keep(operations.toAdd)
+	operations.toUpdate, skippedUpdates = keep(operations.toUpdate)
+	operations.toRemove = nil
+
+	return skippedAdds + skippedUpdates
+}
+
+// printSincePlan notes in the sync plan what --since left untouched
+func printSincePlan(since time.Time, skipped int) {
+	fmt.Printf("  Since %s: skipping %d repositories starred earlier (nothing is removed)\n",
+		since.Format(time.DateOnly), skipped)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+func TestSyncService_ApplySince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newOperations := func() *syncOperations {
+		return &syncOperations{
+			toAdd: []github.Repository{
+				{FullName: "user/recent", StarredAt: since.Add(24 * time.Hour)},
+				{FullName: "user/old", StarredAt: since.Add(-24 * time.Hour)},
+				{FullName: "user/unknown"},
+			},
+			toUpdate: []github.Repository{{FullName: "user/changed", StarredAt: since.Add(time.Hour)}},
+			toRemove: []string{"user/unstarred"},
+		}
+	}
+
+	operations := newOperations()
+	if skipped := (&SyncService{}).applySince(operations); skipped != 0 || len(operations.toRemove) != 1 {
+		t.Errorf("applySince() changed operations without --since")
+	}
+
+	operations = newOperations()
+	skipped := (&SyncService{since: since}).applySince(operations)
+
+	if skipped != 2 {
+		t.Errorf("applySince() skipped %d repositories, want 2", skipped)
+	}
+
+	if len(operations.toAdd) != 1 || operations.toAdd[0].FullName != "user/recent" {
+		t.Errorf("applySince() kept additions %+v, want only user/recent", operations.toAdd)
+	}
+
+	if len(operations.toUpdate) != 1 || len(operations.toRemove) != 0 {
+		t.Errorf("applySince() left %+v, want the update and no removals", operations)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// skipReason explains why sync left a starred repository unchanged
+type skipReason string
+
+const (
+	// skipUpToDate: GitHub reports no push since the last sync and the listed metadata matches
+	skipUpToDate skipReason = "timestamp not advanced, metadata identical"
+	// skipContentUnchanged: the repository was fetched but produced the stored content hash and metadata
+	skipContentUnchanged skipReason = "content hash and metadata identical"
+	// skipAppendOnly: the repository is already stored and --append-only forbids updates
+	skipAppendOnly skipReason = "already stored, --append-only"
+	// skipCheckpoint: an interrupted sync already processed the repository and --resume was given
+	skipCheckpoint skipReason = "completed before interruption, --resume"
+	// skipBeforeSince: the repository was starred before the --since date
+	skipBeforeSince skipReason = "starred before --since"
+)
+
+// AddSkipped safely records n repositories skipped for reason
+func (s *SyncStats) AddSkipped(reason skipReason, n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.SkipReasons == nil {
+		s.SkipReasons = make(map[skipReason]int)
+	}
+
+	s.SkipReasons[reason] += n
+	s.SkippedRepos += n
+}
+
+// logSkip prints a repository skip in the same format at every stage of the sync
+func (s *SyncService) logSkip(fullName string, reason skipReason, showDetails bool) {
+	if showDetails {
+		fmt.Printf("  SKIP: %s (%s)\n", fullName, reason)
+		return
+	}
+
+	s.log().Debug("Skipped repository", slog.String("repo", fullName), slog.String("reason", string(reason)))
+}
+
+// printSkipReasons breaks the skipped count in the sync summary down by reason,
+// most frequent first
+func printSkipReasons(stats *SyncStats) {
+	reasons := make([]skipReason, 0, len(stats.SkipReasons))
+	for reason := range stats.SkipReasons {
+		reasons = append(reasons, reason)
+	}
+
+	sort.Slice(reasons, func(i, j int) bool {
+		if stats.SkipReasons[reasons[i]] != stats.SkipReasons[reasons[j]] {
+			return stats.SkipReasons[reasons[i]] > stats.SkipReasons[reasons[j]]
+		}
+
+		return reasons[i] < reasons[j]
+	})
+
+	for _, reason := range reasons {
+		fmt.Printf("  %s: %d\n", reason, stats.SkipReasons[reason])
+	}
+}
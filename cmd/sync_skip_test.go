@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestSyncStats_AddSkipped(t *testing.T) {
+	stats := &SyncStats{}
+
+	stats.AddSkipped(skipUpToDate, 3)
+	stats.AddSkipped(skipContentUnchanged, 1)
+	stats.AddSkipped(skipContentUnchanged, 1)
+	stats.AddSkipped(skipAppendOnly, 0)
+
+	assert.Equal(t, 5, stats.SkippedRepos)
+	assert.Equal(t, map[skipReason]int{skipUpToDate: 3, skipContentUnchanged: 2}, stats.SkipReasons)
+}
+
+func TestDetermineSyncOperations_RecordsUpToDate(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	starredRepos := []github.Repository{
+		{FullName: "user/fresh", StargazersCount: 10, UpdatedAt: baseTime.Add(-time.Hour)},
+		{FullName: "user/pushed", StargazersCount: 10, UpdatedAt: baseTime.Add(time.Hour)},
+	}
+	existingRepos := map[string]*storage.StoredRepo{
+		"user/fresh":  {FullName: "user/fresh", StargazersCount: 10, LastSynced: baseTime},
+		"user/pushed": {FullName: "user/pushed", StargazersCount: 10, LastSynced: baseTime},
+	}
+
+	operations := (&SyncService{}).determineSyncOperations(starredRepos, existingRepos, false)
+
+	assert.Equal(t, []string{"user/fresh"}, operations.upToDate)
+	assert.Len(t, operations.toUpdate, 1)
+}
+
+func TestPrintSkipReasons(t *testing.T) {
+	stats := &SyncStats{}
+	stats.AddSkipped(skipAppendOnly, 1)
+	stats.AddSkipped(skipUpToDate, 4)
+	stats.AddSkipped(skipContentUnchanged, 1)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	printSkipReasons(stats)
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+
+	_, _ = buf.ReadFrom(r)
+
+	assert.Equal(t,
+		"  timestamp not advanced, metadata identical: 4\n"+
+			"  already stored, --append-only: 1\n"+
+			"  content hash and metadata identical: 1\n",
+		buf.String())
+}
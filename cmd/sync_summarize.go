@@ -7,15 +7,27 @@ import (
 
 	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/python"
+	"github.com/KyleKing/gh-star-search/internal/storage"
 	"github.com/KyleKing/gh-star-search/internal/summarizer"
 )
 
 // generateSummaries generates AI summaries for repositories that need them
 func (s *SyncService) generateSummaries(ctx context.Context, force bool) error {
-	s.logVerbose("\nGenerating repository summaries...")
+	s.log().Debug("Generating repository summaries")
 
-	// Get repositories that need summary updates
-	repos, err := s.storage.GetRepositoriesNeedingSummaryUpdate(ctx, force)
+	return generateSummaries(ctx, s.storage, s.config, force, s.verbose)
+}
+
+// generateSummaries summarizes the repositories that need it and prints a
+// summary. It is shared by 'sync --summarize' and the summarize command.
+func generateSummaries(
+	ctx context.Context,
+	repo storage.Repository,
+	cfg *config.Config,
+	force bool,
+	verbose bool,
+) error {
+	repos, err := repo.GetRepositoriesNeedingSummaryUpdate(ctx, force)
 	if err != nil {
 		return fmt.Errorf("failed to get repositories needing summary: %w", err)
 	}
@@ -27,87 +39,130 @@ func (s *SyncService) generateSummaries(ctx context.Context, force bool) error {
 
 	fmt.Printf("\nGenerating summaries for %d repositories...\n", len(repos))
 
-	// Prepare Python environment
-	uvPath, err := python.FindUV()
+	sum, err := newSummarizer(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("summarization requires uv: %w", err)
+		return err
 	}
 
-	cacheDir := config.ExpandPath(s.config.Cache.Directory)
-	projectDir, err := python.EnsureEnvironment(ctx, uvPath, cacheDir)
-	if err != nil {
-		return fmt.Errorf("failed to prepare Python environment: %w", err)
+	stats := summarizeRepositories(ctx, repo, sum, cfg.Summarize, repos, verbose)
+
+	// Print summary
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("SUMMARIZATION COMPLETE")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Total repositories: %d\n", len(repos))
+	fmt.Printf("Successfully summarized: %d\n", stats.Successful)
+	fmt.Printf("Failed: %d\n", stats.Failed)
+
+	if stats.Skipped > 0 {
+		fmt.Printf("Skipped (unsupported content language): %d\n", stats.Skipped)
+	}
+
+	if stats.Failed > 0 {
+		fmt.Printf("\n%d repositories failed to summarize\n", stats.Failed)
+	} else {
+		fmt.Println("\nAll repositories summarized successfully!")
 	}
 
-	// Initialize summarizer
-	sum := summarizer.New(uvPath, projectDir)
+	return partialFailure(stats.Failed, "summarize")
+}
 
-	// Track statistics
-	successful := 0
-	failed := 0
+// summarizeStats counts the outcomes of summarizeRepositories
+type summarizeStats struct {
+	Successful int
+	Failed     int
+	Skipped    int // Content language not in summarize.languages
+}
 
-	// Process each repository
-	for i, repoName := range repos {
-		fmt.Printf("  [%d/%d] %s: ", i+1, len(repos), repoName)
+// summarizeRepositories generates and stores a summary for each named
+// repository, printing one line per repository. With verbose set, each new
+// summary is printed too.
+func summarizeRepositories(
+	ctx context.Context,
+	repo storage.Repository,
+	gen summaryGenerator,
+	sumCfg config.SummarizeConfig,
+	fullNames []string,
+	verbose bool,
+) summarizeStats {
+	var stats summarizeStats
+
+	for i, repoName := range fullNames {
+		fmt.Printf("  [%d/%d] %s: ", i+1, len(fullNames), repoName)
 
 		// Get repository details
-		repo, err := s.storage.GetRepository(ctx, repoName)
+		stored, err := repo.GetRepository(ctx, repoName)
 		if err != nil {
 			fmt.Printf("Failed to get repository: %v\n", err)
-			failed++
+			stats.Failed++
 			continue
 		}
 
-		// Build text to summarize from repository metadata
-		text := buildSummaryInput(
-			repo.FullName,
-			repo.Description,
-			repo.Homepage,
-			repo.Topics,
-			repo.Language,
-		)
+		// Summaries are English; skip READMEs in languages the model handles poorly
+		if !sumCfg.SupportsLanguage(stored.ContentLanguage) {
+			fmt.Printf("Skipped (content language %q is not in summarize.languages)\n", stored.ContentLanguage)
+			stats.Skipped++
+			continue
+		}
 
 		// Generate summary
-		result, err := sum.Summarize(ctx, text, summarizer.MethodAuto)
+		result, err := gen.Summarize(ctx, repoSummaryInput(stored), summarizer.MethodAuto)
 		if err != nil {
 			fmt.Printf("Failed to generate summary: %v\n", err)
-			failed++
+			stats.Failed++
 			continue
 		}
 
 		if result.Error != "" {
 			fmt.Printf("Summarization failed: %s\n", result.Error)
-			failed++
+			stats.Failed++
 			continue
 		}
 
 		// Store summary
-		if err := s.storage.UpdateRepositorySummary(ctx, repoName, result.Summary); err != nil {
+		if err := repo.UpdateRepositorySummary(ctx, repoName, result.Summary); err != nil {
 			fmt.Printf("Failed to store summary: %v\n", err)
-			failed++
+			stats.Failed++
 			continue
 		}
 
 		fmt.Printf("Summary generated (%s method)\n", result.Method)
-		s.logVerbose(fmt.Sprintf("    Summary: %s", result.Summary))
-		successful++
+
+		if verbose {
+			fmt.Printf("[VERBOSE]     Summary: %s\n", result.Summary)
+		}
+
+		stats.Successful++
 	}
 
-	// Print summary
-	fmt.Println("\n" + strings.Repeat("=", 60))
-	fmt.Println("SUMMARIZATION COMPLETE")
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Printf("Total repositories: %d\n", len(repos))
-	fmt.Printf("Successfully summarized: %d\n", successful)
-	fmt.Printf("Failed: %d\n", failed)
+	return stats
+}
 
-	if failed > 0 {
-		fmt.Printf("\n%d repositories failed to summarize\n", failed)
-	} else {
-		fmt.Println("\nAll repositories summarized successfully!")
+// newSummarizer prepares the Python environment and returns a summarizer
+func (s *SyncService) newSummarizer(ctx context.Context) (*summarizer.Summarizer, error) {
+	return newSummarizer(ctx, s.config)
+}
+
+// newSummarizer prepares the Python environment and returns a summarizer
+func newSummarizer(ctx context.Context, cfg *config.Config) (*summarizer.Summarizer, error) {
+	uvPath, err := python.FindUV()
+	if err != nil {
+		return nil, fmt.Errorf("summarization requires uv: %w", err)
+	}
+
+	cacheDir := config.ExpandPath(cfg.Cache.Directory)
+
+	projectDir, err := python.EnsureEnvironment(ctx, uvPath, cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare Python environment: %w", err)
 	}
 
-	return nil
+	return summarizer.New(uvPath, projectDir), nil
+}
+
+// repoSummaryInput builds the text to summarize from a stored repository's metadata
+func repoSummaryInput(repo *storage.StoredRepo) string {
+	return buildSummaryInput(repo.FullName, repo.Description, repo.Homepage, repo.Topics, repo.Language)
 }
 
 // buildSummaryInput creates text input for summarization from repository metadata
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/summarizer"
+)
+
+// summaryGenerator produces a summary for repository metadata; satisfied by *summarizer.Summarizer
+type summaryGenerator interface {
+	Summarize(ctx context.Context, text string, method summarizer.Method) (*summarizer.Result, error)
+}
+
+// summaryPreview is the outcome of summarizing a sample of repositories without storing them
+type summaryPreview struct {
+	Pending     int           // Repositories a full --summarize run would summarize
+	Sampled     int           // Repositories summarized for the preview
+	Failed      int           // Sampled repositories that failed to summarize
+	InputTokens int           // Estimated input tokens across all pending repositories
+	SampleTime  time.Duration // Time spent summarizing the sample
+}
+
+// projectedTime extrapolates the sample's average summarization time to every
+// pending repository
+func (p summaryPreview) projectedTime() time.Duration {
+	if p.Sampled == 0 {
+		return 0
+	}
+
+	return p.SampleTime / time.Duration(p.Sampled) * time.Duration(p.Pending)
+}
+
+// estimateInputTokens approximates the tokens in text, using the same four
+// characters per token as content chunking
+func estimateInputTokens(text string) int {
+	return len(text) / 4
+}
+
+// runSummaryPreview summarizes a sample of the repositories needing summaries
+// and prints the results with an estimate for the full run, storing nothing
+func (s *SyncService) runSummaryPreview(ctx context.Context, sampleSize int, force bool) error {
+	sum, err := s.newSummarizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	preview, err := s.previewSummaries(ctx, sum, sampleSize, force, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	printSummaryPreview(os.Stdout, preview)
+
+	return nil
+}
+
+// previewSummaries summarizes up to sampleSize repositories, spread evenly over
+// those needing summaries, and writes each summary to w without storing it
+func (s *SyncService) previewSummaries(
+	ctx context.Context,
+	gen summaryGenerator,
+	sampleSize int,
+	force bool,
+	w io.Writer,
+) (*summaryPreview, error) {
+	names, err := s.storage.GetRepositoriesNeedingSummaryUpdate(ctx, force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories needing summary: %w", err)
+	}
+
+	type pendingSummary struct {
+		name, text string
+	}
+
+	preview := &summaryPreview{}
+	pending := make([]pendingSummary, 0, len(names))
+
+	for _, name := range names {
+		repo, err := s.storage.GetRepository(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository %s: %w", name, err)
+		}
+
+		// Matches generateSummaries, which skips unsupported README languages
+		if !s.config.Summarize.SupportsLanguage(repo.ContentLanguage) {
+			continue
+		}
+
+		text := repoSummaryInput(repo)
+		preview.InputTokens += estimateInputTokens(text)
+		pending = append(pending, pendingSummary{name: name, text: text})
+	}
+
+	preview.Pending = len(pending)
+	sample := min(sampleSize, len(pending))
+
+	for i := range sample {
+		// Spread the sample over the alphabetical list rather than taking its head
+		p := pending[i*len(pending)/sample]
+
+		start := time.Now()
+		result, err := gen.Summarize(ctx, p.text, summarizer.MethodAuto)
+		preview.SampleTime += time.Since(start)
+		preview.Sampled++
+
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "  [%d/%d] %s: Failed to generate summary: %v\n", i+1, sample, p.name, err)
+			preview.Failed++
+		case result.Error != "":
+			fmt.Fprintf(w, "  [%d/%d] %s: Summarization failed: %s\n", i+1, sample, p.name, result.Error)
+			preview.Failed++
+		default:
+			fmt.Fprintf(w, "  [%d/%d] %s (%s method)\n    %s\n", i+1, sample, p.name, result.Method, result.Summary)
+		}
+	}
+
+	return preview, nil
+}
+
+// printSummaryPreview reports the sample outcome and the estimate for a full run
+func printSummaryPreview(w io.Writer, p *summaryPreview) {
+	fmt.Fprintf(w, "\nPreviewed %d of %d repositories needing summaries; nothing was stored\n",
+		p.Sampled, p.Pending)
+
+	if p.Failed > 0 {
+		fmt.Fprintf(w, "Failed in sample: %d\n", p.Failed)
+	}
+
+	fmt.Fprintf(w, "Estimated input for all %d: ~%d tokens\n", p.Pending, p.InputTokens)
+
+	if p.Sampled > 0 {
+		fmt.Fprintf(w, "Estimated time for all %d: ~%s (%s per repository in the sample)\n",
+			p.Pending,
+			p.projectedTime().Round(time.Second),
+			(p.SampleTime / time.Duration(p.Sampled)).Round(time.Millisecond))
+	}
+
+	fmt.Fprintln(w, "Summaries run locally, so there is no API cost. Run 'gh star-search sync --summarize' to store them.")
+}
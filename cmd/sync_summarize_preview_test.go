@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+	"github.com/KyleKing/gh-star-search/internal/summarizer"
+	"github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+// fakeSummaryGenerator returns a canned summary, or fails for the listed repositories
+type fakeSummaryGenerator struct {
+	calls []string
+	fail  map[string]bool
+}
+
+func (f *fakeSummaryGenerator) Summarize(
+	_ context.Context,
+	text string,
+	_ summarizer.Method,
+) (*summarizer.Result, error) {
+	name := strings.TrimPrefix(strings.SplitN(text, ".", 2)[0], "Repository: ")
+	f.calls = append(f.calls, name)
+
+	if f.fail[name] {
+		return nil, fmt.Errorf("model crashed")
+	}
+
+	return &summarizer.Result{Summary: "Summary of " + name, Method: "heuristic"}, nil
+}
+
+func TestPreviewSummaries(t *testing.T) {
+	ctx := context.Background()
+
+	repo, cleanup := storage.NewTestDB(t)
+	defer cleanup()
+
+	for i := range 10 {
+		processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(
+			testutil.WithFullName(fmt.Sprintf("user/r%02d", i))), nil)
+		if i == 9 {
+			processed.ContentLanguage = "zh"
+		}
+
+		require.NoError(t, repo.UpsertRepository(ctx, processed, storage.UpsertOptions{}))
+	}
+
+	syncService := &SyncService{
+		storage: repo,
+		config:  &config.Config{Summarize: config.SummarizeConfig{Languages: []string{"en"}}},
+	}
+
+	tests := []struct {
+		name        string
+		sampleSize  int
+		fail        map[string]bool
+		wantCalls   []string
+		wantFailed  int
+		wantPending int
+	}{
+		{
+			name:        "sample spread over pending repositories",
+			sampleSize:  3,
+			wantCalls:   []string{"user/r00", "user/r03", "user/r06"},
+			wantPending: 9,
+		},
+		{
+			name:        "sample larger than pending",
+			sampleSize:  20,
+			wantCalls:   []string{"user/r00", "user/r01", "user/r02", "user/r03", "user/r04", "user/r05", "user/r06", "user/r07", "user/r08"},
+			wantPending: 9,
+		},
+		{
+			name:        "failures are counted",
+			sampleSize:  2,
+			fail:        map[string]bool{"user/r04": true},
+			wantCalls:   []string{"user/r00", "user/r04"},
+			wantFailed:  1,
+			wantPending: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &fakeSummaryGenerator{fail: tt.fail}
+
+			var out bytes.Buffer
+
+			preview, err := syncService.previewSummaries(ctx, gen, tt.sampleSize, false, &out)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCalls, gen.calls)
+			assert.Equal(t, tt.wantPending, preview.Pending)
+			assert.Equal(t, len(tt.wantCalls), preview.Sampled)
+			assert.Equal(t, tt.wantFailed, preview.Failed)
+			assert.Positive(t, preview.InputTokens)
+			assert.Contains(t, out.String(), "Summary of user/r00")
+
+			// Nothing is stored, so every repository still needs a summary
+			remaining, err := repo.GetRepositoriesNeedingSummaryUpdate(ctx, false)
+			require.NoError(t, err)
+			assert.Len(t, remaining, 10)
+		})
+	}
+}
+
+func TestPrintSummaryPreview(t *testing.T) {
+	var out bytes.Buffer
+
+	printSummaryPreview(&out, &summaryPreview{
+		Pending:     100,
+		Sampled:     4,
+		Failed:      1,
+		InputTokens: 2500,
+		SampleTime:  2 * time.Second,
+	})
+
+	for _, want := range []string{
+		"Previewed 4 of 100",
+		"Failed in sample: 1",
+		"~2500 tokens",
+		"~50s (500ms per repository",
+		"no API cost",
+	} {
+		assert.Contains(t, out.String(), want)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/urfave/cli/v3"
+
 	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/github"
 	"github.com/KyleKing/gh-star-search/internal/processor"
@@ -34,6 +37,23 @@ func (m *MockGitHubClient) GetStarredRepos(
 	return m.starredRepos, nil
 }
 
+func (m *MockGitHubClient) GetRepository(
+	_ context.Context,
+	fullName string,
+) (*github.Repository, error) {
+	if err, exists := m.errors[fullName+"_repository"]; exists {
+		return nil, err
+	}
+
+	for _, repo := range m.starredRepos {
+		if repo.FullName == fullName {
+			return &repo, nil
+		}
+	}
+
+	return nil, errors.New("repository not found: " + fullName)
+}
+
 func (m *MockGitHubClient) GetRepositoryContent(
 	_ context.Context,
 	repo github.Repository,
@@ -283,6 +303,45 @@ func TestSyncService_NeedsUpdate(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "replaced repository with same name",
+			repo: github.Repository{
+				ID:              2,
+				FullName:        "user/repo",
+				UpdatedAt:       baseTime.Add(-1 * time.Hour),
+				StargazersCount: 100,
+				ForksCount:      10,
+				Size:            1000,
+			},
+			existing: &storage.StoredRepo{
+				GitHubID:        1,
+				FullName:        "user/repo",
+				StargazersCount: 100,
+				ForksCount:      10,
+				SizeKB:          1000,
+				LastSynced:      baseTime,
+			},
+			expected: true,
+		},
+		{
+			name: "missing stored id is not a change",
+			repo: github.Repository{
+				ID:              2,
+				FullName:        "user/repo",
+				UpdatedAt:       baseTime.Add(-1 * time.Hour),
+				StargazersCount: 100,
+				ForksCount:      10,
+				Size:            1000,
+			},
+			existing: &storage.StoredRepo{
+				FullName:        "user/repo",
+				StargazersCount: 100,
+				ForksCount:      10,
+				SizeKB:          1000,
+				LastSynced:      baseTime,
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -651,6 +710,100 @@ func TestSyncService_LicenseChanged(t *testing.T) {
 	}
 }
 
+func TestSyncService_ConvertMetrics(t *testing.T) {
+	syncService := &SyncService{}
+	recentWeek := time.Now().AddDate(0, 0, -7).Unix()
+
+	tests := []struct {
+		name           string
+		metrics        *github.RepositoryMetrics
+		wantIssuesOpen int
+		wantPRsTotal   int
+		wantCommitsTot int
+		wantCommits30d int
+	}{
+		{
+			name: "all metrics fetched",
+			metrics: &github.RepositoryMetrics{
+				OpenIssues: 2, TotalIssues: 5, OpenPRs: 0, TotalPRs: 3,
+				CommitActivity: &github.CommitActivity{
+					Weeks: []github.WeeklyCommits{{Week: recentWeek, Commits: 4}},
+					Total: 4,
+				},
+			},
+			wantIssuesOpen: 2,
+			wantPRsTotal:   3,
+			wantCommitsTot: 4,
+			wantCommits30d: 4,
+		},
+		{
+			name: "failed fetches become unknown",
+			metrics: &github.RepositoryMetrics{
+				Failed: map[string]error{
+					"issues":  errors.New("boom"),
+					"prs":     errors.New("boom"),
+					"commits": errors.New("boom"),
+				},
+			},
+			wantIssuesOpen: storage.UnknownCount,
+			wantPRsTotal:   storage.UnknownCount,
+			wantCommitsTot: storage.UnknownCount,
+			wantCommits30d: storage.UnknownCount,
+		},
+		{
+			name: "commit stats still computing",
+			metrics: &github.RepositoryMetrics{
+				CommitActivity: &github.CommitActivity{Weeks: []github.WeeklyCommits{}, Total: -1},
+			},
+			wantIssuesOpen: 0,
+			wantPRsTotal:   0,
+			wantCommitsTot: storage.UnknownCount,
+			wantCommits30d: storage.UnknownCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := syncService.convertMetrics(tt.metrics, "")
+			if sm.OpenIssuesOpen != tt.wantIssuesOpen {
+				t.Errorf("OpenIssuesOpen = %d, want %d", sm.OpenIssuesOpen, tt.wantIssuesOpen)
+			}
+
+			if sm.OpenPRsTotal != tt.wantPRsTotal {
+				t.Errorf("OpenPRsTotal = %d, want %d", sm.OpenPRsTotal, tt.wantPRsTotal)
+			}
+
+			if sm.CommitsTotal != tt.wantCommitsTot {
+				t.Errorf("CommitsTotal = %d, want %d", sm.CommitsTotal, tt.wantCommitsTot)
+			}
+
+			if sm.Commits30d != tt.wantCommits30d {
+				t.Errorf("Commits30d = %d, want %d", sm.Commits30d, tt.wantCommits30d)
+			}
+		})
+	}
+}
+
+func TestSyncService_ConvertMetrics_CommitWindows(t *testing.T) {
+	weekAgo := func(days int) int64 { return time.Now().AddDate(0, 0, -days).Unix() }
+
+	sm := (&SyncService{}).convertMetrics(&github.RepositoryMetrics{
+		CommitActivity: &github.CommitActivity{
+			Weeks: []github.WeeklyCommits{
+				{Week: weekAgo(400), Commits: 100},
+				{Week: weekAgo(200), Commits: 20},
+				{Week: weekAgo(7), Commits: 3},
+			},
+			Total: 123,
+		},
+	}, "")
+
+	if sm.Commits30d != 3 || sm.Commits1y != 23 || sm.CommitsTotal != 123 {
+		t.Errorf("commits = %d in 30 days, %d in a year, %d total; want 3, 23, 123",
+			sm.Commits30d, sm.Commits1y, sm.CommitsTotal)
+	}
+}
+
 func TestProgressTracker(t *testing.T) {
 	// Test progress tracker functionality
 	tracker := NewProgressTracker(5, "Testing progress")
@@ -679,6 +832,144 @@ func TestProgressTracker(t *testing.T) {
 	}
 }
 
+func TestProgressTracker_Plain(t *testing.T) {
+	var out strings.Builder
+
+	tracker := newProgressTrackerWithETA(2, "Processing batch 1/1", newETAEstimator(2), true)
+	tracker.out = &out
+
+	if tracker.spinner != nil {
+		t.Fatal("Expected no spinner for plain progress")
+	}
+
+	tracker.Start()
+	tracker.Update("owner/one")
+	tracker.Update("owner/two")
+	tracker.Finish("Completed batch 1/1")
+
+	want := "Processing batch 1/1 (0/2)\n" +
+		"  Processing owner/one (1/2)\n" +
+		"  Processing owner/two (2/2)\n" +
+		"✓ Completed batch 1/1 (2/2)\n"
+	if out.String() != want {
+		t.Errorf("Expected plain progress lines %q, got %q", want, out.String())
+	}
+}
+
+func TestSyncService_NoProgress(t *testing.T) {
+	service := &SyncService{noProgress: true}
+
+	if !service.plainProgress() {
+		t.Error("Expected --no-progress to print plain progress lines")
+	}
+
+	if tracker := service.newProgress(3, "Removing repositories"); tracker.spinner != nil {
+		t.Error("Expected no spinner with --no-progress")
+	}
+}
+
+// rateLimitedGitHubClient adds rate limit reporting to MockGitHubClient
+type rateLimitedGitHubClient struct {
+	*MockGitHubClient
+	rateLimit *github.RateLimit
+	err       error
+}
+
+func (m *rateLimitedGitHubClient) GetRateLimit(_ context.Context) (*github.RateLimit, error) {
+	return m.rateLimit, m.err
+}
+
+func TestSyncService_BatchDelay(t *testing.T) {
+	adaptive := &config.Config{Sync: config.SyncConfig{AdaptiveBatchDelay: true, RateLimitThreshold: 1000}}
+	fixed := &config.Config{Sync: config.SyncConfig{AdaptiveBatchDelay: false, RateLimitThreshold: 1000}}
+
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		explicit bool
+		client   github.Client
+		expected time.Duration
+	}{
+		{
+			name:     "healthy quota skips delay",
+			cfg:      adaptive,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 4000}},
+			expected: 0,
+		},
+		{
+			name:     "quota below threshold uses default delay",
+			cfg:      adaptive,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 500}},
+			expected: BatchDelaySeconds * time.Second,
+		},
+		{
+			name:     "nearly exhausted quota uses longer delay",
+			cfg:      adaptive,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 100}},
+			expected: LowRateLimitBatchDelaySeconds * time.Second,
+		},
+		{
+			name:     "rate limit error uses default delay",
+			cfg:      adaptive,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, err: context.DeadlineExceeded},
+			expected: BatchDelaySeconds * time.Second,
+		},
+		{
+			name:     "client without rate limits uses default delay",
+			cfg:      adaptive,
+			client:   &MockGitHubClient{},
+			expected: BatchDelaySeconds * time.Second,
+		},
+		{
+			name:     "adaptive delay disabled",
+			cfg:      fixed,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 4000}},
+			expected: BatchDelaySeconds * time.Second,
+		},
+		{
+			name: "configured delay replaces the default",
+			cfg:  &config.Config{Sync: config.SyncConfig{BatchDelay: "500ms"}},
+			client: &rateLimitedGitHubClient{
+				MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 4000},
+			},
+			expected: 500 * time.Millisecond,
+		},
+		{
+			name: "nearly exhausted quota keeps a longer configured delay",
+			cfg:  &config.Config{Sync: config.SyncConfig{AdaptiveBatchDelay: true, RateLimitThreshold: 1000, BatchDelay: "30s"}},
+			client: &rateLimitedGitHubClient{
+				MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 100},
+			},
+			expected: 30 * time.Second,
+		},
+		{
+			name: "healthy quota keeps a configured delay",
+			cfg:  &config.Config{Sync: config.SyncConfig{AdaptiveBatchDelay: true, RateLimitThreshold: 1000, BatchDelay: "10s"}},
+			client: &rateLimitedGitHubClient{
+				MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 4000},
+			},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "healthy quota keeps --batch-delay at the default value",
+			cfg:      adaptive,
+			explicit: true,
+			client:   &rateLimitedGitHubClient{MockGitHubClient: &MockGitHubClient{}, rateLimit: &github.RateLimit{Remaining: 4000}},
+			expected: BatchDelaySeconds * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncService := &SyncService{githubClient: tt.client, config: tt.cfg, batchDelaySet: tt.explicit}
+
+			if got := syncService.batchDelay(context.Background()); got != tt.expected {
+				t.Errorf("Expected delay %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestSyncStats_SafeIncrement(t *testing.T) {
 	stats := &SyncStats{}
 
@@ -712,3 +1003,135 @@ func TestSyncStats_SafeIncrement(t *testing.T) {
 		t.Errorf("Expected ProcessedRepos 10, got %d", stats.ProcessedRepos)
 	}
 }
+
+func TestSyncService_PruneChunks(t *testing.T) {
+	repo, err := storage.NewDuckDBRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if err := repo.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mockGitHub := &MockGitHubClient{
+		content: map[string][]github.Content{
+			"user/chunky": {
+				{Path: "README.md", Type: "file", Content: "# Chunky\n\nA readme.", Size: 20},
+				{Path: "docs/guide.md", Type: "file", Content: "# Guide\n\nSome docs.", Size: 20},
+				{Path: "main.go", Type: "file", Content: "package main", Size: 12},
+			},
+		},
+	}
+
+	syncService := &SyncService{
+		githubClient: mockGitHub,
+		processor:    processor.NewService(mockGitHub),
+		storage:      repo,
+		maxChunks:    1,
+	}
+
+	result, err := syncService.processRepositoryWithChangeTracking(
+		ctx,
+		github.Repository{FullName: "user/chunky", UpdatedAt: time.Now()},
+		nil,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("Failed to process repository: %v", err)
+	}
+
+	if result.TrimmedChunks != 2 {
+		t.Errorf("Expected 2 trimmed chunks, got %d", result.TrimmedChunks)
+	}
+
+	stats := &SyncStats{}
+	stats.AddTrimmedChunks(result.TrimmedChunks)
+	stats.AddTrimmedChunks(3)
+
+	if stats.TrimmedChunks != 5 {
+		t.Errorf("Expected TrimmedChunks 5, got %d", stats.TrimmedChunks)
+	}
+}
+
+func TestCalculateOptimalWorkers_Configured(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxWorkers int
+		batchSize  int
+		expected   int
+	}{
+		{name: "configured count is used for small batches", maxWorkers: 4, batchSize: 5, expected: 4},
+		{name: "never more workers than repositories", maxWorkers: 16, batchSize: 3, expected: 3},
+		{name: "clamped to the limit", maxWorkers: 100, batchSize: 100, expected: MaxWorkerLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncService := &SyncService{config: &config.Config{Sync: config.SyncConfig{MaxWorkers: tt.maxWorkers}}}
+
+			if got := syncService.calculateOptimalWorkers(tt.batchSize); got != tt.expected {
+				t.Errorf("calculateOptimalWorkers(%d) = %d, want %d", tt.batchSize, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplySyncPacing(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantWorkers int
+		wantDelay   string
+		errSubstr   string
+	}{
+		{name: "flags unset keep the config", args: nil, wantWorkers: 2, wantDelay: "2s"},
+		{name: "workers and delay override", args: []string{"--workers", "6", "--batch-delay", "0"}, wantWorkers: 6, wantDelay: "0s"},
+		{name: "workers clamped", args: []string{"--workers", "1000"}, wantWorkers: MaxWorkerLimit, wantDelay: "2s"},
+		{name: "zero workers", args: []string{"--workers", "0"}, errSubstr: "at least 1"},
+		{name: "negative delay", args: []string{"--batch-delay", "-1s"}, errSubstr: "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Sync: config.SyncConfig{MaxWorkers: 2, BatchDelay: "2s"}}
+
+			var applyErr error
+
+			app := &cli.Command{
+				Name: "app",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "workers"},
+					&cli.DurationFlag{Name: "batch-delay"},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					applyErr = applySyncPacing(cfg, cmd)
+					return nil
+				},
+			}
+
+			if err := app.Run(context.Background(), append([]string{"app"}, tt.args...)); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if tt.errSubstr != "" {
+				if applyErr == nil || !strings.Contains(applyErr.Error(), tt.errSubstr) {
+					t.Fatalf("applySyncPacing() error = %v, want substring %q", applyErr, tt.errSubstr)
+				}
+
+				return
+			}
+
+			if applyErr != nil {
+				t.Fatalf("applySyncPacing() error = %v", applyErr)
+			}
+
+			if cfg.Sync.MaxWorkers != tt.wantWorkers || cfg.Sync.BatchDelay != tt.wantDelay {
+				t.Errorf("got workers %d, delay %s; want %d, %s",
+					cfg.Sync.MaxWorkers, cfg.Sync.BatchDelay, tt.wantWorkers, tt.wantDelay)
+			}
+		})
+	}
+}
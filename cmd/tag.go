@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TagCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tag",
+		Usage: "Manage local tags on starred repositories",
+		Description: `Annotate repositories with local-only tags. Tags are never sent to GitHub
+and are preserved when repositories are re-synced.
+
+Examples:
+  gh star-search tag add cli/cli "evaluate for work"
+  gh star-search tag remove cli/cli "evaluate for work"
+  gh star-search tag list cli/cli
+  gh star-search query --tag "evaluate for work" "terminal"`,
+		Commands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Add a tag to a repository",
+				ArgsUsage: "<repository> <tag>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return withTagArgs(ctx, cmd, RunTagAddWithStorage)
+				},
+			},
+			{
+				Name:      "remove",
+				Usage:     "Remove a tag from a repository",
+				ArgsUsage: "<repository> <tag>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return withTagArgs(ctx, cmd, RunTagRemoveWithStorage)
+				},
+			},
+			{
+				Name:      "list",
+				Usage:     "List the tags on a repository",
+				ArgsUsage: "<repository>",
+				Action:    runTagList,
+			},
+		},
+	}
+}
+
+// withTagArgs parses <repository> <tag> arguments and runs fn against opened storage
+func withTagArgs(
+	ctx context.Context,
+	cmd *cli.Command,
+	fn func(context.Context, string, string, storage.Repository) error,
+) error {
+	args := cmd.Args().Slice()
+	if len(args) != 2 {
+		return errors.New(errors.ErrTypeValidation, "expected a repository and a tag")
+	}
+
+	repo, err := openStorage(ctx, getConfigFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	return fn(ctx, strings.TrimSpace(args[0]), strings.TrimSpace(args[1]), repo)
+}
+
+// RunTagAddWithStorage attaches a tag to a stored repository
+func RunTagAddWithStorage(
+	ctx context.Context,
+	repoName, tag string,
+	repo storage.Repository,
+) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	if err := repo.AddTag(ctx, repoName, tag); err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, "failed to add tag").
+			WithSuggestion("Run 'gh star-search list' to see stored repositories")
+	}
+
+	fmt.Printf("Tagged %s with %q\n", repoName, tag)
+
+	return nil
+}
+
+// RunTagRemoveWithStorage detaches a tag from a repository
+func RunTagRemoveWithStorage(
+	ctx context.Context,
+	repoName, tag string,
+	repo storage.Repository,
+) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	if err := repo.RemoveTag(ctx, repoName, tag); err != nil {
+		return errors.Wrap(err, errors.ErrTypeNotFound, "failed to remove tag").
+			WithSuggestion(fmt.Sprintf("Run 'gh star-search tag list %s' to see its tags", repoName))
+	}
+
+	fmt.Printf("Removed tag %q from %s\n", tag, repoName)
+
+	return nil
+}
+
+func runTagList(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New(errors.ErrTypeValidation, "expected exactly one repository")
+	}
+
+	repo, err := openStorage(ctx, getConfigFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	return RunTagListWithStorage(ctx, strings.TrimSpace(cmd.Args().First()), repo)
+}
+
+// RunTagListWithStorage prints the tags on a repository
+func RunTagListWithStorage(ctx context.Context, repoName string, repo storage.Repository) error {
+	tags, err := repo.GetTags(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrTypeDatabase, "failed to list tags")
+	}
+
+	if len(tags) == 0 {
+		fmt.Printf("No tags on %s.\n", repoName)
+		return nil
+	}
+
+	for _, tag := range tags {
+		fmt.Println(tag)
+	}
+
+	return nil
+}
+
+// validateTag ensures a tag is non-empty
+func validateTag(tag string) error {
+	if tag == "" {
+		return errors.New(errors.ErrTypeValidation, "tag cannot be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestRunTagAddWithStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoName string
+		tag      string
+		wantErr  bool
+		wantTags []string
+	}{
+		{
+			name:     "add tag",
+			repoName: "user/repo",
+			tag:      "evaluate for work",
+			wantTags: []string{"evaluate for work"},
+		},
+		{
+			name:     "empty tag",
+			repoName: "user/repo",
+			tag:      "",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown repository",
+			repoName: "user/missing",
+			tag:      "cli",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				repos: []storage.StoredRepo{{FullName: "user/repo"}},
+			}
+
+			err := RunTagAddWithStorage(context.Background(), tt.repoName, tt.tag, mockRepo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunTagAddWithStorage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			tags, _ := mockRepo.GetTags(context.Background(), tt.repoName)
+			if len(tags) != len(tt.wantTags) {
+				t.Fatalf("tags = %v, want %v", tags, tt.wantTags)
+			}
+
+			for i := range tags {
+				if tags[i] != tt.wantTags[i] {
+					t.Errorf("tags[%d] = %q, want %q", i, tags[i], tt.wantTags[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRunTagRemoveWithStorage(t *testing.T) {
+	mockRepo := &MockRepository{
+		repos: []storage.StoredRepo{{FullName: "user/repo"}},
+		tags:  map[string][]string{"user/repo": {"cli", "replaced by x"}},
+	}
+
+	if err := RunTagRemoveWithStorage(context.Background(), "user/repo", "cli", mockRepo); err != nil {
+		t.Fatalf("RunTagRemoveWithStorage() error = %v", err)
+	}
+
+	if err := RunTagRemoveWithStorage(context.Background(), "user/repo", "cli", mockRepo); err == nil {
+		t.Error("expected error removing a tag that is not present")
+	}
+
+	tags, _ := mockRepo.GetTags(context.Background(), "user/repo")
+	if len(tags) != 1 || tags[0] != "replaced by x" {
+		t.Errorf("tags = %v, want [replaced by x]", tags)
+	}
+}
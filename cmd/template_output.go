@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/errors"
+	"github.com/KyleKing/gh-star-search/internal/formatter"
+)
+
+// loadOutputTemplate loads a report template by file path, or by name from the templates directory
+func loadOutputTemplate(nameOrPath string) (*template.Template, error) {
+	templatesDir := config.GetTemplatesDir()
+
+	tmpl, err := formatter.LoadTemplate(nameOrPath, templatesDir)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeValidation, "failed to load output template").
+			WithSuggestion(fmt.Sprintf("Pass a file path, or save the template as %s/<name>%s",
+				templatesDir, formatter.TemplateExt))
+	}
+
+	return tmpl, nil
+}
+
+// renderOutputTemplate writes the rendered template to stdout
+func renderOutputTemplate(tmpl *template.Template, data formatter.TemplateData) error {
+	if data.GeneratedAt.IsZero() {
+		data.GeneratedAt = time.Now()
+	}
+
+	if err := tmpl.Execute(os.Stdout, data); err != nil {
+		return errors.Wrap(err, errors.ErrTypeValidation, "failed to render output template")
+	}
+
+	return nil
+}
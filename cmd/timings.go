@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// TimingsFlag enables the per-phase timing report. It belongs on the root
+// command so every subcommand accepts it.
+func TimingsFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "timings",
+		Usage: "Print time spent in GitHub API calls, content processing, database writes and LLM calls",
+	}
+}
+
+// WithTimings wraps the action of each command (and its subcommands) so that
+// --timings records phase durations and prints them to stderr on return
+func WithTimings(commands []*cli.Command) []*cli.Command {
+	for _, command := range commands {
+		if command.Action != nil {
+			command.Action = timedAction(command.Action)
+		}
+
+		WithTimings(command.Commands)
+	}
+
+	return commands
+}
+
+func timedAction(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		if !cmd.Bool("timings") {
+			return action(ctx, cmd)
+		}
+
+		recorder := timing.NewRecorder()
+		err := action(timing.WithRecorder(ctx, recorder), cmd)
+		recorder.Write(os.Stderr)
+
+		return err
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+func TestWithTimings(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantReport bool
+	}{
+		{name: "flag after subcommand", args: []string{"app", "work", "--timings"}, wantReport: true},
+		{name: "flag before subcommand", args: []string{"app", "--timings", "work"}, wantReport: true},
+		{name: "nested subcommand", args: []string{"app", "group", "inner", "--timings"}, wantReport: true},
+		{name: "disabled", args: []string{"app", "work"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sawRecorder bool
+
+			action := func(ctx context.Context, _ *cli.Command) error {
+				recorder := timing.FromContext(ctx)
+				sawRecorder = recorder != nil
+				recorder.Add(timing.PhaseGitHub, time.Second)
+
+				return nil
+			}
+
+			app := &cli.Command{
+				Name:  "app",
+				Flags: []cli.Flag{TimingsFlag()},
+				Commands: WithTimings([]*cli.Command{
+					{Name: "work", Action: action},
+					{Name: "group", Commands: []*cli.Command{{Name: "inner", Action: action}}},
+				}),
+			}
+
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			err := app.Run(context.Background(), tt.args)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+
+			_, _ = buf.ReadFrom(r)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReport, sawRecorder)
+
+			if tt.wantReport {
+				assert.Contains(t, buf.String(), "GitHub API")
+				assert.Contains(t, buf.String(), "(1 calls)")
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
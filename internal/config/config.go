@@ -1,30 +1,45 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"reflect"
 	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
+
+	"github.com/KyleKing/gh-star-search/internal/scoring"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Database DatabaseConfig `json:"database" envPrefix:"GH_STAR_SEARCH_"`
-	Cache    CacheConfig    `json:"cache"    envPrefix:"GH_STAR_SEARCH_"`
-	Logging  LoggingConfig  `json:"logging"  envPrefix:"GH_STAR_SEARCH_"`
-	Debug    DebugConfig    `json:"debug"    envPrefix:"GH_STAR_SEARCH_"`
-	Test     TestConfig     `json:"test"     envPrefix:"GH_STAR_SEARCH_"`
+	Database  DatabaseConfig  `json:"database"  envPrefix:"GH_STAR_SEARCH_"`
+	Cache     CacheConfig     `json:"cache"     envPrefix:"GH_STAR_SEARCH_"`
+	Logging   LoggingConfig   `json:"logging"   envPrefix:"GH_STAR_SEARCH_"`
+	Debug     DebugConfig     `json:"debug"     envPrefix:"GH_STAR_SEARCH_"`
+	Sync      SyncConfig      `json:"sync"      envPrefix:"GH_STAR_SEARCH_"`
+	Processor ProcessorConfig `json:"processor" envPrefix:"GH_STAR_SEARCH_"`
+	Formatter FormatterConfig `json:"formatter" envPrefix:"GH_STAR_SEARCH_"`
+	Summarize SummarizeConfig `json:"summarize" envPrefix:"GH_STAR_SEARCH_"`
+	Search    SearchConfig    `json:"search"    envPrefix:"GH_STAR_SEARCH_"`
+	Test      TestConfig      `json:"test"      envPrefix:"GH_STAR_SEARCH_"`
 }
 
 // DatabaseConfig represents database configuration
 type DatabaseConfig struct {
-	Path         string `json:"path"          env:"DB_PATH"          envDefault:"~/.config/gh-star-search/database.db"`
-	QueryTimeout string `json:"query_timeout" env:"DB_QUERY_TIMEOUT" envDefault:"30s"`
+	Path             string `json:"path"               env:"DB_PATH"               envDefault:"~/.config/gh-star-search/database.db"`
+	MaxConnections   int    `json:"max_connections"    env:"DB_MAX_CONNECTIONS"    envDefault:"10"`
+	MaxIdleConns     int    `json:"max_idle_conns"     env:"DB_MAX_IDLE_CONNS"     envDefault:"5"`
+	ConnMaxLifetime  string `json:"conn_max_lifetime"  env:"DB_CONN_MAX_LIFETIME"  envDefault:"30m"`
+	ConnMaxIdleTime  string `json:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" envDefault:"5m"`
+	SingleConnection bool   `json:"single_connection"  env:"DB_SINGLE_CONNECTION"  envDefault:"false"`
+	QueryTimeout     string `json:"query_timeout"      env:"DB_QUERY_TIMEOUT"      envDefault:"30s"`
+	OpenAttempts     int    `json:"open_attempts"      env:"DB_OPEN_ATTEMPTS"      envDefault:"3"`
+	OpenBackoff      string `json:"open_backoff"       env:"DB_OPEN_BACKOFF"       envDefault:"500ms"`
+	LockWait         string `json:"lock_wait"          env:"DB_LOCK_WAIT"          envDefault:"0s"`
 }
 
 // CacheConfig represents caching configuration
@@ -52,6 +67,96 @@ type DebugConfig struct {
 	TraceAPI bool `json:"trace_api" env:"DEBUG_TRACE_API" envDefault:"false"`
 }
 
+// SyncConfig represents sync pacing configuration
+type SyncConfig struct {
+	AdaptiveBatchDelay bool `json:"adaptive_batch_delay" env:"SYNC_ADAPTIVE_BATCH_DELAY" envDefault:"true"`
+	RateLimitThreshold int  `json:"rate_limit_threshold" env:"SYNC_RATE_LIMIT_THRESHOLD" envDefault:"1000"`
+	// RetryAttempts retries GitHub requests that fail with a server error or a
+	// rate limit, backing off or waiting as GitHub asks; 0 disables retries
+	RetryAttempts int `json:"retry_attempts" env:"SYNC_RETRY_ATTEMPTS" envDefault:"3"`
+	// SearchRequestsPerMinute paces the search API requests behind issue and
+	// pull request counts, which GitHub limits to 30 a minute apart from the
+	// core quota; 0 disables the pacing
+	SearchRequestsPerMinute int `json:"search_requests_per_minute" env:"SYNC_SEARCH_REQUESTS_PER_MINUTE" envDefault:"30"`
+	// GraphQL fetches the starred list with languages and issue/PR counts in one
+	// query per 100 repositories, falling back to REST on errors
+	GraphQL bool `json:"graphql" env:"SYNC_GRAPHQL" envDefault:"true"`
+	// MaxRequests and MaxBytes cap the network use of one sync; 0 is unlimited
+	MaxRequests int `json:"max_requests" env:"SYNC_MAX_REQUESTS" envDefault:"0"`
+	MaxBytes    int `json:"max_bytes"    env:"SYNC_MAX_BYTES"    envDefault:"0"`
+	// ConfirmLargeSync asks for confirmation before processing more repositories
+	// than this in an interactive sync; 0 never asks
+	ConfirmLargeSync int `json:"confirm_large_sync" env:"SYNC_CONFIRM_LARGE_SYNC" envDefault:"500"`
+	// DocsDedupThreshold drops a docs index (docs/index.md, docs/README.md, ...)
+	// whose word-shingle similarity to the README is at least this; 0 keeps them all
+	DocsDedupThreshold float64 `json:"docs_dedup_threshold" env:"SYNC_DOCS_DEDUP_THRESHOLD" envDefault:"0.8"`
+	// HomepageText adds the text of each repository's homepage (when it is not
+	// on github.com) to the content that is chunked, summarized and searched
+	HomepageText bool `json:"homepage_text" env:"SYNC_HOMEPAGE_TEXT" envDefault:"false"`
+	// MaxWorkers fixes the number of repositories processed concurrently in a
+	// batch; 0 picks it from the batch size and CPU count
+	MaxWorkers int `json:"max_workers" env:"SYNC_MAX_WORKERS" envDefault:"0"`
+	// BatchDelay and RepoDelay pause between batches and between repositories
+	// on each worker; with adaptive_batch_delay the batch pause is skipped while
+	// the rate limit is healthy
+	BatchDelay string `json:"batch_delay" env:"SYNC_BATCH_DELAY" envDefault:"2s"`
+	RepoDelay  string `json:"repo_delay"  env:"SYNC_REPO_DELAY"  envDefault:"100ms"`
+}
+
+// ProcessorConfig represents the content limits applied while processing each
+// repository. Tokens are estimated at four characters each.
+type ProcessorConfig struct {
+	// MaxFileKB skips fetched files larger than this
+	MaxFileKB int `json:"max_file_kb" env:"PROCESSOR_MAX_FILE_KB" envDefault:"512"`
+	// MaxTotalTokens caps the content kept per repository, dropping the
+	// lowest-priority files first
+	MaxTotalTokens int `json:"max_total_tokens" env:"PROCESSOR_MAX_TOTAL_TOKENS" envDefault:"50000"`
+	// MaxTokensPerChunk splits larger files into chunks of at most this size
+	MaxTokensPerChunk int `json:"max_tokens_per_chunk" env:"PROCESSOR_MAX_TOKENS_PER_CHUNK" envDefault:"2000"`
+}
+
+// FormatterConfig represents result truncation settings for search output
+type FormatterConfig struct {
+	MatchContextWidth    int `json:"match_context_width"    env:"FORMATTER_MATCH_CONTEXT_WIDTH"    envDefault:"30"`
+	MaxContributors      int `json:"max_contributors"       env:"FORMATTER_MAX_CONTRIBUTORS"       envDefault:"10"`
+	MaxDescriptionLength int `json:"max_description_length" env:"FORMATTER_MAX_DESCRIPTION_LENGTH" envDefault:"80"`
+}
+
+// SummarizeConfig represents AI summarization settings
+type SummarizeConfig struct {
+	// Languages lists the README content languages (ISO 639-1) worth summarizing;
+	// empty summarizes every repository
+	Languages []string `json:"languages" env:"SUMMARIZE_LANGUAGES" envDefault:"en"`
+}
+
+// SupportsLanguage reports whether a repository with the given detected content
+// language should be summarized. Undetected ("") languages are always summarized.
+func (c SummarizeConfig) SupportsLanguage(language string) bool {
+	if language == "" || len(c.Languages) == 0 {
+		return true
+	}
+
+	for _, supported := range c.Languages {
+		if strings.EqualFold(strings.TrimSpace(supported), language) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SearchConfig represents search mode and ranking settings
+type SearchConfig struct {
+	// DefaultMode is the query mode used when --mode is not given: fuzzy or vector
+	DefaultMode string `json:"default_mode" env:"SEARCH_DEFAULT_MODE" envDefault:"fuzzy"`
+	// ScoreExpression replaces the built-in star and recency boosts with an
+	// arithmetic expression over scoring.Variables; empty keeps the default
+	ScoreExpression string `json:"score_expression" env:"SEARCH_SCORE_EXPRESSION" envDefault:""`
+	// MinScore drops query results scoring below it when --min-score is not
+	// given: the boosted BM25 score in fuzzy mode, cosine similarity in vector mode
+	MinScore float64 `json:"min_score" env:"SEARCH_MIN_SCORE" envDefault:"0"`
+}
+
 // TestConfig represents test-specific configuration
 type TestConfig struct {
 	PerPage  int `json:"per_page"  env:"TEST_PER_PAGE"  envDefault:"5"`
@@ -63,10 +168,18 @@ func LoadConfig() (*Config, error) {
 	return LoadConfigWithOverrides(nil)
 }
 
-// LoadConfigWithOverrides loads configuration with optional command-line flag overrides
+// ProjectConfigFileName is the project-local config discovered from the working
+// directory upwards and merged over the user config
+const ProjectConfigFileName = ".gh-star-search.json"
+
+// LoadConfigWithOverrides loads configuration with optional command-line flag overrides.
+// Precedence, lowest first: defaults, user config file, project config file,
+// environment variables, flags.
 func LoadConfigWithOverrides(flagOverrides map[string]interface{}) (*Config, error) {
-	// Start with empty configuration (defaults will be set by env.Parse)
-	config := &Config{}
+	config, err := DefaultConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	// Load from config file if it exists
 	configPath := getConfigPath()
@@ -76,9 +189,18 @@ func LoadConfigWithOverrides(flagOverrides map[string]interface{}) (*Config, err
 		}
 	}
 
-	// Apply environment variable overrides using env library (also sets defaults)
+	// Merge a project config found from the working directory upwards
+	if projectPath := ProjectConfigPath(); projectPath != "" {
+		if err := loadProjectConfig(config, projectPath); err != nil {
+			return nil, fmt.Errorf("failed to load project config %s: %w", projectPath, err)
+		}
+	}
+
+	// Apply environment variable overrides; an unknown tag name keeps the
+	// defaults from overwriting values set by the config files. Each section's
+	// envPrefix tag supplies GH_STAR_SEARCH_, so no global prefix is set.
 	if err := env.ParseWithOptions(config, env.Options{
-		Prefix: "GH_STAR_SEARCH_",
+		DefaultValueTagName: "envOverrideOnly",
 	}); err != nil {
 		return nil, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
@@ -96,6 +218,81 @@ func LoadConfigWithOverrides(flagOverrides map[string]interface{}) (*Config, err
 	return config, nil
 }
 
+// DefaultConfig returns the configuration from the envDefault tags alone,
+// without reading config files or the environment
+func DefaultConfig() (*Config, error) {
+	config := &Config{}
+
+	if err := env.ParseWithOptions(config, env.Options{
+		Environment: map[string]string{},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to apply configuration defaults: %w", err)
+	}
+
+	return config, nil
+}
+
+// UserConfigPath returns the user config file read by LoadConfig and written
+// by SaveConfig, whether or not it exists
+func UserConfigPath() string {
+	return getConfigPath()
+}
+
+// ProjectConfigPath returns the project config that applies to the working
+// directory, or "" when there is none
+func ProjectConfigPath() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	return findProjectConfig(dir)
+}
+
+// findProjectConfig walks up from dir and returns the first project config found
+func findProjectConfig(dir string) string {
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// loadProjectConfig merges a project config over config. Relative paths it sets
+// are resolved against the project config's directory, so a checked-in config
+// works from any subdirectory.
+func loadProjectConfig(config *Config, projectPath string) error {
+	projectConfig := *config
+	if err := loadConfigFromFile(&projectConfig, projectPath); err != nil {
+		return err
+	}
+
+	baseDir := filepath.Dir(projectPath)
+	for _, paths := range [][2]*string{
+		{&projectConfig.Database.Path, &config.Database.Path},
+		{&projectConfig.Cache.Directory, &config.Cache.Directory},
+		{&projectConfig.Logging.File, &config.Logging.File},
+	} {
+		path := paths[0]
+		if *path != *paths[1] && *path != "" &&
+			!strings.HasPrefix(*path, "~") && !filepath.IsAbs(*path) {
+			*path = filepath.Join(baseDir, *path)
+		}
+	}
+
+	*config = projectConfig
+
+	return nil
+}
+
 // loadConfigFromFile loads configuration from a JSON file
 func loadConfigFromFile(config *Config, configPath string) error {
 	data, err := os.ReadFile(configPath)
@@ -103,14 +300,34 @@ func loadConfigFromFile(config *Config, configPath string) error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse JSON into a temporary struct to merge with defaults
-	var fileConfig Config
+	// Unmarshal over a copy so keys missing from the file, including false
+	// booleans, keep their defaults
+	fileConfig := *config
 	if err := json.Unmarshal(data, &fileConfig); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	// Merge file config with defaults
-	mergeConfigs(config, &fileConfig)
+	*config = fileConfig
+
+	return nil
+}
+
+// CheckFile parses a config file strictly, reporting keys that match no
+// setting. Loading ignores such keys, so a misspelled key silently keeps its
+// default.
+func CheckFile(configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
 
 	return nil
 }
@@ -127,6 +344,10 @@ func applyFlagOverrides(config *Config, overrides map[string]interface{}) {
 			if str, ok := value.(string); ok && str != "" {
 				config.Logging.Level = str
 			}
+		case "log-format":
+			if str, ok := value.(string); ok && str != "" {
+				config.Logging.Format = str
+			}
 		case "verbose":
 			if b, ok := value.(bool); ok {
 				config.Debug.Verbose = b
@@ -143,26 +364,9 @@ func applyFlagOverrides(config *Config, overrides map[string]interface{}) {
 	}
 }
 
-// mergeConfigs merges source configuration into target configuration
-func mergeConfigs(target, source *Config) {
-	var mergeValues func(t, s reflect.Value)
-	mergeValues = func(t, s reflect.Value) {
-		if t.Kind() != s.Kind() {
-			return
-		}
-
-		if t.Kind() == reflect.Struct {
-			for i := range s.NumField() {
-				mergeValues(t.Field(i), s.Field(i))
-			}
-		} else if s.Kind() == reflect.Bool {
-			t.Set(s)
-		} else if !s.IsZero() {
-			t.Set(s)
-		}
-	}
-
-	mergeValues(reflect.ValueOf(target).Elem(), reflect.ValueOf(source).Elem())
+// Validate checks the configuration for the errors LoadConfig rejects
+func (c *Config) Validate() error {
+	return validateConfig(c)
 }
 
 // validateConfig validates the configuration for common errors
@@ -202,6 +406,155 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("invalid database query timeout: %s", config.Database.QueryTimeout)
 	}
 
+	// Validate connection pool settings
+	if config.Database.MaxConnections <= 0 {
+		return fmt.Errorf(
+			"invalid database max connections: %d (must be positive)",
+			config.Database.MaxConnections,
+		)
+	}
+
+	// Zero would fall back to the pool default rather than disable idle connections
+	if config.Database.MaxIdleConns <= 0 {
+		return fmt.Errorf(
+			"invalid database max idle connections: %d (must be positive)",
+			config.Database.MaxIdleConns,
+		)
+	}
+
+	if _, err := time.ParseDuration(config.Database.ConnMaxLifetime); err != nil {
+		return fmt.Errorf("invalid database connection max lifetime: %s", config.Database.ConnMaxLifetime)
+	}
+
+	if _, err := time.ParseDuration(config.Database.ConnMaxIdleTime); err != nil {
+		return fmt.Errorf("invalid database connection max idle time: %s", config.Database.ConnMaxIdleTime)
+	}
+
+	if config.Database.OpenAttempts <= 0 {
+		return fmt.Errorf(
+			"invalid database open attempts: %d (must be positive)",
+			config.Database.OpenAttempts,
+		)
+	}
+
+	if _, err := time.ParseDuration(config.Database.OpenBackoff); err != nil {
+		return fmt.Errorf("invalid database open backoff: %s", config.Database.OpenBackoff)
+	}
+
+	if wait, err := time.ParseDuration(config.Database.LockWait); err != nil || wait < 0 {
+		return fmt.Errorf("invalid database lock wait: %s (must be a non-negative duration)", config.Database.LockWait)
+	}
+
+	if config.Sync.RateLimitThreshold < 0 {
+		return fmt.Errorf(
+			"invalid sync rate limit threshold: %d (must not be negative)",
+			config.Sync.RateLimitThreshold,
+		)
+	}
+
+	if config.Sync.RetryAttempts < 0 {
+		return fmt.Errorf("invalid sync retry attempts: %d (must not be negative)", config.Sync.RetryAttempts)
+	}
+
+	if config.Sync.SearchRequestsPerMinute < 0 {
+		return fmt.Errorf(
+			"invalid sync search requests per minute: %d (must not be negative)",
+			config.Sync.SearchRequestsPerMinute,
+		)
+	}
+
+	if config.Sync.MaxRequests < 0 || config.Sync.MaxBytes < 0 {
+		return fmt.Errorf(
+			"invalid sync budget: max requests %d, max bytes %d (must not be negative)",
+			config.Sync.MaxRequests, config.Sync.MaxBytes,
+		)
+	}
+
+	if config.Sync.ConfirmLargeSync < 0 {
+		return fmt.Errorf(
+			"invalid sync confirmation threshold: %d (must not be negative)",
+			config.Sync.ConfirmLargeSync,
+		)
+	}
+
+	if config.Sync.DocsDedupThreshold < 0 || config.Sync.DocsDedupThreshold > 1 {
+		return fmt.Errorf(
+			"invalid sync docs dedup threshold: %g (must be between 0 and 1)",
+			config.Sync.DocsDedupThreshold,
+		)
+	}
+
+	if config.Sync.MaxWorkers < 0 {
+		return fmt.Errorf("invalid sync max workers: %d (must not be negative)", config.Sync.MaxWorkers)
+	}
+
+	if delay, err := time.ParseDuration(config.Sync.BatchDelay); err != nil || delay < 0 {
+		return fmt.Errorf("invalid sync batch delay: %s (must be a non-negative duration)", config.Sync.BatchDelay)
+	}
+
+	if delay, err := time.ParseDuration(config.Sync.RepoDelay); err != nil || delay < 0 {
+		return fmt.Errorf("invalid sync repo delay: %s (must be a non-negative duration)", config.Sync.RepoDelay)
+	}
+
+	if config.Processor.MaxFileKB <= 0 || config.Processor.MaxTotalTokens <= 0 ||
+		config.Processor.MaxTokensPerChunk <= 0 {
+		return fmt.Errorf(
+			"invalid processor limits: max file KB %d, max total tokens %d, max tokens per chunk %d (must be positive)",
+			config.Processor.MaxFileKB, config.Processor.MaxTotalTokens, config.Processor.MaxTokensPerChunk,
+		)
+	}
+
+	if err := validateFormatterConfig(config.Formatter); err != nil {
+		return err
+	}
+
+	for _, language := range config.Summarize.Languages {
+		if strings.TrimSpace(language) == "" {
+			return fmt.Errorf("invalid summarize languages: empty entry in %q", config.Summarize.Languages)
+		}
+	}
+
+	if config.Search.DefaultMode != "fuzzy" && config.Search.DefaultMode != "vector" {
+		return fmt.Errorf("invalid search default mode: %s (must be fuzzy or vector)", config.Search.DefaultMode)
+	}
+
+	if config.Search.MinScore < 0 {
+		return fmt.Errorf("invalid search min score: %g (must not be negative)", config.Search.MinScore)
+	}
+
+	if config.Search.ScoreExpression != "" {
+		if _, err := scoring.Parse(config.Search.ScoreExpression); err != nil {
+			return fmt.Errorf("invalid search score expression %q: %w", config.Search.ScoreExpression, err)
+		}
+	}
+
+	return nil
+}
+
+// validateFormatterConfig rejects truncation settings that would hide all output
+func validateFormatterConfig(cfg FormatterConfig) error {
+	if cfg.MatchContextWidth <= 0 {
+		return fmt.Errorf(
+			"invalid formatter match context width: %d (must be positive)",
+			cfg.MatchContextWidth,
+		)
+	}
+
+	if cfg.MaxContributors <= 0 {
+		return fmt.Errorf(
+			"invalid formatter max contributors: %d (must be positive)",
+			cfg.MaxContributors,
+		)
+	}
+
+	// Room for at least one character plus the "..." suffix
+	if cfg.MaxDescriptionLength < 4 {
+		return fmt.Errorf(
+			"invalid formatter max description length: %d (must be at least 4)",
+			cfg.MaxDescriptionLength,
+		)
+	}
+
 	return nil
 }
 
@@ -297,6 +650,11 @@ func GetLogDir() string {
 	return filepath.Join(GetConfigDir(), "logs")
 }
 
+// GetTemplatesDir returns the directory searched for named output templates
+func GetTemplatesDir() string {
+	return filepath.Join(GetConfigDir(), "templates")
+}
+
 // EnsureDirectories creates necessary directories for the configuration
 func (c *Config) EnsureDirectories() error {
 	dirs := []string{
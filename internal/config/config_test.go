@@ -46,6 +46,7 @@ func TestLoadConfigFromFile(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "/custom/path/db.db", config.Database.Path)
+	assert.Equal(t, 20, config.Database.MaxConnections)
 	assert.Equal(t, "60s", config.Database.QueryTimeout)
 	assert.Equal(t, "debug", config.Logging.Level)
 	assert.Equal(t, "json", config.Logging.Format)
@@ -75,17 +76,19 @@ func TestApplyFlagOverrides(t *testing.T) {
 	require.NoError(t, err)
 
 	overrides := map[string]interface{}{
-		"db-path":   "/flag/db/path.db",
-		"log-level": "error",
-		"verbose":   true,
-		"debug":     true,
-		"cache-dir": "/flag/cache",
+		"db-path":    "/flag/db/path.db",
+		"log-level":  "error",
+		"log-format": "json",
+		"verbose":    true,
+		"debug":      true,
+		"cache-dir":  "/flag/cache",
 	}
 
 	applyFlagOverrides(config, overrides)
 
 	assert.Equal(t, "/flag/db/path.db", config.Database.Path)
 	assert.Equal(t, "error", config.Logging.Level)
+	assert.Equal(t, "json", config.Logging.Format)
 	assert.True(t, config.Debug.Verbose)
 	assert.True(t, config.Debug.Enabled)
 	assert.Equal(t, "/flag/cache", config.Cache.Directory)
@@ -137,7 +140,257 @@ func TestValidateConfig(t *testing.T) {
 			expectError:   true,
 			errorContains: "invalid database query timeout",
 		},
-
+		{
+			name: "non-positive max connections",
+			modifyConfig: func(c *Config) {
+				c.Database.MaxConnections = 0
+			},
+			expectError:   true,
+			errorContains: "invalid database max connections",
+		},
+		{
+			name: "negative max idle connections",
+			modifyConfig: func(c *Config) {
+				c.Database.MaxIdleConns = -1
+			},
+			expectError:   true,
+			errorContains: "invalid database max idle connections",
+		},
+		{
+			name: "zero max idle connections",
+			modifyConfig: func(c *Config) {
+				c.Database.MaxIdleConns = 0
+			},
+			expectError:   true,
+			errorContains: "invalid database max idle connections",
+		},
+		{
+			name: "invalid connection max lifetime",
+			modifyConfig: func(c *Config) {
+				c.Database.ConnMaxLifetime = "forever"
+			},
+			expectError:   true,
+			errorContains: "invalid database connection max lifetime",
+		},
+		{
+			name: "invalid connection max idle time",
+			modifyConfig: func(c *Config) {
+				c.Database.ConnMaxIdleTime = "soon"
+			},
+			expectError:   true,
+			errorContains: "invalid database connection max idle time",
+		},
+		{
+			name: "non-positive open attempts",
+			modifyConfig: func(c *Config) {
+				c.Database.OpenAttempts = 0
+			},
+			expectError:   true,
+			errorContains: "invalid database open attempts",
+		},
+		{
+			name: "invalid open backoff",
+			modifyConfig: func(c *Config) {
+				c.Database.OpenBackoff = "briefly"
+			},
+			expectError:   true,
+			errorContains: "invalid database open backoff",
+		},
+		{
+			name: "negative lock wait",
+			modifyConfig: func(c *Config) {
+				c.Database.LockWait = "-1m"
+			},
+			expectError:   true,
+			errorContains: "invalid database lock wait",
+		},
+		{
+			name: "negative sync rate limit threshold",
+			modifyConfig: func(c *Config) {
+				c.Sync.RateLimitThreshold = -1
+			},
+			expectError:   true,
+			errorContains: "invalid sync rate limit threshold",
+		},
+		{
+			name: "zero formatter max contributors",
+			modifyConfig: func(c *Config) {
+				c.Formatter.MaxContributors = 0
+			},
+			expectError:   true,
+			errorContains: "invalid formatter max contributors",
+		},
+		{
+			name: "too short formatter max description length",
+			modifyConfig: func(c *Config) {
+				c.Formatter.MaxDescriptionLength = 3
+			},
+			expectError:   true,
+			errorContains: "invalid formatter max description length",
+		},
+		{
+			name: "zero formatter match context width",
+			modifyConfig: func(c *Config) {
+				c.Formatter.MatchContextWidth = 0
+			},
+			expectError:   true,
+			errorContains: "invalid formatter match context width",
+		},
+		{
+			name: "empty summarize language",
+			modifyConfig: func(c *Config) {
+				c.Summarize.Languages = []string{"en", " "}
+			},
+			expectError:   true,
+			errorContains: "invalid summarize languages",
+		},
+		{
+			name: "no summarize languages",
+			modifyConfig: func(c *Config) {
+				c.Summarize.Languages = nil
+			},
+			expectError: false,
+		},
+		{
+			name: "negative sync max requests",
+			modifyConfig: func(c *Config) {
+				c.Sync.MaxRequests = -1
+			},
+			expectError:   true,
+			errorContains: "invalid sync budget",
+		},
+		{
+			name: "negative sync retry attempts",
+			modifyConfig: func(c *Config) {
+				c.Sync.RetryAttempts = -1
+			},
+			expectError:   true,
+			errorContains: "invalid sync retry attempts",
+		},
+		{
+			name: "negative sync confirmation threshold",
+			modifyConfig: func(c *Config) {
+				c.Sync.ConfirmLargeSync = -1
+			},
+			expectError:   true,
+			errorContains: "invalid sync confirmation threshold",
+		},
+		{
+			name: "sync docs dedup threshold above one",
+			modifyConfig: func(c *Config) {
+				c.Sync.DocsDedupThreshold = 1.5
+			},
+			expectError:   true,
+			errorContains: "invalid sync docs dedup threshold",
+		},
+		{
+			name: "disabled sync docs dedup",
+			modifyConfig: func(c *Config) {
+				c.Sync.DocsDedupThreshold = 0
+			},
+			expectError: false,
+		},
+		{
+			name: "zero processor max file size",
+			modifyConfig: func(c *Config) {
+				c.Processor.MaxFileKB = 0
+			},
+			expectError:   true,
+			errorContains: "invalid processor limits",
+		},
+		{
+			name: "negative processor total token budget",
+			modifyConfig: func(c *Config) {
+				c.Processor.MaxTotalTokens = -1
+			},
+			expectError:   true,
+			errorContains: "invalid processor limits",
+		},
+		{
+			name: "zero processor tokens per chunk",
+			modifyConfig: func(c *Config) {
+				c.Processor.MaxTokensPerChunk = 0
+			},
+			expectError:   true,
+			errorContains: "invalid processor limits",
+		},
+		{
+			name: "larger processor limits",
+			modifyConfig: func(c *Config) {
+				c.Processor = ProcessorConfig{MaxFileKB: 2048, MaxTotalTokens: 200000, MaxTokensPerChunk: 4000}
+			},
+			expectError: false,
+		},
+		{
+			name: "negative sync max workers",
+			modifyConfig: func(c *Config) {
+				c.Sync.MaxWorkers = -1
+			},
+			expectError:   true,
+			errorContains: "invalid sync max workers",
+		},
+		{
+			name: "unparseable sync batch delay",
+			modifyConfig: func(c *Config) {
+				c.Sync.BatchDelay = "2"
+			},
+			expectError:   true,
+			errorContains: "invalid sync batch delay",
+		},
+		{
+			name: "negative sync repo delay",
+			modifyConfig: func(c *Config) {
+				c.Sync.RepoDelay = "-1s"
+			},
+			expectError:   true,
+			errorContains: "invalid sync repo delay",
+		},
+		{
+			name: "no sync delays",
+			modifyConfig: func(c *Config) {
+				c.Sync.BatchDelay = "0s"
+				c.Sync.RepoDelay = "0s"
+			},
+			expectError: false,
+		},
+		{
+			name: "vector search default mode",
+			modifyConfig: func(c *Config) {
+				c.Search.DefaultMode = "vector"
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid search default mode",
+			modifyConfig: func(c *Config) {
+				c.Search.DefaultMode = "semantic"
+			},
+			expectError:   true,
+			errorContains: "invalid search default mode",
+		},
+		{
+			name: "negative search min score",
+			modifyConfig: func(c *Config) {
+				c.Search.MinScore = -0.5
+			},
+			expectError:   true,
+			errorContains: "invalid search min score",
+		},
+		{
+			name: "valid search score expression",
+			modifyConfig: func(c *Config) {
+				c.Search.ScoreExpression = "match_score * log10(stars + 10)"
+			},
+			expectError: false,
+		},
+		{
+			name: "unsafe search score expression",
+			modifyConfig: func(c *Config) {
+				c.Search.ScoreExpression = "os.Exit(1)"
+			},
+			expectError:   true,
+			errorContains: "invalid search score expression",
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,6 +413,28 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestSummarizeConfigSupportsLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		language  string
+		expected  bool
+	}{
+		{"listed", []string{"en", "de"}, "de", true},
+		{"case insensitive", []string{"EN"}, "en", true},
+		{"unlisted", []string{"en"}, "zh", false},
+		{"undetected", []string{"en"}, "", true},
+		{"no restriction", nil, "zh", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SummarizeConfig{Languages: tt.languages}
+			assert.Equal(t, tt.expected, cfg.SupportsLanguage(tt.language))
+		})
+	}
+}
+
 func TestExpandPath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -279,24 +554,184 @@ func TestLoadConfigWithOverrides(t *testing.T) {
 	assert.Equal(t, defaultConfig.Logging.Level, config.Logging.Level)
 }
 
-func TestMergeConfigs(t *testing.T) {
-	target, err := LoadConfigWithOverrides(nil)
+func TestLoadConfigWithOverrides_Precedence(t *testing.T) {
+	userDir := t.TempDir()
+	userConfigPath := filepath.Join(userDir, "config.json")
+	require.NoError(t, os.WriteFile(userConfigPath, []byte(`{
+		"database": {"path": "/user/database.db", "max_connections": 20},
+		"logging": {"level": "debug"}
+	}`), 0o600))
+	t.Setenv("GH_STAR_SEARCH_CONFIG", userConfigPath)
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ProjectConfigFileName), []byte(`{
+		"database": {"path": "data/stars.db"},
+		"logging": {"level": "warn"}
+	}`), 0o600))
+
+	nested := filepath.Join(projectDir, "src", "pkg")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+	t.Chdir(nested)
+
+	config, err := LoadConfigWithOverrides(nil)
 	require.NoError(t, err)
 
-	source := &Config{
-		Database: DatabaseConfig{
-			Path: "/new/path",
+	// Project config wins over the user config; relative paths resolve
+	// against the project config's directory
+	assert.Equal(t, filepath.Join(projectDir, "data", "stars.db"), config.Database.Path)
+	assert.Equal(t, "warn", config.Logging.Level)
+	// User config values survive where the project config is silent
+	assert.Equal(t, 20, config.Database.MaxConnections)
+	// Defaults fill the rest, including booleans defaulting to true
+	assert.Equal(t, "30s", config.Database.QueryTimeout)
+	assert.True(t, config.Sync.AdaptiveBatchDelay)
+
+	// Environment variables win over the project config
+	t.Setenv("GH_STAR_SEARCH_LOG_LEVEL", "info")
+
+	config, err = LoadConfigWithOverrides(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "info", config.Logging.Level)
+
+	// Flags win over the environment
+	config, err = LoadConfigWithOverrides(map[string]interface{}{"log-level": "error"})
+	require.NoError(t, err)
+	assert.Equal(t, "error", config.Logging.Level)
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	assert.Empty(t, findProjectConfig(nested))
+
+	projectPath := filepath.Join(root, ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(projectPath, []byte(`{}`), 0o600))
+	assert.Equal(t, projectPath, findProjectConfig(nested))
+
+	// The closest project config wins
+	closerPath := filepath.Join(root, "a", ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(closerPath, []byte(`{}`), 0o600))
+	assert.Equal(t, closerPath, findProjectConfig(nested))
+
+	// A directory with the same name is ignored
+	require.NoError(t, os.Mkdir(filepath.Join(nested, ProjectConfigFileName), 0o755))
+	assert.Equal(t, closerPath, findProjectConfig(nested))
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	projectDir := t.TempDir()
+	projectPath := filepath.Join(projectDir, ProjectConfigFileName)
+
+	tests := []struct {
+		name     string
+		content  string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:     "relative path",
+			content:  `{"database": {"path": "stars.db"}}`,
+			wantPath: filepath.Join(projectDir, "stars.db"),
 		},
-		Logging: LoggingConfig{
-			Level: "debug",
+		{
+			name:     "absolute path",
+			content:  `{"database": {"path": "/abs/stars.db"}}`,
+			wantPath: "/abs/stars.db",
+		},
+		{
+			name:     "home path",
+			content:  `{"database": {"path": "~/stars.db"}}`,
+			wantPath: "~/stars.db",
+		},
+		{
+			name:     "path not set",
+			content:  `{"logging": {"level": "debug"}}`,
+			wantPath: "relative/user.db",
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"database": `,
+			wantErr: true,
 		},
 	}
 
-	mergeConfigs(target, source)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(projectPath, []byte(tt.content), 0o600))
+
+			config := &Config{Database: DatabaseConfig{Path: "relative/user.db"}}
+			err := loadProjectConfig(config, projectPath)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, config.Database.Path)
+		})
+	}
+}
+
+func TestLoadConfigFromFile_KeepsUnsetValues(t *testing.T) {
+	target, err := LoadConfigWithOverrides(nil)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"database": {"path": "/new/path"},
+		"logging": {"level": "debug"}
+	}`), 0o600))
+
+	require.NoError(t, loadConfigFromFile(target, configPath))
 
 	assert.Equal(t, "/new/path", target.Database.Path)
 	assert.Equal(t, "debug", target.Logging.Level)
 	// Other values should remain from target
 	assert.Equal(t, "30s", target.Database.QueryTimeout)
 	assert.Equal(t, "text", target.Logging.Format)
+	assert.True(t, target.Sync.AdaptiveBatchDelay)
+}
+
+func TestDefaultConfig(t *testing.T) {
+	t.Setenv("GH_STAR_SEARCH_LOG_LEVEL", "debug")
+
+	config, err := DefaultConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "info", config.Logging.Level, "the environment is not read")
+	assert.Equal(t, 14, config.Cache.MetadataStaleDays)
+	assert.Equal(t, []string{"en"}, config.Summarize.Languages)
+	require.NoError(t, config.Validate())
+}
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "known keys", content: `{"database": {"path": "/db"}, "logging": {"level": "debug"}}`},
+		{name: "unknown section", content: `{"databse": {"path": "/db"}}`, wantErr: `unknown field "databse"`},
+		{name: "unknown key", content: `{"cache": {"ttl": 5}}`, wantErr: `unknown field "ttl"`},
+		{name: "syntax error", content: `{"database": `, wantErr: "failed to parse config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0o600))
+
+			err := CheckFile(configPath)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
 }
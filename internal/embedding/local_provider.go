@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/KyleKing/gh-star-search/internal/python"
+	"github.com/KyleKing/gh-star-search/internal/timing"
 )
 
 // LocalProvider implements embedding generation using local Python script via uv
@@ -48,6 +49,8 @@ func (p *LocalProvider) GenerateEmbedding(ctx context.Context, text string) ([]f
 		return make([]float32, p.dimensions), nil
 	}
 
+	defer timing.FromContext(ctx).Track(timing.PhaseLLM)()
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
@@ -91,7 +94,7 @@ func (p *LocalProvider) GenerateEmbedding(ctx context.Context, text string) ([]f
 
 	// Parse JSON output
 	var result embeddingResult
-	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+	if err := python.DecodeJSON(stdout.Bytes(), &result); err != nil {
 		return nil, fmt.Errorf("failed to parse embedding result: %w", err)
 	}
 
@@ -126,6 +129,8 @@ func (p *LocalProvider) GenerateEmbeddings(
 		return nil, nil
 	}
 
+	defer timing.FromContext(ctx).Track(timing.PhaseLLM)()
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
@@ -165,7 +170,7 @@ func (p *LocalProvider) GenerateEmbeddings(
 
 	// Parse JSON output
 	var result embeddingResult
-	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+	if err := python.DecodeJSON(stdout.Bytes(), &result); err != nil {
 		return nil, fmt.Errorf("failed to parse embedding result: %w", err)
 	}
 
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// delimitedColumns are the columns of FormatCSV and FormatTSV records
+var delimitedColumns = []string{"full_name", "language", "stars", "forks", "topics", "updated", "description"}
+
+// delimiter returns the field separator of a delimited format, and false for
+// any other format
+func delimiter(format OutputFormat) (rune, bool) {
+	switch format {
+	case FormatCSV:
+		return ',', true
+	case FormatTSV:
+		return '\t', true
+	default:
+		return 0, false
+	}
+}
+
+// FormatHeader returns the header row of a delimited format, or "" for formats
+// without one
+func FormatHeader(format OutputFormat) string {
+	comma, ok := delimiter(format)
+	if !ok {
+		return ""
+	}
+
+	return DelimitedHeader(comma)
+}
+
+// DelimitedHeader returns the header row of FormatDelimited records separated
+// by comma
+func DelimitedHeader(comma rune) string {
+	return formatRecord(comma, delimitedColumns)
+}
+
+// FormatDelimited renders repo as one record with the FormatCSV columns,
+// separated by comma, which must be a valid encoding/csv delimiter. Topics are
+// joined with '|' so they stay in a single field.
+func FormatDelimited(repo storage.StoredRepo, comma rune) string {
+	updated := ""
+	if !repo.UpdatedAt.IsZero() {
+		updated = repo.UpdatedAt.UTC().Format("2006-01-02")
+	}
+
+	return formatRecord(comma, []string{
+		repo.FullName,
+		repo.Language,
+		strconv.Itoa(repo.StargazersCount),
+		strconv.Itoa(repo.ForksCount),
+		strings.Join(repo.Topics, "|"),
+		updated,
+		repo.Description,
+	})
+}
+
+// formatRecord encodes fields as a single record without the trailing newline,
+// quoting fields that contain the delimiter, quotes or line breaks
+func formatRecord(comma rune, fields []string) string {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	w.Comma = comma
+
+	// Writing to a bytes.Buffer cannot fail, and comma is always valid
+	_ = w.Write(fields)
+	w.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
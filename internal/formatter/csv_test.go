@@ -0,0 +1,100 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// delimitedFixtures cover quoting: commas, quotes, newlines and tabs in
+// descriptions, and repositories without topics or an update time
+var delimitedFixtures = []storage.StoredRepo{
+	{
+		FullName:        "hashicorp/terraform",
+		Description:     "Safely create, change, and improve infrastructure",
+		Language:        "Go",
+		StargazersCount: 42000,
+		ForksCount:      9500,
+		Topics:          []string{"terraform", "iac"},
+		UpdatedAt:       time.Date(2024, 9, 10, 14, 30, 0, 0, time.UTC),
+	},
+	{
+		FullName:        "user/quoted",
+		Description:     "A \"quoted\" description\nover two lines\twith a tab",
+		Language:        "Rust",
+		StargazersCount: 12,
+		UpdatedAt:       time.Date(2024, 1, 31, 23, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+	},
+	{
+		FullName: "user/minimal",
+	},
+}
+
+func TestFormatter_DelimitedGoldenFiles(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{})
+
+	tests := []struct {
+		format     OutputFormat
+		goldenFile string
+	}{
+		{format: FormatCSV, goldenFile: "testdata/golden_repositories.csv"},
+		{format: FormatTSV, goldenFile: "testdata/golden_repositories.tsv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			lines := []string{FormatHeader(tt.format)}
+			for _, repo := range delimitedFixtures {
+				lines = append(lines, formatter.FormatRepository(repo, tt.format))
+			}
+
+			output := strings.Join(lines, "\n") + "\n"
+
+			golden, err := os.ReadFile(tt.goldenFile)
+			if err != nil {
+				t.Fatalf("Failed to read golden file %s: %v", tt.goldenFile, err)
+			}
+
+			if output != string(golden) {
+				t.Errorf("Output does not match %s.\nExpected:\n%s\nGot:\n%s", tt.goldenFile, golden, output)
+			}
+
+			// The output must read back as the same fields
+			reader := csv.NewReader(strings.NewReader(output))
+			reader.Comma, _ = delimiter(tt.format)
+
+			records, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("Output is not valid %s: %v", tt.format, err)
+			}
+
+			if len(records) != len(delimitedFixtures)+1 {
+				t.Fatalf("Expected %d records, got %d", len(delimitedFixtures)+1, len(records))
+			}
+
+			if got := records[2][6]; got != delimitedFixtures[1].Description {
+				t.Errorf("Description did not round-trip: got %q", got)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatResultDelimited(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{})
+	result := query.Result{Score: 0.5, Rank: 1, Repository: delimitedFixtures[0]}
+
+	if got, want := formatter.FormatResult(result, FormatCSV),
+		formatter.FormatRepository(delimitedFixtures[0], FormatCSV); got != want {
+		t.Errorf("FormatResult() = %q, want the repository record %q", got, want)
+	}
+
+	if header := FormatHeader(FormatShort); header != "" {
+		t.Errorf("FormatHeader(short) = %q, want empty", header)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/query"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
@@ -17,14 +19,43 @@ type OutputFormat string
 const (
 	FormatLong  OutputFormat = "long"
 	FormatShort OutputFormat = "short"
+	FormatJSON  OutputFormat = "json" // One JSON object per line, for piping into jq
+	FormatCSV   OutputFormat = "csv"  // One CSV record per line, after the FormatHeader row
+	FormatTSV   OutputFormat = "tsv"  // As FormatCSV, tab-separated
+)
+
+// Default truncation settings, used for zero-valued FormatterConfig fields
+const (
+	DefaultMatchContextWidth    = 30
+	DefaultMaxContributors      = 10
+	DefaultMaxDescriptionLength = 80
 )
 
 // Formatter handles repository output formatting
-type Formatter struct{}
+type Formatter struct {
+	cfg config.FormatterConfig
+}
 
-// NewFormatter creates a new formatter instance
-func NewFormatter() *Formatter {
-	return &Formatter{}
+// NewFormatter creates a new formatter instance with the given truncation settings
+func NewFormatter(cfg config.FormatterConfig) *Formatter {
+	return &Formatter{cfg: WithDefaults(cfg)}
+}
+
+// WithDefaults fills zero-valued truncation settings with the package defaults
+func WithDefaults(cfg config.FormatterConfig) config.FormatterConfig {
+	if cfg.MatchContextWidth <= 0 {
+		cfg.MatchContextWidth = DefaultMatchContextWidth
+	}
+
+	if cfg.MaxContributors <= 0 {
+		cfg.MaxContributors = DefaultMaxContributors
+	}
+
+	if cfg.MaxDescriptionLength <= 0 {
+		cfg.MaxDescriptionLength = DefaultMaxDescriptionLength
+	}
+
+	return cfg
 }
 
 // FormatResult formats a single search result
@@ -34,6 +65,11 @@ func (f *Formatter) FormatResult(result query.Result, format OutputFormat) strin
 		return f.formatLong(result.Repository)
 	case FormatShort:
 		return f.formatShort(result.Repository, result.Score, result.Rank)
+	case FormatJSON:
+		return formatJSON(newJSONResult(result))
+	case FormatCSV, FormatTSV:
+		comma, _ := delimiter(format)
+		return FormatDelimited(result.Repository, comma)
 	default:
 		return f.formatShort(result.Repository, result.Score, result.Rank)
 	}
@@ -46,6 +82,11 @@ func (f *Formatter) FormatRepository(repo storage.StoredRepo, format OutputForma
 		return f.formatLong(repo)
 	case FormatShort:
 		return f.formatShortBasic(repo)
+	case FormatJSON:
+		return formatJSON(newJSONRepository(repo))
+	case FormatCSV, FormatTSV:
+		comma, _ := delimiter(format)
+		return FormatDelimited(repo, comma)
 	default:
 		return f.formatShortBasic(repo)
 	}
@@ -119,9 +160,9 @@ func (f *Formatter) formatLong(repo storage.StoredRepo) string {
 
 	lines = append(lines, "License: "+license)
 
-	// Line 8: Top 10 Contributors
+	// Line 8: Top N Contributors
 	contributors := f.formatContributors(repo.Contributors)
-	lines = append(lines, "Top 10 Contributors: "+contributors)
+	lines = append(lines, fmt.Sprintf("Top %d Contributors: %s", f.cfg.MaxContributors, contributors))
 
 	// Line 9: GitHub Topics
 	topics := strings.Join(repo.Topics, ", ")
@@ -131,6 +172,15 @@ func (f *Formatter) formatLong(repo storage.StoredRepo) string {
 
 	lines = append(lines, "GitHub Topics: "+topics)
 
+	// Local tags, only shown when the repository has been annotated
+	if len(repo.Tags) > 0 {
+		lines = append(lines, "Tags: "+strings.Join(repo.Tags, ", "))
+	}
+
+	if len(repo.Keywords) > 0 {
+		lines = append(lines, "Keywords: "+strings.Join(repo.Keywords, ", "))
+	}
+
 	// Line 10: Languages
 	languages := f.formatLanguages(repo.Languages)
 	lines = append(lines, "Languages: "+languages)
@@ -160,10 +210,7 @@ func (f *Formatter) formatShort(repo storage.StoredRepo, score float64, rank int
 	}
 
 	// Add score, truncated description, and primary language
-	description := repo.Description
-	if len(description) > 80 {
-		description = description[:77] + "..."
-	}
+	description := TruncateRunes(f.cfg.MaxDescriptionLength, repo.Description)
 
 	if description == "" {
 		description = "-"
@@ -241,11 +288,8 @@ func (f *Formatter) formatContributors(contributors []storage.Contributor) strin
 		return "-"
 	}
 
-	// Limit to top 10 contributors
-	limit := len(contributors)
-	if limit > 10 {
-		limit = 10
-	}
+	// Limit to the top contributors
+	limit := min(len(contributors), f.cfg.MaxContributors)
 
 	parts := make([]string, 0, limit)
 
@@ -318,3 +362,101 @@ func (f *Formatter) formatRelatedStars(repo storage.StoredRepo) string {
 	return fmt.Sprintf("%d in %s, %d by top contributors",
 		repo.RelatedSameOrgCount, orgName, repo.RelatedSharedContribCount)
 }
+
+// MatchSnippet trims text to the matching term plus the configured context on
+// each side. Text without the term is truncated to the max description length.
+func (f *Formatter) MatchSnippet(text, term string) string {
+	index := strings.Index(strings.ToLower(text), strings.ToLower(term))
+	if term == "" || index == -1 || index+len(term) > len(text) {
+		return TruncateRunes(f.cfg.MaxDescriptionLength, text)
+	}
+
+	start := max(index-f.cfg.MatchContextWidth, 0)
+	end := min(index+len(term)+f.cfg.MatchContextWidth, len(text))
+
+	// Widen to rune boundaries so multi-byte characters are never split
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	result := text[start:end]
+	if start > 0 {
+		result = "..." + result
+	}
+
+	if end < len(text) {
+		result += "..."
+	}
+
+	return result
+}
+
+// matchLabels names the matched fields of storage.Match in FormatMatched
+var matchLabels = map[string]string{
+	storage.MatchFieldName: "name",
+	"full_name":            "name",
+	"topics":               "topic",
+}
+
+// FormatMatched lists why a result matched query, one field per match ordered
+// by match score, e.g. "description ('...web framework...'), topic (go)".
+// Descriptions are trimmed around a query term by MatchSnippet. Chunk matches
+// are left out, and an empty string means nothing to explain.
+func (f *Formatter) FormatMatched(matches []storage.Match, query string) string {
+	sorted := make([]storage.Match, 0, len(matches))
+
+	for _, match := range matches {
+		if match.Field != storage.MatchFieldChunk && match.Content != "" {
+			sorted = append(sorted, match)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	parts := make([]string, 0, len(sorted))
+	seen := make(map[string]bool)
+
+	for _, match := range sorted {
+		label, ok := matchLabels[match.Field]
+		if !ok {
+			label = match.Field
+		}
+
+		// The name can match both as a name and fuzzily; list it once
+		key := label + "\x00" + match.Content
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		if match.Field == "description" {
+			snippet := f.MatchSnippet(match.Content, matchedTerm(match.Content, query))
+			parts = append(parts, fmt.Sprintf("%s ('%s')", label, snippet))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s (%s)", label, match.Content))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// matchedTerm returns the first word of query found in text, ignoring case,
+// or "" when text matched only by prefix or typo
+func matchedTerm(text, query string) string {
+	lower := strings.ToLower(text)
+
+	for _, term := range strings.Fields(query) {
+		if strings.Contains(lower, strings.ToLower(term)) {
+			return term
+		}
+	}
+
+	return ""
+}
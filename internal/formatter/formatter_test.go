@@ -7,12 +7,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/query"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
 func TestFormatter_FormatResult(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	// Create a test repository with complete data
 	repo := storage.StoredRepo{
@@ -98,7 +99,7 @@ func TestFormatter_FormatResult(t *testing.T) {
 }
 
 func TestFormatter_FormatRepository(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	// Test with minimal data (unknown values)
 	minimalRepo := storage.StoredRepo{
@@ -137,7 +138,7 @@ func TestFormatter_FormatRepository(t *testing.T) {
 }
 
 func TestFormatter_humanizeAge(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 	now := time.Date(2024, 9, 14, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
@@ -210,7 +211,7 @@ func TestFormatter_humanizeAge(t *testing.T) {
 }
 
 func TestFormatter_formatContributors(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	tests := []struct {
 		name     string
@@ -264,8 +265,95 @@ func TestFormatter_formatContributors(t *testing.T) {
 	}
 }
 
+func TestFormatter_CustomConfig(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{MaxContributors: 2, MaxDescriptionLength: 20})
+
+	repo := storage.StoredRepo{
+		FullName:    "owner/repo",
+		Description: "A fairly long description that will be cut",
+		Contributors: []storage.Contributor{
+			{Login: "alice", Contributions: 3},
+			{Login: "bob", Contributions: 2},
+			{Login: "carol", Contributions: 1},
+		},
+	}
+
+	long := formatter.FormatRepository(repo, FormatLong)
+	if !strings.Contains(long, "Top 2 Contributors: alice (3), bob (2)\n") {
+		t.Errorf("Expected contributors capped at 2, got:\n%s", long)
+	}
+
+	short := formatter.FormatResult(query.Result{Repository: repo, Rank: 1}, FormatShort)
+	if !strings.Contains(short, "(A fairly long des...)") {
+		t.Errorf("Expected description truncated to 20 characters, got:\n%s", short)
+	}
+}
+
+func TestFormatter_MatchSnippet(t *testing.T) {
+	text := "A lightweight toolkit for building terminal user interfaces in Go"
+
+	tests := []struct {
+		name     string
+		width    int
+		term     string
+		expected string
+	}{
+		{
+			name:     "default context",
+			term:     "terminal",
+			expected: "...htweight toolkit for building terminal user interfaces in Go",
+		},
+		{
+			name:     "narrow context",
+			width:    5,
+			term:     "TERMINAL",
+			expected: "...ding terminal user...",
+		},
+		{
+			name:     "term not found",
+			width:    5,
+			term:     "rust",
+			expected: "A lightweight toolkit for building terminal user interfaces in Go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(config.FormatterConfig{MatchContextWidth: tt.width})
+
+			result := formatter.MatchSnippet(text, tt.term)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatMatched(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{MatchContextWidth: 10})
+
+	matches := []storage.Match{
+		{Field: "topics", Content: "go", Score: 0.6},
+		{Field: storage.MatchFieldChunk, Source: "README.md", Content: "chunk text", Score: 0.9},
+		{Field: "description", Content: "A fast and minimal web framework for Go services", Score: 0.7},
+		{Field: "full_name", Content: "acme/webkit", Score: 0.5},
+		{Field: storage.MatchFieldName, Content: "acme/webkit", Score: 0.95},
+	}
+
+	got := formatter.FormatMatched(matches, "Web framework")
+	want := "name (acme/webkit), description ('...d minimal web framework...'), topic (go)"
+
+	if got != want {
+		t.Errorf("FormatMatched() = %q, want %q", got, want)
+	}
+
+	if got := formatter.FormatMatched(matches[1:2], "anything"); got != "" {
+		t.Errorf("Expected chunk matches to be left out, got %q", got)
+	}
+}
+
 func TestFormatter_formatLanguages(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	tests := []struct {
 		name     string
@@ -312,7 +400,7 @@ func TestFormatter_formatLanguages(t *testing.T) {
 }
 
 func TestFormatter_getPrimaryLanguage(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	tests := []struct {
 		name     string
@@ -362,7 +450,7 @@ func TestFormatter_getPrimaryLanguage(t *testing.T) {
 }
 
 func TestFormatter_formatInt(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	tests := []struct {
 		name     string
@@ -403,7 +491,7 @@ func TestFormatter_formatInt(t *testing.T) {
 
 // Golden test for complete long-form output
 func TestFormatter_GoldenLongForm(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	// Create a repository with all fields populated
 	repo := storage.StoredRepo{
@@ -481,7 +569,7 @@ Last synced: today`
 
 // TestFormatter_GoldenFiles tests against golden files for deterministic output
 func TestFormatter_GoldenFiles(t *testing.T) {
-	formatter := NewFormatter()
+	formatter := NewFormatter(config.FormatterConfig{})
 
 	tests := []struct {
 		name       string
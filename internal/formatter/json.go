@@ -0,0 +1,157 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// jsonRepository is the stable JSON shape of a stored repository. Every field is
+// always present: times are RFC3339, empty lists are [] rather than null, and
+// counts that could not be fetched are null. The embedding vector is reduced to
+// has_embedding since the raw floats are of no use in a pipeline.
+type jsonRepository struct {
+	ID                 string                `json:"id"`
+	GitHubID           int64                 `json:"github_id"`
+	FullName           string                `json:"full_name"`
+	URL                string                `json:"url"`
+	Description        string                `json:"description"`
+	Homepage           string                `json:"homepage"`
+	Language           string                `json:"language"`
+	StargazersCount    int                   `json:"stargazers_count"`
+	ForksCount         int                   `json:"forks_count"`
+	SizeKB             int                   `json:"size_kb"`
+	CreatedAt          string                `json:"created_at"`
+	UpdatedAt          string                `json:"updated_at"`
+	LastSynced         string                `json:"last_synced"`
+	Archived           bool                  `json:"archived"`
+	OpenIssuesOpen     *int                  `json:"open_issues_open"`
+	OpenIssuesTotal    *int                  `json:"open_issues_total"`
+	OpenPRsOpen        *int                  `json:"open_prs_open"`
+	OpenPRsTotal       *int                  `json:"open_prs_total"`
+	Commits30d         *int                  `json:"commits_30d"`
+	Commits1y          *int                  `json:"commits_1y"`
+	CommitsTotal       *int                  `json:"commits_total"`
+	Topics             []string              `json:"topics"`
+	Languages          map[string]int64      `json:"languages"`
+	Contributors       []storage.Contributor `json:"contributors"`
+	LicenseName        string                `json:"license_name"`
+	LicenseSPDXID      string                `json:"license_spdx_id"`
+	ContentHash        string                `json:"content_hash"`
+	ContentLanguage    string                `json:"content_language"`
+	ReadmePath         string                `json:"readme_path"`
+	ReadmeFormat       string                `json:"readme_format"`
+	Purpose            string                `json:"purpose"`
+	SummaryGeneratedAt *string               `json:"summary_generated_at"`
+	SummaryVersion     int                   `json:"summary_version"`
+	HasEmbedding       bool                  `json:"has_embedding"`
+	Keywords           []string              `json:"keywords"`
+	Tags               []string              `json:"tags"`
+}
+
+// jsonResult is the stable JSON shape of a search result
+type jsonResult struct {
+	jsonRepository
+	Score         float64  `json:"score"`
+	Rank          int      `json:"rank"`
+	MatchedFields []string `json:"matched_fields"`
+}
+
+// formatJSON renders v as a single line of JSON
+func formatJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Only reachable with non-finite scores; keep the output valid JSON
+		data, _ = json.Marshal(map[string]string{"error": fmt.Sprintf("failed to encode result: %v", err)})
+	}
+
+	return string(data)
+}
+
+// newJSONRepository converts a stored repository to its JSON shape
+func newJSONRepository(repo storage.StoredRepo) jsonRepository {
+	out := jsonRepository{
+		ID:              repo.ID,
+		GitHubID:        repo.GitHubID,
+		FullName:        repo.FullName,
+		URL:             "https://github.com/" + repo.FullName,
+		Description:     repo.Description,
+		Homepage:        repo.Homepage,
+		Language:        repo.Language,
+		StargazersCount: repo.StargazersCount,
+		ForksCount:      repo.ForksCount,
+		SizeKB:          repo.SizeKB,
+		CreatedAt:       formatJSONTime(repo.CreatedAt),
+		UpdatedAt:       formatJSONTime(repo.UpdatedAt),
+		LastSynced:      formatJSONTime(repo.LastSynced),
+		Archived:        repo.Archived,
+		OpenIssuesOpen:  jsonCount(repo.OpenIssuesOpen),
+		OpenIssuesTotal: jsonCount(repo.OpenIssuesTotal),
+		OpenPRsOpen:     jsonCount(repo.OpenPRsOpen),
+		OpenPRsTotal:    jsonCount(repo.OpenPRsTotal),
+		Commits30d:      jsonCount(repo.Commits30d),
+		Commits1y:       jsonCount(repo.Commits1y),
+		CommitsTotal:    jsonCount(repo.CommitsTotal),
+		Topics:          nonNilSlice(repo.Topics),
+		Languages:       repo.Languages,
+		Contributors:    nonNilSlice(repo.Contributors),
+		LicenseName:     repo.LicenseName,
+		LicenseSPDXID:   repo.LicenseSPDXID,
+		ContentHash:     repo.ContentHash,
+		ContentLanguage: repo.ContentLanguage,
+		ReadmePath:      repo.ReadmePath,
+		ReadmeFormat:    repo.ReadmeFormat,
+		Purpose:         repo.Purpose,
+		SummaryVersion:  repo.SummaryVersion,
+		HasEmbedding:    len(repo.RepoEmbedding) > 0,
+		Keywords:        nonNilSlice(repo.Keywords),
+		Tags:            nonNilSlice(repo.Tags),
+	}
+
+	if out.Languages == nil {
+		out.Languages = map[string]int64{}
+	}
+
+	if repo.SummaryGeneratedAt != nil {
+		generatedAt := formatJSONTime(*repo.SummaryGeneratedAt)
+		out.SummaryGeneratedAt = &generatedAt
+	}
+
+	return out
+}
+
+// newJSONResult converts a search result to its JSON shape
+func newJSONResult(result query.Result) jsonResult {
+	return jsonResult{
+		jsonRepository: newJSONRepository(result.Repository),
+		Score:          result.Score,
+		Rank:           result.Rank,
+		MatchedFields:  nonNilSlice(result.MatchFields),
+	}
+}
+
+// formatJSONTime formats t as RFC3339 in UTC
+func formatJSONTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// jsonCount maps unknown (negative) counts to null
+func jsonCount(count int) *int {
+	if count < 0 {
+		return nil
+	}
+
+	return &count
+}
+
+// nonNilSlice returns an empty slice for nil so it encodes as [] rather than null
+func nonNilSlice[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
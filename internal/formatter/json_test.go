@@ -0,0 +1,112 @@
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/config"
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestFormatter_FormatResultJSON(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{})
+	created := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.FixedZone("EST", -5*60*60))
+
+	result := query.Result{
+		Score:       0.75,
+		Rank:        2,
+		MatchFields: []string{"description"},
+		Repository: storage.StoredRepo{
+			FullName:        "user/repo",
+			CreatedAt:       created,
+			OpenIssuesOpen:  storage.UnknownCount,
+			CommitsTotal:    12,
+			RepoEmbedding:   []float32{0.1},
+			ContentLanguage: "en",
+			ReadmeFormat:    "rst",
+			Archived:        true,
+		},
+	}
+
+	output := formatter.FormatResult(result, FormatJSON)
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("FormatResult() produced invalid JSON: %v\n%s", err, output)
+	}
+
+	expected := map[string]any{
+		"full_name":        "user/repo",
+		"url":              "https://github.com/user/repo",
+		"created_at":       "2023-01-02T08:04:05Z",
+		"open_issues_open": nil,
+		"commits_total":    float64(12),
+		"has_embedding":    true,
+		"content_language": "en",
+		"readme_format":    "rst",
+		"archived":         true,
+		"score":            0.75,
+		"rank":             float64(2),
+	}
+
+	for key, want := range expected {
+		got, ok := decoded[key]
+		if !ok {
+			t.Errorf("missing key %q", key)
+			continue
+		}
+
+		if got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+
+	// Empty collections are always present and never null
+	for _, key := range []string{"topics", "contributors", "keywords", "tags"} {
+		if items, ok := decoded[key].([]any); !ok || len(items) != 0 {
+			t.Errorf("%s = %v, want []", key, decoded[key])
+		}
+	}
+
+	if languages, ok := decoded["languages"].(map[string]any); !ok || len(languages) != 0 {
+		t.Errorf("languages = %v, want {}", decoded["languages"])
+	}
+
+	if fields, ok := decoded["matched_fields"].([]any); !ok || len(fields) != 1 {
+		t.Errorf("matched_fields = %v, want [description]", decoded["matched_fields"])
+	}
+
+	if _, ok := decoded["repo_embedding"]; ok {
+		t.Error("raw embedding should not be serialized")
+	}
+}
+
+func TestFormatter_FormatRepositoryJSON(t *testing.T) {
+	formatter := NewFormatter(config.FormatterConfig{})
+	generated := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	output := formatter.FormatRepository(storage.StoredRepo{
+		FullName:           "user/repo",
+		Topics:             []string{"cli"},
+		SummaryGeneratedAt: &generated,
+	}, FormatJSON)
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("FormatRepository() produced invalid JSON: %v\n%s", err, output)
+	}
+
+	if decoded["summary_generated_at"] != "2024-06-01T12:00:00Z" {
+		t.Errorf("summary_generated_at = %v", decoded["summary_generated_at"])
+	}
+
+	if _, ok := decoded["score"]; ok {
+		t.Error("repository JSON should not include a search score")
+	}
+
+	if topics, ok := decoded["topics"].([]any); !ok || len(topics) != 1 || topics[0] != "cli" {
+		t.Errorf("topics = %v, want [cli]", decoded["topics"])
+	}
+}
@@ -0,0 +1,93 @@
+package formatter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/query"
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// TemplateExt is the file extension of named templates in the templates directory
+const TemplateExt = ".tmpl"
+
+// TemplateData is the value passed to report templates
+type TemplateData struct {
+	Query        string               // Search query; empty for list output
+	Results      []query.Result       // Search results with scores; empty for list output
+	Repositories []storage.StoredRepo // Repositories in display order
+	GeneratedAt  time.Time
+}
+
+// markdownEscaper escapes characters that change the meaning of inline markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+// TemplateFuncs returns the helpers available to report templates
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"mdEscape": markdownEscaper.Replace,
+		"truncate": TruncateRunes,
+		"repoURL":  func(fullName string) string { return "https://github.com/" + fullName },
+		"join":     func(sep string, items []string) string { return strings.Join(items, sep) },
+	}
+}
+
+// TruncateRunes shortens s to at most n runes, ending with "..." when cut
+func TruncateRunes(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	if n <= 3 {
+		return string(runes[:n])
+	}
+
+	return string(runes[:n-3]) + "..."
+}
+
+// ResolveTemplatePath returns the file for nameOrPath: an existing file path, or
+// a template name looked up in templatesDir with or without the .tmpl extension
+func ResolveTemplatePath(nameOrPath, templatesDir string) (string, error) {
+	if info, err := os.Stat(nameOrPath); err == nil && !info.IsDir() {
+		return nameOrPath, nil
+	}
+
+	if templatesDir != "" && !strings.ContainsRune(nameOrPath, os.PathSeparator) {
+		for _, candidate := range []string{nameOrPath, nameOrPath + TemplateExt} {
+			path := filepath.Join(templatesDir, candidate)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("template %q not found (checked path and %s)", nameOrPath, templatesDir)
+}
+
+// LoadTemplate resolves and parses a report template
+func LoadTemplate(nameOrPath, templatesDir string) (*template.Template, error) {
+	path, err := ResolveTemplatePath(nameOrPath, templatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs()).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
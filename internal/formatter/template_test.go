@@ -0,0 +1,82 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	funcs := TemplateFuncs()
+
+	mdEscape := funcs["mdEscape"].(func(string) string)
+	assert.Equal(t, `fast \*and\* \[small\] \| cli`, mdEscape("fast *and* [small] | cli"))
+
+	truncate := funcs["truncate"].(func(int, string) string)
+	assert.Equal(t, "short", truncate(10, "short"))
+	assert.Equal(t, "a long...", truncate(9, "a long description"))
+	assert.Equal(t, "héll...", truncate(7, "héllo wörld"))
+
+	repoURL := funcs["repoURL"].(func(string) string)
+	assert.Equal(t, "https://github.com/owner/repo", repoURL("owner/repo"))
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatesDir := filepath.Join(dir, "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0o755))
+
+	awesome := `{{range .Repositories}}- [{{.FullName}}]({{repoURL .FullName}}) - {{.Description | truncate 18 | mdEscape}}{{"\n"}}{{end}}`
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "awesome.tmpl"), []byte(awesome), 0o600))
+
+	explicitPath := filepath.Join(dir, "topics.txt")
+	require.NoError(t, os.WriteFile(explicitPath, []byte(`{{range .Repositories}}{{join ", " .Topics}}{{end}}`), 0o600))
+
+	data := TemplateData{
+		Repositories: []storage.StoredRepo{
+			{FullName: "owner/repo", Description: "A *fast* parser for everything", Topics: []string{"go", "cli"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		nameOrPath string
+		expected   string
+	}{
+		{"name with extension", "awesome.tmpl", "- [owner/repo](https://github.com/owner/repo) - A \\*fast\\* parser...\n"},
+		{"name without extension", "awesome", "- [owner/repo](https://github.com/owner/repo) - A \\*fast\\* parser...\n"},
+		{"explicit path", explicitPath, "go, cli"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := LoadTemplate(tt.nameOrPath, templatesDir)
+			require.NoError(t, err)
+
+			var out strings.Builder
+			require.NoError(t, tmpl.Execute(&out, data))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+
+	t.Run("missing template", func(t *testing.T) {
+		_, err := LoadTemplate("weekly-digest", templatesDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		broken := filepath.Join(dir, "broken.tmpl")
+		require.NoError(t, os.WriteFile(broken, []byte("{{range .Repositories}"), 0o600))
+
+		_, err := LoadTemplate(broken, templatesDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse template")
+	})
+}
@@ -0,0 +1,240 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrBudgetExhausted is returned instead of making a request once a Budget is spent
+var ErrBudgetExhausted = errors.New("network budget exhausted")
+
+// Budget is a hard cap on the requests and bytes a sync may spend, shared by all
+// workers. It is separate from rate-limit backoff: once spent, no new fetch starts.
+// Requests in flight when the byte cap is crossed still complete, so the byte cap
+// can be overshot by at most one response per worker.
+type Budget struct {
+	maxRequests int64 // 0 means unlimited
+	maxBytes    int64 // 0 means unlimited
+	requests    atomic.Int64
+	bytes       atomic.Int64
+}
+
+// NewBudget creates a budget; a zero limit leaves that dimension unlimited
+func NewBudget(maxRequests, maxBytes int64) *Budget {
+	return &Budget{maxRequests: maxRequests, maxBytes: maxBytes}
+}
+
+// Exhausted reports whether either limit has been reached
+func (b *Budget) Exhausted() bool {
+	return (b.maxRequests > 0 && b.requests.Load() >= b.maxRequests) ||
+		(b.maxBytes > 0 && b.bytes.Load() >= b.maxBytes)
+}
+
+// Requests returns the number of requests spent so far
+func (b *Budget) Requests() int64 {
+	return b.requests.Load()
+}
+
+// Bytes returns the number of content bytes received so far
+func (b *Budget) Bytes() int64 {
+	return b.bytes.Load()
+}
+
+// Reserve claims n requests, failing without claiming any if that would exceed
+// the cap. Clients created WithBudget reserve one per HTTP request; a Client
+// that does not go over HTTP, like a test double, reserves for itself.
+func (b *Budget) Reserve(n int64) error {
+	if b.maxBytes > 0 && b.bytes.Load() >= b.maxBytes {
+		return ErrBudgetExhausted
+	}
+
+	for {
+		current := b.requests.Load()
+		if b.maxRequests > 0 && current+n > b.maxRequests {
+			return ErrBudgetExhausted
+		}
+
+		if b.requests.CompareAndSwap(current, current+n) {
+			return nil
+		}
+	}
+}
+
+// spend records n bytes received
+func (b *Budget) spend(n int) {
+	b.bytes.Add(int64(n))
+}
+
+// check fails once either limit has been reached
+func (b *Budget) check() error {
+	if b.Exhausted() {
+		return ErrBudgetExhausted
+	}
+
+	return nil
+}
+
+// WithBudget draws every HTTP request the client sends from budget: each page
+// of a listing, each retry and each homepage fetch counts as one request.
+// Requests past the cap fail with ErrBudgetExhausted without being sent.
+func WithBudget(budget *Budget) ClientOption {
+	return func(o *clientOptions) {
+		o.budget = budget
+	}
+}
+
+// budgetTransport reserves one request from a Budget before each round trip
+type budgetTransport struct {
+	next   http.RoundTripper
+	budget *Budget
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The quota endpoint never counts against the rate limit, so it is free here too
+	if !strings.HasSuffix(req.URL.Path, "/rate_limit") {
+		if err := t.budget.Reserve(1); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// BudgetClient wraps a GitHub client so no call starts once a shared Budget is
+// spent. Requests are counted by the wrapped client, created WithBudget, one per
+// HTTP request. Bytes are measured here on file content and homepage text, the
+// only responses whose size depends on the repository rather than the API.
+type BudgetClient struct {
+	client Client
+	budget *Budget
+}
+
+// NewBudgetClient creates a client that stops making requests once budget is spent
+func NewBudgetClient(client Client, budget *Budget) *BudgetClient {
+	return &BudgetClient{client: client, budget: budget}
+}
+
+// GetStarredRepos fetches starred repositories within the budget
+func (c *BudgetClient) GetStarredRepos(ctx context.Context, username string) ([]Repository, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetStarredRepos(ctx, username)
+}
+
+// GetRepository fetches a single repository within the budget
+func (c *BudgetClient) GetRepository(ctx context.Context, fullName string) (*Repository, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetRepository(ctx, fullName)
+}
+
+// GetRepositoryContent fetches file contents within the budget
+func (c *BudgetClient) GetRepositoryContent(
+	ctx context.Context,
+	repo Repository,
+	paths []string,
+) ([]Content, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	contents, err := c.client.GetRepositoryContent(ctx, repo, paths)
+	for _, content := range contents {
+		c.budget.spend(len(content.Content))
+	}
+
+	return contents, err
+}
+
+// GetRepositoryMetadata fetches repository metadata within the budget
+func (c *BudgetClient) GetRepositoryMetadata(ctx context.Context, repo Repository) (*Metadata, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetRepositoryMetadata(ctx, repo)
+}
+
+// GetContributors fetches top contributors within the budget
+func (c *BudgetClient) GetContributors(ctx context.Context, fullName string, topN int) ([]Contributor, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetContributors(ctx, fullName, topN)
+}
+
+// GetTopics fetches topics within the budget
+func (c *BudgetClient) GetTopics(ctx context.Context, fullName string) ([]string, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetTopics(ctx, fullName)
+}
+
+// GetLanguages fetches language statistics within the budget
+func (c *BudgetClient) GetLanguages(ctx context.Context, fullName string) (map[string]int64, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetLanguages(ctx, fullName)
+}
+
+// GetCommitActivity fetches commit activity within the budget
+func (c *BudgetClient) GetCommitActivity(ctx context.Context, fullName string) (*CommitActivity, error) {
+	if err := c.budget.check(); err != nil {
+		return nil, err
+	}
+
+	return c.client.GetCommitActivity(ctx, fullName)
+}
+
+// GetPullCounts fetches pull request counts within the budget
+func (c *BudgetClient) GetPullCounts(ctx context.Context, fullName string) (int, int, error) {
+	if err := c.budget.check(); err != nil {
+		return 0, 0, err
+	}
+
+	return c.client.GetPullCounts(ctx, fullName)
+}
+
+// GetIssueCounts fetches issue counts within the budget
+func (c *BudgetClient) GetIssueCounts(ctx context.Context, fullName string) (int, int, error) {
+	if err := c.budget.check(); err != nil {
+		return 0, 0, err
+	}
+
+	return c.client.GetIssueCounts(ctx, fullName)
+}
+
+// GetHomepageText fetches homepage text within the budget
+func (c *BudgetClient) GetHomepageText(ctx context.Context, url string) (string, error) {
+	if err := c.budget.check(); err != nil {
+		return "", err
+	}
+
+	text, err := c.client.GetHomepageText(ctx, url)
+	c.budget.spend(len(text))
+
+	return text, err
+}
+
+// GetRateLimit delegates to the wrapped client; quota checks are free and not counted
+func (c *BudgetClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	reporter, ok := c.client.(RateLimitReporter)
+	if !ok {
+		return nil, errRateLimitUnsupported
+	}
+
+	return reporter.GetRateLimit(ctx)
+}
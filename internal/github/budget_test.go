@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetStubClient answers the calls BudgetClient tests make and counts them.
+// It reserves one request per call and per content path, as the transport of a
+// client created WithBudget would.
+type budgetStubClient struct {
+	Client
+	budget *Budget
+	calls  atomic.Int64
+}
+
+func (c *budgetStubClient) GetTopics(_ context.Context, _ string) ([]string, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return nil, err
+	}
+
+	c.calls.Add(1)
+
+	return []string{"go"}, nil
+}
+
+func (c *budgetStubClient) GetRepositoryContent(_ context.Context, _ Repository, paths []string) ([]Content, error) {
+	c.calls.Add(1)
+
+	contents := make([]Content, 0, len(paths))
+	for _, path := range paths {
+		if err := c.budget.Reserve(1); err != nil {
+			return contents, err
+		}
+
+		contents = append(contents, Content{Path: path, Content: strings.Repeat("x", 100)})
+	}
+
+	return contents, nil
+}
+
+func (c *budgetStubClient) GetHomepageText(_ context.Context, _ string) (string, error) {
+	if err := c.budget.Reserve(1); err != nil {
+		return "", err
+	}
+
+	c.calls.Add(1)
+
+	return strings.Repeat("x", 50), nil
+}
+
+func TestBudgetClient_MaxRequests(t *testing.T) {
+	budget := NewBudget(3, 0)
+	stub := &budgetStubClient{budget: budget}
+	client := NewBudgetClient(stub, budget)
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := client.GetTopics(ctx, "user/repo")
+		require.NoError(t, err)
+	}
+
+	_, err := client.GetTopics(ctx, "user/repo")
+	require.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.True(t, budget.Exhausted())
+	assert.Equal(t, int64(3), stub.calls.Load(), "no request should be made once the budget is spent")
+}
+
+func TestBudgetClient_MaxBytes(t *testing.T) {
+	budget := NewBudget(0, 120)
+	stub := &budgetStubClient{budget: budget}
+	client := NewBudgetClient(stub, budget)
+	ctx := context.Background()
+
+	_, err := client.GetRepositoryContent(ctx, Repository{}, []string{"README.md"})
+	require.NoError(t, err)
+
+	// Under the cap, so the next fetch may start and overshoot it
+	_, err = client.GetHomepageText(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), budget.Bytes())
+
+	_, err = client.GetHomepageText(ctx, "https://example.com")
+	require.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.Equal(t, int64(2), stub.calls.Load())
+}
+
+func TestBudgetClient_ConcurrentWorkers(t *testing.T) {
+	budget := NewBudget(25, 0)
+	stub := &budgetStubClient{budget: budget}
+	client := NewBudgetClient(stub, budget)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 10 {
+				_, _ = client.GetTopics(context.Background(), "user/repo")
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(25), stub.calls.Load())
+	assert.Equal(t, int64(25), budget.Requests())
+}
+
+// newBudgetTestServer serves three one-repository pages of stars, then an empty
+// one, and topics that fail once before succeeding. It counts every request.
+func newBudgetTestServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var requests, topicCalls atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		switch {
+		case r.URL.Path == "/rate_limit":
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/user/starred" && page <= 3:
+			fmt.Fprintf(w, `[{"full_name": "user/repo-%d"}]`, page)
+		case r.URL.Path == "/user/starred":
+			fmt.Fprint(w, `[]`)
+		case topicCalls.Add(1) == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"names": ["go"]}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+// newBudgetTestClient returns a REST client for server drawing from budget
+func newBudgetTestClient(server *httptest.Server, budget *Budget) *clientImpl {
+	rest := &httpRESTClient{
+		client:  &http.Client{Transport: clientOptions{budget: budget}.transport(http.DefaultTransport)},
+		baseURL: server.URL,
+	}
+
+	return &clientImpl{apiClient: rest, retryAttempts: 1}
+}
+
+func TestWithBudget_CountsEveryHTTPRequest(t *testing.T) {
+	t.Setenv("GH_STAR_SEARCH_TEST_PER_PAGE", "1")
+
+	server, requests := newBudgetTestServer(t)
+	budget := NewBudget(0, 0)
+	client := newBudgetTestClient(server, budget)
+	ctx := context.Background()
+
+	repos, err := client.GetStarredRepos(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, repos, 3)
+	assert.Equal(t, int64(4), budget.Requests(), "each page of stars is a request")
+
+	topics, err := client.GetTopics(ctx, "user/repo-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, topics)
+	assert.Equal(t, int64(6), budget.Requests(), "a retry is a request")
+
+	var quota struct{}
+	require.NoError(t, client.apiClient.Get("rate_limit", &quota))
+	assert.Equal(t, int64(6), budget.Requests(), "quota checks are free")
+	assert.Equal(t, int64(7), requests.Load())
+}
+
+func TestWithBudget_StopsMidListing(t *testing.T) {
+	t.Setenv("GH_STAR_SEARCH_TEST_PER_PAGE", "1")
+
+	server, requests := newBudgetTestServer(t)
+	budget := NewBudget(2, 0)
+	client := newBudgetTestClient(server, budget)
+
+	_, err := client.GetStarredRepos(context.Background(), "")
+	require.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.Equal(t, int64(2), requests.Load(), "the third page is not requested")
+	assert.Equal(t, int64(2), budget.Requests())
+}
@@ -37,7 +37,7 @@ type CacheEntry struct {
 
 // GetStarredRepos fetches starred repositories with caching
 func (c *CachedClient) GetStarredRepos(ctx context.Context, username string) ([]Repository, error) {
-	cacheKey := "starred_repos:" + username
+	cacheKey := "starred_repos_v2:" + username // v2 entries include StarredAt
 	ttl := time.Duration(c.config.Cache.MetadataStaleDays) * 24 * time.Hour
 
 	// Try to get from cache first
@@ -59,6 +59,17 @@ func (c *CachedClient) GetStarredRepos(ctx context.Context, username string) ([]
 	return repos, nil
 }
 
+// GetRepository fetches a single repository without caching, so targeted syncs
+// always see current metadata
+func (c *CachedClient) GetRepository(ctx context.Context, fullName string) (*Repository, error) {
+	repo, err := c.client.GetRepository(ctx, fullName)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrTypeGitHubAPI, "failed to get repository")
+	}
+
+	return repo, nil
+}
+
 // GetRepositoryContent fetches repository content with caching
 func (c *CachedClient) GetRepositoryContent(
 	ctx context.Context,
@@ -108,6 +119,11 @@ func (c *CachedClient) GetRepositoryMetadata(
 		return nil, err
 	}
 
+	// Don't cache partial results so the failed parts are retried next time
+	if metadata.Partial() {
+		return metadata, nil
+	}
+
 	// Cache the result
 	c.setCachedData(ctx, cacheKey, metadata, ttl, "metadata")
 
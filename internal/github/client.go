@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -14,6 +15,8 @@ import (
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
 	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
 )
 
 // Client defines the interface for GitHub API operations.
@@ -25,6 +28,9 @@ type Client interface {
 	// The username parameter is currently unused but reserved for future use.
 	GetStarredRepos(ctx context.Context, username string) ([]Repository, error)
 
+	// GetRepository fetches a single repository by its "owner/name" full name.
+	GetRepository(ctx context.Context, fullName string) (*Repository, error)
+
 	// GetRepositoryContent fetches specific file contents from a repository.
 	// It accepts a list of file paths and returns the content for files that exist.
 	// Missing files are silently skipped rather than causing an error.
@@ -33,7 +39,7 @@ type Client interface {
 	// GetRepositoryMetadata fetches additional metadata for a repository including
 	// commit count, contributors, and release information.
 	// Partial failures are handled gracefully - if some metadata cannot be fetched,
-	// the available data is still returned.
+	// the available data is still returned and the matching Fetched flag is false.
 	GetRepositoryMetadata(ctx context.Context, repo Repository) (*Metadata, error)
 
 	// GetContributors fetches the top N contributors for a repository.
@@ -62,6 +68,11 @@ type Client interface {
 	GetHomepageText(ctx context.Context, url string) (string, error)
 }
 
+// GraphQLClientInterface defines the interface for GraphQL API operations
+type GraphQLClientInterface interface {
+	DoWithContext(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error
+}
+
 // RESTClientInterface defines the interface for REST API operations
 type RESTClientInterface interface {
 	Get(path string, resp interface{}) error
@@ -69,6 +80,7 @@ type RESTClientInterface interface {
 
 // Repository represents a GitHub repository with essential metadata
 type Repository struct {
+	ID              int64     `json:"id"`
 	FullName        string    `json:"full_name"`
 	Description     string    `json:"description"`
 	Homepage        string    `json:"homepage"`
@@ -88,6 +100,23 @@ type Repository struct {
 	Disabled        bool      `json:"disabled"`
 	Private         bool      `json:"private"`
 	Fork            bool      `json:"fork"`
+
+	// StarredAt is when the authenticated user starred the repository. It is
+	// only set by GetStarredRepos; zero elsewhere.
+	StarredAt time.Time `json:"starred_at,omitzero"`
+
+	// Prefetched holds metrics fetched alongside the repository by GraphQL, so
+	// they need not be fetched again per repository; nil when fetched over REST
+	Prefetched *PrefetchedMetrics `json:"prefetched,omitempty"`
+}
+
+// PrefetchedMetrics are repository metrics returned by the GraphQL starred query
+type PrefetchedMetrics struct {
+	Languages   map[string]int64 `json:"languages"`
+	OpenIssues  int              `json:"open_issues"`
+	TotalIssues int              `json:"total_issues"`
+	OpenPRs     int              `json:"open_prs"`
+	TotalPRs    int              `json:"total_prs"`
 }
 
 // License represents repository license information
@@ -108,13 +137,24 @@ type Content struct {
 	SHA      string `json:"sha"`
 }
 
-// Metadata represents additional repository metadata
+// Metadata represents additional repository metadata.
+// The Fetched flags report which sub-fetches succeeded, so a zero CommitCount
+// can be told apart from a commit count that could not be fetched.
 type Metadata struct {
 	CommitCount    int       `json:"commit_count"`
 	Contributors   []string  `json:"contributors"`
 	LastCommitDate time.Time `json:"last_commit_date"`
 	ReleaseCount   int       `json:"release_count"`
 	LatestRelease  *Release  `json:"latest_release"`
+
+	CommitCountFetched  bool `json:"commit_count_fetched"`
+	ContributorsFetched bool `json:"contributors_fetched"`
+	ReleasesFetched     bool `json:"releases_fetched"`
+}
+
+// Partial reports whether any metadata sub-fetch failed
+func (m *Metadata) Partial() bool {
+	return !m.CommitCountFetched || !m.ContributorsFetched || !m.ReleasesFetched
 }
 
 // Release represents a GitHub release
@@ -142,10 +182,47 @@ type CommitActivity struct {
 
 // WeeklyCommits represents commit counts for a specific week
 type WeeklyCommits struct {
-	Week    int64 `json:"w"` // Unix timestamp for the start of the week
-	Commits int   `json:"c"` // Number of commits
-	Adds    int   `json:"a"` // Lines added
-	Deletes int   `json:"d"` // Lines deleted
+	Week    int64 `json:"w"`              // Unix timestamp for the start of the week
+	Commits int   `json:"c"`              // Number of commits
+	Adds    int   `json:"a"`              // Lines added
+	Deletes int   `json:"d"`              // Lines deleted
+	Days    []int `json:"days,omitempty"` // Commits per day, Sunday first; empty when unknown
+}
+
+// commitActivityWeek is one week as returned by the stats/commit_activity endpoint
+type commitActivityWeek struct {
+	Week  int64 `json:"week"`
+	Total int   `json:"total"`
+	Days  []int `json:"days"`
+}
+
+// CommitsSince sums the commits made at or after since. Weeks with a daily
+// breakdown are counted per day; others count in full when the week starts at
+// or after since.
+func (a *CommitActivity) CommitsSince(since time.Time) int {
+	const day = 24 * time.Hour
+
+	total := 0
+
+	for _, week := range a.Weeks {
+		start := time.Unix(week.Week, 0)
+
+		if len(week.Days) == 0 {
+			if !start.Before(since) {
+				total += week.Commits
+			}
+
+			continue
+		}
+
+		for i, commits := range week.Days {
+			if !start.Add(time.Duration(i) * day).Before(since) {
+				total += commits
+			}
+		}
+	}
+
+	return total
 }
 
 // SearchResult represents a search result from GitHub API
@@ -157,7 +234,13 @@ type SearchResult struct {
 
 // clientImpl implements the Client interface using go-gh
 type clientImpl struct {
-	apiClient RESTClientInterface
+	apiClient     RESTClientInterface
+	starred       RESTClientInterface    // Lists stars with starred_at (starredMediaType); apiClient when nil
+	graphQL       GraphQLClientInterface // Set when WithGraphQL is used
+	etags         *etagStore             // Set when conditional requests are enabled
+	retryAttempts int                    // Retries for server errors and rate limits; see get
+	searchLimit   *searchLimiter         // Paces search API requests; nil does not
+	budget        *Budget                // Draws every HTTP request, homepages included; nil does not
 }
 
 // getPerPageWithOverride returns perPage with test override if available
@@ -171,20 +254,148 @@ func (c *clientImpl) getPerPageWithOverride(defaultPerPage int, envVar string) i
 	return defaultPerPage
 }
 
+// testMaxPages returns the test-only cap on starred pages, or 0 for no cap
+func testMaxPages() int {
+	if testMaxPages := os.Getenv("GH_STAR_SEARCH_TEST_MAX_PAGES"); testMaxPages != "" {
+		if val, err := strconv.Atoi(testMaxPages); err == nil && val > 0 {
+			return val
+		}
+	}
+
+	return 0
+}
+
+// ClientOption configures a client created by NewClient or NewClientWithETagCache
+type ClientOption func(*clientOptions)
+
+// clientOptions collects ClientOption settings
+type clientOptions struct {
+	graphQL         bool
+	retryAttempts   int
+	searchPerMinute int
+	budget          *Budget
+}
+
+// newClientOptions applies opts over the defaults
+func newClientOptions(opts []ClientOption) clientOptions {
+	o := clientOptions{retryAttempts: DefaultRetryAttempts, searchPerMinute: DefaultSearchRequestsPerMinute}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// transport returns next, drawing every request from the budget when one is set
+func (o clientOptions) transport(next http.RoundTripper) http.RoundTripper {
+	if o.budget == nil {
+		return next
+	}
+
+	return &budgetTransport{next: next, budget: o.budget}
+}
+
+// WithGraphQL fetches starred repositories through GraphQL, 100 per query with
+// languages and issue/PR counts included, instead of paging REST and querying
+// those metrics per repository. REST remains the fallback on GraphQL errors.
+func WithGraphQL() ClientOption {
+	return func(o *clientOptions) {
+		o.graphQL = true
+	}
+}
+
 // NewClient creates a new GitHub client using existing GitHub CLI authentication
-func NewClient() (Client, error) {
-	client, err := api.DefaultRESTClient()
+func NewClient(opts ...ClientOption) (Client, error) {
+	o := newClientOptions(opts)
+	apiOpts := api.ClientOptions{Transport: o.transport(http.DefaultTransport)}
+
+	client, err := api.NewRESTClient(apiOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
 	}
 
-	return &clientImpl{
+	impl := &clientImpl{
 		apiClient: client,
-	}, nil
+	}
+
+	if err := impl.applyOptions(apiOpts, o); err != nil {
+		return nil, err
+	}
+
+	return impl, nil
+}
+
+// starredMediaType makes the starred REST endpoint include when each
+// repository was starred, wrapping each one as {"starred_at", "repo"}
+const starredMediaType = "application/vnd.github.star+json"
+
+// starredRepository is an item of the starred endpoint under starredMediaType
+type starredRepository struct {
+	StarredAt time.Time  `json:"starred_at"`
+	Repo      Repository `json:"repo"`
+}
+
+// applyOptions configures the starred-list and optional GraphQL clients from o
+func (c *clientImpl) applyOptions(apiOpts api.ClientOptions, o clientOptions) error {
+	c.retryAttempts = o.retryAttempts
+	c.budget = o.budget
+
+	if o.searchPerMinute > 0 {
+		c.searchLimit = newSearchLimiter(o.searchPerMinute)
+	}
+
+	starOpts := apiOpts
+	starOpts.Headers = map[string]string{"Accept": starredMediaType}
+
+	starred, err := api.NewRESTClient(starOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	c.starred = starred
+	if c.etags != nil {
+		c.starred = &etagRESTClient{client: starred, store: c.etags}
+	}
+
+	if o.graphQL {
+		graphQL, err := api.NewGraphQLClient(apiOpts)
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub GraphQL client: %w", err)
+		}
+
+		c.graphQL = graphQL
+	}
+
+	return nil
 }
 
-// GetStarredRepos fetches all starred repositories for the authenticated user
+// GetStarredRepos fetches all starred repositories for the authenticated user,
+// using GraphQL when enabled and falling back to REST if the query fails
 func (c *clientImpl) GetStarredRepos(ctx context.Context, _ string) ([]Repository, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
+	if c.graphQL != nil {
+		repos, err := c.getStarredReposGraphQL(ctx)
+		if err == nil {
+			return repos, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if errors.Is(err, ErrBudgetExhausted) {
+			return nil, err
+		}
+
+		slog.Warn("GraphQL starred fetch failed, falling back to REST", slog.String("error", err.Error()))
+	}
+
+	return c.getStarredReposREST(ctx)
+}
+
+// getStarredReposREST pages through the starred REST endpoint
+func (c *clientImpl) getStarredReposREST(ctx context.Context) ([]Repository, error) {
 	var allRepos []Repository
 
 	page := 1
@@ -193,14 +404,7 @@ func (c *clientImpl) GetStarredRepos(ctx context.Context, _ string) ([]Repositor
 		50,
 		"GH_STAR_SEARCH_TEST_PER_PAGE",
 	) // Default for testing to limit recorded data
-	maxPages := 0 // Default limit pages for testing to prevent timeouts
-
-	// Check for test maxPages override
-	if testMaxPages := os.Getenv("GH_STAR_SEARCH_TEST_MAX_PAGES"); testMaxPages != "" {
-		if val, err := strconv.Atoi(testMaxPages); err == nil && val > 0 {
-			maxPages = val
-		}
-	}
+	maxPages := testMaxPages() // Limit pages for testing to prevent timeouts
 
 	for {
 		select {
@@ -209,12 +413,7 @@ func (c *clientImpl) GetStarredRepos(ctx context.Context, _ string) ([]Repositor
 		default:
 		}
 
-		var repos []Repository
-
-		err := c.apiClient.Get(
-			fmt.Sprintf("user/starred?page=%d&per_page=%d", page, perPage),
-			&repos,
-		)
+		repos, err := c.getStarredPage(ctx, fmt.Sprintf("user/starred?page=%d&per_page=%d", page, perPage))
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch starred repositories (page %d): %w", page, err)
 		}
@@ -245,12 +444,57 @@ func (c *clientImpl) GetStarredRepos(ctx context.Context, _ string) ([]Repositor
 	return allRepos, nil
 }
 
+// getStarredPage fetches one page of the starred REST endpoint, with StarredAt
+// set when the starred-list client is configured
+func (c *clientImpl) getStarredPage(ctx context.Context, path string) ([]Repository, error) {
+	if c.starred == nil {
+		var repos []Repository
+		err := c.get(ctx, path, &repos)
+
+		return repos, err
+	}
+
+	var stars []starredRepository
+	if err := c.getWith(ctx, c.starred, path, &stars); err != nil {
+		return nil, err
+	}
+
+	repos := make([]Repository, len(stars))
+	for i, star := range stars {
+		repos[i] = star.Repo
+		repos[i].StarredAt = star.StarredAt
+	}
+
+	return repos, nil
+}
+
+// GetRepository fetches a single repository by full name
+func (c *clientImpl) GetRepository(ctx context.Context, fullName string) (*Repository, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var repo Repository
+
+	if err := c.get(ctx, "repos/"+fullName, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository %s: %w", fullName, err)
+	}
+
+	return &repo, nil
+}
+
 // GetRepositoryContent fetches specific file contents from a repository
 func (c *clientImpl) GetRepositoryContent(
 	ctx context.Context,
 	repo Repository,
 	paths []string,
 ) ([]Content, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	contents := make([]Content, 0, len(paths))
 
 	for _, path := range paths {
@@ -262,7 +506,7 @@ func (c *clientImpl) GetRepositoryContent(
 
 		var content Content
 
-		err := c.apiClient.Get(fmt.Sprintf("repos/%s/contents/%s", repo.FullName, path), &content)
+		err := c.get(ctx, fmt.Sprintf("repos/%s/contents/%s", repo.FullName, path), &content)
 		if err != nil {
 			// If file doesn't exist, skip it rather than failing
 			var httpErr *api.HTTPError
@@ -292,18 +536,24 @@ func (c *clientImpl) GetRepositoryMetadata(
 	ctx context.Context,
 	repo Repository,
 ) (*Metadata, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	metadata := &Metadata{}
 
 	// Fetch commit count from the default branch
 	if err := c.fetchCommitCount(ctx, repo, metadata); err != nil {
 		// Don't fail completely if commit count fails
 		metadata.CommitCount = 0
+	} else {
+		metadata.CommitCountFetched = true
 	}
 
 	// Fetch contributors
 	if err := c.fetchContributors(ctx, repo, metadata); err != nil {
 		// Don't fail completely if contributors fails
 		metadata.Contributors = []string{}
+	} else {
+		metadata.ContributorsFetched = true
 	}
 
 	// Fetch latest release
@@ -311,6 +561,8 @@ func (c *clientImpl) GetRepositoryMetadata(
 		// Don't fail completely if release info fails
 		metadata.LatestRelease = nil
 		metadata.ReleaseCount = 0
+	} else {
+		metadata.ReleasesFetched = true
 	}
 
 	return metadata, nil
@@ -333,7 +585,8 @@ func (c *clientImpl) fetchCommitCount(
 
 	perPage := c.getPerPageWithOverride(1, "GH_STAR_SEARCH_TEST_COMMITS_PER_PAGE")
 
-	err := c.apiClient.Get(
+	err := c.get(
+		ctx,
 		fmt.Sprintf(
 			"repos/%s/commits?sha=%s&per_page=%d",
 			repo.FullName,
@@ -381,7 +634,8 @@ func (c *clientImpl) fetchContributors(
 
 	perPage := c.getPerPageWithOverride(10, "GH_STAR_SEARCH_TEST_CONTRIBUTORS_PER_PAGE")
 
-	err := c.apiClient.Get(
+	err := c.get(
+		ctx,
 		fmt.Sprintf("repos/%s/contributors?per_page=%d", repo.FullName, perPage),
 		&contributors,
 	)
@@ -417,7 +671,7 @@ func (c *clientImpl) fetchLatestRelease(
 	// First get the latest release
 	var release Release
 
-	err := c.apiClient.Get(fmt.Sprintf("repos/%s/releases/latest", repo.FullName), &release)
+	err := c.get(ctx, fmt.Sprintf("repos/%s/releases/latest", repo.FullName), &release)
 	if err != nil {
 		// If no releases exist, that's okay
 		var httpErr *api.HTTPError
@@ -435,7 +689,8 @@ func (c *clientImpl) fetchLatestRelease(
 
 	perPage := c.getPerPageWithOverride(1, "GH_STAR_SEARCH_TEST_RELEASES_PER_PAGE")
 
-	err = c.apiClient.Get(
+	err = c.get(
+		ctx,
 		fmt.Sprintf("repos/%s/releases?per_page=%d", repo.FullName, perPage),
 		&releases,
 	)
@@ -455,6 +710,8 @@ func (c *clientImpl) GetContributors(
 	fullName string,
 	topN int,
 ) ([]Contributor, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -465,7 +722,8 @@ func (c *clientImpl) GetContributors(
 
 	perPage := c.getPerPageWithOverride(topN, "GH_STAR_SEARCH_TEST_CONTRIBUTORS_PER_PAGE")
 
-	err := c.apiClient.Get(
+	err := c.get(
+		ctx,
 		fmt.Sprintf("repos/%s/contributors?per_page=%d", fullName, perPage),
 		&contributors,
 	)
@@ -478,6 +736,8 @@ func (c *clientImpl) GetContributors(
 
 // GetTopics fetches the topics associated with a repository
 func (c *clientImpl) GetTopics(ctx context.Context, fullName string) ([]string, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -488,7 +748,7 @@ func (c *clientImpl) GetTopics(ctx context.Context, fullName string) ([]string,
 		Names []string `json:"names"`
 	}
 
-	err := c.apiClient.Get(fmt.Sprintf("repos/%s/topics", fullName), &response)
+	err := c.get(ctx, fmt.Sprintf("repos/%s/topics", fullName), &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch topics for %s: %w", fullName, err)
 	}
@@ -498,6 +758,8 @@ func (c *clientImpl) GetTopics(ctx context.Context, fullName string) ([]string,
 
 // GetLanguages fetches the programming languages used in a repository
 func (c *clientImpl) GetLanguages(ctx context.Context, fullName string) (map[string]int64, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -506,7 +768,7 @@ func (c *clientImpl) GetLanguages(ctx context.Context, fullName string) (map[str
 
 	var languages map[string]int64
 
-	err := c.apiClient.Get(fmt.Sprintf("repos/%s/languages", fullName), &languages)
+	err := c.get(ctx, fmt.Sprintf("repos/%s/languages", fullName), &languages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch languages for %s: %w", fullName, err)
 	}
@@ -519,15 +781,17 @@ func (c *clientImpl) GetCommitActivity(
 	ctx context.Context,
 	fullName string,
 ) (*CommitActivity, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
-	var weeks []WeeklyCommits
+	var response []commitActivityWeek
 
-	err := c.apiClient.Get(fmt.Sprintf("repos/%s/stats/commit_activity", fullName), &weeks)
+	err := c.get(ctx, fmt.Sprintf("repos/%s/stats/commit_activity", fullName), &response)
 	if err != nil {
 		// Handle 202 Accepted response (stats being computed)
 		var httpErr *api.HTTPError
@@ -543,8 +807,11 @@ func (c *clientImpl) GetCommitActivity(
 
 	// Calculate total commits
 	total := 0
-	for _, week := range weeks {
-		total += week.Commits
+	weeks := make([]WeeklyCommits, 0, len(response))
+
+	for _, week := range response {
+		weeks = append(weeks, WeeklyCommits{Week: week.Week, Commits: week.Total, Days: week.Days})
+		total += week.Total
 	}
 
 	return &CommitActivity{
@@ -558,6 +825,8 @@ func (c *clientImpl) GetPullCounts(
 	ctx context.Context,
 	fullName string,
 ) (int, int, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return 0, 0, ctx.Err()
@@ -569,7 +838,8 @@ func (c *clientImpl) GetPullCounts(
 	// Get open PRs
 	var openResult SearchResult
 
-	err := c.apiClient.Get(
+	err := c.search(
+		ctx,
 		fmt.Sprintf("search/issues?q=repo:%s+type:pr+state:open&per_page=%d", fullName, perPage),
 		&openResult,
 	)
@@ -580,7 +850,8 @@ func (c *clientImpl) GetPullCounts(
 	// Get total PRs (open + closed)
 	var totalResult SearchResult
 
-	err = c.apiClient.Get(
+	err = c.search(
+		ctx,
 		fmt.Sprintf("search/issues?q=repo:%s+type:pr&per_page=%d", fullName, perPage),
 		&totalResult,
 	)
@@ -596,6 +867,8 @@ func (c *clientImpl) GetIssueCounts(
 	ctx context.Context,
 	fullName string,
 ) (int, int, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return 0, 0, ctx.Err()
@@ -607,7 +880,8 @@ func (c *clientImpl) GetIssueCounts(
 	// Get open issues (excluding PRs)
 	var openResult SearchResult
 
-	err := c.apiClient.Get(
+	err := c.search(
+		ctx,
 		fmt.Sprintf("search/issues?q=repo:%s+type:issue+state:open&per_page=%d", fullName, perPage),
 		&openResult,
 	)
@@ -618,7 +892,8 @@ func (c *clientImpl) GetIssueCounts(
 	// Get total issues (open + closed, excluding PRs)
 	var totalResult SearchResult
 
-	err = c.apiClient.Get(
+	err = c.search(
+		ctx,
 		fmt.Sprintf("search/issues?q=repo:%s+type:issue&per_page=%d", fullName, perPage),
 		&totalResult,
 	)
@@ -649,6 +924,8 @@ func stripHTMLTags(html string) string {
 
 // GetHomepageText fetches text content from an external homepage URL
 func (c *clientImpl) GetHomepageText(ctx context.Context, urlStr string) (string, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
 	select {
 	case <-ctx.Done():
 		return "", ctx.Err()
@@ -668,7 +945,8 @@ func (c *clientImpl) GetHomepageText(ctx context.Context, urlStr string) (string
 
 	// Create HTTP client with timeout
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: clientOptions{budget: c.budget}.transport(http.DefaultTransport),
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
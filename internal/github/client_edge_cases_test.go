@@ -204,6 +204,10 @@ func TestGetRepositoryMetadata_EmptyMetadata(t *testing.T) {
 	require.NoError(t, err, "should not error on missing metadata")
 	assert.NotNil(t, result)
 	assert.Equal(t, 0, result.CommitCount, "should have zero commit count when API fails")
+	assert.False(t, result.CommitCountFetched, "failed commit count should be flagged as unknown")
+	assert.False(t, result.ContributorsFetched)
+	assert.True(t, result.ReleasesFetched, "a missing release is not a failure")
+	assert.True(t, result.Partial())
 }
 
 func TestGetCommitActivity_EmptyRepository(t *testing.T) {
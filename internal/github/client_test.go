@@ -440,6 +440,9 @@ func TestGetRepositoryMetadata_Success(t *testing.T) {
 	if metadata.ReleaseCount != 1 {
 		t.Errorf("Expected release count 1, got: %d", metadata.ReleaseCount)
 	}
+	if metadata.Partial() {
+		t.Errorf("Expected all metadata to be fetched, got: %+v", metadata)
+	}
 }
 
 func TestGetRepositoryMetadata_NoReleases(t *testing.T) {
@@ -614,12 +617,13 @@ func TestGetCommitActivity_Success(t *testing.T) {
 	mockClient := newMockRESTClient()
 	client := &clientImpl{apiClient: mockClient}
 
-	expectedWeeks := []WeeklyCommits{
-		{Week: 1640995200, Commits: 10, Adds: 100, Deletes: 20},
-		{Week: 1641600000, Commits: 5, Adds: 50, Deletes: 10},
+	// GitHub's stats/commit_activity shape: one entry per week with daily counts
+	response := []commitActivityWeek{
+		{Week: 1640995200, Total: 10, Days: []int{0, 2, 3, 1, 4, 0, 0}},
+		{Week: 1641600000, Total: 5, Days: []int{1, 1, 1, 1, 1, 0, 0}},
 	}
 
-	mockClient.setResponse("repos/owner/repo/stats/commit_activity", expectedWeeks)
+	mockClient.setResponse("repos/owner/repo/stats/commit_activity", response)
 
 	ctx := context.Background()
 	activity, err := client.GetCommitActivity(ctx, "owner/repo")
@@ -638,6 +642,42 @@ func TestGetCommitActivity_Success(t *testing.T) {
 	if activity.Weeks[0].Commits != 10 {
 		t.Errorf("Expected first week commits 10, got: %d", activity.Weeks[0].Commits)
 	}
+
+	if activity.Weeks[1].Week != 1641600000 || len(activity.Weeks[1].Days) != 7 {
+		t.Errorf("Expected second week start and daily counts, got: %+v", activity.Weeks[1])
+	}
+}
+
+func TestCommitActivity_CommitsSince(t *testing.T) {
+	const day = 24 * 60 * 60
+
+	weekStart := int64(1700000000)
+	activity := &CommitActivity{
+		Weeks: []WeeklyCommits{
+			{Week: weekStart - 7*day, Commits: 20},
+			{Week: weekStart, Commits: 7, Days: []int{1, 1, 1, 1, 1, 1, 1}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		since int64
+		want  int
+	}{
+		{name: "before every week", since: weekStart - 30*day, want: 27},
+		{name: "start of the earlier week", since: weekStart - 7*day, want: 27},
+		{name: "inside the earlier week", since: weekStart - 3*day, want: 7},
+		{name: "mid-week counts days on or after", since: weekStart + 4*day, want: 3},
+		{name: "after every week", since: weekStart + 7*day, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activity.CommitsSince(time.Unix(tt.since, 0)); got != tt.want {
+				t.Errorf("CommitsSince() = %d, want %d", got, tt.want)
+			}
+		})
+	}
 }
 
 func TestGetCommitActivity_StatsComputing(t *testing.T) {
@@ -753,3 +793,73 @@ func TestGetHomepageText_Success(t *testing.T) {
 		t.Errorf("Expected unsupported scheme error, got: %s", err.Error())
 	}
 }
+
+func TestGetRateLimit(t *testing.T) {
+	mockClient := newMockRESTClient()
+	client := &clientImpl{apiClient: mockClient}
+
+	mockClient.setResponse("rate_limit", map[string]interface{}{
+		"resources": map[string]interface{}{
+			"core": map[string]interface{}{
+				"limit":     5000,
+				"remaining": 4321,
+				"reset":     1700000000,
+			},
+		},
+	})
+
+	rateLimit, err := client.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rateLimit.Limit != 5000 || rateLimit.Remaining != 4321 {
+		t.Errorf("Expected 4321/5000 remaining, got: %d/%d", rateLimit.Remaining, rateLimit.Limit)
+	}
+
+	if !rateLimit.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected reset at 1700000000, got: %v", rateLimit.Reset)
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	mockClient := newMockRESTClient()
+	client := &clientImpl{apiClient: mockClient}
+
+	mockClient.setResponse("repos/owner/repo", Repository{FullName: "owner/repo", StargazersCount: 42})
+
+	repo, err := client.GetRepository(context.Background(), "owner/repo")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if repo.FullName != "owner/repo" || repo.StargazersCount != 42 {
+		t.Errorf("Unexpected repository: %+v", repo)
+	}
+
+	if _, err := client.GetRepository(context.Background(), "owner/missing"); err == nil {
+		t.Error("Expected error for missing repository")
+	}
+}
+
+func TestIsStarred(t *testing.T) {
+	mockClient := newMockRESTClient()
+	client := &clientImpl{apiClient: mockClient}
+
+	mockClient.setError("user/starred/owner/starred", nil)
+	mockClient.setError("user/starred/owner/broken", &api.HTTPError{StatusCode: http.StatusForbidden})
+
+	starred, err := client.IsStarred(context.Background(), "owner/starred")
+	if err != nil || !starred {
+		t.Errorf("Expected owner/starred to be starred, got %v (error: %v)", starred, err)
+	}
+
+	starred, err = client.IsStarred(context.Background(), "owner/other")
+	if err != nil || starred {
+		t.Errorf("Expected a 404 to mean not starred, got %v (error: %v)", starred, err)
+	}
+
+	if _, err := client.IsStarred(context.Background(), "owner/broken"); err == nil {
+		t.Error("Expected an error for a 403")
+	}
+}
@@ -0,0 +1,199 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/KyleKing/gh-star-search/internal/cache"
+)
+
+// etagTTL is how long a stored ETag and response body are kept. GitHub ETags do
+// not expire, so this only bounds cache growth for repositories no longer starred.
+const etagTTL = 30 * 24 * time.Hour
+
+// NotModifiedReporter is implemented by clients that send conditional requests.
+// It is kept separate from Client so test doubles do not need to implement it.
+type NotModifiedReporter interface {
+	// NotModifiedCount returns how many responses were 304 Not Modified and served from cache
+	NotModifiedCount() int64
+}
+
+// etagEntry is the stored validator and body of a successful GET
+type etagEntry struct {
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
+// etagStore persists ETags and response bodies in the file cache, keyed by API path
+type etagStore struct {
+	cache       cache.Cache
+	notModified atomic.Int64
+}
+
+// etagCacheKey returns the cache key for an API request, ignoring the host and the
+// GitHub Enterprise /api/v3 prefix so transport URLs and client paths agree
+func etagCacheKey(u *url.URL) string {
+	key := "etag:" + strings.TrimPrefix(strings.TrimPrefix(u.Path, "/api/v3"), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+
+	return key
+}
+
+func (s *etagStore) load(ctx context.Context, u *url.URL) (*etagEntry, bool) {
+	data, err := s.cache.Get(ctx, etagCacheKey(u))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry etagEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.ETag == "" {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+func (s *etagStore) save(ctx context.Context, u *url.URL, entry etagEntry) {
+	if data, err := json.Marshal(entry); err == nil {
+		_ = s.cache.Set(ctx, etagCacheKey(u), data, etagTTL)
+	}
+}
+
+// etagTransport adds If-None-Match to GET requests with a stored ETag and records
+// the ETag and body of successful responses. A 304 is passed through unchanged
+// for etagRESTClient to resolve.
+type etagTransport struct {
+	next  http.RoundTripper
+	store *etagStore
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The quota endpoint changes on every call and never counts against it
+	if req.Method != http.MethodGet || strings.HasSuffix(req.URL.Path, "/rate_limit") {
+		return t.next.RoundTrip(req)
+	}
+
+	if entry, ok := t.store.load(req.Context(), req.URL); ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.store.save(req.Context(), req.URL, etagEntry{ETag: etag, Body: body})
+
+	return resp, nil
+}
+
+// etagRESTClient serves 304 Not Modified responses from the stored body. The REST
+// client reports any non-2xx status as an error, so a 304 arrives on the error path.
+type etagRESTClient struct {
+	client RESTClientInterface
+	store  *etagStore
+}
+
+// Get implements RESTClientInterface
+func (c *etagRESTClient) Get(path string, resp interface{}) error {
+	err := c.client.Get(path, resp)
+
+	var httpErr *api.HTTPError
+	if err == nil || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotModified {
+		return err
+	}
+
+	// Requests without a response body, like the 204 of a star check, need no stored body
+	if resp == nil {
+		c.store.notModified.Add(1)
+		return nil
+	}
+
+	u, parseErr := url.Parse(path)
+	if parseErr != nil {
+		return err
+	}
+
+	entry, ok := c.store.load(context.Background(), u)
+	if !ok {
+		return fmt.Errorf("not modified but no cached response for %s: %w", path, err)
+	}
+
+	c.store.notModified.Add(1)
+
+	return json.Unmarshal(entry.Body, resp)
+}
+
+// NewClientWithETagCache creates a GitHub client that sends conditional requests,
+// storing ETags and response bodies in store. Unchanged resources come back as
+// 304 Not Modified, which GitHub does not count against the rate limit.
+func NewClientWithETagCache(store cache.Cache, opts ...ClientOption) (Client, error) {
+	o := newClientOptions(opts)
+	etags := &etagStore{cache: store}
+
+	apiOpts := api.ClientOptions{
+		Transport: o.transport(&etagTransport{next: http.DefaultTransport, store: etags}),
+	}
+
+	client, err := api.NewRESTClient(apiOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	impl := &clientImpl{
+		apiClient: &etagRESTClient{client: client, store: etags},
+		etags:     etags,
+	}
+
+	if err := impl.applyOptions(apiOpts, o); err != nil {
+		return nil, err
+	}
+
+	return impl, nil
+}
+
+// NotModifiedCount returns how many responses were served from the ETag cache
+func (c *clientImpl) NotModifiedCount() int64 {
+	if c.etags == nil {
+		return 0
+	}
+
+	return c.etags.notModified.Load()
+}
+
+// NotModifiedCount delegates to the wrapped client
+func (c *BudgetClient) NotModifiedCount() int64 {
+	if reporter, ok := c.client.(NotModifiedReporter); ok {
+		return reporter.NotModifiedCount()
+	}
+
+	return 0
+}
@@ -0,0 +1,127 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/cache"
+)
+
+// httpRESTClient mimics the go-gh REST client: it issues GETs through an
+// http.Client and reports any non-2xx status as an *api.HTTPError
+type httpRESTClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+func (c *httpRESTClient) Get(path string, resp interface{}) error {
+	r, err := c.client.Get(c.baseURL + "/" + path)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		return &api.HTTPError{StatusCode: r.StatusCode, RequestURL: r.Request.URL, Headers: r.Header}
+	}
+
+	return json.NewDecoder(r.Body).Decode(resp)
+}
+
+func TestETagClient_ConditionalRequests(t *testing.T) {
+	var requests, conditional atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		etag := `"v1"`
+		if r.Header.Get("If-None-Match") == etag {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, `[{"full_name": "user/repo"}]`)
+	}))
+	defer server.Close()
+
+	fileCache, err := cache.NewFileCache(t.TempDir(), 10, time.Hour)
+	require.NoError(t, err)
+
+	// Two clients over one cache, as two syncs in separate processes would be
+	newClient := func() *clientImpl {
+		store := &etagStore{cache: fileCache}
+		rest := &httpRESTClient{
+			client:  &http.Client{Transport: &etagTransport{next: http.DefaultTransport, store: store}},
+			baseURL: server.URL,
+		}
+
+		return &clientImpl{apiClient: &etagRESTClient{client: rest, store: store}, etags: store}
+	}
+
+	first := newClient()
+
+	var repos []Repository
+	require.NoError(t, first.apiClient.Get("user/starred?page=1", &repos))
+	require.Len(t, repos, 1)
+	assert.Equal(t, int64(0), first.NotModifiedCount())
+
+	second := newClient()
+
+	repos = nil
+	require.NoError(t, second.apiClient.Get("user/starred?page=1", &repos))
+	require.Len(t, repos, 1, "a 304 should be answered from the cached body")
+	assert.Equal(t, "user/repo", repos[0].FullName)
+	assert.Equal(t, int64(1), second.NotModifiedCount())
+	assert.Equal(t, int64(2), requests.Load())
+	assert.Equal(t, int64(1), conditional.Load())
+
+	// A different query string is a different resource
+	repos = nil
+	require.NoError(t, second.apiClient.Get("user/starred?page=2", &repos))
+	assert.Equal(t, int64(1), conditional.Load())
+}
+
+func TestETagRESTClient_NotModifiedWithoutCache(t *testing.T) {
+	fileCache, err := cache.NewFileCache(t.TempDir(), 10, time.Hour)
+	require.NoError(t, err)
+
+	mock := newMockRESTClient()
+	mock.setError("repos/user/repo", &api.HTTPError{StatusCode: http.StatusNotModified})
+
+	client := &etagRESTClient{client: mock, store: &etagStore{cache: fileCache}}
+
+	var repo Repository
+	err = client.Get("repos/user/repo", &repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no cached response")
+}
+
+func TestETagCacheKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://api.github.com/user/starred?page=2&per_page=50", "etag:user/starred?page=2&per_page=50"},
+		{"https://ghe.example.com/api/v3/repos/o/n/contents/README.md", "etag:repos/o/n/contents/README.md"},
+		{"repos/o/n/contents/README.md", "etag:repos/o/n/contents/README.md"},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, etagCacheKey(u))
+	}
+}
@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// starredReposQuery fetches one page of starred repositories with the fields of
+// Repository, when each was starred, plus the languages and issue/PR counts that
+// REST needs separate calls for
+const starredReposQuery = `
+query($first: Int!, $after: String) {
+  viewer {
+    starredRepositories(first: $first, after: $after, orderBy: {field: STARRED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      edges {
+        starredAt
+        node {
+          databaseId
+          nameWithOwner
+          description
+          homepageUrl
+          primaryLanguage { name }
+          stargazerCount
+          forkCount
+          diskUsage
+          createdAt
+          updatedAt
+          defaultBranchRef { name }
+          repositoryTopics(first: 20) { nodes { topic { name } } }
+          licenseInfo { key name spdxId url }
+          hasWikiEnabled
+          isArchived
+          isDisabled
+          isPrivate
+          isFork
+          languages(first: 20, orderBy: {field: SIZE, direction: DESC}) { edges { size node { name } } }
+          openIssues: issues(states: OPEN) { totalCount }
+          issues { totalCount }
+          openPullRequests: pullRequests(states: OPEN) { totalCount }
+          pullRequests { totalCount }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLStarredPageSize is the largest page GraphQL connections allow
+const graphQLStarredPageSize = 100
+
+type graphQLCount struct {
+	TotalCount int `json:"totalCount"`
+}
+
+type graphQLRepository struct {
+	DatabaseID      int64     `json:"databaseId"`
+	NameWithOwner   string    `json:"nameWithOwner"`
+	Description     string    `json:"description"`
+	HomepageURL     string    `json:"homepageUrl"`
+	StargazerCount  int       `json:"stargazerCount"`
+	ForkCount       int       `json:"forkCount"`
+	DiskUsage       int       `json:"diskUsage"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	HasWikiEnabled  bool      `json:"hasWikiEnabled"`
+	IsArchived      bool      `json:"isArchived"`
+	IsDisabled      bool      `json:"isDisabled"`
+	IsPrivate       bool      `json:"isPrivate"`
+	IsFork          bool      `json:"isFork"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	LicenseInfo *struct {
+		Key    string `json:"key"`
+		Name   string `json:"name"`
+		SPDXID string `json:"spdxId"`
+		URL    string `json:"url"`
+	} `json:"licenseInfo"`
+	Languages struct {
+		Edges []struct {
+			Size int64 `json:"size"`
+			Node struct {
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"languages"`
+	OpenIssues       graphQLCount `json:"openIssues"`
+	Issues           graphQLCount `json:"issues"`
+	OpenPullRequests graphQLCount `json:"openPullRequests"`
+	PullRequests     graphQLCount `json:"pullRequests"`
+}
+
+type starredReposResponse struct {
+	Viewer struct {
+		StarredRepositories struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Edges []struct {
+				StarredAt time.Time         `json:"starredAt"`
+				Node      graphQLRepository `json:"node"`
+			} `json:"edges"`
+		} `json:"starredRepositories"`
+	} `json:"viewer"`
+}
+
+// getStarredReposGraphQL pages through the viewer's stars with one query per page
+func (c *clientImpl) getStarredReposGraphQL(ctx context.Context) ([]Repository, error) {
+	var allRepos []Repository
+
+	perPage := c.getPerPageWithOverride(graphQLStarredPageSize, "GH_STAR_SEARCH_TEST_PER_PAGE")
+	maxPages := testMaxPages()
+
+	variables := map[string]interface{}{"first": perPage, "after": nil}
+
+	for page := 1; ; page++ {
+		var resp starredReposResponse
+		if err := c.graphQL.DoWithContext(ctx, starredReposQuery, variables, &resp); err != nil {
+			return nil, fmt.Errorf("failed to query starred repositories (page %d): %w", page, err)
+		}
+
+		starred := resp.Viewer.StarredRepositories
+		for _, edge := range starred.Edges {
+			repo := edge.Node.toRepository()
+			repo.StarredAt = edge.StarredAt
+			allRepos = append(allRepos, repo)
+		}
+
+		if !starred.PageInfo.HasNextPage || (maxPages != 0 && page >= maxPages) {
+			return allRepos, nil
+		}
+
+		variables["after"] = starred.PageInfo.EndCursor
+	}
+}
+
+// toRepository converts a GraphQL node to the REST-shaped Repository
+func (r graphQLRepository) toRepository() Repository {
+	repo := Repository{
+		ID:              r.DatabaseID,
+		FullName:        r.NameWithOwner,
+		Description:     r.Description,
+		Homepage:        r.HomepageURL,
+		StargazersCount: r.StargazerCount,
+		ForksCount:      r.ForkCount,
+		UpdatedAt:       r.UpdatedAt,
+		CreatedAt:       r.CreatedAt,
+		Topics:          make([]string, 0, len(r.RepositoryTopics.Nodes)),
+		Size:            r.DiskUsage,
+		// REST counts open pull requests as open issues
+		OpenIssuesCount: r.OpenIssues.TotalCount + r.OpenPullRequests.TotalCount,
+		HasWiki:         r.HasWikiEnabled,
+		Archived:        r.IsArchived,
+		Disabled:        r.IsDisabled,
+		Private:         r.IsPrivate,
+		Fork:            r.IsFork,
+		Prefetched: &PrefetchedMetrics{
+			Languages:   make(map[string]int64, len(r.Languages.Edges)),
+			OpenIssues:  r.OpenIssues.TotalCount,
+			TotalIssues: r.Issues.TotalCount,
+			OpenPRs:     r.OpenPullRequests.TotalCount,
+			TotalPRs:    r.PullRequests.TotalCount,
+		},
+	}
+
+	if r.PrimaryLanguage != nil {
+		repo.Language = r.PrimaryLanguage.Name
+	}
+
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = r.DefaultBranchRef.Name
+	}
+
+	for _, node := range r.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, node.Topic.Name)
+	}
+
+	if r.LicenseInfo != nil {
+		repo.License = &License{
+			Key:    r.LicenseInfo.Key,
+			Name:   r.LicenseInfo.Name,
+			SPDXID: r.LicenseInfo.SPDXID,
+			URL:    r.LicenseInfo.URL,
+		}
+	}
+
+	for _, edge := range r.Languages.Edges {
+		repo.Prefetched.Languages[edge.Node.Name] = edge.Size
+	}
+
+	return repo
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockGraphQLClient answers starred queries from pages keyed by the after cursor
+type mockGraphQLClient struct {
+	pages map[string]string
+	err   error
+	calls int
+}
+
+func (m *mockGraphQLClient) DoWithContext(
+	_ context.Context,
+	_ string,
+	variables map[string]interface{},
+	response interface{},
+) error {
+	m.calls++
+
+	if m.err != nil {
+		return m.err
+	}
+
+	after, _ := variables["after"].(string)
+
+	return json.Unmarshal([]byte(m.pages[after]), response)
+}
+
+const starredPageOne = `{"viewer": {"starredRepositories": {
+  "pageInfo": {"hasNextPage": true, "endCursor": "c1"},
+  "edges": [{"starredAt": "2024-06-01T12:00:00Z", "node": {
+    "databaseId": 42, "nameWithOwner": "user/alpha", "description": "Alpha",
+    "homepageUrl": "https://alpha.dev", "primaryLanguage": {"name": "Go"},
+    "stargazerCount": 120, "forkCount": 7, "diskUsage": 2048,
+    "createdAt": "2020-01-02T03:04:05Z", "updatedAt": "2024-05-06T07:08:09Z",
+    "defaultBranchRef": {"name": "main"},
+    "repositoryTopics": {"nodes": [{"topic": {"name": "cli"}}, {"topic": {"name": "search"}}]},
+    "licenseInfo": {"key": "mit", "name": "MIT License", "spdxId": "MIT", "url": ""},
+    "isArchived": true,
+    "languages": {"edges": [{"size": 9000, "node": {"name": "Go"}}, {"size": 100, "node": {"name": "Shell"}}]},
+    "openIssues": {"totalCount": 3}, "issues": {"totalCount": 30},
+    "openPullRequests": {"totalCount": 2}, "pullRequests": {"totalCount": 20}
+  }}]
+}}}`
+
+const starredPageTwo = `{"viewer": {"starredRepositories": {
+  "pageInfo": {"hasNextPage": false, "endCursor": "c2"},
+  "edges": [{"starredAt": "2023-02-03T04:05:06Z",
+    "node": {"databaseId": 43, "nameWithOwner": "user/beta", "primaryLanguage": null, "licenseInfo": null}}]
+}}}`
+
+func TestGetStarredRepos_GraphQL(t *testing.T) {
+	graphQL := &mockGraphQLClient{pages: map[string]string{"": starredPageOne, "c1": starredPageTwo}}
+	rest := newMockRESTClient()
+	client := &clientImpl{apiClient: rest, graphQL: graphQL}
+
+	repos, err := client.GetStarredRepos(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, 2, graphQL.calls)
+
+	alpha := repos[0]
+	assert.Equal(t, int64(42), alpha.ID)
+	assert.Equal(t, "user/alpha", alpha.FullName)
+	assert.Equal(t, "Go", alpha.Language)
+	assert.Equal(t, "main", alpha.DefaultBranch)
+	assert.Equal(t, []string{"cli", "search"}, alpha.Topics)
+	assert.Equal(t, "MIT", alpha.License.SPDXID)
+	assert.Equal(t, 5, alpha.OpenIssuesCount, "open issues include open pull requests, as in REST")
+	assert.True(t, alpha.Archived)
+	assert.Equal(t, 2024, alpha.UpdatedAt.Year())
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), alpha.StarredAt)
+	require.NotNil(t, alpha.Prefetched)
+	assert.Equal(t, map[string]int64{"Go": 9000, "Shell": 100}, alpha.Prefetched.Languages)
+	assert.Equal(t, PrefetchedMetrics{
+		Languages: alpha.Prefetched.Languages, OpenIssues: 3, TotalIssues: 30, OpenPRs: 2, TotalPRs: 20,
+	}, *alpha.Prefetched)
+
+	beta := repos[1]
+	assert.Empty(t, beta.Language)
+	assert.Nil(t, beta.License)
+	assert.Empty(t, beta.Topics)
+	assert.Equal(t, 2023, beta.StarredAt.Year())
+}
+
+func TestGetStarredRepos_GraphQLFallsBackToREST(t *testing.T) {
+	graphQL := &mockGraphQLClient{err: errors.New("Something went wrong while executing your query")}
+	rest := newMockRESTClient()
+	rest.setResponse("user/starred?page=1&per_page=50", []Repository{{FullName: "user/rest"}})
+	client := &clientImpl{apiClient: rest, graphQL: graphQL}
+
+	repos, err := client.GetStarredRepos(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "user/rest", repos[0].FullName)
+	assert.Nil(t, repos[0].Prefetched)
+}
+
+func TestGetStarredRepos_RESTStarredAt(t *testing.T) {
+	starredAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	starred := newMockRESTClient()
+	starred.setResponse("user/starred?page=1&per_page=50", []starredRepository{
+		{StarredAt: starredAt, Repo: Repository{FullName: "user/rest"}},
+	})
+	client := &clientImpl{apiClient: newMockRESTClient(), starred: starred}
+
+	repos, err := client.GetStarredRepos(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "user/rest", repos[0].FullName)
+	assert.Equal(t, starredAt, repos[0].StarredAt)
+}
+
+// metricsStubClient counts the per-repository metric calls made by BatchExecutor
+type metricsStubClient struct {
+	Client
+	calls atomic.Int64
+}
+
+func (c *metricsStubClient) GetContributors(_ context.Context, _ string, _ int) ([]Contributor, error) {
+	c.calls.Add(1)
+	return []Contributor{{Login: "octocat"}}, nil
+}
+
+func (c *metricsStubClient) GetCommitActivity(_ context.Context, _ string) (*CommitActivity, error) {
+	c.calls.Add(1)
+	return &CommitActivity{Total: 5}, nil
+}
+
+func TestFetchRepositoryMetrics_UsesPrefetched(t *testing.T) {
+	stub := &metricsStubClient{}
+	executor := NewBatchExecutor(stub, 2, 10)
+
+	repo := Repository{
+		FullName: "user/alpha",
+		Topics:   []string{"cli"},
+		Prefetched: &PrefetchedMetrics{
+			Languages: map[string]int64{"Go": 10}, OpenIssues: 1, TotalIssues: 4, OpenPRs: 2, TotalPRs: 8,
+		},
+	}
+
+	metrics := executor.FetchRepositoryMetrics(context.Background(), []Repository{repo})["user/alpha"]
+	require.NotNil(t, metrics)
+
+	assert.Equal(t, int64(2), stub.calls.Load(), "only contributors and commit activity should be fetched")
+	assert.Empty(t, metrics.Failed)
+	assert.Equal(t, []string{"cli"}, metrics.Topics)
+	assert.Equal(t, map[string]int64{"Go": 10}, metrics.Languages)
+	assert.Equal(t, 1, metrics.OpenIssues)
+	assert.Equal(t, 4, metrics.TotalIssues)
+	assert.Equal(t, 2, metrics.OpenPRs)
+	assert.Equal(t, 8, metrics.TotalPRs)
+	assert.Equal(t, "octocat", metrics.Contributors[0].Login)
+	assert.Equal(t, 5, metrics.CommitActivity.Total)
+}
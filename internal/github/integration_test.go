@@ -110,8 +110,8 @@ func TestWorkerPoolIntegration(t *testing.T) {
 
 	// Mock commit activity (with 202 response for repo1, success for repo2)
 	mockClient.setError("repos/owner/repo1/stats/commit_activity", &api.HTTPError{StatusCode: 202})
-	mockClient.setResponse("repos/owner/repo2/stats/commit_activity", []WeeklyCommits{
-		{Week: 1640995200, Commits: 5},
+	mockClient.setResponse("repos/owner/repo2/stats/commit_activity", []commitActivityWeek{
+		{Week: 1640995200, Total: 5},
 	})
 
 	// Mock PR and issue counts
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RateLimit describes the remaining quota of the core REST API
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitReporter is implemented by clients that can report the current REST API quota.
+// It is kept separate from Client so test doubles do not need to implement it.
+type RateLimitReporter interface {
+	GetRateLimit(ctx context.Context) (*RateLimit, error)
+}
+
+// errRateLimitUnsupported is returned when the wrapped client cannot report its quota
+var errRateLimitUnsupported = errors.New("client does not report rate limits")
+
+// GetRateLimit fetches the core REST API quota. Requests to this endpoint do not
+// count against the rate limit.
+func (c *clientImpl) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+
+	if err := c.get(ctx, "rate_limit", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch rate limit: %w", err)
+	}
+
+	core := resp.Resources.Core
+
+	return &RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
+
+// GetRateLimit delegates to the wrapped client; rate limits are never cached
+func (c *CachedClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	reporter, ok := c.client.(RateLimitReporter)
+	if !ok {
+		return nil, errRateLimitUnsupported
+	}
+
+	return reporter.GetRateLimit(ctx)
+}
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	// DefaultRetryAttempts is how many times NewClient retries a REST request that
+	// failed with a server error or a rate limit
+	DefaultRetryAttempts = 3
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff used when
+	// GitHub does not say how long to wait
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+	// maxRetryWait is the longest a request waits for a rate limit to reset. A
+	// longer wait fails the request instead, so a sync can stop and be resumed.
+	maxRetryWait = 15 * time.Minute
+)
+
+// WithRetryAttempts sets how many times a REST request failing with a 5xx
+// status or a rate limit is retried; 0 disables retries
+func WithRetryAttempts(attempts int) ClientOption {
+	return func(o *clientOptions) {
+		o.retryAttempts = attempts
+	}
+}
+
+// get performs a REST GET, retrying server errors and rate limits up to
+// c.retryAttempts times. It waits as long as Retry-After or X-RateLimit-Reset
+// ask for, and backs off exponentially when GitHub gives no hint.
+func (c *clientImpl) get(ctx context.Context, path string, resp interface{}) error {
+	return c.getWith(ctx, c.apiClient, path, resp)
+}
+
+// getWith is get through client rather than c.apiClient
+func (c *clientImpl) getWith(ctx context.Context, client RESTClientInterface, path string, resp interface{}) error {
+	backoff := retryBaseDelay
+
+	for attempt := 0; ; attempt++ {
+		err := client.Get(path, resp)
+		if err == nil || attempt >= c.retryAttempts {
+			return err
+		}
+
+		wait, retryable := retryDelay(err, backoff, time.Now())
+		if !retryable {
+			return err
+		}
+
+		if wait > maxRetryWait {
+			return fmt.Errorf("%w (rate limit resets in %s)", err, wait.Round(time.Second))
+		}
+
+		slog.Warn("Retrying GitHub request",
+			slog.String("path", path),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("wait", wait),
+			slog.String("error", err.Error()))
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+
+		backoff = min(backoff*2, retryMaxDelay)
+	}
+}
+
+// retryDelay reports whether a failed request is worth retrying and how long to
+// wait first. Server errors and 429s are retried, as are 403s that are rate
+// limits: a secondary limit (with Retry-After) or an exhausted quota
+// (X-RateLimit-Remaining of 0). Other 403s are permission errors. The wait is
+// Retry-After when present, else until X-RateLimit-Reset for an exhausted
+// quota, else the backoff.
+func retryDelay(err error, backoff time.Duration, now time.Time) (time.Duration, bool) {
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+
+	if httpErr.StatusCode < http.StatusInternalServerError && !isRateLimitResponse(httpErr) {
+		return 0, false
+	}
+
+	headers := httpErr.Headers
+	retryAfter, retryAfterErr := strconv.Atoi(headers.Get("Retry-After"))
+	hasRetryAfter := retryAfterErr == nil && retryAfter >= 0
+	quotaExhausted := headers.Get("X-RateLimit-Remaining") == "0"
+
+	if hasRetryAfter {
+		return time.Duration(retryAfter) * time.Second, true
+	}
+
+	if quotaExhausted {
+		if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			// One extra second so the request lands after the reset
+			return max(time.Unix(reset, 0).Sub(now), 0) + time.Second, true
+		}
+	}
+
+	return backoff, true
+}
+
+// isRateLimitResponse reports whether an HTTP error is a rate limit: a 429, or
+// a 403 with Retry-After (secondary limit) or X-RateLimit-Remaining of 0
+func isRateLimitResponse(httpErr *api.HTTPError) bool {
+	switch httpErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		retryAfter, err := strconv.Atoi(httpErr.Headers.Get("Retry-After"))
+
+		return (err == nil && retryAfter >= 0) || httpErr.Headers.Get("X-RateLimit-Remaining") == "0"
+	default:
+		return false
+	}
+}
+
+// IsRateLimitError reports whether err is, or wraps, a GitHub rate limit response
+func IsRateLimitError(err error) bool {
+	var httpErr *api.HTTPError
+
+	return errors.As(err, &httpErr) && isRateLimitResponse(httpErr)
+}
+
+// IsAuthError reports whether err is, or wraps, a GitHub 401 response, meaning the
+// token is missing, expired or revoked
+func IsAuthError(err error) bool {
+	var httpErr *api.HTTPError
+
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func httpError(status int, headers map[string]string) error {
+	h := http.Header{}
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+
+	return &api.HTTPError{StatusCode: status, Headers: h}
+}
+
+func TestRetryDelay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	backoff := 2 * time.Second
+
+	tests := []struct {
+		name      string
+		err       error
+		wantWait  time.Duration
+		wantRetry bool
+	}{
+		{name: "server error backs off", err: httpError(http.StatusBadGateway, nil), wantWait: backoff, wantRetry: true},
+		{name: "429 backs off", err: httpError(http.StatusTooManyRequests, nil), wantWait: backoff, wantRetry: true},
+		{
+			name:      "secondary rate limit honors Retry-After",
+			err:       httpError(http.StatusForbidden, map[string]string{"Retry-After": "60"}),
+			wantWait:  time.Minute,
+			wantRetry: true,
+		},
+		{
+			name: "exhausted quota waits for reset",
+			err: httpError(http.StatusForbidden, map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(now.Add(90*time.Second).Unix(), 10),
+			}),
+			wantWait:  91 * time.Second,
+			wantRetry: true,
+		},
+		{name: "permission 403 is not retried", err: httpError(http.StatusForbidden, nil)},
+		{name: "404 is not retried", err: httpError(http.StatusNotFound, nil)},
+		{name: "non-HTTP error is not retried", err: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, retry := retryDelay(tt.err, backoff, now)
+			assert.Equal(t, tt.wantRetry, retry)
+			assert.Equal(t, tt.wantWait, wait)
+		})
+	}
+}
+
+func TestClassifyHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRateLimit bool
+		wantAuth      bool
+	}{
+		{name: "429", err: httpError(http.StatusTooManyRequests, nil), wantRateLimit: true},
+		{
+			name:          "exhausted quota",
+			err:           httpError(http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0"}),
+			wantRateLimit: true,
+		},
+		{
+			name:          "wrapped secondary limit",
+			err:           fmt.Errorf("fetch: %w", httpError(http.StatusForbidden, map[string]string{"Retry-After": "5"})),
+			wantRateLimit: true,
+		},
+		{name: "bad credentials", err: httpError(http.StatusUnauthorized, nil), wantAuth: true},
+		{name: "wrapped bad credentials", err: fmt.Errorf("fetch: %w", httpError(http.StatusUnauthorized, nil)), wantAuth: true},
+		{name: "permission 403", err: httpError(http.StatusForbidden, nil)},
+		{name: "server error", err: httpError(http.StatusBadGateway, nil)},
+		{name: "non-HTTP error", err: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRateLimit, IsRateLimitError(tt.err))
+			assert.Equal(t, tt.wantAuth, IsAuthError(tt.err))
+		})
+	}
+}
+
+// flakyRESTClient fails with errs in order, then succeeds
+type flakyRESTClient struct {
+	errs  []error
+	calls int
+}
+
+func (f *flakyRESTClient) Get(_ string, _ interface{}) error {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+
+	return nil
+}
+
+func TestClientGet_Retries(t *testing.T) {
+	secondary := httpError(http.StatusForbidden, map[string]string{"Retry-After": "0"})
+	unavailable := httpError(http.StatusServiceUnavailable, map[string]string{"Retry-After": "0"})
+
+	tests := []struct {
+		name      string
+		attempts  int
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "recovers after transient errors", attempts: 3, errs: []error{unavailable, secondary}, wantCalls: 3},
+		{name: "gives up after the retry budget", attempts: 1, errs: []error{unavailable, unavailable}, wantCalls: 2, wantErr: true},
+		{name: "retries disabled", attempts: 0, errs: []error{unavailable}, wantCalls: 1, wantErr: true},
+		{name: "not found is final", attempts: 3, errs: []error{httpError(http.StatusNotFound, nil)}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest := &flakyRESTClient{errs: tt.errs}
+			client := &clientImpl{apiClient: rest, retryAttempts: tt.attempts}
+
+			err := client.get(context.Background(), "repos/owner/repo", &Repository{})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantCalls, rest.calls)
+		})
+	}
+}
+
+func TestClientGet_LongRateLimitWaitFails(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	rest := &flakyRESTClient{errs: []error{httpError(http.StatusForbidden, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+	})}}
+	client := &clientImpl{apiClient: rest, retryAttempts: 3}
+
+	err := client.get(context.Background(), "repos/owner/repo", &Repository{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit resets in")
+	assert.Equal(t, 1, rest.calls, "should not wait an hour for the quota")
+
+	var httpErr *api.HTTPError
+	assert.True(t, errors.As(err, &httpErr), "the HTTP error should stay inspectable")
+}
+
+func TestClientGet_CanceledWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rest := &flakyRESTClient{errs: []error{httpError(http.StatusBadGateway, nil)}}
+	client := &clientImpl{apiClient: rest, retryAttempts: 3}
+
+	err := client.get(ctx, "repos/owner/repo", &Repository{})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, rest.calls)
+}
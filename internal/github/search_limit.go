@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultSearchRequestsPerMinute paces search API requests (issue and pull
+// request counts) to GitHub's limit of 30 per minute, which is separate from
+// the core REST quota
+const DefaultSearchRequestsPerMinute = 30
+
+// WithSearchRateLimit sets how many search API requests the client sends per
+// minute, shared by every goroutine using it; 0 disables the pacing
+func WithSearchRateLimit(perMinute int) ClientOption {
+	return func(o *clientOptions) {
+		o.searchPerMinute = perMinute
+	}
+}
+
+// searchLimiter is a token bucket holding a single search request, so requests
+// go out at most one per interval and no 60 second window sees more than the
+// per-minute limit. A bucket holding a minute of requests would allow a burst
+// on top of the refill and double the limit in the first minute. Callers that
+// find it empty take a token on credit and sleep until it is earned, so
+// concurrent workers queue behind each other instead of all waking at once.
+type searchLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time to earn one token
+	burst    float64
+	tokens   float64
+	last     time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newSearchLimiter returns a limiter spacing requests to perMinute a minute
+func newSearchLimiter(perMinute int) *searchLimiter {
+	return &searchLimiter{
+		interval: time.Minute / time.Duration(perMinute),
+		burst:    1,
+		tokens:   1,
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// wait blocks until a search request may be sent. A nil limiter never waits.
+func (l *searchLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	}
+
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens * float64(l.interval))
+
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := l.sleep(ctx, delay); err != nil {
+		// Give back the token this caller will not use
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// search performs a search API GET once the search rate limit allows it
+func (c *clientImpl) search(ctx context.Context, path string, resp interface{}) error {
+	if err := c.searchLimit.wait(ctx); err != nil {
+		return err
+	}
+
+	return c.get(ctx, path, resp)
+}
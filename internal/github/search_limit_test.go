@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock stands in for time in searchLimiter: sleeping advances it
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Sleep(_ context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sleeps = append(c.sleeps, d)
+
+	return nil
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newFakeSearchLimiter(perMinute int) (*searchLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newSearchLimiter(perMinute)
+	limiter.now = clock.Now
+	limiter.sleep = clock.Sleep
+
+	return limiter, clock
+}
+
+func TestSearchLimiterPacesRequests(t *testing.T) {
+	limiter, clock := newFakeSearchLimiter(30)
+	ctx := context.Background()
+
+	// The first request goes out at once
+	if err := limiter.wait(ctx); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+
+	if len(clock.sleeps) != 0 {
+		t.Fatalf("Expected the first request not to wait, got %v", clock.sleeps)
+	}
+
+	// Then each caller queues two seconds behind the previous one
+	for range 3 {
+		if err := limiter.wait(ctx); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second}
+	for i, d := range want {
+		if clock.sleeps[i] != d {
+			t.Errorf("Wait %d: expected %v, got %v", i+1, d, clock.sleeps[i])
+		}
+	}
+
+	// Idle time pays off the debt but earns at most one request ahead
+	clock.Advance(6*time.Second + 10*time.Second)
+	clock.sleeps = nil
+
+	for range 2 {
+		if err := limiter.wait(ctx); err != nil {
+			t.Fatalf("wait failed: %v", err)
+		}
+	}
+
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 2*time.Second {
+		t.Errorf("Expected one immediate request then a 2s wait, got %v", clock.sleeps)
+	}
+}
+
+func TestSearchLimiterNeverExceedsLimitPerMinute(t *testing.T) {
+	const perMinute = 30
+
+	limiter, clock := newFakeSearchLimiter(perMinute)
+	ctx := context.Background()
+
+	// Bursts of callers separated by idle gaps of varying length
+	var sent []time.Time
+
+	for round, gap := range []time.Duration{0, 0, 90 * time.Second, 5 * time.Second, 0, 61 * time.Second, 30 * time.Second} {
+		clock.Advance(gap)
+
+		for range 10 + 5*round {
+			before := len(clock.sleeps)
+			if err := limiter.wait(ctx); err != nil {
+				t.Fatalf("wait failed: %v", err)
+			}
+
+			at := clock.Now()
+			if len(clock.sleeps) > before {
+				at = at.Add(clock.sleeps[len(clock.sleeps)-1])
+			}
+
+			sent = append(sent, at)
+		}
+	}
+
+	for i, start := range sent {
+		inWindow := 0
+
+		for _, at := range sent {
+			if !at.Before(start) && at.Before(start.Add(time.Minute)) {
+				inWindow++
+			}
+		}
+
+		if inWindow > perMinute {
+			t.Fatalf("%d requests in the minute from request %d; limit is %d", inWindow, i+1, perMinute)
+		}
+	}
+}
+
+func TestSearchLimiterSharedAcrossWorkers(t *testing.T) {
+	limiter, clock := newFakeSearchLimiter(60)
+	limiter.tokens = 0
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := limiter.wait(context.Background()); err != nil {
+				t.Errorf("wait failed: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Eight workers on an empty bucket each take a distinct slot, one second apart
+	seen := make(map[time.Duration]bool)
+	for _, d := range clock.sleeps {
+		seen[d] = true
+	}
+
+	for i := 1; i <= 8; i++ {
+		if !seen[time.Duration(i)*time.Second] {
+			t.Errorf("No worker waited %ds; waits were %v", i, clock.sleeps)
+		}
+	}
+}
+
+func TestSearchLimiterCanceled(t *testing.T) {
+	limiter := newSearchLimiter(30)
+	limiter.tokens = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.wait(ctx); err == nil {
+		t.Error("Expected a canceled wait to fail")
+	}
+
+	if limiter.tokens != 0 {
+		t.Errorf("Expected the canceled caller to return its token, have %v", limiter.tokens)
+	}
+}
+
+func TestSearchRequestsUseLimiter(t *testing.T) {
+	mockClient := newMockRESTClient()
+	limiter, clock := newFakeSearchLimiter(30)
+	limiter.tokens = 0
+	client := &clientImpl{apiClient: mockClient, searchLimit: limiter}
+
+	mockClient.setResponse("search/issues?q=repo:owner/repo+type:pr+state:open&per_page=1", SearchResult{TotalCount: 2})
+	mockClient.setResponse("search/issues?q=repo:owner/repo+type:pr&per_page=1", SearchResult{TotalCount: 5})
+
+	if _, _, err := client.GetPullCounts(context.Background(), "owner/repo"); err != nil {
+		t.Fatalf("GetPullCounts failed: %v", err)
+	}
+
+	if len(clock.sleeps) != 2 {
+		t.Errorf("Expected both search requests to wait for the limiter, got %v", clock.sleeps)
+	}
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// StarChecker is implemented by clients that can check whether the
+// authenticated user starred a repository. It is kept separate from Client so
+// test doubles do not need to implement it.
+type StarChecker interface {
+	IsStarred(ctx context.Context, fullName string) (bool, error)
+}
+
+// errStarCheckUnsupported is returned when the wrapped client cannot check stars
+var errStarCheckUnsupported = errors.New("client does not check stars")
+
+// IsStarred asks GitHub whether the authenticated user starred fullName, which
+// answers 204 when starred and 404 when not
+func (c *clientImpl) IsStarred(ctx context.Context, fullName string) (bool, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	err := c.get(ctx, "user/starred/"+fullName, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to check star on %s: %w", fullName, err)
+}
+
+// IsStarred delegates to the wrapped client; stars are never cached
+func (c *CachedClient) IsStarred(ctx context.Context, fullName string) (bool, error) {
+	checker, ok := c.client.(StarChecker)
+	if !ok {
+		return false, errStarCheckUnsupported
+	}
+
+	return checker.IsStarred(ctx, fullName)
+}
+
+// IsStarred checks a star within the budget
+func (c *BudgetClient) IsStarred(ctx context.Context, fullName string) (bool, error) {
+	checker, ok := c.client.(StarChecker)
+	if !ok {
+		return false, errStarCheckUnsupported
+	}
+
+	if err := c.budget.check(); err != nil {
+		return false, err
+	}
+
+	return checker.IsStarred(ctx, fullName)
+}
@@ -248,6 +248,19 @@ func (be *BatchExecutor) FetchRepositoryMetrics(
 			},
 		})
 
+		// Commit activity task
+		tasks = append(tasks, Task{
+			ID: repoName + ":commits",
+			Func: func(ctx context.Context) (interface{}, error) {
+				return be.client.GetCommitActivity(ctx, repoName)
+			},
+		})
+
+		// Topics, languages and issue/PR counts came with the GraphQL starred query
+		if repo.Prefetched != nil {
+			continue
+		}
+
 		// Topics task
 		tasks = append(tasks, Task{
 			ID: repoName + ":topics",
@@ -264,14 +277,6 @@ func (be *BatchExecutor) FetchRepositoryMetrics(
 			},
 		})
 
-		// Commit activity task
-		tasks = append(tasks, Task{
-			ID: repoName + ":commits",
-			Func: func(ctx context.Context) (interface{}, error) {
-				return be.client.GetCommitActivity(ctx, repoName)
-			},
-		})
-
 		// Pull request counts task
 		tasks = append(tasks, Task{
 			ID: repoName + ":prs",
@@ -303,7 +308,16 @@ func (be *BatchExecutor) FetchRepositoryMetrics(
 	// Organize results by repository
 	metrics := make(map[string]*RepositoryMetrics)
 	for _, repo := range repos {
-		metrics[repo.FullName] = &RepositoryMetrics{}
+		repoMetrics := &RepositoryMetrics{Failed: make(map[string]error)}
+
+		if p := repo.Prefetched; p != nil {
+			repoMetrics.Topics = repo.Topics
+			repoMetrics.Languages = p.Languages
+			repoMetrics.OpenIssues, repoMetrics.TotalIssues = p.OpenIssues, p.TotalIssues
+			repoMetrics.OpenPRs, repoMetrics.TotalPRs = p.OpenPRs, p.TotalPRs
+		}
+
+		metrics[repo.FullName] = repoMetrics
 	}
 
 	// Process results
@@ -316,13 +330,14 @@ func (be *BatchExecutor) FetchRepositoryMetrics(
 		repoName := parts[0]
 		metricType := parts[1]
 
-		if result.Error != nil {
-			// Log error but continue with other metrics
+		repoMetrics := metrics[repoName]
+		if repoMetrics == nil {
 			continue
 		}
 
-		repoMetrics := metrics[repoName]
-		if repoMetrics == nil {
+		if result.Error != nil {
+			// Record the failure but continue with other metrics
+			repoMetrics.Failed[metricType] = result.Error
 			continue
 		}
 
@@ -369,6 +384,16 @@ type RepositoryMetrics struct {
 	TotalPRs       int
 	OpenIssues     int
 	TotalIssues    int
+
+	// Failed holds the error for each metric type ("contributors", "topics",
+	// "languages", "commits", "prs", "issues") that could not be fetched
+	Failed map[string]error
+}
+
+// FetchFailed reports whether the given metric type could not be fetched
+func (m *RepositoryMetrics) FetchFailed(metricType string) bool {
+	_, failed := m.Failed[metricType]
+	return failed
 }
 
 // splitString splits a string by delimiter (simple implementation)
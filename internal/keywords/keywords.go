@@ -0,0 +1,147 @@
+// Package keywords extracts README-derived keywords using TF-IDF across the
+// corpus of starred repositories.
+package keywords
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxTermsPerDocument caps how many candidate terms are stored per repository
+	MaxTermsPerDocument = 50
+	// DefaultKeywordCount is the number of keywords kept per repository
+	DefaultKeywordCount = 10
+	// minTermLength drops short tokens that are rarely meaningful
+	minTermLength = 3
+	// maxTermLength drops hashes, URLs and other long noise tokens
+	maxTermLength = 30
+)
+
+// stopwords are common English and README boilerplate words excluded from keywords
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"are": true, "was": true, "you": true, "your": true, "can": true, "will": true,
+	"from": true, "have": true, "has": true, "not": true, "but": true, "all": true,
+	"any": true, "use": true, "using": true, "used": true, "into": true, "its": true,
+	"our": true, "out": true, "more": true, "also": true, "which": true, "when": true,
+	"how": true, "what": true, "there": true, "their": true, "then": true, "than": true,
+	"these": true, "those": true, "been": true, "being": true, "such": true, "may": true,
+	"should": true, "would": true, "could": true, "each": true, "other": true, "some": true,
+	"only": true, "one": true, "two": true, "get": true, "set": true, "see": true,
+	"just": true, "like": true, "about": true, "via": true, "etc": true, "per": true,
+	"http": true, "https": true, "www": true, "com": true, "org": true, "github": true,
+	"readme": true, "license": true, "install": true, "installation": true, "usage": true,
+	"example": true, "examples": true, "run": true, "file": true, "files": true,
+	"project": true, "support": true, "please": true, "new": true, "version": true,
+}
+
+// TermFrequencies tokenizes text and returns counts for the most frequent
+// non-stopword terms, keeping at most maxTerms entries.
+func TermFrequencies(text string, maxTerms int) map[string]int {
+	counts := make(map[string]int)
+
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	})
+
+	for _, token := range tokens {
+		token = strings.Trim(token, "-_")
+		if !isCandidate(token) {
+			continue
+		}
+
+		counts[token]++
+	}
+
+	if maxTerms <= 0 || len(counts) <= maxTerms {
+		return counts
+	}
+
+	top := make(map[string]int, maxTerms)
+	for _, term := range rankTerms(counts, maxTerms) {
+		top[term] = counts[term]
+	}
+
+	return top
+}
+
+// TopKeywords scores each document's terms with TF-IDF against the whole corpus
+// and returns the n highest scoring terms per document
+func TopKeywords(corpus map[string]map[string]int, n int) map[string][]string {
+	docFreq := make(map[string]int)
+
+	for _, terms := range corpus {
+		for term := range terms {
+			docFreq[term]++
+		}
+	}
+
+	docCount := float64(len(corpus))
+	result := make(map[string][]string, len(corpus))
+
+	for doc, terms := range corpus {
+		total := 0
+		for _, count := range terms {
+			total += count
+		}
+
+		if total == 0 {
+			result[doc] = nil
+			continue
+		}
+
+		scores := make(map[string]float64, len(terms))
+
+		for term, count := range terms {
+			tf := float64(count) / float64(total)
+			// Smoothed IDF keeps scores positive for small corpora
+			idf := math.Log((1+docCount)/(1+float64(docFreq[term]))) + 1
+			scores[term] = tf * idf
+		}
+
+		result[doc] = rankTerms(scores, n)
+	}
+
+	return result
+}
+
+// isCandidate reports whether a token can be a keyword
+func isCandidate(token string) bool {
+	if len(token) < minTermLength || len(token) > maxTermLength || stopwords[token] {
+		return false
+	}
+
+	// Skip purely numeric tokens such as versions and years
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rankTerms returns up to n terms ordered by descending score, then alphabetically
+func rankTerms[V int | float64](scores map[string]V, n int) []string {
+	terms := make([]string, 0, len(scores))
+	for term := range scores {
+		terms = append(terms, term)
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if scores[terms[i]] != scores[terms[j]] {
+			return scores[terms[i]] > scores[terms[j]]
+		}
+
+		return terms[i] < terms[j]
+	})
+
+	if n > 0 && len(terms) > n {
+		terms = terms[:n]
+	}
+
+	return terms
+}
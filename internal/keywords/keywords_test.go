@@ -0,0 +1,69 @@
+package keywords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTermFrequencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxTerms int
+		want     map[string]int
+	}{
+		{
+			name: "drops stopwords, short and numeric tokens",
+			text: "The terminal UI library for Go. Terminal widgets, 2024, v1 and more.",
+			want: map[string]int{"terminal": 2, "library": 1, "widgets": 1},
+		},
+		{
+			name: "keeps hyphenated terms",
+			text: "A command-line tool. command-line parsing",
+			want: map[string]int{"command-line": 2, "tool": 1, "parsing": 1},
+		},
+		{
+			name:     "caps number of terms",
+			text:     "alpha alpha alpha beta beta gamma",
+			maxTerms: 2,
+			want:     map[string]int{"alpha": 3, "beta": 2},
+		},
+		{
+			name: "empty text",
+			text: "",
+			want: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TermFrequencies(tt.text, tt.maxTerms)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TermFrequencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopKeywords(t *testing.T) {
+	corpus := map[string]map[string]int{
+		"user/tui":   {"terminal": 5, "widgets": 2, "library": 2},
+		"user/http":  {"http": 4, "router": 3, "library": 3},
+		"user/empty": {},
+	}
+
+	got := TopKeywords(corpus, 2)
+
+	// "library" appears in two documents, so rarer terms outrank it
+	if want := []string{"terminal", "widgets"}; !reflect.DeepEqual(got["user/tui"], want) {
+		t.Errorf("keywords for user/tui = %v, want %v", got["user/tui"], want)
+	}
+
+	if want := []string{"http", "router"}; !reflect.DeepEqual(got["user/http"], want) {
+		t.Errorf("keywords for user/http = %v, want %v", got["user/http"], want)
+	}
+
+	if len(got["user/empty"]) != 0 {
+		t.Errorf("keywords for user/empty = %v, want none", got["user/empty"])
+	}
+}
@@ -61,7 +61,7 @@ func BenchmarkProcessRepository(b *testing.B) {
 }
 
 func BenchmarkChunkContent(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Create large content for chunking
 	content := strings.Repeat(
@@ -80,7 +80,7 @@ func BenchmarkChunkContent(b *testing.B) {
 }
 
 func BenchmarkDetermineContentType(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	paths := []string{
 		"README.md",
@@ -103,7 +103,7 @@ func BenchmarkDetermineContentType(b *testing.B) {
 }
 
 func BenchmarkFilterContent(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Create content with mix of valid and invalid files
 	content := []github.Content{
@@ -128,7 +128,7 @@ func BenchmarkFilterContent(b *testing.B) {
 }
 
 func BenchmarkGenerateContentHash(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Create chunks for hashing
 	chunks := make([]ContentChunk, 100)
@@ -151,7 +151,7 @@ func BenchmarkGenerateContentHash(b *testing.B) {
 }
 
 func BenchmarkSplitMarkdownContent(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Create markdown content with multiple sections
 	content := ""
@@ -176,7 +176,7 @@ func BenchmarkSplitMarkdownContent(b *testing.B) {
 }
 
 func BenchmarkDecodeContent(b *testing.B) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Create base64 encoded content
 	originalText := strings.Repeat("This is test content for decoding benchmarks.\n", 100)
@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// CacheStats counts content cache lookups made by ExtractContent
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// Lookups returns the total number of cache lookups
+func (s CacheStats) Lookups() int64 {
+	return s.Hits + s.Misses
+}
+
+// CacheStatsReporter is implemented by services that read content through a cache.
+// It is kept separate from Service so test doubles do not need to implement it.
+type CacheStatsReporter interface {
+	// ContentCacheStats returns the cache hits and misses since the service was created
+	ContentCacheStats() CacheStats
+}
+
+// cacheCounters are the running totals behind CacheStats
+type cacheCounters struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// CacheOutcome records how one ExtractContent call used the content cache
+type CacheOutcome struct {
+	Checked bool // A cache was configured and consulted
+	Hit     bool // Content was served from the cache
+}
+
+type cacheOutcomeKey struct{}
+
+// WithCacheOutcome returns a context under which ExtractContent records its cache
+// outcome in the returned CacheOutcome, so callers can report it per repository
+func WithCacheOutcome(ctx context.Context) (context.Context, *CacheOutcome) {
+	outcome := &CacheOutcome{}
+
+	return context.WithValue(ctx, cacheOutcomeKey{}, outcome), outcome
+}
+
+// recordCacheLookup counts a lookup and records it on the context's CacheOutcome, if any
+func (s *serviceImpl) recordCacheLookup(ctx context.Context, hit bool) {
+	if hit {
+		s.cacheCounters.hits.Add(1)
+	} else {
+		s.cacheCounters.misses.Add(1)
+	}
+
+	if outcome, ok := ctx.Value(cacheOutcomeKey{}).(*CacheOutcome); ok {
+		outcome.Checked = true
+		outcome.Hit = hit
+	}
+}
+
+// ContentCacheStats returns the content cache hits and misses so far
+func (s *serviceImpl) ContentCacheStats() CacheStats {
+	return CacheStats{
+		Hits:   s.cacheCounters.hits.Load(),
+		Misses: s.cacheCounters.misses.Load(),
+	}
+}
@@ -0,0 +1,88 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// memoryCache implements ContentCache in memory for testing
+type memoryCache struct {
+	data map[string][]byte
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	data, ok := c.data[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return data, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, data []byte, _ time.Duration) error {
+	c.data[key] = data
+
+	return nil
+}
+
+func TestExtractContent_CacheStats(t *testing.T) {
+	client := &mockGitHubClient{content: []github.Content{{Path: "README.md", Type: "file", Size: 100}}}
+	service := NewServiceWithCache(client, &memoryCache{data: map[string][]byte{}})
+	reporter, ok := service.(CacheStatsReporter)
+	if !ok {
+		t.Fatal("service with cache should implement CacheStatsReporter")
+	}
+
+	repo := github.Repository{FullName: "test/repo"}
+
+	tests := []struct {
+		name       string
+		wantHit    bool
+		wantHits   int64
+		wantMisses int64
+	}{
+		{name: "first extraction misses", wantHit: false, wantHits: 0, wantMisses: 1},
+		{name: "second extraction hits", wantHit: true, wantHits: 1, wantMisses: 1},
+		{name: "third extraction hits", wantHit: true, wantHits: 2, wantMisses: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, outcome := WithCacheOutcome(context.Background())
+
+			if _, err := service.ExtractContent(ctx, repo); err != nil {
+				t.Fatalf("ExtractContent failed: %v", err)
+			}
+
+			if !outcome.Checked || outcome.Hit != tt.wantHit {
+				t.Errorf("outcome = %+v, want checked with hit=%v", *outcome, tt.wantHit)
+			}
+
+			stats := reporter.ContentCacheStats()
+			if stats.Hits != tt.wantHits || stats.Misses != tt.wantMisses {
+				t.Errorf("stats = %+v, want %d hits and %d misses", stats, tt.wantHits, tt.wantMisses)
+			}
+		})
+	}
+}
+
+func TestExtractContent_NoCacheRecordsNothing(t *testing.T) {
+	service := NewService(&mockGitHubClient{})
+
+	ctx, outcome := WithCacheOutcome(context.Background())
+	if _, err := service.ExtractContent(ctx, github.Repository{FullName: "test/repo"}); err != nil {
+		t.Fatalf("ExtractContent failed: %v", err)
+	}
+
+	if outcome.Checked {
+		t.Error("outcome should not be checked without a cache")
+	}
+
+	if stats := service.(CacheStatsReporter).ContentCacheStats(); stats.Lookups() != 0 {
+		t.Errorf("stats = %+v, want no lookups", stats)
+	}
+}
@@ -21,6 +21,10 @@ func (m *mockGitHubClientSimple) GetStarredRepos(_ context.Context, _ string) ([
 	return nil, nil
 }
 
+func (m *mockGitHubClientSimple) GetRepository(_ context.Context, fullName string) (*github.Repository, error) {
+	return &github.Repository{FullName: fullName}, nil
+}
+
 func (m *mockGitHubClientSimple) GetRepositoryContent(_ context.Context, repo github.Repository, _ []string) ([]github.Content, error) {
 	if content, exists := m.content[repo.FullName]; exists {
 		return content, nil
@@ -120,7 +124,7 @@ func TestProcessRepository_ExceedsMaxTotalTokens(t *testing.T) {
 		totalTokens += chunk.Tokens
 	}
 
-	assert.LessOrEqual(t, totalTokens, MaxTotalTokens, "total tokens should not exceed limit")
+	assert.LessOrEqual(t, totalTokens, DefaultMaxTotalTokens, "total tokens should not exceed limit")
 }
 
 func TestChunkContent_ExceedsMaxTokensPerChunk(t *testing.T) {
@@ -183,10 +187,10 @@ func TestProcessRepository_MultipleFilesWithLimits(t *testing.T) {
 	totalTokens := 0
 	for _, chunk := range processed.Chunks {
 		totalTokens += chunk.Tokens
-		assert.LessOrEqual(t, chunk.Tokens, MaxTokensPerChunk, "each chunk should respect limit")
+		assert.LessOrEqual(t, chunk.Tokens, DefaultMaxTokensPerChunk, "each chunk should respect limit")
 	}
 
-	t.Logf("Total tokens: %d / %d", totalTokens, MaxTotalTokens)
+	t.Logf("Total tokens: %d / %d", totalTokens, DefaultMaxTotalTokens)
 }
 
 func TestProcessRepository_PriorityOrdering(t *testing.T) {
@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultDocsDedupThreshold is the shingle similarity at or above which a docs
+// index is treated as a copy of the README
+const DefaultDocsDedupThreshold = 0.8
+
+// shingleSize is the number of consecutive words compared by docsSimilarity.
+// Three words tolerate reworded badges and links while still telling different
+// documents apart.
+const shingleSize = 3
+
+// docsIndexPaths are the fetched documentation entry points that commonly
+// duplicate the README, for example when a docs site is generated from it
+var docsIndexPaths = map[string]bool{
+	"docs/README.md":    true,
+	"docs/index.md":     true,
+	"doc/README.md":     true,
+	"doc/index.md":      true,
+	".github/README.md": true,
+}
+
+// ServiceOption configures a processor service
+type ServiceOption func(*serviceImpl)
+
+// WithDocsDedupThreshold sets the similarity (0 to 1) at which a docs index is
+// dropped as a duplicate of the README; 0 keeps every docs index
+func WithDocsDedupThreshold(threshold float64) ServiceOption {
+	return func(s *serviceImpl) {
+		s.docsDedupThreshold = threshold
+	}
+}
+
+// dedupeDocs drops the chunks of docs index files that are near-identical to
+// the top-level README, which keeps the same text from being indexed and
+// summarized twice. It returns the kept chunks and, for each dropped file, the
+// README it duplicates.
+func dedupeDocs(chunks []ContentChunk, threshold float64) ([]ContentChunk, map[string]string) {
+	readmePath, _ := findReadme(chunks)
+	if threshold <= 0 || readmePath == "" {
+		return chunks, nil
+	}
+
+	texts := make(map[string]*strings.Builder)
+
+	for _, chunk := range chunks {
+		if chunk.Source != readmePath && !docsIndexPaths[chunk.Source] {
+			continue
+		}
+
+		if texts[chunk.Source] == nil {
+			texts[chunk.Source] = &strings.Builder{}
+		}
+
+		texts[chunk.Source].WriteString(chunk.Content)
+		texts[chunk.Source].WriteString("\n")
+	}
+
+	readmeShingles := shingles(texts[readmePath].String())
+
+	var duplicates map[string]string
+
+	for source, text := range texts {
+		if source == readmePath || docsSimilarity(readmeShingles, shingles(text.String())) < threshold {
+			continue
+		}
+
+		if duplicates == nil {
+			duplicates = make(map[string]string)
+		}
+
+		duplicates[source] = readmePath
+	}
+
+	if len(duplicates) == 0 {
+		return chunks, nil
+	}
+
+	kept := make([]ContentChunk, 0, len(chunks))
+
+	for _, chunk := range chunks {
+		if _, ok := duplicates[chunk.Source]; !ok {
+			kept = append(kept, chunk)
+		}
+	}
+
+	return kept, duplicates
+}
+
+// shingles returns the set of lowercase word n-grams in text, ignoring
+// punctuation and markup so formatting differences do not count
+func shingles(text string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	set := make(map[string]struct{})
+
+	if len(words) < shingleSize {
+		if len(words) > 0 {
+			set[strings.Join(words, " ")] = struct{}{}
+		}
+
+		return set
+	}
+
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = struct{}{}
+	}
+
+	return set
+}
+
+// docsSimilarity is the Jaccard similarity of two shingle sets, from 0 (nothing
+// shared) to 1 (identical); empty documents are never similar
+func docsSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,180 @@
+package processor
+
+import (
+	"context"
+	"encoding/base64"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+const dedupReadme = `# Widget
+
+Widget renders interactive charts from streaming data sources with a small
+declarative API. It supports line, bar and scatter plots, live updates over
+websockets, and exporting snapshots to PNG or SVG for reports.`
+
+// dedupDocsCopy is the README as a generated docs site would publish it:
+// same text, different markup
+const dedupDocsCopy = `---
+title: Widget
+---
+
+Widget renders *interactive charts* from streaming data sources with a small
+declarative API. It supports line, bar and scatter plots, live updates over
+websockets, and exporting snapshots to PNG or SVG for reports.`
+
+const dedupDocsGuide = `# Configuration guide
+
+Set the refresh interval and color palette in widget.yaml. Each chart reads its
+data source from the sources section; see the reference for every option.`
+
+func TestDedupeDocs(t *testing.T) {
+	chunk := func(source, content string) ContentChunk {
+		return ContentChunk{Source: source, Type: ContentTypeReadme, Content: content}
+	}
+
+	tests := []struct {
+		name           string
+		chunks         []ContentChunk
+		threshold      float64
+		wantSources    []string
+		wantDuplicates map[string]string
+	}{
+		{
+			name:           "generated docs index is dropped",
+			chunks:         []ContentChunk{chunk("README.md", dedupReadme), chunk("docs/index.md", dedupDocsCopy)},
+			threshold:      DefaultDocsDedupThreshold,
+			wantSources:    []string{"README.md"},
+			wantDuplicates: map[string]string{"docs/index.md": "README.md"},
+		},
+		{
+			name:        "distinct docs index is kept",
+			chunks:      []ContentChunk{chunk("README.md", dedupReadme), chunk("docs/index.md", dedupDocsGuide)},
+			threshold:   DefaultDocsDedupThreshold,
+			wantSources: []string{"README.md", "docs/index.md"},
+		},
+		{
+			name: "every chunk of a duplicate file is dropped",
+			chunks: []ContentChunk{
+				chunk("README.md", dedupReadme),
+				chunk("doc/README.md", dedupDocsCopy[:strings.Index(dedupDocsCopy, "declarative")]),
+				chunk("doc/README.md", dedupDocsCopy[strings.Index(dedupDocsCopy, "declarative"):]),
+				{Source: "go.mod", Type: ContentTypePackage, Content: "module widget"},
+			},
+			threshold:      DefaultDocsDedupThreshold,
+			wantSources:    []string{"README.md", "go.mod"},
+			wantDuplicates: map[string]string{"doc/README.md": "README.md"},
+		},
+		{
+			name:        "other files are never compared",
+			chunks:      []ContentChunk{chunk("README.md", dedupReadme), chunk("docs/getting-started.md", dedupReadme)},
+			threshold:   DefaultDocsDedupThreshold,
+			wantSources: []string{"README.md", "docs/getting-started.md"},
+		},
+		{
+			name:        "zero threshold disables deduplication",
+			chunks:      []ContentChunk{chunk("README.md", dedupReadme), chunk("docs/index.md", dedupReadme)},
+			threshold:   0,
+			wantSources: []string{"README.md", "docs/index.md"},
+		},
+		{
+			name:        "no top-level README keeps docs",
+			chunks:      []ContentChunk{chunk("docs/README.md", dedupReadme), chunk("docs/index.md", dedupReadme)},
+			threshold:   DefaultDocsDedupThreshold,
+			wantSources: []string{"docs/README.md", "docs/index.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, duplicates := dedupeDocs(tt.chunks, tt.threshold)
+
+			var sources []string
+			for _, c := range kept {
+				if !slices.Contains(sources, c.Source) {
+					sources = append(sources, c.Source)
+				}
+			}
+
+			if !slices.Equal(sources, tt.wantSources) {
+				t.Errorf("kept sources = %v, want %v", sources, tt.wantSources)
+			}
+
+			if !maps.Equal(duplicates, tt.wantDuplicates) {
+				t.Errorf("duplicates = %v, want %v", duplicates, tt.wantDuplicates)
+			}
+		})
+	}
+}
+
+func TestDocsSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		wantMin float64
+		wantMax float64
+	}{
+		{name: "identical", a: dedupReadme, b: dedupReadme, wantMin: 1, wantMax: 1},
+		{name: "markup only differs", a: dedupReadme, b: dedupDocsCopy, wantMin: 0.8, wantMax: 1},
+		{name: "unrelated", a: dedupReadme, b: dedupDocsGuide, wantMin: 0, wantMax: 0.1},
+		{name: "empty", a: dedupReadme, b: "", wantMin: 0, wantMax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := docsSimilarity(shingles(tt.a), shingles(tt.b))
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("docsSimilarity() = %.3f, want between %.2f and %.2f", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestProcessRepository_DedupesDocs(t *testing.T) {
+	file := func(path, text string) github.Content {
+		return github.Content{
+			Path:     path,
+			Type:     "file",
+			Content:  base64.StdEncoding.EncodeToString([]byte(text)),
+			Encoding: "base64",
+			Size:     len(text),
+		}
+	}
+
+	content := []github.Content{file("README.md", dedupReadme), file("docs/index.md", dedupDocsCopy)}
+	repo := github.Repository{FullName: "test/widget"}
+
+	tests := []struct {
+		name           string
+		opts           []ServiceOption
+		wantDuplicates int
+	}{
+		{name: "default threshold", wantDuplicates: 1},
+		{name: "disabled", opts: []ServiceOption{WithDocsDedupThreshold(0)}, wantDuplicates: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(&mockGitHubClient{}, tt.opts...)
+
+			processed, err := service.ProcessRepository(context.Background(), repo, content)
+			if err != nil {
+				t.Fatalf("ProcessRepository failed: %v", err)
+			}
+
+			if len(processed.DuplicateDocs) != tt.wantDuplicates {
+				t.Errorf("DuplicateDocs = %v, want %d entries", processed.DuplicateDocs, tt.wantDuplicates)
+			}
+
+			for _, c := range processed.Chunks {
+				if c.Source == "docs/index.md" && tt.wantDuplicates > 0 {
+					t.Error("chunks of the duplicate docs index should be dropped")
+				}
+			}
+		})
+	}
+}
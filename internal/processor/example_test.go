@@ -81,6 +81,6 @@ func ExampleService_ProcessRepository() {
 	// Output:
 	// Processed repository: example/demo-project
 	// Number of chunks: 1
-	// Content hash length: 64
+	// Content hash length: 67
 	// Chunk 1: README.md (readme, priority 1)
 }
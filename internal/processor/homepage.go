@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// HomepageSource is the content path and chunk source of the text fetched from
+// a repository's homepage
+const HomepageSource = "homepage"
+
+// maxHomepageChars bounds the homepage text, as landing pages are often long
+// and mostly navigation
+const maxHomepageChars = 8000
+
+// HomepageFetcher fetches the text of an external web page. The GitHub client
+// implements it; a GitHubClient that does not is never asked for homepages.
+type HomepageFetcher interface {
+	GetHomepageText(ctx context.Context, url string) (string, error)
+}
+
+// WithHomepageText adds the text of each repository's homepage to its content
+// when enabled. A homepage that cannot be fetched is skipped.
+func WithHomepageText(enabled bool) ServiceOption {
+	return func(s *serviceImpl) {
+		s.homepageText = enabled
+	}
+}
+
+// homepageContent fetches repo's homepage as a content file. It reports false
+// when homepages are disabled, the repository has none (or only a GitHub URL,
+// which would repeat the README), or the fetch fails.
+func (s *serviceImpl) homepageContent(ctx context.Context, repo github.Repository) (github.Content, bool) {
+	if !s.homepageText || repo.Homepage == "" {
+		return github.Content{}, false
+	}
+
+	fetcher, ok := s.githubClient.(HomepageFetcher)
+	if !ok {
+		return github.Content{}, false
+	}
+
+	if u, err := url.Parse(repo.Homepage); err == nil {
+		host := strings.ToLower(u.Hostname())
+		if host == "github.com" || strings.HasSuffix(host, ".github.com") {
+			return github.Content{}, false
+		}
+	}
+
+	text, err := fetcher.GetHomepageText(ctx, repo.Homepage)
+	if err != nil {
+		slog.Debug("Skipping homepage",
+			slog.String("repository", repo.FullName),
+			slog.String("url", repo.Homepage),
+			slog.String("error", err.Error()))
+
+		return github.Content{}, false
+	}
+
+	text = truncateRunes(strings.TrimSpace(text), maxHomepageChars)
+	if text == "" {
+		return github.Content{}, false
+	}
+
+	return github.Content{Path: HomepageSource, Type: "file", Content: text, Size: len(text)}, true
+}
+
+// truncateRunes shortens s to at most n runes. Unlike formatter.TruncateRunes
+// it adds no ellipsis, and the formatter imports this package.
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// homepageClient serves repository content and homepage text
+type homepageClient struct {
+	mockGitHubClient
+	homepages map[string]string
+	fetched   []string
+}
+
+func (c *homepageClient) GetHomepageText(_ context.Context, url string) (string, error) {
+	c.fetched = append(c.fetched, url)
+
+	text, ok := c.homepages[url]
+	if !ok {
+		return "", errors.New("HTTP error: 404")
+	}
+
+	return text, nil
+}
+
+func TestExtractContentHomepage(t *testing.T) {
+	readme := github.Content{Path: "README.md", Type: "file", Content: "# Demo", Size: 6}
+	longPage := strings.Repeat("é", maxHomepageChars+10)
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		homepage   string
+		wantText   string
+		wantFetch  bool
+		wantChunks int
+	}{
+		{name: "disabled", homepage: "https://demo.dev", wantFetch: false},
+		{name: "no homepage", enabled: true, wantFetch: false},
+		{name: "fetched", enabled: true, homepage: "https://demo.dev", wantText: "Demo lands here", wantFetch: true},
+		{name: "github homepage", enabled: true, homepage: "https://github.com/user/demo", wantFetch: false},
+		{name: "fetch error", enabled: true, homepage: "https://broken.dev", wantFetch: true},
+		{name: "truncated", enabled: true, homepage: "https://long.dev", wantText: longPage[:2*maxHomepageChars], wantFetch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &homepageClient{
+				mockGitHubClient: mockGitHubClient{content: []github.Content{readme}},
+				homepages: map[string]string{
+					"https://demo.dev":             "  Demo lands here\n",
+					"https://long.dev":             longPage,
+					"https://github.com/user/demo": "README again",
+				},
+			}
+			service := NewService(client, WithHomepageText(tt.enabled))
+			repo := github.Repository{FullName: "user/demo", Homepage: tt.homepage}
+
+			content, err := service.ExtractContent(context.Background(), repo)
+			if err != nil {
+				t.Fatalf("ExtractContent failed: %v", err)
+			}
+
+			if fetched := len(client.fetched) > 0; fetched != tt.wantFetch {
+				t.Errorf("homepage fetched = %v, want %v", fetched, tt.wantFetch)
+			}
+
+			if tt.wantText == "" {
+				if len(content) != 1 {
+					t.Errorf("expected only the README, got %d files", len(content))
+				}
+
+				return
+			}
+
+			if len(content) != 2 || content[1].Path != HomepageSource {
+				t.Fatalf("expected the README and the homepage, got %+v", content)
+			}
+
+			if content[1].Content != tt.wantText {
+				t.Errorf("homepage text = %q, want %q", content[1].Content, tt.wantText)
+			}
+
+			processed, err := service.ProcessRepository(context.Background(), repo, content)
+			if err != nil {
+				t.Fatalf("ProcessRepository failed: %v", err)
+			}
+
+			for _, chunk := range processed.Chunks {
+				if strings.HasPrefix(chunk.Source, HomepageSource) {
+					if chunk.Type != ContentTypeDocs || chunk.Priority != PriorityMedium {
+						t.Errorf("homepage chunk is %s/%d, want docs/medium", chunk.Type, chunk.Priority)
+					}
+
+					return
+				}
+			}
+
+			t.Error("no chunk has the homepage as its source")
+		})
+	}
+}
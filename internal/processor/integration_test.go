@@ -127,8 +127,8 @@ func TestIntegrationContentExtraction(t *testing.T) {
 			t.Error("Chunk has invalid token count")
 		}
 
-		if chunk.Tokens > MaxTokensPerChunk {
-			t.Errorf("Chunk exceeds max tokens: %d > %d", chunk.Tokens, MaxTokensPerChunk)
+		if chunk.Tokens > DefaultMaxTokensPerChunk {
+			t.Errorf("Chunk exceeds max tokens: %d > %d", chunk.Tokens, DefaultMaxTokensPerChunk)
 		}
 	}
 
@@ -219,14 +219,14 @@ func TestIntegrationContentExtractionWithLargeContent(t *testing.T) {
 		totalTokens += chunk.Tokens
 	}
 
-	if totalTokens > MaxTotalTokens {
-		t.Errorf("Total tokens exceed limit: %d > %d", totalTokens, MaxTotalTokens)
+	if totalTokens > DefaultMaxTotalTokens {
+		t.Errorf("Total tokens exceed limit: %d > %d", totalTokens, DefaultMaxTotalTokens)
 	}
 
 	// Verify each chunk respects token limits
 	for i, chunk := range processed.Chunks {
-		if chunk.Tokens > MaxTokensPerChunk {
-			t.Errorf("Chunk %d exceeds max tokens: %d > %d", i, chunk.Tokens, MaxTokensPerChunk)
+		if chunk.Tokens > DefaultMaxTokensPerChunk {
+			t.Errorf("Chunk %d exceeds max tokens: %d > %d", i, chunk.Tokens, DefaultMaxTokensPerChunk)
 		}
 	}
 
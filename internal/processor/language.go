@@ -0,0 +1,133 @@
+package processor
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Content language detection is deliberately lightweight: the writing system
+// decides non-Latin languages, and common stopwords separate Latin-script ones.
+// It only needs to tell "English" from "clearly something else" for README text.
+const (
+	// minLanguageLetters is the least text, in letters, worth classifying
+	minLanguageLetters = 20
+	// nonLatinScriptShare is the share of letters a non-Latin script needs to win.
+	// CJK characters carry a word or more each, so they win well below half.
+	nonLatinScriptShare = 0.2
+	// minStopwordHits is the least stopword evidence for a Latin-script language
+	minStopwordHits = 3
+)
+
+var (
+	fencedCodePattern = regexp.MustCompile("(?s)```.*?```")
+	inlineCodePattern = regexp.MustCompile("`[^`\n]*`")
+	urlPattern        = regexp.MustCompile(`https?://\S+`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>]+>`)
+)
+
+// scriptLanguages maps a writing system to the language it identifies, in
+// precedence order (kana before Han so Japanese is not read as Chinese)
+var scriptLanguages = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+}
+
+// latinStopwords are frequent function words that rarely appear in the other
+// listed languages
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "is", "to", "of", "for", "with", "this", "that", "you", "are", "it"},
+	"es": {"el", "los", "las", "del", "es", "para", "con", "una", "por", "que", "se", "como"},
+	"fr": {"le", "les", "des", "est", "pour", "avec", "une", "dans", "vous", "sur", "du", "et"},
+	"de": {"der", "die", "das", "und", "ist", "mit", "für", "nicht", "ein", "eine", "sie", "auf"},
+	"pt": {"os", "das", "dos", "para", "com", "uma", "não", "em", "ao", "que", "é", "você"},
+	"it": {"il", "gli", "della", "per", "con", "una", "sono", "di", "che", "non", "questo", "è"},
+}
+
+// DetectLanguage returns the ISO 639-1 code of the dominant natural language in
+// text, or "" when there is too little prose to tell. Code blocks, URLs and HTML
+// are ignored so a translated README with English examples is still classified
+// by its prose.
+func DetectLanguage(text string) string {
+	text = fencedCodePattern.ReplaceAllString(text, " ")
+	text = inlineCodePattern.ReplaceAllString(text, " ")
+	text = urlPattern.ReplaceAllString(text, " ")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+
+	letters := 0
+	scriptCounts := make(map[string]int)
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.table, r) {
+				scriptCounts[script.language]++
+				break
+			}
+		}
+	}
+
+	if letters < minLanguageLetters {
+		return ""
+	}
+
+	// Any kana means Japanese, even when kanji outnumber it
+	if scriptCounts["ja"] > 0 && float64(scriptCounts["ja"]+scriptCounts["zh"]) >= nonLatinScriptShare*float64(letters) {
+		return "ja"
+	}
+
+	for _, script := range scriptLanguages {
+		if float64(scriptCounts[script.language]) >= nonLatinScriptShare*float64(letters) {
+			return script.language
+		}
+	}
+
+	return detectLatinLanguage(text)
+}
+
+// detectLatinLanguage picks the Latin-script language with the most stopword hits
+func detectLatinLanguage(text string) string {
+	counts := make(map[string]int)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		counts[word]++
+	}
+
+	best, bestHits := "", 0
+
+	// Iterate in a fixed order so ties resolve the same way every run
+	for _, language := range []string{"en", "es", "fr", "de", "pt", "it"} {
+		hits := 0
+		for _, stopword := range latinStopwords[language] {
+			hits += counts[stopword]
+		}
+
+		if hits > bestHits {
+			best, bestHits = language, hits
+		}
+	}
+
+	if bestHits < minStopwordHits {
+		return ""
+	}
+
+	return best
+}
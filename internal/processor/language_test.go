@@ -0,0 +1,65 @@
+package processor
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "english",
+			text: "# Tool\n\nThis is a fast tool for the command line. It works with any shell and you can install it with Homebrew.",
+			want: "en",
+		},
+		{
+			name: "chinese with english code",
+			text: "# 工具\n\n这是一个用于命令行的快速工具，支持所有常见的终端环境。\n\n```bash\nbrew install the-tool --with-all-the-options\n```",
+			want: "zh",
+		},
+		{
+			name: "japanese",
+			text: "# ツール\n\nこれはコマンドライン用の高速なツールです。どのシェルでも動作します。",
+			want: "ja",
+		},
+		{
+			name: "korean",
+			text: "# 도구\n\n이것은 명령줄을 위한 빠른 도구입니다. 모든 셸에서 작동합니다.",
+			want: "ko",
+		},
+		{
+			name: "russian",
+			text: "# Инструмент\n\nЭто быстрый инструмент для командной строки. Он работает с любой оболочкой.",
+			want: "ru",
+		},
+		{
+			name: "spanish",
+			text: "# Herramienta\n\nEs una herramienta para la línea de comandos. Funciona con todos los shells y se instala con Homebrew, como el resto del proyecto.",
+			want: "es",
+		},
+		{
+			name: "german",
+			text: "# Werkzeug\n\nDas ist ein schnelles Werkzeug für die Kommandozeile. Es funktioniert mit jeder Shell und ist nicht schwer zu installieren.",
+			want: "de",
+		},
+		{
+			name: "too short",
+			text: "# Tool",
+			want: "",
+		},
+		{
+			name: "code only",
+			text: "```go\npackage main\n\nfunc main() { fmt.Println(\"the and is to of\") }\n```",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
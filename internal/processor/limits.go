@@ -0,0 +1,43 @@
+package processor
+
+// Default content limits, used for any limit that is not configured
+const (
+	DefaultMaxFileKB         = 512
+	DefaultMaxTotalTokens    = 50000
+	DefaultMaxTokensPerChunk = 2000
+)
+
+// Limits bounds how much content is processed per repository. Tokens are
+// estimated at four characters each.
+type Limits struct {
+	MaxFileKB         int // Fetched files larger than this are skipped
+	MaxTotalTokens    int // Chunks beyond this many tokens per repository are dropped
+	MaxTokensPerChunk int // Content is split into chunks of at most this many tokens
+}
+
+// DefaultLimits returns the built-in content limits
+func DefaultLimits() Limits {
+	return Limits{
+		MaxFileKB:         DefaultMaxFileKB,
+		MaxTotalTokens:    DefaultMaxTotalTokens,
+		MaxTokensPerChunk: DefaultMaxTokensPerChunk,
+	}
+}
+
+// WithLimits sets the content limits. Fields that are not positive keep their
+// default.
+func WithLimits(limits Limits) ServiceOption {
+	return func(s *serviceImpl) {
+		if limits.MaxFileKB > 0 {
+			s.limits.MaxFileKB = limits.MaxFileKB
+		}
+
+		if limits.MaxTotalTokens > 0 {
+			s.limits.MaxTotalTokens = limits.MaxTotalTokens
+		}
+
+		if limits.MaxTokensPerChunk > 0 {
+			s.limits.MaxTokensPerChunk = limits.MaxTokensPerChunk
+		}
+	}
+}
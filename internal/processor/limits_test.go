@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+func TestWithLimits(t *testing.T) {
+	s := newService(nil, nil, []ServiceOption{WithLimits(Limits{MaxTotalTokens: 100, MaxTokensPerChunk: -1})})
+
+	assert.Equal(t, Limits{
+		MaxFileKB:         DefaultMaxFileKB,
+		MaxTotalTokens:    100,
+		MaxTokensPerChunk: DefaultMaxTokensPerChunk,
+	}, s.limits, "limits that are not positive keep their default")
+}
+
+func TestProcessRepository_EnforcesConfiguredLimits(t *testing.T) {
+	repo := createTestRepo("owner/docs-heavy")
+	page := strings.Repeat("word word word word\n", 100) // 500 tokens on short lines
+
+	var content []github.Content
+	for _, path := range []string{"README.md", "docs/a.md", "docs/b.md", "docs/c.md"} {
+		content = append(content, encodedContent(path, "", "# Title\n\n"+page))
+	}
+
+	t.Run("total token budget", func(t *testing.T) {
+		service := NewService(&mockGitHubClientSimple{}, WithLimits(Limits{MaxTotalTokens: 1200}))
+
+		processed, err := service.ProcessRepository(context.Background(), repo, content)
+		require.NoError(t, err)
+
+		totalTokens := 0
+		for _, chunk := range processed.Chunks {
+			totalTokens += chunk.Tokens
+		}
+
+		assert.LessOrEqual(t, totalTokens, 1200)
+		assert.Len(t, processed.Chunks, 2, "only two 500-token files fit the budget")
+		assert.Equal(t, "README.md", processed.Chunks[0].Source, "the README has the highest priority")
+	})
+
+	t.Run("larger total token budget keeps every file", func(t *testing.T) {
+		service := NewService(&mockGitHubClientSimple{}, WithLimits(Limits{MaxTotalTokens: 10000}))
+
+		processed, err := service.ProcessRepository(context.Background(), repo, content)
+		require.NoError(t, err)
+		assert.Len(t, processed.Chunks, 4)
+	})
+
+	t.Run("tokens per chunk", func(t *testing.T) {
+		service := NewService(&mockGitHubClientSimple{}, WithLimits(Limits{MaxTokensPerChunk: 100}))
+
+		processed, err := service.ProcessRepository(context.Background(), repo, content[:1])
+		require.NoError(t, err)
+		require.Greater(t, len(processed.Chunks), 1, "the README is split")
+
+		for _, chunk := range processed.Chunks {
+			assert.LessOrEqual(t, chunk.Tokens, 100)
+			assert.Equal(t, 100, chunk.SourceLimit)
+		}
+	})
+}
+
+func TestFilterContent_MaxFileKB(t *testing.T) {
+	content := []github.Content{
+		{Path: "README.md", Type: "file", Size: 2 * 1024},
+		{Path: "docs/large.md", Type: "file", Size: 2*1024 + 1},
+	}
+
+	paths := func(files []github.Content) []string {
+		var out []string
+		for _, f := range files {
+			out = append(out, f.Path)
+		}
+
+		return out
+	}
+
+	small := newService(nil, nil, []ServiceOption{WithLimits(Limits{MaxFileKB: 2})})
+	assert.Equal(t, []string{"README.md"}, paths(small.filterContent(content)))
+
+	defaults := newService(nil, nil, nil)
+	assert.Equal(t, []string{"README.md", "docs/large.md"}, paths(defaults.filterContent(content)))
+}
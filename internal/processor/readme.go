@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// README formats recorded on a processed repository, so detail views can render
+// the README with the right markup
+const (
+	ReadmeFormatMarkdown = "markdown"
+	ReadmeFormatRST      = "rst"
+	ReadmeFormatText     = "text"
+)
+
+// ReadmeFormat returns the markup format of a README from its file name:
+// markdown for .md/.markdown, rst for .rst, and text for .txt, a bare README or
+// any other extension
+func ReadmeFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return ReadmeFormatMarkdown
+	case ".rst":
+		return ReadmeFormatRST
+	default:
+		return ReadmeFormatText
+	}
+}
+
+// findReadme returns the path and format of the top-level README among the
+// chunks, or empty strings when the repository has none. READMEs in
+// subdirectories (docs/README.md) are documentation, not the repository README.
+func findReadme(chunks []ContentChunk) (string, string) {
+	for _, chunk := range chunks {
+		if chunk.Type == ContentTypeReadme && !strings.Contains(chunk.Source, "/") {
+			return chunk.Source, ReadmeFormat(chunk.Source)
+		}
+	}
+
+	return "", ""
+}
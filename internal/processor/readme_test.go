@@ -0,0 +1,64 @@
+package processor
+
+import "testing"
+
+func TestReadmeFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "README.md", want: ReadmeFormatMarkdown},
+		{path: "readme.markdown", want: ReadmeFormatMarkdown},
+		{path: "README.rst", want: ReadmeFormatRST},
+		{path: "README.txt", want: ReadmeFormatText},
+		{path: "README", want: ReadmeFormatText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ReadmeFormat(tt.path); got != tt.want {
+				t.Errorf("ReadmeFormat(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindReadme(t *testing.T) {
+	tests := []struct {
+		name       string
+		chunks     []ContentChunk
+		wantPath   string
+		wantFormat string
+	}{
+		{
+			name: "top-level rst",
+			chunks: []ContentChunk{
+				{Source: "README.rst", Type: ContentTypeReadme},
+				{Source: "main.go", Type: ContentTypeCode},
+			},
+			wantPath:   "README.rst",
+			wantFormat: ReadmeFormatRST,
+		},
+		{
+			name: "docs readme is not the repository readme",
+			chunks: []ContentChunk{
+				{Source: "docs/README.md", Type: ContentTypeReadme},
+			},
+		},
+		{
+			name: "no readme",
+			chunks: []ContentChunk{
+				{Source: "go.mod", Type: ContentTypePackage},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, format := findReadme(tt.chunks)
+			if path != tt.wantPath || format != tt.wantFormat {
+				t.Errorf("findReadme() = (%q, %q), want (%q, %q)", path, format, tt.wantPath, tt.wantFormat)
+			}
+		})
+	}
+}
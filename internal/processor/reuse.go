@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// ChunkStore looks up the chunks stored for a repository by the git blob SHA of
+// the file they came from. The storage layer implements it; without one every
+// file is decoded and chunked on each sync.
+type ChunkStore interface {
+	ChunksBySourceSHA(ctx context.Context, fullName string) (map[string][]ContentChunk, error)
+}
+
+// WithChunkStore reuses stored chunks for files whose blob SHA is unchanged,
+// skipping their decoding and chunking
+func WithChunkStore(store ChunkStore) ServiceOption {
+	return func(s *serviceImpl) {
+		s.chunkStore = store
+	}
+}
+
+// storedChunks returns the chunks previously stored for repo, keyed by source
+// SHA, or nil when there is no store or the lookup fails. A failed lookup only
+// costs the reuse, so it is logged rather than returned.
+func (s *serviceImpl) storedChunks(ctx context.Context, repo github.Repository) map[string][]ContentChunk {
+	if s.chunkStore == nil {
+		return nil
+	}
+
+	stored, err := s.chunkStore.ChunksBySourceSHA(ctx, repo.FullName)
+	if err != nil {
+		slog.Debug("Skipping chunk reuse",
+			slog.String("repository", repo.FullName),
+			slog.String("error", err.Error()))
+
+		return nil
+	}
+
+	return stored
+}
+
+// reusableChunks returns the stored chunks of file when they are complete and
+// were cut from the same path, as the same content type, at the same blob SHA
+// and with the current chunk size limit.
+// Priorities and token estimates are not stored, so they are derived again.
+func (s *serviceImpl) reusableChunks(stored map[string][]ContentChunk, file github.Content) ([]ContentChunk, bool) {
+	if file.SHA == "" {
+		return nil, false
+	}
+
+	contentType := s.determineContentType(file.Path)
+
+	// Files with identical content share a SHA, so keep this path's chunks
+	var chunks []ContentChunk
+
+	for _, chunk := range stored[file.SHA] {
+		if chunk.Source != file.Path && !strings.HasPrefix(chunk.Source, file.Path+"#") {
+			continue
+		}
+
+		if chunk.Type != contentType {
+			return nil, false
+		}
+
+		chunk.Tokens = estimateTokens(chunk.Content)
+		chunk.Priority = s.determinePriority(chunk.Type, file.Path)
+		chunks = append(chunks, chunk)
+	}
+
+	// Chunks cut with another size limit would be cut differently now
+	if len(chunks) == 0 || chunks[0].SourceParts != len(chunks) ||
+		chunks[0].SourceLimit != s.limits.MaxTokensPerChunk {
+		return nil, false
+	}
+
+	return chunks, true
+}
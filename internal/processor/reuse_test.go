@@ -0,0 +1,161 @@
+package processor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+)
+
+// fakeChunkStore serves chunks grouped by source SHA, as stored by a previous
+// sync
+type fakeChunkStore struct {
+	chunks map[string][]ContentChunk
+	err    error
+}
+
+func (f *fakeChunkStore) ChunksBySourceSHA(context.Context, string) (map[string][]ContentChunk, error) {
+	return f.chunks, f.err
+}
+
+// storeChunks groups chunks by source SHA, as the storage layer returns them.
+// Priorities and token estimates are not stored, so they are dropped.
+func storeChunks(chunks []ContentChunk) map[string][]ContentChunk {
+	stored := make(map[string][]ContentChunk)
+
+	for _, chunk := range chunks {
+		chunk.Tokens, chunk.Priority = 0, 0
+		stored[chunk.SourceSHA] = append(stored[chunk.SourceSHA], chunk)
+	}
+
+	return stored
+}
+
+func encodedContent(path, sha, text string) github.Content {
+	return github.Content{
+		Path:     path,
+		Type:     "file",
+		Content:  base64.StdEncoding.EncodeToString([]byte(text)),
+		Encoding: "base64",
+		SHA:      sha,
+	}
+}
+
+func TestProcessRepositoryReusesChunksOfUnchangedFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := github.Repository{FullName: "user/demo"}
+
+	sections := make([]string, 3)
+	for i := range sections {
+		sections[i] = "## Section\n\n" + strings.Repeat("word ", 2*DefaultMaxTokensPerChunk)
+	}
+
+	content := []github.Content{
+		encodedContent("README.md", "readme-sha", "# Demo\n\n"+strings.Join(sections, "\n")),
+		encodedContent("docs/guide.md", "guide-sha", "A short guide"),
+	}
+
+	first, err := NewService(&mockGitHubClient{}).ProcessRepository(ctx, repo, content)
+	if err != nil {
+		t.Fatalf("ProcessRepository() error = %v", err)
+	}
+
+	if len(first.Chunks) < 3 {
+		t.Fatalf("expected the README to be split into several chunks, got %d", len(first.Chunks))
+	}
+
+	for _, chunk := range first.Chunks {
+		if chunk.SourceSHA == "" || chunk.SourceParts == 0 {
+			t.Fatalf("chunk %s has no source SHA or part count", chunk.Source)
+		}
+	}
+
+	// Unchanged SHAs with undecodable content: the files are only reused,
+	// never decoded, or they would be skipped
+	unchanged := []github.Content{
+		{Path: "README.md", Type: "file", Content: "!not base64!", Encoding: "base64", SHA: "readme-sha"},
+		{Path: "docs/guide.md", Type: "file", Content: "!not base64!", Encoding: "base64", SHA: "guide-sha"},
+	}
+
+	store := &fakeChunkStore{chunks: storeChunks(first.Chunks)}
+
+	second, err := NewService(&mockGitHubClient{}, WithChunkStore(store)).ProcessRepository(ctx, repo, unchanged)
+	if err != nil {
+		t.Fatalf("ProcessRepository() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(first.Chunks, second.Chunks) {
+		t.Errorf("reused chunks differ from the original ones:\n got %+v\nwant %+v", second.Chunks, first.Chunks)
+	}
+	// A different chunk size limit cuts the file differently, so it is decoded
+	// again; the undecodable content is then skipped
+	resized, err := NewService(&mockGitHubClient{}, WithChunkStore(store),
+		WithLimits(Limits{MaxTokensPerChunk: DefaultMaxTokensPerChunk / 2})).ProcessRepository(ctx, repo, unchanged)
+	if err != nil {
+		t.Fatalf("ProcessRepository() error = %v", err)
+	}
+
+	if len(resized.Chunks) != 0 {
+		t.Errorf("expected no chunks reused under another size limit, got %d", len(resized.Chunks))
+	}
+}
+
+func TestReusableChunks(t *testing.T) {
+	s := newService(&mockGitHubClient{}, nil, nil)
+	stored := map[string][]ContentChunk{
+		"shared": {
+			{Source: "README.md", Type: ContentTypeReadme, Content: "same", SourceSHA: "shared", SourceParts: 1, SourceLimit: DefaultMaxTokensPerChunk},
+			{Source: "docs/README.md", Type: ContentTypeReadme, Content: "same", SourceSHA: "shared", SourceParts: 1, SourceLimit: DefaultMaxTokensPerChunk},
+		},
+		"partial": {
+			{Source: "CHANGELOG.md#1", Type: ContentTypeChangelog, Content: "v1", SourceSHA: "partial", SourceParts: 2, SourceLimit: DefaultMaxTokensPerChunk},
+		},
+		"moved": {
+			{Source: "old.md", Type: ContentTypeDocs, Content: "text", SourceSHA: "moved", SourceParts: 1, SourceLimit: DefaultMaxTokensPerChunk},
+		},
+	}
+
+	tests := []struct {
+		name string
+		file github.Content
+		want string // Content of the single reused chunk; "" when nothing is reused
+	}{
+		{name: "identical files keep their own chunks", file: github.Content{Path: "docs/README.md", SHA: "shared"}, want: "same"},
+		{name: "partially stored file", file: github.Content{Path: "CHANGELOG.md", SHA: "partial"}},
+		{name: "same SHA at another path", file: github.Content{Path: "new.md", SHA: "moved"}},
+		{name: "changed SHA", file: github.Content{Path: "README.md", SHA: "edited"}},
+		{name: "no SHA", file: github.Content{Path: "README.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, ok := s.reusableChunks(stored, tt.file)
+			if ok != (tt.want != "") {
+				t.Fatalf("reusableChunks() ok = %v, want %v", ok, tt.want != "")
+			}
+
+			if ok && (len(chunks) != 1 || chunks[0].Content != tt.want || chunks[0].Source != tt.file.Path) {
+				t.Errorf("reusableChunks() = %+v, want one chunk of %s", chunks, tt.file.Path)
+			}
+		})
+	}
+}
+
+func TestProcessRepositoryChunkStoreError(t *testing.T) {
+	store := &fakeChunkStore{err: errors.New("database is locked")}
+	content := []github.Content{encodedContent("README.md", "readme-sha", "# Demo")}
+
+	processed, err := NewService(&mockGitHubClient{}, WithChunkStore(store)).
+		ProcessRepository(context.Background(), github.Repository{FullName: "user/demo"}, content)
+	if err != nil {
+		t.Fatalf("ProcessRepository() error = %v", err)
+	}
+
+	if len(processed.Chunks) != 1 || processed.Chunks[0].Content != "# Demo" {
+		t.Errorf("expected the README to be chunked after a failed lookup, got %+v", processed.Chunks)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/KyleKing/gh-star-search/internal/github"
+	"github.com/KyleKing/gh-star-search/internal/timing"
 )
 
 // Service defines the interface for content processing operations
@@ -30,19 +31,27 @@ type Service interface {
 
 // ContentChunk represents a processed piece of repository content
 type ContentChunk struct {
-	Source   string `json:"source"` // file path or section
-	Type     string `json:"type"`   // readme, code, docs, etc.
-	Content  string `json:"content"`
-	Tokens   int    `json:"tokens"`
-	Priority int    `json:"priority"` // for size limit handling
+	Source      string `json:"source"` // file path or section
+	Type        string `json:"type"`   // readme, code, docs, etc.
+	Content     string `json:"content"`
+	Tokens      int    `json:"tokens"`
+	Priority    int    `json:"priority"`               // for size limit handling
+	SourceSHA   string `json:"source_sha,omitempty"`   // Git blob SHA of the source file; "" when it has none
+	SourceParts int    `json:"source_parts,omitempty"` // Number of chunks the source file was cut into
+	SourceLimit int    `json:"source_limit,omitempty"` // Limits.MaxTokensPerChunk the source file was cut with
 }
 
 // ProcessedRepo represents a fully processed repository with chunks
 type ProcessedRepo struct {
-	Repository  github.Repository `json:"repository"`
-	Chunks      []ContentChunk    `json:"chunks"`
-	ProcessedAt time.Time         `json:"processed_at"`
-	ContentHash string            `json:"content_hash"` // For change detection
+	Repository        github.Repository `json:"repository"`
+	Chunks            []ContentChunk    `json:"chunks"`
+	ProcessedAt       time.Time         `json:"processed_at"`
+	ContentHash       string            `json:"content_hash"`               // For change detection; see ContentChangedSince
+	LegacyContentHash string            `json:"-"`                          // Unversioned chunk hash, compared with hashes stored before versioning
+	ContentLanguage   string            `json:"content_language,omitempty"` // ISO 639-1 code from the README; "" when unknown
+	ReadmePath        string            `json:"readme_path,omitempty"`      // Top-level README file, e.g. README.rst; "" when none was found
+	ReadmeFormat      string            `json:"readme_format,omitempty"`    // ReadmeFormat of ReadmePath; "" when none was found
+	DuplicateDocs     map[string]string `json:"duplicate_docs,omitempty"`   // Docs files dropped as near-copies of the README, mapped to the README path
 }
 
 // ContentType constants for different types of repository content
@@ -63,12 +72,6 @@ const (
 	PriorityLow    = 3
 )
 
-// Token limits for content processing
-const (
-	MaxTokensPerChunk = 2000
-	MaxTotalTokens    = 50000 // Total tokens per repository
-)
-
 // GitHubClient interface for fetching repository content
 type GitHubClient interface {
 	GetRepositoryContent(
@@ -80,8 +83,13 @@ type GitHubClient interface {
 
 // serviceImpl implements the Service interface
 type serviceImpl struct {
-	githubClient GitHubClient
-	cache        ContentCache
+	githubClient       GitHubClient
+	cache              ContentCache
+	cacheCounters      cacheCounters
+	docsDedupThreshold float64 // Similarity at which a docs index duplicating the README is dropped; 0 disables
+	homepageText       bool    // Add the text of the repository's homepage to its content
+	chunkStore         ChunkStore
+	limits             Limits
 }
 
 // ContentCache interface for caching repository content
@@ -91,18 +99,28 @@ type ContentCache interface {
 }
 
 // NewService creates a new processor service
-func NewService(githubClient GitHubClient) Service {
-	return &serviceImpl{
-		githubClient: githubClient,
-	}
+func NewService(githubClient GitHubClient, opts ...ServiceOption) Service {
+	return newService(githubClient, nil, opts)
 }
 
 // NewServiceWithCache creates a new processor service with caching
-func NewServiceWithCache(githubClient GitHubClient, cache ContentCache) Service {
-	return &serviceImpl{
-		githubClient: githubClient,
-		cache:        cache,
+func NewServiceWithCache(githubClient GitHubClient, cache ContentCache, opts ...ServiceOption) Service {
+	return newService(githubClient, cache, opts)
+}
+
+func newService(githubClient GitHubClient, cache ContentCache, opts []ServiceOption) *serviceImpl {
+	s := &serviceImpl{
+		githubClient:       githubClient,
+		cache:              cache,
+		docsDedupThreshold: DefaultDocsDedupThreshold,
+		limits:             DefaultLimits(),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // ProcessRepository processes a repository by extracting content and generating summaries
@@ -111,26 +129,52 @@ func (s *serviceImpl) ProcessRepository(
 	repo github.Repository,
 	content []github.Content,
 ) (*ProcessedRepo, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseProcessing)()
+
 	// Extract and chunk content
 	chunks, err := s.extractAndChunkContent(ctx, repo, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract and chunk content: %w", err)
 	}
 
-	// Generate content hash for change detection
-	contentHash := s.generateContentHash(chunks)
+	chunks, duplicateDocs := dedupeDocs(chunks, s.docsDedupThreshold)
 
 	// Create processed repository
 	processed := &ProcessedRepo{
-		Repository:  repo,
-		Chunks:      chunks,
-		ProcessedAt: time.Now(),
-		ContentHash: contentHash,
+		Repository:        repo,
+		Chunks:            chunks,
+		ProcessedAt:       time.Now(),
+		ContentHash:       generateBlobHash(content),
+		LegacyContentHash: s.generateContentHash(chunks),
+		ContentLanguage:   detectReadmeLanguage(chunks),
+		DuplicateDocs:     duplicateDocs,
 	}
 
+	processed.ReadmePath, processed.ReadmeFormat = findReadme(chunks)
+
 	return processed, nil
 }
 
+// detectReadmeLanguage detects the content language from the README chunks
+func detectReadmeLanguage(chunks []ContentChunk) string {
+	var readme strings.Builder
+
+	for _, chunk := range chunks {
+		if chunk.Type == ContentTypeReadme {
+			readme.WriteString(chunk.Content)
+			readme.WriteString("\n")
+		}
+	}
+
+	return DetectLanguage(readme.String())
+}
+
+// ContentCacheKey is the cache key for a repository's extracted content. It
+// includes the push timestamp, so a changed repository misses the cache.
+func ContentCacheKey(repo github.Repository) string {
+	return fmt.Sprintf("content:%s:%s", repo.FullName, repo.UpdatedAt.Format(time.RFC3339))
+}
+
 // ExtractContent extracts relevant content from a repository with caching
 func (s *serviceImpl) ExtractContent(
 	ctx context.Context,
@@ -138,14 +182,16 @@ func (s *serviceImpl) ExtractContent(
 ) ([]github.Content, error) {
 	// Try to get content from cache first
 	if s.cache != nil {
-		cacheKey := fmt.Sprintf("content:%s:%s", repo.FullName, repo.UpdatedAt.Format(time.RFC3339))
-
-		if cachedData, err := s.cache.Get(ctx, cacheKey); err == nil {
+		if cachedData, err := s.cache.Get(ctx, ContentCacheKey(repo)); err == nil {
 			var content []github.Content
 			if err := json.Unmarshal(cachedData, &content); err == nil {
+				s.recordCacheLookup(ctx, true)
+
 				return content, nil
 			}
 		}
+
+		s.recordCacheLookup(ctx, false)
 	}
 
 	// Define priority paths to extract
@@ -160,27 +206,33 @@ func (s *serviceImpl) ExtractContent(
 	// Filter and validate content
 	filteredContent := s.filterContent(content)
 
+	if homepage, ok := s.homepageContent(ctx, repo); ok {
+		filteredContent = append(filteredContent, homepage)
+	}
+
 	// Cache the result if cache is available
 	if s.cache != nil {
-		cacheKey := fmt.Sprintf("content:%s:%s", repo.FullName, repo.UpdatedAt.Format(time.RFC3339))
 		if cachedData, err := json.Marshal(filteredContent); err == nil {
 			// Cache for 24 hours
-			_ = s.cache.Set(ctx, cacheKey, cachedData, 24*time.Hour)
+			_ = s.cache.Set(ctx, ContentCacheKey(repo), cachedData, 24*time.Hour)
 		}
 	}
 
 	return filteredContent, nil
 }
 
-// extractAndChunkContent processes repository content into chunks
+// extractAndChunkContent processes repository content into chunks. Files
+// whose chunks are stored at the same blob SHA reuse them instead of being
+// decoded and chunked again.
 func (s *serviceImpl) extractAndChunkContent(
 	ctx context.Context,
-	_ github.Repository,
+	repo github.Repository,
 	content []github.Content,
 ) ([]ContentChunk, error) {
 	var allChunks []ContentChunk
 
 	totalTokens := 0
+	stored := s.storedChunks(ctx, repo)
 
 	// Process each content file
 	for _, file := range content {
@@ -190,22 +242,30 @@ func (s *serviceImpl) extractAndChunkContent(
 		default:
 		}
 
-		// Decode content if base64 encoded
-		decodedContent, err := s.decodeContent(file)
-		if err != nil {
-			continue // Skip files we can't decode
-		}
+		chunks, ok := s.reusableChunks(stored, file)
+		if !ok {
+			// Decode content if base64 encoded
+			decodedContent, err := s.decodeContent(file)
+			if err != nil {
+				continue // Skip files we can't decode
+			}
 
-		// Determine content type and priority
-		contentType := s.determineContentType(file.Path)
-		priority := s.determinePriority(contentType, file.Path)
+			// Determine content type and priority
+			contentType := s.determineContentType(file.Path)
+			priority := s.determinePriority(contentType, file.Path)
 
-		// Create chunks from the content
-		chunks := s.chunkContent(decodedContent, file.Path, contentType, priority)
+			// Create chunks from the content
+			chunks = s.chunkContent(decodedContent, file.Path, contentType, priority)
+			for i := range chunks {
+				chunks[i].SourceSHA = file.SHA
+				chunks[i].SourceParts = len(chunks)
+				chunks[i].SourceLimit = s.limits.MaxTokensPerChunk
+			}
+		}
 
 		// Add chunks while respecting token limits
 		for _, chunk := range chunks {
-			if totalTokens+chunk.Tokens > MaxTotalTokens {
+			if totalTokens+chunk.Tokens > s.limits.MaxTotalTokens {
 				break
 			}
 
@@ -213,7 +273,7 @@ func (s *serviceImpl) extractAndChunkContent(
 			totalTokens += chunk.Tokens
 		}
 
-		if totalTokens >= MaxTotalTokens {
+		if totalTokens >= s.limits.MaxTotalTokens {
 			break
 		}
 	}
@@ -226,9 +286,42 @@ func (s *serviceImpl) extractAndChunkContent(
 	return allChunks, nil
 }
 
+// LimitChunks caps chunks at maxChunks, keeping the highest-priority ones in
+// their original order, and returns how many were dropped. A maxChunks of zero
+// or less leaves chunks untouched.
+func LimitChunks(chunks []ContentChunk, maxChunks int) ([]ContentChunk, int) {
+	if maxChunks <= 0 || len(chunks) <= maxChunks {
+		return chunks, 0
+	}
+
+	order := make([]int, len(chunks))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return chunks[order[i]].Priority < chunks[order[j]].Priority
+	})
+
+	selected := order[:maxChunks]
+	sort.Ints(selected)
+
+	kept := make([]ContentChunk, 0, maxChunks)
+	for _, i := range selected {
+		kept = append(kept, chunks[i])
+	}
+
+	return kept, len(chunks) - maxChunks
+}
+
 // getPriorityPaths returns a list of file paths to prioritize for content extraction
-// Focuses on top-level documentation and key source files, avoiding tests and large assets
 func (s *serviceImpl) getPriorityPaths() []string {
+	return PriorityPaths()
+}
+
+// PriorityPaths lists the files fetched from each repository, one contents request each.
+// Focuses on top-level documentation and key source files, avoiding tests and large assets
+func PriorityPaths() []string {
 	return []string{
 		// README files (highest priority - top level only)
 		"README.md", "README.rst", "README.txt", "README",
@@ -266,8 +359,8 @@ func (s *serviceImpl) filterContent(content []github.Content) []github.Content {
 	var filtered []github.Content
 
 	for _, file := range content {
-		// Skip if file is too large (> 500KB for more selective downloading)
-		if file.Size > 512*1024 {
+		// Skip files over the size limit for more selective downloading
+		if file.Size > s.limits.MaxFileKB*1024 {
 			continue
 		}
 
@@ -371,23 +464,34 @@ func (s *serviceImpl) isExcludedPath(path string) bool {
 	return false
 }
 
+// DecodeContent returns the raw bytes of a file from the GitHub contents API,
+// decoding base64 when the API reports that encoding
+func DecodeContent(file github.Content) ([]byte, error) {
+	if file.Encoding != "base64" {
+		return []byte(file.Content), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 content: %w", err)
+	}
+
+	return decoded, nil
+}
+
 // decodeContent decodes base64 encoded content from GitHub API
 func (s *serviceImpl) decodeContent(file github.Content) (string, error) {
-	if file.Encoding == "base64" {
-		decoded, err := base64.StdEncoding.DecodeString(file.Content)
-		if err != nil {
-			return "", fmt.Errorf("failed to decode base64 content: %w", err)
-		}
-
-		// Validate UTF-8
-		if !utf8.Valid(decoded) {
-			return "", errors.New("content is not valid UTF-8")
-		}
+	decoded, err := DecodeContent(file)
+	if err != nil {
+		return "", err
+	}
 
-		return string(decoded), nil
+	// Validate UTF-8
+	if !utf8.Valid(decoded) {
+		return "", errors.New("content is not valid UTF-8")
 	}
 
-	return file.Content, nil
+	return string(decoded), nil
 }
 
 // determineContentType determines the type of content based on file path
@@ -396,6 +500,11 @@ func (s *serviceImpl) determineContentType(path string) string {
 	ext := strings.ToLower(filepath.Ext(path))
 	base := strings.ToLower(filepath.Base(path))
 
+	// Homepage text is documentation written for users
+	if path == HomepageSource {
+		return ContentTypeDocs
+	}
+
 	// README files
 	if strings.HasPrefix(base, "readme") {
 		return ContentTypeReadme
@@ -482,6 +591,20 @@ func (s *serviceImpl) determineContentType(path string) string {
 
 // determinePriority determines processing priority based on content type and path
 func (s *serviceImpl) determinePriority(contentType, path string) int {
+	return priorityFor(contentType, path)
+}
+
+// ChunkPriority derives the priority of a stored chunk from its type and
+// source, which may name a section of its file after a "#". Priorities are not
+// stored, so this is how stored chunks are ranked against each other.
+func ChunkPriority(chunkType, source string) int {
+	path, _, _ := strings.Cut(source, "#")
+
+	return priorityFor(chunkType, path)
+}
+
+// priorityFor ranks content by type, lifting main documentation and entry points
+func priorityFor(contentType, path string) int {
 	switch contentType {
 	case ContentTypeReadme:
 		return PriorityHigh
@@ -513,6 +636,11 @@ func (s *serviceImpl) determinePriority(contentType, path string) int {
 	}
 }
 
+// estimateTokens approximates the token count of text (1 token ≈ 4 characters)
+func estimateTokens(text string) int {
+	return len(text) / 4
+}
+
 // chunkContent splits content into manageable chunks
 func (s *serviceImpl) chunkContent(
 	content, source, contentType string,
@@ -520,13 +648,8 @@ func (s *serviceImpl) chunkContent(
 ) []ContentChunk {
 	var chunks []ContentChunk
 
-	// Estimate tokens (rough approximation: 1 token ≈ 4 characters)
-	estimateTokens := func(text string) int {
-		return len(text) / 4
-	}
-
 	// If content is small enough, return as single chunk
-	if estimateTokens(content) <= MaxTokensPerChunk {
+	if estimateTokens(content) <= s.limits.MaxTokensPerChunk {
 		return []ContentChunk{{
 			Source:   source,
 			Type:     contentType,
@@ -555,9 +678,9 @@ func (s *serviceImpl) chunkContent(
 		}
 
 		tokens := estimateTokens(section)
-		if tokens > MaxTokensPerChunk {
+		if tokens > s.limits.MaxTokensPerChunk {
 			// Further split large sections
-			subSections := s.splitLargeSection(section, MaxTokensPerChunk)
+			subSections := s.splitLargeSection(section, s.limits.MaxTokensPerChunk)
 			for j, subSection := range subSections {
 				chunks = append(chunks, ContentChunk{
 					Source:   fmt.Sprintf("%s#%d.%d", source, i+1, j+1),
@@ -686,10 +809,6 @@ func (s *serviceImpl) splitLargeSection(content string, maxTokens int) []string
 
 	var currentSection strings.Builder
 
-	estimateTokens := func(text string) int {
-		return len(text) / 4
-	}
-
 	for _, line := range lines {
 		testContent := currentSection.String() + line + "\n"
 		if estimateTokens(testContent) > maxTokens && currentSection.Len() > 0 {
@@ -728,7 +847,53 @@ func (s *serviceImpl) isBinaryFile(path string) bool {
 	return false
 }
 
-// generateContentHash creates a hash of the processed content for change detection
+// contentHashVersion prefixes content hashes of the current scheme. Hashes
+// without a version are from the original scheme (generateContentHash).
+const contentHashVersion = "v2:"
+
+// ContentChangedSince reports whether the content differs from storedHash. A
+// hash stored before content hashes were versioned is compared with the legacy
+// chunk hash, so upgrading does not mark every repository as changed.
+func (p *ProcessedRepo) ContentChangedSince(storedHash string) bool {
+	if !strings.Contains(storedHash, ":") && p.LegacyContentHash != "" {
+		return storedHash != p.LegacyContentHash
+	}
+
+	return storedHash != p.ContentHash
+}
+
+// generateBlobHash hashes the git blob SHA of each fetched file, sorted by
+// path, so the hash tracks upstream files rather than how they were chunked.
+// A file without a SHA contributes its content instead.
+func generateBlobHash(content []github.Content) string {
+	files := make([]github.Content, 0, len(content))
+
+	for _, file := range content {
+		if file.Type == "" || file.Type == "file" {
+			files = append(files, file)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	hasher := sha256.New()
+
+	for _, file := range files {
+		blob := "sha:" + file.SHA
+		if file.SHA == "" {
+			blob = "content:" + file.Content
+		}
+
+		fmt.Fprintf(hasher, "%s\x00%s\n", file.Path, blob)
+	}
+
+	return contentHashVersion + hex.EncodeToString(hasher.Sum(nil))
+}
+
+// generateContentHash creates the legacy, unversioned hash of the processed
+// chunks, which changes whenever the same files are chunked differently
 func (s *serviceImpl) generateContentHash(chunks []ContentChunk) string {
 	hasher := sha256.New()
 
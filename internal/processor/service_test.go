@@ -37,7 +37,7 @@ func TestNewService(t *testing.T) {
 }
 
 func TestDetermineContentType(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	tests := []struct {
 		path     string
@@ -65,7 +65,7 @@ func TestDetermineContentType(t *testing.T) {
 }
 
 func TestDeterminePriority(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	tests := []struct {
 		contentType string
@@ -97,7 +97,7 @@ func TestDeterminePriority(t *testing.T) {
 }
 
 func TestIsBinaryFile(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	tests := []struct {
 		path     string
@@ -123,7 +123,7 @@ func TestIsBinaryFile(t *testing.T) {
 }
 
 func TestDecodeContent(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Test base64 encoded content
 	originalText := "Hello, World!"
@@ -157,10 +157,34 @@ func TestDecodeContent(t *testing.T) {
 	if decoded != originalText {
 		t.Errorf("decodeContent() for plain text = %q, want %q", decoded, originalText)
 	}
+
+	// Binary content decodes to bytes but is rejected for indexing
+	binaryFile := github.Content{
+		Content:  base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00}),
+		Encoding: "base64",
+	}
+
+	raw, err := DecodeContent(binaryFile)
+	if err != nil {
+		t.Fatalf("DecodeContent failed for binary content: %v", err)
+	}
+
+	if string(raw) != "\xff\xfe\x00" {
+		t.Errorf("DecodeContent() = %q, want the original bytes", raw)
+	}
+
+	if _, err := service.decodeContent(binaryFile); err == nil {
+		t.Error("decodeContent() should reject non-UTF-8 content")
+	}
+
+	// Corrupt base64 is reported
+	if _, err := DecodeContent(github.Content{Content: "%%%", Encoding: "base64"}); err == nil {
+		t.Error("DecodeContent() should fail on invalid base64")
+	}
 }
 
 func TestFilterContent(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	content := []github.Content{
 		{Path: "README.md", Type: "file", Size: 1000},
@@ -194,7 +218,7 @@ func TestFilterContent(t *testing.T) {
 }
 
 func TestSplitMarkdownContent(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	content := `# Title
 This is the introduction.
@@ -221,7 +245,7 @@ Content of section 2.`
 }
 
 func TestSplitCodeContent(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	content := `package main
 
@@ -248,7 +272,7 @@ type MyStruct struct {
 }
 
 func TestChunkContent(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	// Test small content (should return single chunk)
 	smallContent := "This is a small piece of content."
@@ -287,8 +311,8 @@ func TestChunkContent(t *testing.T) {
 			t.Errorf("Chunk %d has invalid token count: %d", i, chunk.Tokens)
 		}
 
-		if chunk.Tokens > MaxTokensPerChunk {
-			t.Errorf("Chunk %d exceeds max tokens: %d > %d", i, chunk.Tokens, MaxTokensPerChunk)
+		if chunk.Tokens > DefaultMaxTokensPerChunk {
+			t.Errorf("Chunk %d exceeds max tokens: %d > %d", i, chunk.Tokens, DefaultMaxTokensPerChunk)
 		}
 	}
 }
@@ -382,7 +406,7 @@ func TestExtractContent(t *testing.T) {
 }
 
 func TestGenerateContentHash(t *testing.T) {
-	service := &serviceImpl{}
+	service := newService(nil, nil, nil)
 
 	chunks1 := []ContentChunk{
 		{Source: "README.md", Content: "Hello"},
@@ -418,6 +442,123 @@ func TestGenerateContentHash(t *testing.T) {
 	}
 }
 
+func TestGenerateBlobHash(t *testing.T) {
+	service := newService(nil, nil, nil)
+
+	content := []github.Content{
+		{Path: "README.md", Type: "file", Content: "Hello World", SHA: "5e1c309dae7f45e0f39b1bf3ac3cd9db12e7d689"},
+		{Path: "main.go", Type: "file", Content: "package main", SHA: "06ab7d0f9a4b9a1e5de4c9c2f4ddb1ca25c5c2a3"},
+	}
+
+	// The same files chunked two ways, as when token limits change between syncs
+	wholeChunks := []ContentChunk{
+		{Source: "README.md", Content: "Hello World"},
+		{Source: "main.go", Content: "package main"},
+	}
+	splitChunks := []ContentChunk{
+		{Source: "README.md", Content: "Hello"},
+		{Source: "README.md", Content: " World"},
+		{Source: "main.go", Content: "package main"},
+	}
+
+	if service.generateContentHash(wholeChunks) == service.generateContentHash(splitChunks) {
+		t.Fatal("the legacy hash should depend on chunking")
+	}
+
+	hash := generateBlobHash(content)
+	if !strings.HasPrefix(hash, contentHashVersion) {
+		t.Errorf("hash %q should start with %q", hash, contentHashVersion)
+	}
+
+	reordered := []github.Content{content[1], content[0]}
+	if generateBlobHash(reordered) != hash {
+		t.Error("re-chunking or reordering the same blobs should keep the hash stable")
+	}
+
+	changed := []github.Content{content[0], content[1]}
+	changed[1].SHA = "1111111111111111111111111111111111111111"
+
+	if generateBlobHash(changed) == hash {
+		t.Error("a changed blob SHA should change the hash")
+	}
+
+	noSHA := []github.Content{{Path: "README.md", Content: "a"}}
+	noSHAChanged := []github.Content{{Path: "README.md", Content: "b"}}
+
+	if generateBlobHash(noSHA) == generateBlobHash(noSHAChanged) {
+		t.Error("files without a SHA should be hashed by content")
+	}
+}
+
+func TestContentChangedSince(t *testing.T) {
+	processed := &ProcessedRepo{ContentHash: contentHashVersion + "new", LegacyContentHash: "legacy"}
+
+	tests := []struct {
+		name   string
+		stored string
+		want   bool
+	}{
+		{"same versioned hash", contentHashVersion + "new", false},
+		{"different versioned hash", contentHashVersion + "old", true},
+		{"matching legacy hash", "legacy", false},
+		{"different legacy hash", "other", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := processed.ContentChangedSince(tt.stored); got != tt.want {
+				t.Errorf("ContentChangedSince(%q) = %v, want %v", tt.stored, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitChunks(t *testing.T) {
+	chunks := []ContentChunk{
+		{Source: "docs/guide.md", Priority: PriorityMedium},
+		{Source: "README.md", Priority: PriorityHigh},
+		{Source: "main.go", Priority: PriorityLow},
+		{Source: "LICENSE", Priority: PriorityHigh},
+	}
+
+	tests := []struct {
+		name        string
+		maxChunks   int
+		wantSources []string
+		wantTrimmed int
+	}{
+		{"disabled", 0, []string{"docs/guide.md", "README.md", "main.go", "LICENSE"}, 0},
+		{"under limit", 4, []string{"docs/guide.md", "README.md", "main.go", "LICENSE"}, 0},
+		{"keeps highest priority", 2, []string{"README.md", "LICENSE"}, 2},
+		{"drops lowest priority", 3, []string{"docs/guide.md", "README.md", "LICENSE"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, trimmed := LimitChunks(chunks, tt.maxChunks)
+
+			if trimmed != tt.wantTrimmed {
+				t.Errorf("trimmed = %d, want %d", trimmed, tt.wantTrimmed)
+			}
+
+			if len(kept) != len(tt.wantSources) {
+				t.Fatalf("kept %d chunks, want %d", len(kept), len(tt.wantSources))
+			}
+
+			for i, source := range tt.wantSources {
+				if kept[i].Source != source {
+					t.Errorf("kept[%d] = %s, want %s", i, kept[i].Source, source)
+				}
+			}
+		})
+	}
+
+	// The input slice is not reordered
+	if chunks[0].Source != "docs/guide.md" {
+		t.Error("LimitChunks should not modify its input")
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
@@ -0,0 +1,109 @@
+package python
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// maxRawOutputInError caps how much script output is echoed back in decode errors.
+const maxRawOutputInError = 500
+
+// DecodeJSON unmarshals JSON printed by a Python script into v. Scripts can emit
+// library warnings or wrap their output in markdown fences, so when the output is
+// not valid JSON as-is, fences are stripped and the first complete {...} object
+// is decoded instead. The error includes the (truncated) raw output when all attempts fail.
+func DecodeJSON(output []byte, v any) error {
+	err := json.Unmarshal(output, v)
+	if err == nil {
+		return nil
+	}
+
+	if candidate, ok := extractJSONObject(output); ok {
+		if json.Unmarshal(candidate, v) == nil {
+			return nil
+		}
+	}
+
+	raw := bytes.TrimSpace(output)
+	if len(raw) > maxRawOutputInError {
+		raw = append(raw[:maxRawOutputInError:maxRawOutputInError], []byte("...")...)
+	}
+
+	return fmt.Errorf("invalid JSON output: %w (raw output: %q)", err, raw)
+}
+
+// extractJSONObject strips ```json fences and returns the first balanced
+// {...} span that is valid JSON, so braces in surrounding prose are skipped
+func extractJSONObject(output []byte) ([]byte, bool) {
+	text := bytes.TrimSpace(output)
+
+	if start := bytes.Index(text, []byte("```")); start >= 0 {
+		fenced := text[start+3:]
+		// Drop the language tag on the opening fence line (e.g. ```json)
+		if newline := bytes.IndexByte(fenced, '\n'); newline >= 0 {
+			fenced = fenced[newline+1:]
+		}
+
+		if end := bytes.Index(fenced, []byte("```")); end >= 0 {
+			fenced = fenced[:end]
+		}
+
+		text = fenced
+	}
+
+	for offset := 0; offset < len(text); {
+		first := bytes.IndexByte(text[offset:], '{')
+		if first < 0 {
+			break
+		}
+
+		first += offset
+
+		if end, ok := matchingBrace(text, first); ok && json.Valid(text[first:end+1]) {
+			return text[first : end+1], true
+		}
+
+		offset = first + 1
+	}
+
+	return nil, false
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at open. Braces
+// inside JSON strings are ignored.
+func matchingBrace(text []byte, open int) (int, bool) {
+	depth := 0
+	inString, escaped := false, false
+
+	for i := open; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,110 @@
+package python
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type result struct {
+		Summary string `json:"summary"`
+		Method  string `json:"method"`
+	}
+
+	tests := []struct {
+		name        string
+		output      string
+		want        result
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:   "plain JSON",
+			output: `{"summary": "A CLI tool", "method": "heuristic"}`,
+			want:   result{Summary: "A CLI tool", Method: "heuristic"},
+		},
+		{
+			name:   "markdown fenced JSON",
+			output: "```json\n{\"summary\": \"A CLI tool\", \"method\": \"transformers\"}\n```",
+			want:   result{Summary: "A CLI tool", Method: "transformers"},
+		},
+		{
+			name:   "fence without language tag",
+			output: "```\n{\"summary\": \"A CLI tool\"}\n```\n",
+			want:   result{Summary: "A CLI tool"},
+		},
+		{
+			name:   "leading warnings and trailing prose",
+			output: "UserWarning: falling back to CPU\n{\"summary\": \"A CLI tool\"}\nDone.",
+			want:   result{Summary: "A CLI tool"},
+		},
+		{
+			name:   "prose with braces after the object",
+			output: "{\"summary\": \"A CLI tool\"}\nNote: the {summary} field is plain text.",
+			want:   result{Summary: "A CLI tool"},
+		},
+		{
+			name:   "prose with braces before the object",
+			output: "Here is the result for {repo}:\n{\"summary\": \"A CLI tool\", \"method\": \"heuristic\"}",
+			want:   result{Summary: "A CLI tool", Method: "heuristic"},
+		},
+		{
+			name:   "braces and escaped quotes inside strings",
+			output: "Sure!\n```json\n{\"summary\": \"Renders {{templates}} and \\\"quoted}\\\" text\"}\n```\nAnything else?",
+			want:   result{Summary: "Renders {{templates}} and \"quoted}\" text"},
+		},
+		{
+			name:   "nested object followed by a second object",
+			output: "{\"summary\": \"first\", \"extra\": {\"a\": 1}} {\"summary\": \"second\"}",
+			want:   result{Summary: "first"},
+		},
+		{
+			name:        "no JSON object",
+			output:      "Traceback (most recent call last): boom",
+			wantErr:     true,
+			errContains: "Traceback",
+		},
+		{
+			name:        "malformed JSON inside braces",
+			output:      "{summary: unquoted}",
+			wantErr:     true,
+			errContains: "raw output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got result
+
+			err := DecodeJSON([]byte(tt.output), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("error %q does not contain %q", err.Error(), tt.errContains)
+				}
+
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("DecodeJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_TruncatesRawOutput(t *testing.T) {
+	var v map[string]any
+
+	err := DecodeJSON([]byte(strings.Repeat("x", 2*maxRawOutputInError)), &v)
+	if err == nil {
+		t.Fatal("expected error for non-JSON output")
+	}
+
+	if strings.Count(err.Error(), "x") > maxRawOutputInError+10 {
+		t.Errorf("raw output not truncated: %d bytes in error", len(err.Error()))
+	}
+}
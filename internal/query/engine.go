@@ -2,6 +2,7 @@ package query
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/KyleKing/gh-star-search/internal/embedding"
+	"github.com/KyleKing/gh-star-search/internal/scoring"
+	"github.com/KyleKing/gh-star-search/internal/search"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
@@ -28,16 +31,47 @@ type Query struct {
 
 // SearchOptions represents search configuration options
 type SearchOptions struct {
-	Limit    int
-	MinScore float64
+	Limit           int
+	MinScore        float64
+	Tag             string         // Only return repositories carrying this local tag
+	Filter          storage.Filter // Only return repositories matching these structured attributes
+	Keyword         string         // Only return repositories with this README-derived keyword
+	ContentLanguage string         // Only return repositories whose README is in this ISO 639-1 language
+	ReadmeFormat    string         // Only return repositories whose README has this format, or ReadmeFormatNone for no README
+	MaxPerOwner     int            // Keep at most this many results from any one owner after ranking; 0 for no cap
+	CaseSensitive   bool           // Fuzzy only: require every term verbatim, with exact case and accents
+	Chunks          bool           // Vector only: rank by the most similar content chunk instead of the repository embedding
 }
 
+// ReadmeFormatNone selects repositories without a README in SearchOptions.ReadmeFormat
+const ReadmeFormatNone = storage.ReadmeFormatNone
+
+// storageFilter combines the structured filter with the tag, keyword, content
+// language and README format options, so the storage layer applies them all
+func (opts SearchOptions) storageFilter() storage.Filter {
+	filter := opts.Filter
+	filter.Tag = opts.Tag
+	filter.Keyword = opts.Keyword
+	filter.ContentLanguage = opts.ContentLanguage
+	filter.ReadmeFormat = opts.ReadmeFormat
+
+	return filter
+}
+
+// ErrNoEmbedding is returned by SearchSimilar when the seed repository has no stored embedding
+var ErrNoEmbedding = errors.New("repository has no embedding")
+
+// tagFilterOverfetch widens the vector candidate pool when results are filtered by tag,
+// structured filter, keyword, content language or README format, or capped per owner
+const tagFilterOverfetch = 10
+
 // Result represents a search result with enhanced scoring
 type Result struct {
 	RepoID      string
 	Score       float64
 	Rank        int
-	MatchFields []string // Fields that matched the query
+	MatchFields []string        // Fields that matched the query
+	Matches     []storage.Match // Matched content, for searches that report it (fuzzy fields, chunk-level vector search)
 	Repository  storage.StoredRepo
 }
 
@@ -48,16 +82,33 @@ type Engine interface {
 
 // SearchEngine implements the Engine interface
 type SearchEngine struct {
-	repo       storage.Repository
-	embManager *embedding.Manager
+	repo            storage.Repository
+	embManager      *embedding.Manager
+	scoreExpression *scoring.Expression // Replaces the built-in ranking boosts when set
+}
+
+// EngineOption configures a SearchEngine
+type EngineOption func(*SearchEngine)
+
+// WithScoreExpression ranks results by expr instead of the built-in star and recency boosts
+func WithScoreExpression(expr *scoring.Expression) EngineOption {
+	return func(e *SearchEngine) {
+		e.scoreExpression = expr
+	}
 }
 
 // NewSearchEngine creates a new search engine instance
-func NewSearchEngine(repo storage.Repository, embManager *embedding.Manager) *SearchEngine {
-	return &SearchEngine{
+func NewSearchEngine(repo storage.Repository, embManager *embedding.Manager, opts ...EngineOption) *SearchEngine {
+	e := &SearchEngine{
 		repo:       repo,
 		embManager: embManager,
 	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
 }
 
 // Search executes a search query with the specified mode and options
@@ -72,45 +123,78 @@ func (e *SearchEngine) Search(ctx context.Context, q Query, opts SearchOptions)
 	}
 }
 
-// searchFuzzy performs FTS search with BM25 scoring from DuckDB
+// searchFuzzy performs FTS search with BM25 scoring from DuckDB. Each BM25
+// score is scaled by up to 2x by the fuzzy closeness of the repository's name,
+// description or topics to the query, so exact matches outrank partial ones.
 func (e *SearchEngine) searchFuzzy(
 	ctx context.Context,
 	query string,
 	opts SearchOptions,
 ) ([]Result, error) {
-	storageResults, err := e.repo.SearchRepositories(ctx, query)
+	queryTerms := tokenizeQuery(query)
+
+	filter := opts.storageFilter()
+	if opts.CaseSensitive {
+		filter.CaseSensitiveTerms = queryTerms
+	}
+
+	// Every filter, including case-sensitive matching, is applied in SQL, ahead
+	// of the search's result limit
+	storageResults, err := e.repo.SearchFilteredRepositories(ctx, query, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	var results []Result
-	queryTerms := tokenizeQuery(query)
 
 	for _, sr := range storageResults {
-		score := e.applyRankingBoosts(sr.Repository, sr.Score)
+		baseScore := sr.Score * (1 + search.Score(query, closenessFields(sr.Repository)))
+
+		score, err := e.finalScore(sr.Repository, baseScore, sr.Matches)
+		if err != nil {
+			return nil, err
+		}
 
 		if score < opts.MinScore {
 			continue
 		}
 
-		matchFields := e.identifyMatchedFields(sr.Repository, queryTerms)
+		matchFields := e.identifyMatchedFields(sr.Repository, queryTerms, opts.CaseSensitive)
 
 		results = append(results, Result{
 			RepoID:      sr.Repository.ID,
 			Score:       score,
 			Repository:  sr.Repository,
 			MatchFields: matchFields,
+			Matches:     sr.Matches,
 		})
 	}
 
 	normalizeScores(results)
 	results = sortAndRankResults(results)
+	results = limitPerOwner(results, opts.MaxPerOwner)
 
 	if opts.Limit > 0 && len(results) > opts.Limit {
 		results = results[:opts.Limit]
 	}
 
-	return results, nil
+	return e.attachTags(ctx, results)
+}
+
+// closenessFields are the fields whose fuzzy closeness to the query scales a
+// fuzzy result's BM25 score: the repository name (owner stripped), the
+// description and each topic, weighted in that order
+func closenessFields(repo storage.StoredRepo) []search.Field {
+	fields := []search.Field{
+		{Text: repo.FullName[strings.LastIndex(repo.FullName, "/")+1:], Weight: 1},
+		{Text: repo.Description, Weight: 0.8},
+	}
+
+	for _, topic := range repo.Topics {
+		fields = append(fields, search.Field{Text: topic, Weight: 0.6})
+	}
+
+	return fields
 }
 
 // searchVector performs semantic search using pre-computed embeddings
@@ -128,34 +212,264 @@ func (e *SearchEngine) searchVector(
 		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
 	}
 
+	return e.searchByEmbedding(ctx, queryEmbedding, "", opts)
+}
+
+// SearchSimilar returns the repositories nearest to seed's stored embedding,
+// excluding seed itself
+func (e *SearchEngine) SearchSimilar(
+	ctx context.Context,
+	seed storage.StoredRepo,
+	opts SearchOptions,
+) ([]Result, error) {
+	if len(seed.RepoEmbedding) == 0 {
+		return nil, fmt.Errorf("%s: %w", seed.FullName, ErrNoEmbedding)
+	}
+
+	return e.searchByEmbedding(ctx, seed.RepoEmbedding, seed.FullName, opts)
+}
+
+// SearchByContributor returns the repositories login contributes to, ranked by
+// their contribution count with the usual boosts
+func (e *SearchEngine) SearchByContributor(
+	ctx context.Context,
+	login string,
+	opts SearchOptions,
+) ([]Result, error) {
+	storageResults, err := e.repo.SearchByContributor(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("contributor search failed: %w", err)
+	}
+
+	storageResults, err = e.applyFilter(ctx, storageResults, opts.storageFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, sr := range storageResults {
+		score, err := e.finalScore(sr.Repository, sr.Score, sr.Matches)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, Result{
+			RepoID:      sr.Repository.ID,
+			Score:       score,
+			Repository:  sr.Repository,
+			MatchFields: []string{"contributors"},
+		})
+	}
+
+	normalizeScores(results)
+	results = sortAndRankResults(results)
+	results = limitPerOwner(results, opts.MaxPerOwner)
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	return e.attachTags(ctx, results)
+}
+
+// searchByEmbedding ranks repositories by similarity to the given embedding,
+// skipping exclude (a full name) when set
+func (e *SearchEngine) searchByEmbedding(
+	ctx context.Context,
+	queryEmbedding []float32,
+	exclude string,
+	opts SearchOptions,
+) ([]Result, error) {
 	limit := opts.Limit
 	if limit <= 0 {
 		limit = 50
 	}
 
-	storageResults, err := e.repo.SearchByEmbedding(ctx, queryEmbedding, limit, opts.MinScore)
+	candidateLimit := limit
+	if !opts.storageFilter().IsZero() || opts.MaxPerOwner > 0 {
+		candidateLimit = limit * tagFilterOverfetch
+	}
+
+	if exclude != "" {
+		candidateLimit++
+	}
+
+	search, matchField := e.repo.SearchByEmbedding, "embedding"
+	if opts.Chunks {
+		search, matchField = e.repo.SearchChunksByEmbedding, storage.MatchFieldChunk
+	}
+
+	storageResults, err := search(ctx, queryEmbedding, candidateLimit, opts.MinScore)
 	if err != nil {
 		return nil, fmt.Errorf("embedding search failed: %w", err)
 	}
 
+	storageResults = excludeRepository(storageResults, exclude)
+
+	storageResults, err = e.applyFilter(ctx, storageResults, opts.storageFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	// With an owner cap the whole candidate pool is ranked, so results dropped by
+	// the cap are replaced by the next-best candidates
+	if opts.MaxPerOwner <= 0 && len(storageResults) > limit {
+		storageResults = storageResults[:limit]
+	}
+
 	var results []Result
 	for _, sr := range storageResults {
-		score := e.applyRankingBoosts(sr.Repository, sr.Score)
+		score, err := e.finalScore(sr.Repository, sr.Score, sr.Matches)
+		if err != nil {
+			return nil, err
+		}
 
-		results = append(results, Result{
+		result := Result{
 			RepoID:      sr.Repository.ID,
 			Score:       score,
 			Repository:  sr.Repository,
-			MatchFields: []string{"embedding"},
-		})
+			MatchFields: []string{matchField},
+		}
+
+		if opts.Chunks {
+			result.Matches = sr.Matches
+		}
+
+		results = append(results, result)
 	}
 
 	normalizeScores(results)
 	results = sortAndRankResults(results)
+	results = limitPerOwner(results, opts.MaxPerOwner)
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return e.attachTags(ctx, results)
+}
+
+// limitPerOwner keeps at most maxPerOwner ranked results from each owner
+// (case-insensitive), preserving order and renumbering ranks. A maxPerOwner of
+// zero or less leaves results untouched.
+func limitPerOwner(results []Result, maxPerOwner int) []Result {
+	if maxPerOwner <= 0 {
+		return results
+	}
+
+	perOwner := make(map[string]int)
+	kept := results[:0]
+
+	for _, result := range results {
+		owner, _, _ := strings.Cut(result.Repository.FullName, "/")
+		owner = strings.ToLower(owner)
+
+		if perOwner[owner] >= maxPerOwner {
+			continue
+		}
+
+		perOwner[owner]++
+		result.Rank = len(kept) + 1
+		kept = append(kept, result)
+	}
+
+	return kept
+}
+
+// excludeRepository drops the result for the given full name, if present
+func excludeRepository(results []storage.SearchResult, fullName string) []storage.SearchResult {
+	if fullName == "" {
+		return results
+	}
+
+	filtered := results[:0]
+
+	for _, sr := range results {
+		if sr.Repository.FullName != fullName {
+			filtered = append(filtered, sr)
+		}
+	}
+
+	return filtered
+}
+
+// applyFilter keeps only results for repositories matching filter, as selected
+// by the storage layer
+func (e *SearchEngine) applyFilter(
+	ctx context.Context,
+	results []storage.SearchResult,
+	filter storage.Filter,
+) ([]storage.SearchResult, error) {
+	if filter.IsZero() {
+		return results, nil
+	}
+
+	matching, err := e.repo.FilterRepositories(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter repositories: %w", err)
+	}
+
+	matchingSet := make(map[string]bool, len(matching))
+	for _, sr := range matching {
+		matchingSet[sr.Repository.FullName] = true
+	}
+
+	filtered := results[:0]
+
+	for _, sr := range results {
+		if matchingSet[sr.Repository.FullName] {
+			filtered = append(filtered, sr)
+		}
+	}
+
+	return filtered, nil
+}
+
+// attachTags loads local tags for the final result set so they can be displayed
+func (e *SearchEngine) attachTags(ctx context.Context, results []Result) ([]Result, error) {
+	for i := range results {
+		tags, err := e.repo.GetTags(ctx, results[i].Repository.FullName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+
+		results[i].Repository.Tags = tags
+	}
 
 	return results, nil
 }
 
+// finalScore ranks a match with the configured score expression, or with the
+// built-in boosts when none is set
+func (e *SearchEngine) finalScore(
+	repo storage.StoredRepo,
+	baseScore float64,
+	matches []storage.Match,
+) (float64, error) {
+	defaultScore := e.applyRankingBoosts(repo, baseScore) * nameMatchBoost(matches)
+	if e.scoreExpression == nil {
+		return defaultScore, nil
+	}
+
+	daysSinceUpdate := 0.0
+	if !repo.UpdatedAt.IsZero() {
+		daysSinceUpdate = time.Since(repo.UpdatedAt).Hours() / 24
+	}
+
+	score, err := e.scoreExpression.Eval(map[string]float64{
+		scoring.VarMatchScore:      baseScore,
+		scoring.VarDefaultScore:    defaultScore,
+		scoring.VarStars:           float64(repo.StargazersCount),
+		scoring.VarForks:           float64(repo.ForksCount),
+		scoring.VarDaysSinceUpdate: daysSinceUpdate,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to score %s with %q: %w", repo.FullName, e.scoreExpression, err)
+	}
+
+	return score, nil
+}
+
 // applyRankingBoosts applies logarithmic star boost and recency decay
 func (e *SearchEngine) applyRankingBoosts(repo storage.StoredRepo, baseScore float64) float64 {
 	if baseScore <= 0 {
@@ -176,28 +490,51 @@ func (e *SearchEngine) applyRankingBoosts(repo storage.StoredRepo, baseScore flo
 	return baseScore * starBoost * recencyFactor
 }
 
-// identifyMatchedFields identifies which logical fields matched the query
+// Name match boosts multiply the default score. They are large enough that a
+// repository named by the query outranks one that only mentions it, whatever the
+// star and recency boosts (at most about 1.1x between repositories).
+const (
+	exactNameBoost  = 10.0
+	prefixNameBoost = 3.0
+)
+
+// nameMatchBoost returns the boost for the strongest name match in matches, or 1
+func nameMatchBoost(matches []storage.Match) float64 {
+	boost := 1.0
+
+	for _, m := range matches {
+		if m.Field != storage.MatchFieldName {
+			continue
+		}
+
+		switch m.Score {
+		case storage.NameMatchExact:
+			boost = math.Max(boost, exactNameBoost)
+		case storage.NameMatchPrefix:
+			boost = math.Max(boost, prefixNameBoost)
+		}
+	}
+
+	return boost
+}
+
+// identifyMatchedFields identifies which logical fields matched the query,
+// ignoring case and accents unless caseSensitive is set
 func (e *SearchEngine) identifyMatchedFields(
 	repo storage.StoredRepo,
 	queryTerms []string,
+	caseSensitive bool,
 ) []string {
 	var matchedFields []string
 
-	fieldMap := map[string]string{
-		"name":        repo.FullName,
-		"description": repo.Description,
-		"purpose":     repo.Purpose,
-		"topics":      strings.Join(repo.Topics, " "),
-	}
-
-	for field, content := range fieldMap {
+	for field, content := range searchableFields(repo) {
 		if content == "" {
 			continue
 		}
 
-		contentLower := strings.ToLower(content)
+		content = matchText(content, caseSensitive)
 		for _, term := range queryTerms {
-			if strings.Contains(contentLower, strings.ToLower(term)) {
+			if strings.Contains(content, matchText(term, caseSensitive)) {
 				matchedFields = append(matchedFields, field)
 				break
 			}
@@ -3,18 +3,25 @@ package query
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/scoring"
 	"github.com/KyleKing/gh-star-search/internal/storage"
 )
 
 // MockRepository for testing query engine
 type mockQueryRepo struct {
-	repos []storage.StoredRepo
+	repos   []storage.StoredRepo
+	tags    map[string][]string
+	scores  map[string]float64         // Backend score by full name; 0.5 when absent
+	matches map[string][]storage.Match // Matched fields by full name
 }
 
 func (m *mockQueryRepo) Initialize(_ context.Context) error {
@@ -29,19 +36,41 @@ func (m *mockQueryRepo) UpdateRepository(_ context.Context, _ processor.Processe
 	return nil
 }
 
+func (m *mockQueryRepo) UpsertRepository(_ context.Context, _ processor.ProcessedRepo, _ storage.UpsertOptions) error {
+	return nil
+}
+
 func (m *mockQueryRepo) DeleteRepository(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockQueryRepo) SearchRepositories(ctx context.Context, _ string) ([]storage.SearchResult, error) {
+func (m *mockQueryRepo) SearchRepositories(ctx context.Context, query string) ([]storage.SearchResult, error) {
+	return m.SearchFilteredRepositories(ctx, query, storage.Filter{})
+}
+
+func (m *mockQueryRepo) SearchFilteredRepositories(
+	ctx context.Context,
+	_ string,
+	filter storage.Filter,
+) ([]storage.SearchResult, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 	results := make([]storage.SearchResult, 0)
 	for _, repo := range m.repos {
+		if !m.filterMatches(repo, filter) {
+			continue
+		}
+
+		score, ok := m.scores[repo.FullName]
+		if !ok {
+			score = 0.5
+		}
+
 		results = append(results, storage.SearchResult{
 			Repository: repo,
-			Score:      0.5,
+			Score:      score,
+			Matches:    m.matches[repo.FullName],
 		})
 	}
 	return results, nil
@@ -72,10 +101,95 @@ func (m *mockQueryRepo) ListRepositories(ctx context.Context, limit, offset int)
 	return m.repos[start:end], nil
 }
 
+func (m *mockQueryRepo) ListRepositoriesSorted(ctx context.Context, opts storage.ListOptions) ([]storage.StoredRepo, error) {
+	return m.ListRepositories(ctx, opts.Limit, opts.Offset)
+}
+
+func (m *mockQueryRepo) FilterRepositories(_ context.Context, filter storage.Filter) ([]storage.SearchResult, error) {
+	var results []storage.SearchResult
+
+	for _, repo := range m.repos {
+		if m.filterMatches(repo, filter) {
+			results = append(results, storage.SearchResult{Repository: repo, Score: 1.0})
+		}
+	}
+
+	return results, nil
+}
+
+// filterMatches applies the star, topic, tag, keyword, content language and
+// README format conditions of filter
+func (m *mockQueryRepo) filterMatches(repo storage.StoredRepo, filter storage.Filter) bool {
+	matches := (filter.MinStars <= 0 || repo.StargazersCount >= filter.MinStars) &&
+		(filter.MaxStars <= 0 || repo.StargazersCount <= filter.MaxStars)
+	for _, topic := range filter.Topics {
+		matches = matches && slices.Contains(repo.Topics, topic)
+	}
+
+	if filter.Tag != "" {
+		matches = matches && slices.Contains(m.tags[repo.FullName], filter.Tag)
+	}
+
+	if filter.Keyword != "" {
+		matches = matches && slices.ContainsFunc(repo.Keywords, func(keyword string) bool {
+			return strings.EqualFold(keyword, filter.Keyword)
+		})
+	}
+
+	if filter.ContentLanguage != "" {
+		matches = matches && strings.EqualFold(repo.ContentLanguage, filter.ContentLanguage)
+	}
+
+	if format := strings.ToLower(filter.ReadmeFormat); format != "" {
+		if format == storage.ReadmeFormatNone {
+			format = ""
+		}
+
+		matches = matches && repo.ReadmeFormat == format
+	}
+
+	for _, term := range filter.CaseSensitiveTerms {
+		matches = matches && strings.Contains(caseSensitiveText(repo), term)
+	}
+
+	return matches
+}
+
+// caseSensitiveText joins the fields the storage layer matches case-sensitive terms against
+func caseSensitiveText(repo storage.StoredRepo) string {
+	fields := []string{repo.FullName, repo.Description, repo.Purpose}
+	fields = append(fields, repo.Topics...)
+	fields = append(fields, repo.Keywords...)
+
+	for _, contributor := range repo.Contributors {
+		fields = append(fields, contributor.Login)
+	}
+
+	return strings.Join(fields, "\n")
+}
+
+func (m *mockQueryRepo) SearchByContributor(_ context.Context, login string) ([]storage.SearchResult, error) {
+	var results []storage.SearchResult
+
+	for _, repo := range m.repos {
+		for _, contributor := range repo.Contributors {
+			if strings.EqualFold(contributor.Login, login) {
+				results = append(results, storage.SearchResult{Repository: repo, Score: float64(contributor.Contributions)})
+			}
+		}
+	}
+
+	return results, nil
+}
+
 func (m *mockQueryRepo) GetStats(_ context.Context) (*storage.Stats, error) {
 	return &storage.Stats{}, nil
 }
 
+func (m *mockQueryRepo) GetSizeBreakdown(_ context.Context, _ int) (*storage.SizeBreakdown, error) {
+	return &storage.SizeBreakdown{}, nil
+}
+
 func (m *mockQueryRepo) Clear(_ context.Context) error {
 	m.repos = []storage.StoredRepo{}
 	return nil
@@ -93,6 +207,10 @@ func (m *mockQueryRepo) GetRepositoriesNeedingSummaryUpdate(_ context.Context, _
 	return []string{}, nil
 }
 
+func (m *mockQueryRepo) GetRepositoriesNeedingEmbedding(_ context.Context, _ bool) ([]string, error) {
+	return []string{}, nil
+}
+
 func (m *mockQueryRepo) UpdateRepositoryEmbedding(_ context.Context, _ string, _ []float32) error {
 	return nil
 }
@@ -101,6 +219,74 @@ func (m *mockQueryRepo) UpdateRepositorySummary(_ context.Context, _, _ string)
 	return nil
 }
 
+func (m *mockQueryRepo) RecordQuery(_ context.Context, _ storage.QueryHistoryEntry) error {
+	return nil
+}
+
+func (m *mockQueryRepo) ListQueryHistory(_ context.Context, _ int) ([]storage.QueryHistoryEntry, error) {
+	return nil, nil
+}
+
+func (m *mockQueryRepo) GetQueryHistoryEntry(_ context.Context, _ int64) (*storage.QueryHistoryEntry, error) {
+	return nil, errors.New("query history entry not found")
+}
+
+func (m *mockQueryRepo) SaveSearch(_ context.Context, _ storage.SavedSearch) error {
+	return nil
+}
+
+func (m *mockQueryRepo) GetSavedSearch(_ context.Context, _ string) (*storage.SavedSearch, error) {
+	return nil, errors.New("saved search not found")
+}
+
+func (m *mockQueryRepo) ListSavedSearches(_ context.Context) ([]storage.SavedSearch, error) {
+	return nil, nil
+}
+
+func (m *mockQueryRepo) DeleteSavedSearch(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockQueryRepo) RenameRepository(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockQueryRepo) BackfillGitHubIDs(_ context.Context, _ map[string]int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockQueryRepo) ListKeywordTerms(_ context.Context) (map[string]map[string]int, error) {
+	return map[string]map[string]int{}, nil
+}
+
+func (m *mockQueryRepo) UpdateKeywords(_ context.Context, selected map[string][]string) (int, error) {
+	return len(selected), nil
+}
+
+func (m *mockQueryRepo) AddTag(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockQueryRepo) RemoveTag(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockQueryRepo) GetTags(_ context.Context, fullName string) ([]string, error) {
+	return m.tags[fullName], nil
+}
+
+func (m *mockQueryRepo) ListTaggedRepositories(_ context.Context, tag string) ([]string, error) {
+	var names []string
+
+	for name, tags := range m.tags {
+		if slices.Contains(tags, tag) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
 func (m *mockQueryRepo) UpdateRepositoryMetrics(_ context.Context, _ string, _ storage.RepositoryMetrics) error {
 	return nil
 }
@@ -109,10 +295,48 @@ func (m *mockQueryRepo) RebuildFTSIndex(_ context.Context) error {
 	return nil
 }
 
-func (m *mockQueryRepo) SearchByEmbedding(_ context.Context, _ []float32, _ int, _ float64) ([]storage.SearchResult, error) {
+func (m *mockQueryRepo) SearchByEmbedding(_ context.Context, _ []float32, limit int, _ float64) ([]storage.SearchResult, error) {
+	// Repositories are returned in stored order with descending similarity
+	var results []storage.SearchResult
+	for i, r := range m.repos {
+		if i >= limit {
+			break
+		}
+
+		results = append(results, storage.SearchResult{Repository: r, Score: 0.9 - float64(i)*0.1})
+	}
+
+	return results, nil
+}
+
+func (m *mockQueryRepo) GetChunksNeedingEmbedding(_ context.Context, _ bool) ([]storage.StoredChunk, error) {
 	return nil, nil
 }
 
+func (m *mockQueryRepo) UpdateChunkEmbedding(_ context.Context, _ string, _ []float32) error {
+	return nil
+}
+
+func (m *mockQueryRepo) SearchChunksByEmbedding(
+	_ context.Context, _ []float32, limit int, _ float64,
+) ([]storage.SearchResult, error) {
+	// Repositories with matches are returned in stored order, as their best chunk
+	var results []storage.SearchResult
+	for _, r := range m.repos {
+		if len(m.matches[r.FullName]) == 0 || len(results) >= limit {
+			continue
+		}
+
+		results = append(results, storage.SearchResult{
+			Repository: r,
+			Score:      0.9 - float64(len(results))*0.1,
+			Matches:    m.matches[r.FullName],
+		})
+	}
+
+	return results, nil
+}
+
 func (m *mockQueryRepo) GetRelatedCounts(_ context.Context, _ string) (int, int, error) {
 	return 0, 0, nil
 }
@@ -237,6 +461,285 @@ func TestSearchEngine_LimitResults(t *testing.T) {
 	assert.LessOrEqual(t, len(results), 5, "should respect limit option")
 }
 
+func TestSearchEngine_TagFilter(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/tagged", Description: "Test repository"},
+			{FullName: "user/untagged", Description: "Test repository"},
+		},
+		tags: map[string][]string{
+			"user/tagged": {"evaluate for work"},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+	ctx := context.Background()
+
+	q := Query{
+		Raw:  "test",
+		Mode: ModeFuzzy,
+	}
+
+	results, err := engine.Search(ctx, q, SearchOptions{Limit: 10, Tag: "evaluate for work"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1, "should only return tagged repositories")
+	assert.Equal(t, "user/tagged", results[0].Repository.FullName)
+	assert.Equal(t, []string{"evaluate for work"}, results[0].Repository.Tags)
+}
+
+func TestSearchEngine_Filter(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/cli-go", Description: "Test repository", Topics: []string{"cli", "go"}, StargazersCount: 300},
+			{FullName: "user/cli-rust", Description: "Test repository", Topics: []string{"cli", "rust"}, StargazersCount: 200},
+			{FullName: "user/web-go", Description: "Test repository", Topics: []string{"web", "go"}, StargazersCount: 100},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+	ctx := context.Background()
+
+	q := Query{
+		Raw:  "test",
+		Mode: ModeFuzzy,
+	}
+
+	results, err := engine.Search(ctx, q, SearchOptions{Limit: 10, Filter: storage.Filter{Topics: []string{"cli"}}})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = engine.Search(ctx, q, SearchOptions{Limit: 10, Filter: storage.Filter{Topics: []string{"cli", "go"}}})
+	require.NoError(t, err)
+	require.Len(t, results, 1, "topics combine with AND")
+	assert.Equal(t, "user/cli-go", results[0].Repository.FullName)
+
+	results, err = engine.Search(ctx, q, SearchOptions{Limit: 10, Filter: storage.Filter{MinStars: 150, MaxStars: 250}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "user/cli-rust", results[0].Repository.FullName)
+}
+
+func TestSearchEngine_KeywordFilter(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/parser", Description: "Test repository", Keywords: []string{"tokenizer", "grammar"}},
+			{FullName: "user/other", Description: "Test repository", Keywords: []string{"http"}},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+	ctx := context.Background()
+
+	q := Query{
+		Raw:  "test",
+		Mode: ModeFuzzy,
+	}
+
+	results, err := engine.Search(ctx, q, SearchOptions{Limit: 10, Keyword: "Tokenizer"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1, "should only return repositories with the keyword")
+	assert.Equal(t, "user/parser", results[0].Repository.FullName)
+}
+
+func TestSearchEngine_ContentLanguageFilter(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/english", Description: "Test repository", ContentLanguage: "en"},
+			{FullName: "user/chinese", Description: "Test repository", ContentLanguage: "zh"},
+			{FullName: "user/unknown", Description: "Test repository"},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+	ctx := context.Background()
+
+	q := Query{
+		Raw:  "test",
+		Mode: ModeFuzzy,
+	}
+
+	results, err := engine.Search(ctx, q, SearchOptions{Limit: 10, ContentLanguage: "ZH"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1, "should only return repositories in the content language")
+	assert.Equal(t, "user/chinese", results[0].Repository.FullName)
+}
+
+func TestSearchEngine_ReadmeFormatFilter(t *testing.T) {
+	mockRepo := func() *mockQueryRepo {
+		return &mockQueryRepo{
+			repos: []storage.StoredRepo{
+				{FullName: "user/markdown", Description: "Test repository", ReadmeFormat: "markdown"},
+				{FullName: "user/rst", Description: "Test repository", ReadmeFormat: "rst"},
+				{FullName: "user/bare", Description: "Test repository"},
+			},
+		}
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "RST", want: "user/rst"},
+		{format: ReadmeFormatNone, want: "user/bare"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			engine := NewSearchEngine(mockRepo(), nil)
+
+			results, err := engine.Search(context.Background(), Query{Raw: "test", Mode: ModeFuzzy},
+				SearchOptions{Limit: 10, ReadmeFormat: tt.format})
+
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.want, results[0].Repository.FullName)
+		})
+	}
+}
+
+func TestSearchEngine_MaxPerOwner(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "big/one", Description: "Test repository", StargazersCount: 300},
+			{FullName: "big/two", Description: "Test repository", StargazersCount: 200},
+			{FullName: "big/three", Description: "Test repository", StargazersCount: 100},
+			{FullName: "small/only", Description: "Test repository", StargazersCount: 10},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+
+	results, err := engine.Search(context.Background(), Query{Raw: "test", Mode: ModeFuzzy},
+		SearchOptions{Limit: 3, MaxPerOwner: 2})
+
+	require.NoError(t, err)
+	require.Len(t, results, 3, "the capped owner's slot should go to the next owner")
+	assert.Equal(t, "big/one", results[0].Repository.FullName)
+	assert.Equal(t, "big/two", results[1].Repository.FullName)
+	assert.Equal(t, "small/only", results[2].Repository.FullName)
+	assert.Equal(t, 3, results[2].Rank)
+}
+
+func TestSearchEngine_ScoreExpression(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/popular", Description: "Test repository", StargazersCount: 5000},
+			{FullName: "user/forked", Description: "Test repository", StargazersCount: 10, ForksCount: 900},
+		},
+	}
+
+	expr, err := scoring.Parse("match_score * (1 + forks)")
+	require.NoError(t, err)
+
+	engine := NewSearchEngine(mockRepo, nil, WithScoreExpression(expr))
+
+	results, err := engine.Search(context.Background(), Query{Raw: "test", Mode: ModeFuzzy}, SearchOptions{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "user/forked", results[0].Repository.FullName, "the expression should override the star boost")
+
+	expr, err = scoring.Parse("match_score / forks")
+	require.NoError(t, err)
+
+	_, err = NewSearchEngine(mockRepo, nil, WithScoreExpression(expr)).
+		Search(context.Background(), Query{Raw: "test", Mode: ModeFuzzy}, SearchOptions{Limit: 10})
+	require.ErrorIs(t, err, scoring.ErrNonFinite)
+}
+
+func TestSearchEngine_NameMatchBoost(t *testing.T) {
+	nameMatch := func(score float64) []storage.Match {
+		return []storage.Match{{Field: storage.MatchFieldName, Score: score}}
+	}
+
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "popular/search-tools", Description: "Faster than ripgrep", StargazersCount: 500000, UpdatedAt: time.Now()},
+			{FullName: "fan/ripgrep-all", Description: "ripgrep, but also PDFs", StargazersCount: 8000},
+			{FullName: "BurntSushi/ripgrep", Description: "Recursive line search", StargazersCount: 50},
+		},
+		scores: map[string]float64{"popular/search-tools": 2.5, "fan/ripgrep-all": 1.2, "BurntSushi/ripgrep": 1.0},
+		matches: map[string][]storage.Match{
+			"fan/ripgrep-all":    nameMatch(storage.NameMatchPrefix),
+			"BurntSushi/ripgrep": nameMatch(storage.NameMatchExact),
+		},
+	}
+
+	results, err := NewSearchEngine(mockRepo, nil).
+		Search(context.Background(), Query{Raw: "ripgrep", Mode: ModeFuzzy}, SearchOptions{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "BurntSushi/ripgrep", results[0].Repository.FullName, "the exact name match should rank first")
+	assert.Equal(t, "fan/ripgrep-all", results[1].Repository.FullName, "a prefix match should outrank a mention")
+	assert.Equal(t, "popular/search-tools", results[2].Repository.FullName)
+}
+
+func TestSearchEngine_FuzzyClosenessRanksExactFirst(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "user/partial", Description: "A terminal file manager"},
+			{FullName: "user/typo", Description: "Termnal emulatr"},
+			{FullName: "user/exact", Description: "Terminal emulator"},
+			{FullName: "user/phrase", Description: "A GPU-accelerated terminal emulator"},
+		},
+	}
+
+	results, err := NewSearchEngine(mockRepo, nil).
+		Search(context.Background(), Query{Raw: "terminal emulator", Mode: ModeFuzzy}, SearchOptions{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	var order []string
+	for _, r := range results {
+		order = append(order, r.Repository.FullName)
+	}
+
+	assert.Equal(t, []string{"user/exact", "user/phrase", "user/typo", "user/partial"}, order,
+		"with equal BM25 scores, closer matches rank first")
+}
+
+func TestSearchEngine_FuzzyKeepsMatches(t *testing.T) {
+	matches := []storage.Match{
+		{Field: "description", Content: "A web framework", Score: 0.8},
+		{Field: "topics", Content: "go", Score: 0.6},
+	}
+	mockRepo := &mockQueryRepo{
+		repos:   []storage.StoredRepo{{FullName: "user/web", Description: "A web framework"}},
+		matches: map[string][]storage.Match{"user/web": matches},
+	}
+
+	results, err := NewSearchEngine(mockRepo, nil).
+		Search(context.Background(), Query{Raw: "web framework", Mode: ModeFuzzy}, SearchOptions{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, matches, results[0].Matches, "the matched fields explain the result")
+}
+
+func TestNameMatchBoost(t *testing.T) {
+	tests := []struct {
+		name    string
+		matches []storage.Match
+		want    float64
+	}{
+		{name: "no matches", matches: nil, want: 1},
+		{name: "other fields only", matches: []storage.Match{{Field: "description", Score: 0.8}}, want: 1},
+		{name: "prefix", matches: []storage.Match{{Field: storage.MatchFieldName, Score: storage.NameMatchPrefix}}, want: prefixNameBoost},
+		{name: "exact", matches: []storage.Match{{Field: storage.MatchFieldName, Score: storage.NameMatchExact}}, want: exactNameBoost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, nameMatchBoost(tt.matches), 1e-9)
+		})
+	}
+}
+
 func TestSearchEngine_NoResults(t *testing.T) {
 	mockRepo := &mockQueryRepo{
 		repos: []storage.StoredRepo{
@@ -317,3 +820,116 @@ func TestSearchEngine_CaseInsensitiveSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchEngine_CaseSensitiveSearch(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "a/reseau", Description: "Réseau toolkit"},
+			{FullName: "b/reseau", Description: "reseau toolkit"},
+			{FullName: "c/net", Description: "Network toolkit", Topics: []string{"Réseau"}},
+			{
+				FullName:     "d/net",
+				Description:  "Network toolkit",
+				Contributors: []storage.Contributor{{Login: "Réseau"}},
+			},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+
+	results, err := engine.Search(context.Background(), Query{Raw: "Réseau toolkit", Mode: ModeFuzzy},
+		SearchOptions{Limit: 10, CaseSensitive: true})
+	require.NoError(t, err)
+
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Repository.FullName)
+	}
+
+	assert.ElementsMatch(t, []string{"a/reseau", "c/net", "d/net"}, names)
+}
+
+func TestSearchEngine_SearchSimilar(t *testing.T) {
+	seed := storage.StoredRepo{FullName: "seed/repo", RepoEmbedding: []float32{0.1, 0.2}}
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			seed,
+			{FullName: "near/one"},
+			{FullName: "near/two"},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+
+	results, err := engine.SearchSimilar(context.Background(), seed, SearchOptions{Limit: 2})
+	require.NoError(t, err, "stored embeddings should not need an embedding provider")
+	require.Len(t, results, 2)
+	assert.Equal(t, "near/one", results[0].Repository.FullName)
+	assert.Equal(t, "near/two", results[1].Repository.FullName)
+
+	for _, r := range results {
+		assert.NotEqual(t, seed.FullName, r.Repository.FullName, "seed repository should be excluded")
+	}
+}
+
+func TestSearchEngine_SearchSimilarByChunks(t *testing.T) {
+	seed := storage.StoredRepo{FullName: "seed/repo", RepoEmbedding: []float32{0.1, 0.2}}
+	chunk := storage.Match{Field: storage.MatchFieldChunk, Source: "README.md", Content: "## Install", Score: 0.9}
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			seed,
+			{FullName: "near/chunked"},
+			{FullName: "near/unchunked"},
+		},
+		matches: map[string][]storage.Match{
+			"seed/repo":    {chunk},
+			"near/chunked": {chunk},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+
+	results, err := engine.SearchSimilar(context.Background(), seed, SearchOptions{Limit: 5, Chunks: true})
+	require.NoError(t, err)
+	require.Len(t, results, 1, "only repositories with embedded chunks match")
+	assert.Equal(t, "near/chunked", results[0].Repository.FullName)
+	assert.Equal(t, []string{storage.MatchFieldChunk}, results[0].MatchFields)
+	assert.Equal(t, []storage.Match{chunk}, results[0].Matches)
+}
+
+func TestSearchEngine_SearchSimilarWithoutEmbedding(t *testing.T) {
+	engine := NewSearchEngine(&mockQueryRepo{}, nil)
+
+	_, err := engine.SearchSimilar(context.Background(), storage.StoredRepo{FullName: "seed/repo"}, SearchOptions{})
+	require.ErrorIs(t, err, ErrNoEmbedding)
+}
+
+func TestSearchEngine_SearchByContributor(t *testing.T) {
+	mockRepo := &mockQueryRepo{
+		repos: []storage.StoredRepo{
+			{FullName: "a/light", Topics: []string{"cli"}, Contributors: []storage.Contributor{{Login: "alice", Contributions: 5}}},
+			{FullName: "b/heavy", Topics: []string{"cli"}, Contributors: []storage.Contributor{
+				{Login: "bob", Contributions: 900},
+				{Login: "Alice", Contributions: 400},
+			}},
+			{FullName: "c/other", Topics: []string{"web"}, Contributors: []storage.Contributor{{Login: "alice", Contributions: 50}}},
+			{FullName: "d/none", Contributors: []storage.Contributor{{Login: "bob", Contributions: 1}}},
+		},
+	}
+
+	engine := NewSearchEngine(mockRepo, nil)
+
+	results, err := engine.SearchByContributor(context.Background(), "alice", SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "b/heavy", results[0].Repository.FullName, "most contributions should rank first")
+	assert.Equal(t, []string{"contributors"}, results[0].MatchFields)
+
+	results, err = engine.SearchByContributor(context.Background(), "alice", SearchOptions{
+		Limit:  1,
+		Filter: storage.Filter{Topics: []string{"cli"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "b/heavy", results[0].Repository.FullName)
+}
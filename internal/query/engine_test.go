@@ -72,6 +72,7 @@ func TestSearchEngine_IdentifyMatchedFields(t *testing.T) {
 	tests := []struct {
 		name           string
 		queryTerms     []string
+		caseSensitive  bool
 		expectedFields []string
 	}{
 		{
@@ -99,11 +100,28 @@ func TestSearchEngine_IdentifyMatchedFields(t *testing.T) {
 			queryTerms:     []string{"python"},
 			expectedFields: []string{},
 		},
+		{
+			name:           "case-sensitive match",
+			queryTerms:     []string{"JavaScript"},
+			caseSensitive:  true,
+			expectedFields: []string{"description"},
+		},
+		{
+			name:           "case-sensitive miss",
+			queryTerms:     []string{"REACT"},
+			caseSensitive:  true,
+			expectedFields: []string{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fields := engine.identifyMatchedFields(repo, tt.queryTerms)
+			fields := engine.identifyMatchedFields(repo, tt.queryTerms, tt.caseSensitive)
+
+			// Case-sensitive matching must not report fields that only match when folded
+			if tt.caseSensitive && len(fields) != len(tt.expectedFields) {
+				t.Errorf("Expected exactly %v, got %v", tt.expectedFields, fields)
+			}
 
 			// Check that all expected fields are present
 			fieldMap := make(map[string]bool)
@@ -217,6 +235,59 @@ func TestSortAndRankResults(t *testing.T) {
 	}
 }
 
+func TestLimitPerOwner(t *testing.T) {
+	ranked := func(names ...string) []Result {
+		results := make([]Result, len(names))
+		for i, name := range names {
+			results[i] = Result{Rank: i + 1, Repository: storage.StoredRepo{FullName: name}}
+		}
+
+		return results
+	}
+
+	tests := []struct {
+		name        string
+		maxPerOwner int
+		want        []string
+	}{
+		{
+			name:        "no cap",
+			maxPerOwner: 0,
+			want:        []string{"big/a", "big/b", "Big/c", "small/x", "big/d"},
+		},
+		{
+			name:        "one per owner",
+			maxPerOwner: 1,
+			want:        []string{"big/a", "small/x"},
+		},
+		{
+			name:        "owner compared case-insensitively",
+			maxPerOwner: 2,
+			want:        []string{"big/a", "big/b", "small/x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := limitPerOwner(ranked("big/a", "big/b", "Big/c", "small/x", "big/d"), tt.maxPerOwner)
+
+			if len(results) != len(tt.want) {
+				t.Fatalf("limitPerOwner() kept %d results, want %d", len(results), len(tt.want))
+			}
+
+			for i, result := range results {
+				if result.Repository.FullName != tt.want[i] {
+					t.Errorf("position %d = %s, want %s", i, result.Repository.FullName, tt.want[i])
+				}
+
+				if result.Rank != i+1 {
+					t.Errorf("%s rank = %d, want %d", result.Repository.FullName, result.Rank, i+1)
+				}
+			}
+		})
+	}
+}
+
 func TestRecencyDecay(t *testing.T) {
 	engine := &SearchEngine{}
 	baseScore := 0.5
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// foldText lowercases s and strips combining accents, so "Réseau" and "reseau"
+// compare equal. This mirrors the FTS index, which is built with lower = 1 and
+// strip_accents = 1.
+func foldText(s string) string {
+	folded, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.ToLower(folded)
+}
+
+// matchText prepares s for substring matching: folded by default, or unchanged
+// when the search is case-sensitive
+func matchText(s string, caseSensitive bool) string {
+	if caseSensitive {
+		return s
+	}
+
+	return foldText(s)
+}
+
+// searchableFields returns the stored text a fuzzy query is matched against,
+// keyed by the field name reported in Result.MatchFields
+func searchableFields(repo storage.StoredRepo) map[string]string {
+	return map[string]string{
+		"name":        repo.FullName,
+		"description": repo.Description,
+		"purpose":     repo.Purpose,
+		"topics":      strings.Join(repo.Topics, " "),
+		"keywords":    strings.Join(repo.Keywords, " "),
+	}
+}
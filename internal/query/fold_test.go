@@ -0,0 +1,24 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Réseau", "reseau"},
+		{"Ñandú CLI", "nandu cli"},
+		{"Zürich-Über", "zurich-uber"},
+		{"plain ascii", "plain ascii"},
+		{"日本語", "日本語"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, foldText(tt.in), tt.in)
+	}
+}
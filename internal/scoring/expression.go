@@ -0,0 +1,359 @@
+// Package scoring evaluates user-supplied ranking expressions. Expressions are
+// plain arithmetic over a fixed set of per-result variables and math functions;
+// nothing else is reachable, so a config file cannot run arbitrary code.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxExpressionLength bounds the size of an expression so a config value cannot
+// make ranking arbitrarily expensive
+const maxExpressionLength = 512
+
+// Variables available to score expressions
+const (
+	VarMatchScore      = "match_score"       // Raw relevance from the search backend (BM25 or cosine similarity)
+	VarDefaultScore    = "default_score"     // match_score with the built-in star, recency and name-match boosts applied
+	VarStars           = "stars"             // Stargazer count
+	VarForks           = "forks"             // Fork count
+	VarDaysSinceUpdate = "days_since_update" // Days since the repository was last updated on GitHub
+)
+
+// Variables lists every variable name an expression may reference
+var Variables = []string{VarMatchScore, VarDefaultScore, VarStars, VarForks, VarDaysSinceUpdate}
+
+// functions maps each allowed function name to its arity and implementation
+var functions = map[string]struct {
+	arity int
+	fn    func(args []float64) float64
+}{
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log10": {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// ErrNonFinite is returned by Eval when the expression produces NaN or infinity,
+// e.g. dividing by zero stars or taking log(0)
+var ErrNonFinite = errors.New("score expression produced a non-finite value")
+
+// Expression is a parsed score expression, safe for concurrent use
+type Expression struct {
+	source string
+	root   node
+}
+
+// Parse compiles an expression, rejecting unknown variables and functions
+func Parse(source string) (*Expression, error) {
+	if len(source) > maxExpressionLength {
+		return nil, fmt.Errorf("score expression is longer than %d characters", maxExpressionLength)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the expression source
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval computes the expression; variables missing from vars evaluate to 0
+func (e *Expression) Eval(vars map[string]float64) (float64, error) {
+	value := e.root.eval(vars)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, ErrNonFinite
+	}
+
+	return value, nil
+}
+
+// node is an evaluable expression tree node
+type node interface {
+	eval(vars map[string]float64) float64
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) float64 { return float64(n) }
+
+type variableNode string
+
+func (n variableNode) eval(vars map[string]float64) float64 { return vars[string(n)] }
+
+type negateNode struct{ operand node }
+
+func (n negateNode) eval(vars map[string]float64) float64 { return -n.operand.eval(vars) }
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+func (n binaryNode) eval(vars map[string]float64) float64 {
+	left, right := n.left.eval(vars), n.right.eval(vars)
+
+	switch n.op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	default:
+		return left / right
+	}
+}
+
+type callNode struct {
+	fn   func(args []float64) float64
+	args []node
+}
+
+func (n callNode) eval(vars map[string]float64) float64 {
+	values := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		values[i] = arg.eval(vars)
+	}
+
+	return n.fn(values)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits source into numbers, identifiers and single-character operators
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(source); {
+		c := rune(source[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(source) && (unicode.IsDigit(rune(source[i])) || source[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, source[start:i], start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(source) && (unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i])) || source[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, source[start:i], start})
+		case strings.ContainsRune("+-*/(),", c):
+			tokens = append(tokens, token{tokenOperator, string(c), i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+
+	return append(tokens, token{tokenEOF, "end of expression", len(source)}), nil
+}
+
+// parser is a recursive-descent parser over the usual arithmetic precedence:
+// sum := product (('+'|'-') product)*, product := unary (('*'|'/') unary)*,
+// unary := '-' unary | primary, primary := number | variable | call | '(' sum ')'
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) isOperator(ops string) bool {
+	tok := p.peek()
+
+	return tok.kind == tokenOperator && strings.Contains(ops, tok.text)
+}
+
+func (p *parser) expect(op string) error {
+	if tok := p.next(); tok.kind != tokenOperator || tok.text != op {
+		return fmt.Errorf("expected %q at position %d, found %q", op, tok.pos, tok.text)
+	}
+
+	return nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("+-") {
+		op := p.next().text[0]
+
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("*/") {
+		op := p.next().text[0]
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("-") {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return negateNode{operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+
+		return numberNode(value), nil
+	case tokenIdent:
+		if p.isOperator("(") {
+			return p.parseCall(tok)
+		}
+
+		for _, name := range Variables {
+			if tok.text == name {
+				return variableNode(name), nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown variable %q at position %d (available: %s)",
+			tok.text, tok.pos, strings.Join(Variables, ", "))
+	case tokenOperator:
+		if tok.text == "(" {
+			inner, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+
+			return inner, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	function, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+
+	p.next() // opening parenthesis
+
+	var args []node
+
+	for {
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+
+		if !p.isOperator(",") {
+			break
+		}
+
+		p.next()
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(args) != function.arity {
+		return nil, fmt.Errorf("%s takes %d argument(s), got %d", name.text, function.arity, len(args))
+	}
+
+	return callNode{fn: function.fn, args: args}, nil
+}
@@ -0,0 +1,94 @@
+package scoring
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestExpressionEval(t *testing.T) {
+	vars := map[string]float64{
+		VarMatchScore:      2,
+		VarDefaultScore:    2.5,
+		VarStars:           999,
+		VarForks:           10,
+		VarDaysSinceUpdate: 30,
+	}
+
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"match_score", 2},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"8 / 4 / 2", 1},
+		{"-match_score + 5", 3},
+		{"--1", 1},
+		{"match_score * log10(stars + 1)", 6},
+		{"default_score / max(days_since_update, 1)", 2.5 / 30},
+		{"min(forks, 3) + abs(-1) + sqrt(16)", 8},
+		{".5 * forks", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got, err := expr.Eval(vars)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"", "unexpected"},
+		{"stars +", "unexpected"},
+		{"(stars", `expected ")"`},
+		{"stars)", "unexpected"},
+		{"password", "unknown variable"},
+		{"exec(1)", "unknown function"},
+		{"max(1)", "takes 2 argument(s)"},
+		{"stars; 1", "unexpected character"},
+		{"stars ** 2", "unexpected"},
+		{"1.2.3", "invalid number"},
+		{strings.Repeat("1+", 300) + "1", "longer than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpressionEval_NonFinite(t *testing.T) {
+	for _, source := range []string{"match_score / stars", "log(stars)"} {
+		expr, err := Parse(source)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", source, err)
+		}
+
+		if _, err := expr.Eval(map[string]float64{VarMatchScore: 1}); !errors.Is(err, ErrNonFinite) {
+			t.Errorf("Eval(%q) error = %v, want ErrNonFinite", source, err)
+		}
+	}
+}
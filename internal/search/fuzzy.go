@@ -0,0 +1,145 @@
+// Package search scores how closely text matches a query. Terms are compared
+// after folding case and accents, and may match a word of the text exactly, as
+// a prefix, or within a small edit distance, so typos still score. It also
+// scores how alike two repositories are, for finding near-duplicate stars.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MatchThreshold is the Similarity at which text counts as matching a query
+const MatchThreshold = 0.5
+
+// Term similarities below 1 for words that are not an exact match. A prefix
+// ("term" for "terminal") outranks a typo, and neither reaches an exact word.
+const (
+	prefixSimilarity = 0.8
+	typoWeight       = 0.8
+	// minTypoSimilarity is the lowest 1 - distance/length accepted as a typo:
+	// one edit in a four-letter word, two in an eight-letter one
+	minTypoSimilarity = 0.75
+	// minPrefixLength keeps single letters from matching every word they start
+	minPrefixLength = 2
+)
+
+// Field is text matched against a query, weighted by its importance. Weights
+// are at most 1, so Score stays within [0, 1].
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Score returns how closely the best field matches query: the highest
+// Similarity times its field's weight, or 0 when nothing matches
+func Score(query string, fields []Field) float64 {
+	best := 0.0
+
+	for _, field := range fields {
+		best = max(best, Similarity(query, field.Text)*field.Weight)
+	}
+
+	return best
+}
+
+// Similarity returns the closeness of text to query in [0, 1]. Text equal to
+// the query (ignoring case, accents and punctuation) scores 1. Otherwise each
+// query term scores its closest word of the text, and the average is scaled
+// down by up to a quarter as the text grows beyond the query, so an exact
+// phrase outranks the same words in a longer text.
+func Similarity(query, text string) float64 {
+	queryTerms, words := Tokenize(query), Tokenize(text)
+	if len(queryTerms) == 0 || len(words) == 0 {
+		return 0
+	}
+
+	if strings.Join(queryTerms, " ") == strings.Join(words, " ") {
+		return 1
+	}
+
+	total := 0.0
+
+	for _, term := range queryTerms {
+		best := 0.0
+
+		for _, word := range words {
+			best = max(best, termSimilarity(term, word))
+			if best == 1 {
+				break
+			}
+		}
+
+		total += best
+	}
+
+	coverage := float64(len(queryTerms)) / float64(max(len(queryTerms), len(words)))
+
+	return total / float64(len(queryTerms)) * (0.75 + 0.25*coverage)
+}
+
+// termSimilarity scores one query term against one word of the text
+func termSimilarity(term, word string) float64 {
+	switch {
+	case term == word:
+		return 1
+	case len(term) >= minPrefixLength && strings.HasPrefix(word, term):
+		return prefixSimilarity
+	}
+
+	termRunes, wordRunes := []rune(term), []rune(word)
+	longest := max(len(termRunes), len(wordRunes))
+
+	similarity := 1 - float64(levenshtein(termRunes, wordRunes))/float64(longest)
+	if similarity < minTypoSimilarity {
+		return 0
+	}
+
+	return similarity * typoWeight
+}
+
+// Tokenize folds s to lowercase without accents and splits it into words of
+// letters and digits
+func Tokenize(s string) []string {
+	folded, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// levenshtein returns the number of single-rune insertions, deletions and
+// substitutions that turn a into b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
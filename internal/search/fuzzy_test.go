@@ -0,0 +1,85 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		text    string
+		want    float64
+		atLeast bool // want is a lower bound rather than exact
+	}{
+		{name: "identical", query: "ripgrep", text: "ripgrep", want: 1},
+		{name: "case, accents and separators ignored", query: "Tree Sitter", text: "tree-sitter", want: 1},
+		{name: "accents folded", query: "reseau", text: "Réseau", want: 1},
+		{name: "word in longer text", query: "ripgrep", text: "ripgrep searches", want: 0.875},
+		{name: "prefix", query: "term", text: "terminal", want: 0.8},
+		{name: "one typo", query: "kubernets", text: "kubernetes", want: 0.72},
+		{name: "too many edits", query: "rust", text: "go", want: 0},
+		{name: "single letter is not a prefix", query: "r", text: "rust", want: 0},
+		{name: "unrelated", query: "database", text: "terminal emulator", want: 0},
+		{name: "half the terms", query: "terminal database", text: "terminal emulator", want: 0.5},
+		{name: "empty query", query: "", text: "anything", want: 0},
+		{name: "empty text", query: "anything", text: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Similarity(tt.query, tt.text), 1e-9)
+		})
+	}
+}
+
+func TestSimilarity_ExactOutranksPartial(t *testing.T) {
+	query := "terminal emulator"
+
+	exact := Similarity(query, "Terminal emulator")
+	phrase := Similarity(query, "A GPU-accelerated terminal emulator")
+	prefix := Similarity(query, "Terminals and emulators")
+	typo := Similarity(query, "Termnal emulatr")
+	partial := Similarity(query, "A terminal file manager")
+
+	assert.Equal(t, 1.0, exact)
+	assert.Greater(t, exact, phrase, "an exact field outranks the phrase in a longer text")
+	assert.Greater(t, phrase, prefix, "whole words outrank prefixes")
+	assert.Greater(t, prefix, typo, "prefixes outrank typos")
+	assert.Greater(t, typo, partial, "every term close outranks one term exact")
+	assert.Positive(t, partial, "one of two terms still scores")
+}
+
+func TestScore(t *testing.T) {
+	fields := func(name, description string) []Field {
+		return []Field{{Text: name, Weight: 1}, {Text: description, Weight: 0.8}}
+	}
+
+	named := Score("ripgrep", fields("ripgrep", "Recursive line search"))
+	described := Score("ripgrep", fields("search-tools", "ripgrep"))
+	mentioned := Score("ripgrep", fields("search-tools", "Faster than ripgrep"))
+
+	assert.Equal(t, 1.0, named)
+	assert.InDelta(t, 0.8, described, 1e-9, "a field scores its weight at most")
+	assert.Greater(t, described, mentioned)
+	assert.Zero(t, Score("ripgrep", fields("fd", "Find files")))
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"kubernets", "kubernetes", 1},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levenshtein([]rune(tt.a), []rune(tt.b)), "%q -> %q", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,139 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Weights of each signal in RepoScore.Score. When either repository lacks an
+// embedding its weight is dropped and the others rescaled to sum to 1.
+const (
+	topicSimilarityWeight     = 0.5
+	languageSimilarityWeight  = 0.2
+	embeddingSimilarityWeight = 0.3
+)
+
+// RepoFeatures are the attributes of a repository compared by RepoSimilarity
+type RepoFeatures struct {
+	Topics   []string
+	Language string
+}
+
+// RepoScore is how alike two repositories are, by signal and combined
+type RepoScore struct {
+	Topics       float64 // Jaccard overlap of topics, case-insensitive
+	Language     float64 // 1 when primary languages match, else 0
+	Embedding    float64 // Cosine similarity of embeddings, clamped to [0, 1]
+	HasEmbedding bool    // Whether Embedding contributes to Score
+	Score        float64 // Weighted combination in [0, 1]
+}
+
+// SimilarCandidate is a repository ranked against a target by RankSimilar.
+// Cosine is its embedding similarity to the target, as returned by a vector
+// search, and is only used when HasEmbedding is set.
+type SimilarCandidate struct {
+	FullName     string
+	Features     RepoFeatures
+	Cosine       float64
+	HasEmbedding bool
+}
+
+// RankedSimilar is a candidate with its similarity to the target
+type RankedSimilar struct {
+	FullName string
+	RepoScore
+}
+
+// TopicJaccard returns |a ∩ b| / |a ∪ b| for two topic lists, ignoring case
+// and duplicates, or 0 when both are empty
+func TopicJaccard(a, b []string) float64 {
+	setA, setB := topicSet(a), topicSet(b)
+
+	union := len(setA)
+	shared := 0
+
+	for topic := range setB {
+		if setA[topic] {
+			shared++
+		} else {
+			union++
+		}
+	}
+
+	if union == 0 {
+		return 0
+	}
+
+	return float64(shared) / float64(union)
+}
+
+// RepoSimilarity combines topic overlap, language match and, when hasEmbedding
+// is set, the embedding cosine into a single score
+func RepoSimilarity(target, candidate RepoFeatures, cosine float64, hasEmbedding bool) RepoScore {
+	sim := RepoScore{Topics: TopicJaccard(target.Topics, candidate.Topics)}
+
+	if target.Language != "" && strings.EqualFold(target.Language, candidate.Language) {
+		sim.Language = 1
+	}
+
+	if !hasEmbedding {
+		sim.Score = (topicSimilarityWeight*sim.Topics + languageSimilarityWeight*sim.Language) /
+			(topicSimilarityWeight + languageSimilarityWeight)
+
+		return sim
+	}
+
+	sim.HasEmbedding = true
+	sim.Embedding = min(max(cosine, 0), 1)
+	sim.Score = topicSimilarityWeight*sim.Topics + languageSimilarityWeight*sim.Language +
+		embeddingSimilarityWeight*sim.Embedding
+
+	return sim
+}
+
+// RankSimilar scores each candidate against target and returns up to limit of
+// them, most similar first, ties broken by name. Candidates sharing nothing
+// with the target are dropped, as is the target itself when listed.
+func RankSimilar(target RepoFeatures, targetName string, candidates []SimilarCandidate, limit int) []RankedSimilar {
+	ranked := make([]RankedSimilar, 0, len(candidates))
+
+	for _, c := range candidates {
+		if strings.EqualFold(c.FullName, targetName) {
+			continue
+		}
+
+		sim := RepoSimilarity(target, c.Features, c.Cosine, c.HasEmbedding)
+		if sim.Score <= 0 {
+			continue
+		}
+
+		ranked = append(ranked, RankedSimilar{FullName: c.FullName, RepoScore: sim})
+	}
+
+	slices.SortFunc(ranked, func(a, b RankedSimilar) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.FullName, b.FullName)
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked
+}
+
+func topicSet(topics []string) map[string]bool {
+	set := make(map[string]bool, len(topics))
+
+	for _, topic := range topics {
+		if topic = strings.ToLower(strings.TrimSpace(topic)); topic != "" {
+			set[topic] = true
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{name: "identical", a: []string{"cli", "go"}, b: []string{"go", "cli"}, want: 1},
+		{name: "half shared", a: []string{"cli", "go"}, b: []string{"cli", "rust"}, want: 1.0 / 3},
+		{name: "case and duplicates ignored", a: []string{"CLI", "cli"}, b: []string{"cli"}, want: 1},
+		{name: "disjoint", a: []string{"cli"}, b: []string{"web"}, want: 0},
+		{name: "one empty", a: []string{"cli"}, b: nil, want: 0},
+		{name: "both empty", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, TopicJaccard(tt.a, tt.b), 1e-9)
+		})
+	}
+}
+
+func TestRepoSimilarity(t *testing.T) {
+	target := RepoFeatures{Topics: []string{"cli", "go"}, Language: "Go"}
+
+	t.Run("without embeddings weights topics and language", func(t *testing.T) {
+		sim := RepoSimilarity(target, RepoFeatures{Topics: []string{"cli", "go"}, Language: "go"}, 0.9, false)
+
+		assert.InDelta(t, 1, sim.Score, 1e-9)
+		assert.InDelta(t, 1, sim.Language, 1e-9)
+		assert.False(t, sim.HasEmbedding)
+		assert.Zero(t, sim.Embedding)
+	})
+
+	t.Run("with embeddings adds cosine", func(t *testing.T) {
+		sim := RepoSimilarity(target, RepoFeatures{Topics: []string{"web"}, Language: "Rust"}, 0.8, true)
+
+		assert.InDelta(t, embeddingSimilarityWeight*0.8, sim.Score, 1e-9)
+		assert.True(t, sim.HasEmbedding)
+	})
+
+	t.Run("negative cosine counts as zero", func(t *testing.T) {
+		sim := RepoSimilarity(target, RepoFeatures{}, -0.4, true)
+
+		assert.Zero(t, sim.Embedding)
+		assert.Zero(t, sim.Score)
+	})
+
+	t.Run("empty languages do not match", func(t *testing.T) {
+		sim := RepoSimilarity(RepoFeatures{}, RepoFeatures{}, 0, false)
+
+		assert.Zero(t, sim.Language)
+	})
+}
+
+func TestRankSimilar(t *testing.T) {
+	target := RepoFeatures{Topics: []string{"cli", "go"}, Language: "Go"}
+	candidates := []SimilarCandidate{
+		{FullName: "user/target", Features: target},
+		{FullName: "user/unrelated", Features: RepoFeatures{Topics: []string{"web"}, Language: "Rust"}},
+		{FullName: "user/b-same-language", Features: RepoFeatures{Language: "Go"}},
+		{FullName: "user/a-same-language", Features: RepoFeatures{Language: "Go"}},
+		{FullName: "user/twin", Features: RepoFeatures{Topics: []string{"go", "cli"}, Language: "Go"}},
+		{FullName: "user/close-embedding", Features: RepoFeatures{Language: "Rust"}, Cosine: 0.99, HasEmbedding: true},
+	}
+
+	ranked := RankSimilar(target, "user/target", candidates, 0)
+
+	names := make([]string, len(ranked))
+	for i, r := range ranked {
+		names[i] = r.FullName
+	}
+
+	assert.Equal(t, []string{"user/twin", "user/close-embedding", "user/a-same-language", "user/b-same-language"}, names)
+
+	assert.Len(t, RankSimilar(target, "user/target", candidates, 2), 2)
+}
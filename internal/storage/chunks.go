@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// MatchFieldChunk is the Match.Field of a content chunk matched by
+// SearchChunksByEmbedding; Match.Source names the chunk's file or section
+const MatchFieldChunk = "chunk"
+
+// StoredChunk is a content chunk of a repository as stored for chunk-level
+// semantic search
+type StoredChunk struct {
+	ID       string
+	FullName string
+	Index    int    // Position among the repository's chunks
+	Source   string // File path or section
+	Type     string // readme, code, docs, etc.
+	Content  string
+}
+
+// replaceChunks replaces the stored chunks of a repository. Embeddings of
+// chunks whose content is unchanged are carried over, so re-processing a
+// repository only leaves new or edited chunks to embed.
+func replaceChunks(ctx context.Context, tx *sql.Tx, fullName string, chunks []processor.ContentChunk) error {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT content, embedding FROM content_chunk_embeddings
+	WHERE full_name = ? AND embedding IS NOT NULL`, fullName)
+	if err != nil {
+		return fmt.Errorf("failed to read existing chunk embeddings: %w", err)
+	}
+
+	embeddings := make(map[string]string)
+
+	for rows.Next() {
+		var (
+			content string
+			data    any
+		)
+
+		if err := rows.Scan(&content, &data); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan chunk embedding: %w", err)
+		}
+
+		var vector []float32
+		decodeJSONColumn(data, &vector)
+
+		if encoded, err := encodeJSONColumn(vector); err == nil && len(vector) > 0 {
+			embeddings[content] = encoded
+		}
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read existing chunk embeddings: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM content_chunk_embeddings WHERE full_name = ?", fullName); err != nil {
+		return fmt.Errorf("failed to delete content chunks: %w", err)
+	}
+
+	for i, chunk := range chunks {
+		embedding := sql.NullString{}
+		if encoded, ok := embeddings[chunk.Content]; ok {
+			embedding = sql.NullString{String: encoded, Valid: true}
+		}
+
+		sourceSHA := sql.NullString{String: chunk.SourceSHA, Valid: chunk.SourceSHA != ""}
+
+		if _, err := tx.ExecContext(ctx, `
+		INSERT INTO content_chunk_embeddings
+			(id, full_name, chunk_index, source, chunk_type, content, embedding, source_sha, source_parts, source_limit)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			uuid.New().String(), fullName, i, chunk.Source, chunk.Type, chunk.Content, embedding,
+			sourceSHA, chunk.SourceParts, chunk.SourceLimit,
+		); err != nil {
+			return fmt.Errorf("failed to store content chunk %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// TrimChunks caps every repository at maxChunks stored content chunks, deleting
+// the lowest-priority ones in one transaction, and returns how many were
+// deleted. Priorities are derived again from each chunk's type and source, as
+// when processing; chunks of equal priority are kept in chunk order.
+func (r *DuckDBRepository) TrimChunks(ctx context.Context, maxChunks int) (int, error) {
+	if maxChunks <= 0 {
+		return 0, fmt.Errorf("max chunks must be positive, got %d", maxChunks)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, full_name, source, chunk_type FROM content_chunk_embeddings
+	ORDER BY full_name, chunk_index`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query content chunks: %w", err)
+	}
+
+	type rankedChunk struct {
+		id       string
+		priority int
+	}
+
+	var (
+		names  []string
+		chunks = make(map[string][]rankedChunk)
+	)
+
+	for rows.Next() {
+		var id, fullName, source, chunkType string
+		if err := rows.Scan(&id, &fullName, &source, &chunkType); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan content chunk: %w", err)
+		}
+
+		if _, ok := chunks[fullName]; !ok {
+			names = append(names, fullName)
+		}
+
+		chunks[fullName] = append(chunks[fullName],
+			rankedChunk{id: id, priority: processor.ChunkPriority(chunkType, source)})
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to query content chunks: %w", err)
+	}
+
+	deleted := 0
+
+	for _, fullName := range names {
+		ranked := chunks[fullName]
+		if len(ranked) <= maxChunks {
+			continue
+		}
+
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].priority < ranked[j].priority
+		})
+
+		for _, chunk := range ranked[maxChunks:] {
+			if _, err := tx.ExecContext(ctx,
+				"DELETE FROM content_chunk_embeddings WHERE id = ?", chunk.id); err != nil {
+				return 0, fmt.Errorf("failed to trim content chunks of %s: %w", fullName, err)
+			}
+
+			deleted++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit chunk trim: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// ChunksBySourceSHA returns the stored chunks of a repository that record the
+// blob SHA of their source file, grouped by that SHA in chunk order. It lets
+// the processor reuse the chunks of files that have not changed.
+func (r *DuckDBRepository) ChunksBySourceSHA(
+	ctx context.Context,
+	fullName string,
+) (map[string][]processor.ContentChunk, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT source_sha, source, chunk_type, content, COALESCE(source_parts, 0), COALESCE(source_limit, 0)
+	FROM content_chunk_embeddings
+	WHERE full_name = ? AND source_sha IS NOT NULL
+	ORDER BY chunk_index`, fullName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunks by source SHA: %w", err)
+	}
+	defer rows.Close()
+
+	chunks := make(map[string][]processor.ContentChunk)
+
+	for rows.Next() {
+		var chunk processor.ContentChunk
+		if err := rows.Scan(&chunk.SourceSHA, &chunk.Source, &chunk.Type,
+			&chunk.Content, &chunk.SourceParts, &chunk.SourceLimit); err != nil {
+			return nil, fmt.Errorf("failed to scan content chunk: %w", err)
+		}
+
+		chunks[chunk.SourceSHA] = append(chunks[chunk.SourceSHA], chunk)
+	}
+
+	return chunks, rows.Err()
+}
+
+// GetChunksNeedingEmbedding returns the stored content chunks without an
+// embedding, or every chunk when forceUpdate is set, ordered by repository
+func (r *DuckDBRepository) GetChunksNeedingEmbedding(
+	ctx context.Context,
+	forceUpdate bool,
+) ([]StoredChunk, error) {
+	var query string
+	if forceUpdate {
+		query = `
+		SELECT id, full_name, chunk_index, source, chunk_type, content
+		FROM content_chunk_embeddings
+		ORDER BY full_name, chunk_index`
+	} else {
+		query = `
+		SELECT id, full_name, chunk_index, source, chunk_type, content
+		FROM content_chunk_embeddings
+		WHERE embedding IS NULL
+		ORDER BY full_name, chunk_index`
+	}
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chunks needing embedding: %w", err)
+	}
+	defer rows.Close()
+
+	var chunks []StoredChunk
+
+	for rows.Next() {
+		var chunk StoredChunk
+		if err := rows.Scan(&chunk.ID, &chunk.FullName, &chunk.Index,
+			&chunk.Source, &chunk.Type, &chunk.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan content chunk: %w", err)
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, rows.Err()
+}
+
+// UpdateChunkEmbedding stores the embedding of a content chunk
+func (r *DuckDBRepository) UpdateChunkEmbedding(ctx context.Context, id string, embedding []float32) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	embeddingJSON, err := encodeJSONColumn(embedding)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chunk embedding: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE content_chunk_embeddings SET embedding = ? WHERE id = ?", embeddingJSON, id)
+	if err != nil {
+		return fmt.Errorf("failed to update chunk embedding: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("content chunk not found: %s", id)
+	}
+
+	return nil
+}
+
+// chunkSearchSQL ranks repositories by their most similar content chunk. It
+// takes the query embedding, the minimum score and the limit.
+const chunkSearchSQL = `
+	WITH best AS (
+		SELECT full_name AS chunk_repo, source AS chunk_source, content AS chunk_content,
+			   array_cosine_similarity(CAST(embedding AS FLOAT[384]), ?::FLOAT[384]) AS chunk_score
+		FROM content_chunk_embeddings
+		WHERE embedding IS NOT NULL
+		QUALIFY row_number() OVER (PARTITION BY full_name ORDER BY chunk_score DESC, chunk_index) = 1
+	)
+	SELECT ` + searchRepoColumns + `,
+		   chunk_source, chunk_content, chunk_score
+	FROM best b
+	JOIN repositories r ON r.full_name = b.chunk_repo
+	WHERE chunk_score >= ?
+	ORDER BY chunk_score DESC, full_name
+	LIMIT ?`
+
+// SearchChunksByEmbedding performs vector similarity search over content
+// chunks, returning each repository once with its best-matching chunk as the
+// only Match
+func (r *DuckDBRepository) SearchChunksByEmbedding(
+	ctx context.Context,
+	queryEmbedding []float32,
+	limit int,
+	minScore float64,
+) ([]SearchResult, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	embeddingJSON, err := json.Marshal(queryEmbedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query embedding: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(queryCtx, chunkSearchSQL, string(embeddingJSON), minScore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search content chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+
+	for rows.Next() {
+		var (
+			source, chunk string
+			score         float64
+		)
+
+		repo, err := scanSearchRepo(rows, &source, &chunk, &score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chunk search result: %w", err)
+		}
+
+		results = append(results, SearchResult{
+			Repository: repo,
+			Score:      score,
+			Matches: []Match{{
+				Field:   MatchFieldChunk,
+				Source:  source,
+				Content: chunk,
+				Score:   score,
+			}},
+		})
+	}
+
+	return results, rows.Err()
+}
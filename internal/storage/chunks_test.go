@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+// unitVector returns a 384-dimension vector with 1 at index i
+func unitVector(i int) []float32 {
+	v := make([]float32, 384)
+	v[i] = 1
+
+	return v
+}
+
+func upsertWithChunks(t *testing.T, repo *DuckDBRepository, fullName string, contents ...string) {
+	t.Helper()
+
+	chunks := make([]processor.ContentChunk, len(contents))
+	for i, content := range contents {
+		chunks[i] = processor.ContentChunk{Source: "README.md", Type: "readme", Content: content}
+	}
+
+	processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName(fullName)), nil)
+	require.NoError(t, repo.UpsertRepository(context.Background(), processed,
+		UpsertOptions{ReplaceChunks: true, Chunks: chunks}))
+}
+
+func TestReplaceChunks_KeepsEmbeddingsOfUnchangedChunks(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	upsertWithChunks(t, repo, "user/repo", "install", "usage")
+
+	pending, err := repo.GetChunksNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "install", pending[0].Content)
+	assert.Equal(t, 1, pending[1].Index)
+
+	for i, chunk := range pending {
+		require.NoError(t, repo.UpdateChunkEmbedding(ctx, chunk.ID, unitVector(i)))
+	}
+
+	// "usage" is unchanged and keeps its embedding; "install" was edited away
+	upsertWithChunks(t, repo, "user/repo", "usage", "configuration")
+
+	pending, err = repo.GetChunksNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "configuration", pending[0].Content)
+
+	all, err := repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// An upsert without ReplaceChunks leaves stored chunks alone
+	processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName("user/repo")), nil)
+	require.NoError(t, repo.UpsertRepository(ctx, processed, UpsertOptions{}))
+
+	all, err = repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestChunksBySourceSHA(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	chunks := []processor.ContentChunk{
+		{Source: "README.md#1", Type: "readme", Content: "intro", SourceSHA: "aaa", SourceParts: 2, SourceLimit: 2000},
+		{Source: "README.md#2", Type: "readme", Content: "usage", SourceSHA: "aaa", SourceParts: 2, SourceLimit: 2000},
+		{Source: "docs/guide.md", Type: "docs", Content: "guide", SourceSHA: "bbb", SourceParts: 1, SourceLimit: 500},
+		{Source: "homepage", Type: "docs", Content: "landing page"},
+	}
+
+	processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName("user/repo")), nil)
+	require.NoError(t, repo.UpsertRepository(ctx, processed, UpsertOptions{ReplaceChunks: true, Chunks: chunks}))
+
+	bySHA, err := repo.ChunksBySourceSHA(ctx, "user/repo")
+	require.NoError(t, err)
+
+	// Chunks without a source SHA cannot be matched to a file and are left out
+	assert.Equal(t, map[string][]processor.ContentChunk{
+		"aaa": chunks[:2],
+		"bbb": chunks[2:3],
+	}, bySHA)
+
+	other, err := repo.ChunksBySourceSHA(ctx, "user/other")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestSearchChunksByEmbedding(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	upsertWithChunks(t, repo, "user/alpha", "alpha intro", "alpha install")
+	upsertWithChunks(t, repo, "user/beta", "beta intro")
+
+	chunks, err := repo.GetChunksNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+
+	// alpha's install chunk points along the query; beta's intro partly does
+	vectors := map[string][]float32{
+		"alpha intro":   unitVector(1),
+		"alpha install": unitVector(0),
+		"beta intro":    append([]float32{0.6, 0.8}, make([]float32, 382)...),
+	}
+	for _, chunk := range chunks {
+		require.NoError(t, repo.UpdateChunkEmbedding(ctx, chunk.ID, vectors[chunk.Content]))
+	}
+
+	results, err := repo.SearchChunksByEmbedding(ctx, unitVector(0), 10, 0.1)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "user/alpha", results[0].Repository.FullName)
+	assert.InDelta(t, 1.0, results[0].Score, 1e-6)
+	require.Len(t, results[0].Matches, 1)
+	assert.Equal(t, Match{Field: MatchFieldChunk, Source: "README.md", Content: "alpha install", Score: results[0].Score},
+		results[0].Matches[0])
+
+	assert.Equal(t, "user/beta", results[1].Repository.FullName)
+	assert.InDelta(t, 0.6, results[1].Score, 1e-6)
+
+	// The minimum score drops beta
+	results, err = repo.SearchChunksByEmbedding(ctx, unitVector(0), 10, 0.9)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestChunksFollowRenameAndDelete(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	upsertWithChunks(t, repo, "user/old", "content")
+
+	require.NoError(t, repo.RenameRepository(ctx, "user/old", "user/new"))
+
+	chunks, err := repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "user/new", chunks[0].FullName)
+
+	require.NoError(t, repo.DeleteRepository(ctx, "user/new"))
+
+	chunks, err = repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
+
+func TestTrimChunks(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Document order differs from priority order: the README and the getting
+	// started guide rank above the license and a non-entry-point source file
+	chunks := []processor.ContentChunk{
+		{Source: "LICENSE", Type: processor.ContentTypeLicense, Content: "license"},
+		{Source: "docs/getting-started.md#install", Type: processor.ContentTypeDocs, Content: "install"},
+		{Source: "pkg/util.go", Type: processor.ContentTypeCode, Content: "util"},
+		{Source: "README.md", Type: processor.ContentTypeReadme, Content: "readme"},
+	}
+	processed := testutil.NewTestProcessedRepo(testutil.NewTestRepository(testutil.WithFullName("user/many")), nil)
+	require.NoError(t, repo.UpsertRepository(ctx, processed, UpsertOptions{ReplaceChunks: true, Chunks: chunks}))
+
+	upsertWithChunks(t, repo, "user/few", "only")
+
+	deleted, err := repo.TrimChunks(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	stored, err := repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+
+	contents := make([]string, 0, len(stored))
+	for _, chunk := range stored {
+		contents = append(contents, chunk.Content)
+	}
+
+	assert.Equal(t, []string{"only", "install", "readme"}, contents,
+		"the highest-priority chunks of each repository are kept, in chunk order")
+
+	_, err = repo.TrimChunks(ctx, 0)
+	assert.Error(t, err)
+}
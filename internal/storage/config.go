@@ -14,5 +14,70 @@ func NewDuckDBRepositoryFromConfig(cfg *config.DatabaseConfig) (*DuckDBRepositor
 		return nil, fmt.Errorf("invalid query_timeout: %w", err)
 	}
 
-	return NewDuckDBRepositoryWithTimeout(cfg.Path, queryTimeout)
+	opts, err := poolOptionsFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDuckDBRepositoryWithTimeout(config.ExpandPath(cfg.Path), queryTimeout, opts...)
+}
+
+// poolOptionsFromConfig converts the database config into connection pool options.
+// Zero values fall back to the package defaults.
+func poolOptionsFromConfig(cfg *config.DatabaseConfig) ([]Option, error) {
+	var opts []Option
+
+	if cfg.MaxConnections > 0 {
+		opts = append(opts, WithMaxOpenConns(cfg.MaxConnections))
+	}
+
+	if cfg.MaxIdleConns > 0 {
+		opts = append(opts, WithMaxIdleConns(cfg.MaxIdleConns))
+	}
+
+	if cfg.ConnMaxLifetime != "" {
+		lifetime, err := time.ParseDuration(cfg.ConnMaxLifetime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conn_max_lifetime: %w", err)
+		}
+
+		opts = append(opts, WithConnMaxLifetime(lifetime))
+	}
+
+	if cfg.ConnMaxIdleTime != "" {
+		idleTime, err := time.ParseDuration(cfg.ConnMaxIdleTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conn_max_idle_time: %w", err)
+		}
+
+		opts = append(opts, WithConnMaxIdleTime(idleTime))
+	}
+
+	if cfg.SingleConnection {
+		opts = append(opts, WithSingleConnection(true))
+	}
+
+	if cfg.OpenAttempts > 0 {
+		opts = append(opts, WithOpenAttempts(cfg.OpenAttempts))
+	}
+
+	if cfg.OpenBackoff != "" {
+		backoff, err := time.ParseDuration(cfg.OpenBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid open_backoff: %w", err)
+		}
+
+		opts = append(opts, WithOpenBackoff(backoff))
+	}
+
+	if cfg.LockWait != "" {
+		wait, err := time.ParseDuration(cfg.LockWait)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lock_wait: %w", err)
+		}
+
+		opts = append(opts, WithLockWait(wait))
+	}
+
+	return opts, nil
 }
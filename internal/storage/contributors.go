@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// contributorSearchSQL finds repositories whose contributors JSON array has an
+// entry for a login (case-insensitive, as GitHub logins are). It returns the
+// searchRepoColumns, scored by that contributor's commit count.
+const contributorSearchSQL = `
+	SELECT ` + searchRepoColumns + `,
+		   CAST(COALESCE(contributor.contributions, 0) AS DOUBLE) AS score
+	FROM (
+		SELECT r.*,
+			   UNNEST(from_json(COALESCE(r.contributors, '[]'),
+				   '[{"login": "VARCHAR", "contributions": "BIGINT"}]')) AS contributor
+		FROM repositories r
+	)
+	WHERE lower(contributor.login) = lower(?)
+	ORDER BY score DESC, full_name`
+
+// SearchByContributor returns the repositories a GitHub user contributes to,
+// most contributions first. Only the top contributors fetched during sync are
+// stored, so a login matches where it is among them.
+func (r *DuckDBRepository) SearchByContributor(ctx context.Context, login string) ([]SearchResult, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(queryCtx, contributorSearchSQL, login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search by contributor: %w", err)
+	}
+	defer rows.Close()
+
+	results, err := r.scanTextSearchResults(rows, login)
+	if err != nil {
+		return nil, err
+	}
+
+	// The only match is the contributor entry; text matches of the login
+	// elsewhere are irrelevant here
+	for i := range results {
+		results[i].Matches = []Match{{
+			Field:   "contributors",
+			Content: login,
+			Score:   results[i].Score,
+		}}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestSearchByContributor(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	contributors := map[string][]Contributor{
+		"org1/repo-a": {{Login: "alice", Contributions: 100}, {Login: "bob", Contributions: 50}},
+		"org2/repo-b": {{Login: "bob", Contributions: 5}, {Login: "Alice", Contributions: 300}},
+		"org3/repo-c": {{Login: "charlie", Contributions: 30}, {Login: "alice-bot", Contributions: 999}},
+		"org4/repo-d": nil,
+	}
+
+	for fullName, list := range contributors {
+		fixture := testutil.NewTestRepository(testutil.WithFullName(fullName))
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(fixture, nil)))
+
+		if list != nil {
+			require.NoError(t, repo.UpdateRepositoryMetrics(ctx, fullName, RepositoryMetrics{Contributors: list}))
+		}
+	}
+
+	tests := []struct {
+		name       string
+		login      string
+		want       []string
+		wantScores []float64
+	}{
+		{
+			name:       "most contributions first, login case-insensitive",
+			login:      "alice",
+			want:       []string{"org2/repo-b", "org1/repo-a"},
+			wantScores: []float64{300, 100},
+		},
+		{
+			name:       "not only the top contributor",
+			login:      "BOB",
+			want:       []string{"org1/repo-a", "org2/repo-b"},
+			wantScores: []float64{50, 5},
+		},
+		{
+			name:  "unknown login",
+			login: "dave",
+		},
+		{
+			name:  "no partial matches",
+			login: "alice-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.SearchByContributor(ctx, tt.login)
+			require.NoError(t, err)
+
+			var names []string
+			var scores []float64
+
+			for _, result := range results {
+				names = append(names, result.Repository.FullName)
+				scores = append(scores, result.Score)
+
+				require.Len(t, result.Matches, 1)
+				assert.Equal(t, "contributors", result.Matches[0].Field)
+				assert.Equal(t, tt.login, result.Matches[0].Content)
+			}
+
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, tt.wantScores, scores)
+		})
+	}
+}
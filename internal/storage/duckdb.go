@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,49 +16,143 @@ import (
 	_ "github.com/marcboeker/go-duckdb" // DuckDB driver
 
 	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/search"
+	"github.com/KyleKing/gh-star-search/internal/timing"
 )
 
 const (
 	// DefaultQueryTimeout is the default timeout for database queries
 	DefaultQueryTimeout = 30 * time.Second
+	// DefaultMaxOpenConns is the default maximum number of open connections
+	DefaultMaxOpenConns = 10
+	// DefaultMaxIdleConns is the default maximum number of idle connections
+	DefaultMaxIdleConns = 5
+	// DefaultConnMaxLifetime is the default maximum lifetime of a connection
+	DefaultConnMaxLifetime = 30 * time.Minute
+	// DefaultConnMaxIdleTime is the default maximum time a connection may sit idle
+	DefaultConnMaxIdleTime = 5 * time.Minute
+	// DefaultOpenAttempts is the default number of attempts to open and ping the database
+	DefaultOpenAttempts = 3
+	// DefaultOpenBackoff is the default delay before the first retry; it doubles per attempt
+	DefaultOpenBackoff = 500 * time.Millisecond
+	// pingTimeout bounds each connection check
+	pingTimeout = 5 * time.Second
+	// lockPollInterval is how often a locked database is retried while waiting
+	lockPollInterval = time.Second
 )
 
+// ErrDatabaseLocked is returned when another process holds the DuckDB file lock.
+// DuckDB allows a single writer, so concurrent invocations hit this.
+var ErrDatabaseLocked = errors.New("database is locked by another process")
+
 // DuckDBRepository implements the Repository interface using DuckDB
 type DuckDBRepository struct {
 	db           *sql.DB
 	path         string
 	queryTimeout time.Duration
+
+	// beforeCommit, when set, runs just before an upsert commits (tests use it to simulate crashes)
+	beforeCommit func() error
+}
+
+// poolSettings holds connection pool configuration for the underlying sql.DB
+type poolSettings struct {
+	maxOpenConns     int
+	maxIdleConns     int
+	connMaxLifetime  time.Duration
+	connMaxIdleTime  time.Duration
+	singleConnection bool
+	openAttempts     int
+	openBackoff      time.Duration
+	lockWait         time.Duration
+}
+
+// Option configures optional DuckDBRepository settings
+type Option func(*poolSettings)
+
+// WithMaxOpenConns sets the maximum number of open connections
+func WithMaxOpenConns(n int) Option {
+	return func(p *poolSettings) { p.maxOpenConns = n }
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections
+func WithMaxIdleConns(n int) Option {
+	return func(p *poolSettings) { p.maxIdleConns = n }
+}
+
+// WithConnMaxLifetime sets the maximum amount of time a connection may be reused
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(p *poolSettings) { p.connMaxLifetime = d }
+}
+
+// WithConnMaxIdleTime sets the maximum amount of time a connection may be idle
+func WithConnMaxIdleTime(d time.Duration) Option {
+	return func(p *poolSettings) { p.connMaxIdleTime = d }
+}
+
+// WithSingleConnection forces the pool to a single connection. DuckDB allows only
+// one writer at a time, so write-heavy workloads avoid lock contention this way.
+func WithSingleConnection(single bool) Option {
+	return func(p *poolSettings) { p.singleConnection = single }
+}
+
+// WithOpenAttempts sets how many times opening and pinging the database is tried
+// before giving up. Values below 1 are treated as 1.
+func WithOpenAttempts(n int) Option {
+	return func(p *poolSettings) { p.openAttempts = n }
+}
+
+// WithOpenBackoff sets the delay before the first open retry; later retries double it
+func WithOpenBackoff(d time.Duration) Option {
+	return func(p *poolSettings) { p.openBackoff = d }
+}
+
+// WithLockWait keeps retrying for up to d while another process holds the
+// database lock, instead of failing once the open attempts are used up
+func WithLockWait(d time.Duration) Option {
+	return func(p *poolSettings) { p.lockWait = d }
 }
 
 // NewDuckDBRepository creates a new DuckDB repository instance with connection pooling
-func NewDuckDBRepository(dbPath string) (*DuckDBRepository, error) {
-	return NewDuckDBRepositoryWithTimeout(dbPath, DefaultQueryTimeout)
+func NewDuckDBRepository(dbPath string, opts ...Option) (*DuckDBRepository, error) {
+	return NewDuckDBRepositoryWithTimeout(dbPath, DefaultQueryTimeout, opts...)
 }
 
 // NewDuckDBRepositoryWithTimeout creates a new DuckDB repository instance with custom timeout
 func NewDuckDBRepositoryWithTimeout(
 	dbPath string,
 	queryTimeout time.Duration,
+	opts ...Option,
 ) (*DuckDBRepository, error) {
+	pool := poolSettings{
+		maxOpenConns:    DefaultMaxOpenConns,
+		maxIdleConns:    DefaultMaxIdleConns,
+		connMaxLifetime: DefaultConnMaxLifetime,
+		connMaxIdleTime: DefaultConnMaxIdleTime,
+		openAttempts:    DefaultOpenAttempts,
+		openBackoff:     DefaultOpenBackoff,
+	}
+	for _, opt := range opts {
+		opt(&pool)
+	}
+
 	// Ensure the directory exists
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	db, err := sql.Open("duckdb", dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	db, err := openWithRetry(dbPath, pool.openAttempts, pool.openBackoff, openAndPing)
+	if err != nil && pool.lockWait > 0 && errors.Is(err, ErrDatabaseLocked) {
+		db, err = waitForLock(dbPath, pool.lockWait, lockPollInterval, openAndPing)
 	}
 
-	// Test the connection with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
+	applyPoolSettings(db, pool)
+
 	repo := &DuckDBRepository{
 		db:           db,
 		path:         dbPath,
@@ -67,6 +162,109 @@ func NewDuckDBRepositoryWithTimeout(
 	return repo, nil
 }
 
+// openAndPing opens the database file and checks the connection. DuckDB takes
+// the file lock on open, so lock conflicts surface from sql.Open.
+func openAndPing(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("duckdb", dbPath)
+	if err != nil {
+		return nil, classifyOpenError(dbPath, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", classifyOpenError(dbPath, err))
+	}
+
+	return db, nil
+}
+
+// openWithRetry calls open up to attempts times, doubling the backoff between
+// tries, to ride out cold starts, slow filesystems and short-lived lock holders
+func openWithRetry(
+	dbPath string,
+	attempts int,
+	backoff time.Duration,
+	open func(string) (*sql.DB, error),
+) (*sql.DB, error) {
+	attempts = max(attempts, 1)
+
+	var lastErr error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		db, err := open(dbPath)
+		if err == nil {
+			return db, nil
+		}
+
+		lastErr = err
+
+		if attempt < attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return nil, fmt.Errorf("failed to open database after %d attempt(s): %w", attempts, lastErr)
+}
+
+// waitForLock polls open every interval until the lock is released or wait
+// elapses. Errors other than a lock conflict are returned immediately.
+func waitForLock(
+	dbPath string,
+	wait, interval time.Duration,
+	open func(string) (*sql.DB, error),
+) (*sql.DB, error) {
+	slog.Info("Database is locked by another process, waiting",
+		slog.String("path", dbPath),
+		slog.Duration("timeout", wait))
+
+	deadline := time.Now().Add(wait)
+
+	for {
+		db, err := open(dbPath)
+		if err == nil {
+			return db, nil
+		}
+
+		if !errors.Is(err, ErrDatabaseLocked) {
+			return nil, err
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("still locked after waiting %s: %w", wait, err)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// classifyOpenError marks DuckDB lock conflicts with ErrDatabaseLocked so callers
+// can tell them apart from files that cannot be opened at all
+func classifyOpenError(dbPath string, err error) error {
+	if strings.Contains(err.Error(), "Could not set lock on file") {
+		return fmt.Errorf("%w (%s): %w", ErrDatabaseLocked, dbPath, err)
+	}
+
+	return fmt.Errorf("failed to open database %s: %w", dbPath, err)
+}
+
+// applyPoolSettings configures the connection pool on the sql.DB handle
+func applyPoolSettings(db *sql.DB, pool poolSettings) {
+	if pool.singleConnection {
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+	} else {
+		db.SetMaxOpenConns(pool.maxOpenConns)
+		db.SetMaxIdleConns(pool.maxIdleConns)
+	}
+
+	db.SetConnMaxLifetime(pool.connMaxLifetime)
+	db.SetConnMaxIdleTime(pool.connMaxIdleTime)
+}
+
 // withQueryTimeout creates a new context with the configured query timeout
 // If the parent context already has a deadline, it keeps the earlier deadline
 func (r *DuckDBRepository) withQueryTimeout(
@@ -81,11 +279,19 @@ func (r *DuckDBRepository) Initialize(ctx context.Context) error {
 	return schemaManager.CreateLatestSchema(ctx)
 }
 
+// MigrationStatus lists every embedded migration with whether it has been
+// applied, without applying pending ones
+func (r *DuckDBRepository) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	return NewSchemaManager(r.db).Status(ctx)
+}
+
 // StoreRepository stores a new repository in the database
 func (r *DuckDBRepository) StoreRepository(
 	ctx context.Context,
 	repo processor.ProcessedRepo,
 ) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -94,12 +300,12 @@ func (r *DuckDBRepository) StoreRepository(
 	defer func() { _ = tx.Rollback() }()
 
 	// Convert arrays and objects to JSON
-	languagesJSON, err := json.Marshal(map[string]int64{})
+	languagesJSON, err := encodeJSONColumn(map[string]int64{})
 	if err != nil {
 		return fmt.Errorf("failed to marshal languages: %w", err)
 	}
 
-	contributorsJSON, err := json.Marshal([]Contributor{})
+	contributorsJSON, err := encodeJSONColumn([]Contributor{})
 	if err != nil {
 		return fmt.Errorf("failed to marshal contributors: %w", err)
 	}
@@ -108,7 +314,7 @@ func (r *DuckDBRepository) StoreRepository(
 	repoID := uuid.New().String()
 
 	// Convert topics to JSON for storage (DuckDB doesn't handle []string directly)
-	topicsJSON, err := json.Marshal(repo.Repository.Topics)
+	topicsJSON, err := encodeJSONColumn(repo.Repository.Topics)
 	if err != nil {
 		return fmt.Errorf("failed to marshal topics: %w", err)
 	}
@@ -126,8 +332,9 @@ func (r *DuckDBRepository) StoreRepository(
 		topics_array, languages, contributors,
 		license_name, license_spdx_id,
 		content_hash,
-		topics_text, contributors_text
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		topics_text, contributors_text,
+		github_id, content_language, readme_path, readme_format, archived
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	var licenseName, licenseSPDXID string
 	if repo.Repository.License != nil {
@@ -149,14 +356,19 @@ func (r *DuckDBRepository) StoreRepository(
 		repo.ProcessedAt,
 		0, 0, 0, 0, // Default activity metrics, will be populated by sync
 		0, 0, 0, // Default commit metrics, will be populated by sync
-		string(topicsJSON),
-		string(languagesJSON),
-		string(contributorsJSON),
+		topicsJSON,
+		languagesJSON,
+		contributorsJSON,
 		licenseName,
 		licenseSPDXID,
 		repo.ContentHash,
 		topicsText,
 		"", // contributors_text empty on initial store, populated by UpdateRepositoryMetrics
+		sql.NullInt64{Int64: repo.Repository.ID, Valid: repo.Repository.ID != 0},
+		repo.ContentLanguage,
+		repo.ReadmePath,
+		repo.ReadmeFormat,
+		repo.Repository.Archived,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert repository: %w", err)
@@ -165,153 +377,39 @@ func (r *DuckDBRepository) StoreRepository(
 	return tx.Commit()
 }
 
-// UpdateRepository updates an existing repository in the database.
-//
-// WORKAROUND FOR DUCKDB CONSTRAINT LIMITATION:
-// DuckDB v1.8.x has a critical bug where UPDATE and DELETE+INSERT operations fail with
-// "duplicate key" errors when executed within transactions on tables with PRIMARY KEY
-// constraints. This affects JSON/LIST columns particularly.
-//
-// Root cause: DuckDB's over-eager constraint checking evaluates constraints before
-// DELETE operations complete within the transaction scope.
-//
-// Solution: Perform DELETE+INSERT WITHOUT a transaction. While not atomic, the operations
-// execute sequentially and fast enough that race conditions are minimal for single-row
-// updates. This preserves metrics set by UpdateRepositoryMetrics.
-//
-// See: https://github.com/duckdb/duckdb/issues/11915 (UPDATE with LIST columns fails)
-// See: https://github.com/duckdb/duckdb/issues/16520 (DELETE+INSERT in transaction fails)
-// See: https://github.com/duckdb/duckdb/issues/8764 (UPDATE without changing PK fails)
-// See: https://duckdb.org/docs/stable/sql/indexes#index-limitations
+// UpdateRepository replaces the GitHub-derived columns of an existing repository.
+// Metrics, summaries, embeddings and local tags are left untouched.
 func (r *DuckDBRepository) UpdateRepository(
 	ctx context.Context,
 	repo processor.ProcessedRepo,
 ) error {
-	// Step 1: Get existing repository data to preserve metrics
-	var existingData struct {
-		id               string
-		openIssuesOpen   int
-		openIssuesTotal  int
-		openPRsOpen      int
-		openPRsTotal     int
-		commits30d       int
-		commits1y        int
-		commitsTotal     int
-		languages        interface{}
-		contributors     interface{}
-		contributorsText string
-	}
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id,
-			COALESCE(open_issues_open, 0),
-			COALESCE(open_issues_total, 0),
-			COALESCE(open_prs_open, 0),
-			COALESCE(open_prs_total, 0),
-			COALESCE(commits_30d, 0),
-			COALESCE(commits_1y, 0),
-			COALESCE(commits_total, 0),
-			COALESCE(languages, '{}'),
-			COALESCE(contributors, '[]'),
-			COALESCE(contributors_text, '')
-		FROM repositories
-		WHERE full_name = ?`,
-		repo.Repository.FullName).
-		Scan(&existingData.id,
-			&existingData.openIssuesOpen,
-			&existingData.openIssuesTotal,
-			&existingData.openPRsOpen,
-			&existingData.openPRsTotal,
-			&existingData.commits30d,
-			&existingData.commits1y,
-			&existingData.commitsTotal,
-			&existingData.languages,
-			&existingData.contributors,
-			&existingData.contributorsText,
-		)
-	if err != nil {
-		return fmt.Errorf("failed to get repository: %w", err)
-	}
-
-	// Step 2: Delete existing repository
-	_, err = r.db.ExecContext(ctx, "DELETE FROM repositories WHERE id = ?", existingData.id)
-	if err != nil {
-		return fmt.Errorf("failed to delete repository: %w", err)
-	}
-
-	// Step 3: Convert JSON data for reinsertion
-	topicsJSON, err := json.Marshal(repo.Repository.Topics)
-	if err != nil {
-		return fmt.Errorf("failed to marshal topics: %w", err)
-	}
-
-	languagesJSON, err := json.Marshal(existingData.languages)
-	if err != nil {
-		return fmt.Errorf("failed to marshal languages: %w", err)
-	}
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
 
-	contributorsJSON, err := json.Marshal(existingData.contributors)
+	values, err := githubColumnValues(repo)
 	if err != nil {
-		return fmt.Errorf("failed to marshal contributors: %w", err)
+		return err
 	}
 
-	// Step 4: INSERT repository with updated metadata but preserved metrics
-	var licenseName, licenseSPDXID string
-	if repo.Repository.License != nil {
-		licenseName = repo.Repository.License.Name
-		licenseSPDXID = repo.Repository.License.SPDXID
+	assignments := make([]string, len(githubColumns))
+	for i, col := range githubColumns {
+		assignments[i] = col + " = ?"
 	}
 
-	topicsText := strings.Join(repo.Repository.Topics, " ")
+	updateSQL := fmt.Sprintf("UPDATE repositories SET %s WHERE full_name = ?",
+		strings.Join(assignments, ", "))
 
-	insertSQL := `
-		INSERT INTO repositories (
-			id, full_name, description, homepage, language, stargazers_count, forks_count, size_kb,
-			created_at, updated_at, last_synced,
-			open_issues_open, open_issues_total, open_prs_open, open_prs_total,
-			commits_30d, commits_1y, commits_total,
-			topics_array, languages, contributors,
-			license_name, license_spdx_id, content_hash,
-			topics_text, contributors_text
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-
-	_, err = r.db.ExecContext(ctx, insertSQL,
-		existingData.id,
-		repo.Repository.FullName,
-		repo.Repository.Description,
-		repo.Repository.Homepage,
-		repo.Repository.Language,
-		repo.Repository.StargazersCount,
-		repo.Repository.ForksCount,
-		repo.Repository.Size,
-		repo.Repository.CreatedAt,
-		repo.Repository.UpdatedAt,
-		repo.ProcessedAt,
-		existingData.openIssuesOpen,
-		existingData.openIssuesTotal,
-		existingData.openPRsOpen,
-		existingData.openPRsTotal,
-		existingData.commits30d,
-		existingData.commits1y,
-		existingData.commitsTotal,
-		string(topicsJSON),
-		string(languagesJSON),
-		string(contributorsJSON),
-		licenseName,
-		licenseSPDXID,
-		repo.ContentHash,
-		topicsText,
-		existingData.contributorsText,
-	)
+	result, err := r.db.ExecContext(ctx, updateSQL, append(values, repo.Repository.FullName)...)
 	if err != nil {
-		return fmt.Errorf("failed to insert updated repository: %w", err)
+		return fmt.Errorf("failed to update repository: %w", err)
 	}
 
-	return nil
+	return requireRowAffected(result, repo.Repository.FullName)
 }
 
 // DeleteRepository removes a repository from the database
 func (r *DuckDBRepository) DeleteRepository(ctx context.Context, fullName string) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
 	// Delete repository by full_name
 	result, err := r.db.ExecContext(ctx, "DELETE FROM repositories WHERE full_name = ?", fullName)
 	if err != nil {
@@ -327,6 +425,15 @@ func (r *DuckDBRepository) DeleteRepository(ctx context.Context, fullName string
 		return nil // Repository doesn't exist, nothing to delete
 	}
 
+	// Drop local tags for repositories that are no longer starred
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM repository_tags WHERE full_name = ?", fullName); err != nil {
+		return fmt.Errorf("failed to delete repository tags: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM content_chunk_embeddings WHERE full_name = ?", fullName); err != nil {
+		return fmt.Errorf("failed to delete content chunks: %w", err)
+	}
+
 	return nil
 }
 
@@ -339,20 +446,27 @@ func (r *DuckDBRepository) GetRepository(
 		SELECT
 		   id, full_name, description, homepage, language, stargazers_count, forks_count, size_kb,
 		   created_at, updated_at, last_synced,
-		   COALESCE(open_issues_open, 0) as open_issues_open,
-		   COALESCE(open_issues_total, 0) as open_issues_total,
-		   COALESCE(open_prs_open, 0) as open_prs_open,
-		   COALESCE(open_prs_total, 0) as open_prs_total,
-		   COALESCE(commits_30d, 0) as commits_30d,
-		   COALESCE(commits_1y, 0) as commits_1y,
-		   COALESCE(commits_total, 0) as commits_total,
+		   COALESCE(open_issues_open, -1) as open_issues_open,
+		   COALESCE(open_issues_total, -1) as open_issues_total,
+		   COALESCE(open_prs_open, -1) as open_prs_open,
+		   COALESCE(open_prs_total, -1) as open_prs_total,
+		   COALESCE(commits_30d, -1) as commits_30d,
+		   COALESCE(commits_1y, -1) as commits_1y,
+		   COALESCE(commits_total, -1) as commits_total,
 		   COALESCE(topics_array, '[]') as topics_data,
 		   COALESCE(languages, '{}') as languages,
 		   COALESCE(contributors, '[]') as contributors,
 		   license_name, license_spdx_id,
 		   content_hash,
 		   purpose, summary_generated_at, COALESCE(summary_version, 0) as summary_version,
-		   repo_embedding
+		   repo_embedding,
+		   COALESCE(keywords, '') as keywords,
+		   COALESCE(github_id, 0) as github_id,
+		   COALESCE(content_language, '') as content_language,
+		   COALESCE(readme_path, '') as readme_path,
+		   COALESCE(readme_format, '') as readme_format,
+		   COALESCE(archived, false) as archived,
+		   starred_at
 	FROM repositories WHERE full_name = ?`
 
 	row := r.db.QueryRowContext(ctx, query, fullName)
@@ -367,6 +481,8 @@ func (r *DuckDBRepository) GetRepository(
 
 	var embeddingData interface{}
 
+	var keywordsText string
+
 	err := row.Scan(
 		&repo.ID, &repo.FullName, &repo.Description, &repo.Homepage,
 		&repo.Language, &repo.StargazersCount, &repo.ForksCount, &repo.SizeKB,
@@ -378,6 +494,13 @@ func (r *DuckDBRepository) GetRepository(
 		&repo.ContentHash,
 		&purpose, &repo.SummaryGeneratedAt, &repo.SummaryVersion,
 		&embeddingData,
+		&keywordsText,
+		&repo.GitHubID,
+		&repo.ContentLanguage,
+		&repo.ReadmePath,
+		&repo.ReadmeFormat,
+		&repo.Archived,
+		&repo.StarredAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -392,40 +515,45 @@ func (r *DuckDBRepository) GetRepository(
 		repo.Purpose = purpose.String
 	}
 
+	repo.Keywords = strings.Fields(keywordsText)
+
 	// Parse topics array
-	if topicsData != nil {
-		if topicsBytes, err := json.Marshal(topicsData); err == nil {
-			_ = json.Unmarshal(topicsBytes, &repo.Topics)
-		}
-	}
+	decodeJSONColumn(topicsData, &repo.Topics)
 
 	// Parse JSON fields
-	if languagesData != nil {
-		if languagesBytes, err := json.Marshal(languagesData); err == nil {
-			_ = json.Unmarshal(languagesBytes, &repo.Languages)
-		}
-	}
-
-	if contributorsData != nil {
-		if contributorsBytes, err := json.Marshal(contributorsData); err == nil {
-			_ = json.Unmarshal(contributorsBytes, &repo.Contributors)
-		}
-	}
+	decodeJSONColumn(languagesData, &repo.Languages)
+	decodeJSONColumn(contributorsData, &repo.Contributors)
+	decodeJSONColumn(embeddingData, &repo.RepoEmbedding)
 
-	if embeddingData != nil {
-		if embBytes, err := json.Marshal(embeddingData); err == nil {
-			_ = json.Unmarshal(embBytes, &repo.RepoEmbedding)
-		}
+	repo.Tags, err = r.GetTags(ctx, fullName)
+	if err != nil {
+		return nil, err
 	}
 
 	return &repo, nil
 }
 
-// ListRepositories retrieves a paginated list of repositories with a timeout
+// ListRepositories retrieves a paginated list of repositories, most stars first
 func (r *DuckDBRepository) ListRepositories(
 	ctx context.Context,
 	limit, offset int,
 ) ([]StoredRepo, error) {
+	return r.ListRepositoriesSorted(ctx, ListOptions{Limit: limit, Offset: offset})
+}
+
+// ListRepositoriesSorted retrieves a paginated list of the repositories matching
+// opts.Filter in the order selected by opts, with a timeout
+func (r *DuckDBRepository) ListRepositoriesSorted(
+	ctx context.Context,
+	opts ListOptions,
+) ([]StoredRepo, error) {
+	orderBy, err := opts.orderByClause()
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := opts.Filter.whereClause()
+
 	// Apply query timeout to prevent long-running queries
 	queryCtx, cancel := r.withQueryTimeout(ctx)
 	defer cancel()
@@ -435,25 +563,34 @@ func (r *DuckDBRepository) ListRepositories(
 		   COALESCE(homepage, '') as homepage,
 		   language, stargazers_count, forks_count, size_kb,
 		   created_at, updated_at, last_synced,
-		   COALESCE(open_issues_open, 0) as open_issues_open,
-		   COALESCE(open_issues_total, 0) as open_issues_total,
-		   COALESCE(open_prs_open, 0) as open_prs_open,
-		   COALESCE(open_prs_total, 0) as open_prs_total,
-		   COALESCE(commits_30d, 0) as commits_30d,
-		   COALESCE(commits_1y, 0) as commits_1y,
-		   COALESCE(commits_total, 0) as commits_total,
+		   COALESCE(open_issues_open, -1) as open_issues_open,
+		   COALESCE(open_issues_total, -1) as open_issues_total,
+		   COALESCE(open_prs_open, -1) as open_prs_open,
+		   COALESCE(open_prs_total, -1) as open_prs_total,
+		   COALESCE(commits_30d, -1) as commits_30d,
+		   COALESCE(commits_1y, -1) as commits_1y,
+		   COALESCE(commits_total, -1) as commits_total,
 		   COALESCE(topics_array, '[]') as topics_data,
 		   COALESCE(languages, '{}') as languages,
 		   COALESCE(contributors, '[]') as contributors,
 		   license_name, license_spdx_id,
 		   content_hash,
 		   purpose, summary_generated_at, COALESCE(summary_version, 0) as summary_version,
-		   repo_embedding
+		   repo_embedding,
+		   COALESCE(github_id, 0) as github_id,
+		   COALESCE(content_language, '') as content_language,
+		   COALESCE(readme_path, '') as readme_path,
+		   COALESCE(readme_format, '') as readme_format,
+		   COALESCE(archived, false) as archived,
+		   starred_at
 	FROM repositories
-	ORDER BY stargazers_count DESC, full_name
+	` + where + `
+	ORDER BY ` + orderBy + `
 	LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(queryCtx, query, limit, offset)
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := r.db.QueryContext(queryCtx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query repositories: %w", err)
 	}
@@ -483,6 +620,12 @@ func (r *DuckDBRepository) ListRepositories(
 			&repo.ContentHash,
 			&purpose, &repo.SummaryGeneratedAt, &repo.SummaryVersion,
 			&embeddingData,
+			&repo.GitHubID,
+			&repo.ContentLanguage,
+			&repo.ReadmePath,
+			&repo.ReadmeFormat,
+			&repo.Archived,
+			&repo.StarredAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan repository: %w", err)
@@ -494,31 +637,13 @@ func (r *DuckDBRepository) ListRepositories(
 		}
 
 		// Parse topics array
-		if topicsData != nil {
-			if topicsBytes, err := json.Marshal(topicsData); err == nil {
-				_ = json.Unmarshal(topicsBytes, &repo.Topics)
-			}
-		}
+		decodeJSONColumn(topicsData, &repo.Topics)
 
 		// Parse JSON fields
 
-		if languagesData != nil {
-			if languagesBytes, err := json.Marshal(languagesData); err == nil {
-				_ = json.Unmarshal(languagesBytes, &repo.Languages)
-			}
-		}
-
-		if contributorsData != nil {
-			if contributorsBytes, err := json.Marshal(contributorsData); err == nil {
-				_ = json.Unmarshal(contributorsBytes, &repo.Contributors)
-			}
-		}
-
-		if embeddingData != nil {
-			if embBytes, err := json.Marshal(embeddingData); err == nil {
-				_ = json.Unmarshal(embBytes, &repo.RepoEmbedding)
-			}
-		}
+		decodeJSONColumn(languagesData, &repo.Languages)
+		decodeJSONColumn(contributorsData, &repo.Contributors)
+		decodeJSONColumn(embeddingData, &repo.RepoEmbedding)
 
 		repos = append(repos, repo)
 	}
@@ -533,63 +658,142 @@ func (r *DuckDBRepository) SearchRepositories(
 	ctx context.Context,
 	query string,
 ) ([]SearchResult, error) {
-	return r.executeTextSearch(ctx, query)
+	return r.SearchFilteredRepositories(ctx, query, Filter{})
+}
+
+// SearchFilteredRepositories performs the FTS search of SearchRepositories over
+// the repositories matching filter. The filter is part of the SQL, so it applies
+// before the result limit and matches ranked below the limit are not lost.
+func (r *DuckDBRepository) SearchFilteredRepositories(
+	ctx context.Context,
+	query string,
+	filter Filter,
+) ([]SearchResult, error) {
+	return r.executeTextSearch(ctx, query, filter)
+}
+
+// searchRepoColumns are the repository columns every search query selects
+// ahead of its score, read by scanSearchRepo. They match GetRepository, with
+// -1 for unknown metrics, so search results carry every StoredRepo field.
+const searchRepoColumns = `
+		   id, full_name, description, COALESCE(homepage, ''), language,
+		   stargazers_count, forks_count, size_kb, created_at, updated_at, last_synced,
+		   COALESCE(open_issues_open, -1), COALESCE(open_issues_total, -1),
+		   COALESCE(open_prs_open, -1), COALESCE(open_prs_total, -1),
+		   COALESCE(commits_30d, -1), COALESCE(commits_1y, -1), COALESCE(commits_total, -1),
+		   COALESCE(topics_array, '[]'), COALESCE(languages, '{}'), COALESCE(contributors, '[]'),
+		   license_name, license_spdx_id, content_hash,
+		   purpose, summary_generated_at, COALESCE(summary_version, 0), repo_embedding,
+		   COALESCE(keywords, ''), COALESCE(github_id, 0), COALESCE(content_language, ''),
+		   COALESCE(readme_path, ''), COALESCE(readme_format, ''), COALESCE(archived, false),
+		   starred_at`
+
+// scanSearchRepo reads the searchRepoColumns of the current row, then the
+// query's own trailing columns into extra
+func scanSearchRepo(rows *sql.Rows, extra ...any) (StoredRepo, error) {
+	var (
+		repo                                               StoredRepo
+		topicsData, languagesData, contributorsData, embed any
+		purpose                                            sql.NullString
+		keywordsText                                       string
+	)
+
+	dest := []any{
+		&repo.ID, &repo.FullName, &repo.Description, &repo.Homepage, &repo.Language,
+		&repo.StargazersCount, &repo.ForksCount, &repo.SizeKB,
+		&repo.CreatedAt, &repo.UpdatedAt, &repo.LastSynced,
+		&repo.OpenIssuesOpen, &repo.OpenIssuesTotal, &repo.OpenPRsOpen, &repo.OpenPRsTotal,
+		&repo.Commits30d, &repo.Commits1y, &repo.CommitsTotal,
+		&topicsData, &languagesData, &contributorsData,
+		&repo.LicenseName, &repo.LicenseSPDXID, &repo.ContentHash,
+		&purpose, &repo.SummaryGeneratedAt, &repo.SummaryVersion, &embed,
+		&keywordsText, &repo.GitHubID, &repo.ContentLanguage,
+		&repo.ReadmePath, &repo.ReadmeFormat, &repo.Archived,
+		&repo.StarredAt,
+	}
+
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return StoredRepo{}, err
+	}
+
+	if purpose.Valid {
+		repo.Purpose = purpose.String
+	}
+
+	repo.Keywords = strings.Fields(keywordsText)
+
+	decodeJSONColumn(topicsData, &repo.Topics)
+	decodeJSONColumn(languagesData, &repo.Languages)
+	decodeJSONColumn(contributorsData, &repo.Contributors)
+	decodeJSONColumn(embed, &repo.RepoEmbedding)
+
+	return repo, nil
+}
+
+// textSearchSQL is the FTS query used for fuzzy search. It takes the raw query string
+// twice: once for the curated fields and once for README-derived keywords, which are
+// weighted at half of a curated match because they are extracted rather than chosen.
+// The %s is a filter's WHERE clause, or empty.
+const textSearchSQL = `
+	SELECT ` + searchRepoColumns + `,
+		   COALESCE(text_score, 0) + 0.5 * COALESCE(keyword_score, 0) AS score
+	FROM (
+		SELECT r.*,
+			   fts_main_repositories.match_bm25(r.id, ?,
+				   fields := 'full_name,description,purpose,topics_text,contributors_text') AS text_score,
+			   fts_main_repositories.match_bm25(r.id, ?, fields := 'keywords') AS keyword_score
+		FROM repositories r
+		%s
+	)
+	WHERE text_score IS NOT NULL OR keyword_score IS NOT NULL
+	ORDER BY score DESC
+	LIMIT 50`
+
+// textSearch returns textSearchSQL restricted to filter, with its parameters
+func textSearch(query string, filter Filter) (string, []any) {
+	where, filterArgs := filter.whereClause()
+
+	return fmt.Sprintf(textSearchSQL, where), append([]any{query, query}, filterArgs...)
 }
 
 // executeTextSearch performs FTS-based text search with BM25 scoring
 func (r *DuckDBRepository) executeTextSearch(
 	ctx context.Context,
 	query string,
+	filter Filter,
 ) ([]SearchResult, error) {
 	queryCtx, cancel := r.withQueryTimeout(ctx)
 	defer cancel()
 
-	searchQuery := `
-	SELECT r.id, r.full_name, r.description, r.language, r.stargazers_count, r.forks_count, r.size_kb,
-		   r.created_at, r.updated_at, r.last_synced, r.topics_array, r.license_name, r.license_spdx_id,
-		   r.content_hash, r.purpose,
-		   fts_main_repositories.match_bm25(r.id, ?,
-			   fields := 'full_name,description,purpose,topics_text,contributors_text') AS score
-	FROM repositories r
-	WHERE score IS NOT NULL
-	ORDER BY score DESC
-	LIMIT 50`
+	sqlQuery, args := textSearch(query, filter)
+
+	rows, err := r.db.QueryContext(queryCtx, sqlQuery, args...)
+	if err != nil && isFTSUnavailable(err) {
+		// The FTS index is only built after a sync and needs the fts extension,
+		// which may not be installable offline; fall back to substring matching
+		sqlQuery, args = fallbackTextSearch(query, filter)
+		rows, err = r.db.QueryContext(queryCtx, sqlQuery, args...)
+	}
 
-	rows, err := r.db.QueryContext(queryCtx, searchQuery, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search repositories: %w", err)
 	}
 	defer rows.Close()
 
+	return r.scanTextSearchResults(rows, query)
+}
+
+// scanTextSearchResults reads rows shaped like textSearchSQL into search results
+func (r *DuckDBRepository) scanTextSearchResults(rows *sql.Rows, query string) ([]SearchResult, error) {
 	var results []SearchResult
 	for rows.Next() {
-		var repo StoredRepo
 		var score float64
-		var topicsData interface{}
-		var purpose sql.NullString
 
-		err := rows.Scan(
-			&repo.ID, &repo.FullName, &repo.Description, &repo.Language,
-			&repo.StargazersCount, &repo.ForksCount, &repo.SizeKB,
-			&repo.CreatedAt, &repo.UpdatedAt, &repo.LastSynced,
-			&topicsData, &repo.LicenseName, &repo.LicenseSPDXID,
-			&repo.ContentHash, &purpose,
-			&score,
-		)
+		repo, err := scanSearchRepo(rows, &score)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan search result: %w", err)
 		}
 
-		if purpose.Valid {
-			repo.Purpose = purpose.String
-		}
-
-		if topicsData != nil {
-			if topicsBytes, err := json.Marshal(topicsData); err == nil {
-				_ = json.Unmarshal(topicsBytes, &repo.Topics)
-			}
-		}
-
 		matches := r.findMatches(repo, query)
 
 		results = append(results, SearchResult{
@@ -602,44 +806,45 @@ func (r *DuckDBRepository) executeTextSearch(
 	return results, rows.Err()
 }
 
-// findMatches identifies which fields matched the search query
+// findMatchFields are the fields findMatches scores, with the weight of an
+// exact match; a fuzzy match scores its search.Similarity times the weight
+var findMatchFields = []struct {
+	field  string
+	weight float64
+	text   func(StoredRepo) []string
+}{
+	{"full_name", 1.0, func(r StoredRepo) []string { return []string{r.FullName} }},
+	{"description", 0.8, func(r StoredRepo) []string { return []string{r.Description} }},
+	{"language", 0.7, func(r StoredRepo) []string { return []string{r.Language} }},
+	{"topics", 0.6, func(r StoredRepo) []string { return r.Topics }},
+}
+
+// findMatches identifies which fields matched the search query. Fields match
+// when their search.Similarity to the query reaches search.MatchThreshold, so
+// prefixes and typos match as well as whole words.
 func (r *DuckDBRepository) findMatches(repo StoredRepo, query string) []Match {
 	var matches []Match
 
-	queryLower := strings.ToLower(query)
-
-	// Check various fields for matches
-	if strings.Contains(strings.ToLower(repo.FullName), queryLower) {
+	// The query naming the repository itself is the strongest signal
+	if score, ok := nameMatch(repo.FullName, query); ok {
 		matches = append(matches, Match{
-			Field:   "full_name",
+			Field:   MatchFieldName,
 			Content: repo.FullName,
-			Score:   1.0,
-		})
-	}
-
-	if strings.Contains(strings.ToLower(repo.Description), queryLower) {
-		matches = append(matches, Match{
-			Field:   "description",
-			Content: truncateForMatch(repo.Description, queryLower),
-			Score:   0.8,
+			Score:   score,
 		})
 	}
 
-	if strings.Contains(strings.ToLower(repo.Language), queryLower) {
-		matches = append(matches, Match{
-			Field:   "language",
-			Content: repo.Language,
-			Score:   0.7,
-		})
-	}
+	for _, f := range findMatchFields {
+		for _, content := range f.text(repo) {
+			similarity := search.Similarity(query, content)
+			if similarity < search.MatchThreshold {
+				continue
+			}
 
-	// Check topics
-	for _, topic := range repo.Topics {
-		if strings.Contains(strings.ToLower(topic), queryLower) {
 			matches = append(matches, Match{
-				Field:   "topics",
-				Content: topic,
-				Score:   0.6,
+				Field:   f.field,
+				Content: content,
+				Score:   f.weight * similarity,
 			})
 		}
 	}
@@ -647,44 +852,6 @@ func (r *DuckDBRepository) findMatches(repo StoredRepo, query string) []Match {
 	return matches
 }
 
-// truncateForMatch truncates text around the matching term
-func truncateForMatch(text, query string) string {
-	textLower := strings.ToLower(text)
-	queryLower := strings.ToLower(query)
-
-	index := strings.Index(textLower, queryLower)
-	if index == -1 {
-		// Fallback to simple truncation
-		if len(text) > 100 {
-			return text[:100] + "..."
-		}
-
-		return text
-	}
-
-	// Show context around the match
-	start := index - 30
-	if start < 0 {
-		start = 0
-	}
-
-	end := index + len(query) + 30
-	if end > len(text) {
-		end = len(text)
-	}
-
-	result := text[start:end]
-	if start > 0 {
-		result = "..." + result
-	}
-
-	if end < len(text) {
-		result += "..."
-	}
-
-	return result
-}
-
 // GetStats returns database statistics
 func (r *DuckDBRepository) GetStats(ctx context.Context) (*Stats, error) {
 	stats := &Stats{
@@ -741,149 +908,63 @@ func (r *DuckDBRepository) GetStats(ctx context.Context) (*Stats, error) {
 		return nil, fmt.Errorf("failed to iterate language rows: %w", err)
 	}
 
+	// Get topic breakdown (each repository counts once per topic)
+	topicRows, err := r.db.QueryContext(ctx, `
+		SELECT topic, COUNT(*) FROM (
+			SELECT DISTINCT full_name, UNNEST(CAST(topics_array AS VARCHAR[])) AS topic
+			FROM repositories
+		)
+		WHERE topic <> ''
+		GROUP BY topic`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic breakdown: %w", err)
+	}
+	defer topicRows.Close()
+
+	for topicRows.Next() {
+		var topic string
+
+		var count int
+		if err := topicRows.Scan(&topic, &count); err != nil {
+			return nil, err
+		}
+
+		stats.TopicBreakdown[topic] = count
+	}
+
+	if err := topicRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate topic rows: %w", err)
+	}
+
 	return stats, nil
 }
 
 // Clear removes all data from the database
 func (r *DuckDBRepository) Clear(ctx context.Context) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
 	// Delete all repositories
 	_, err := r.db.ExecContext(ctx, "DELETE FROM repositories")
 	if err != nil {
 		return fmt.Errorf("failed to clear repositories: %w", err)
 	}
 
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM content_chunk_embeddings"); err != nil {
+		return fmt.Errorf("failed to clear content chunks: %w", err)
+	}
+
 	return nil
 }
 
-// UpdateRepositoryMetrics updates activity and metrics data for a repository.
-//
-// WORKAROUND: Like UpdateRepository, this avoids using UPDATE due to DuckDB's constraint
-// checking issues with JSON columns. Instead, it uses DELETE+INSERT without a transaction.
-// This is not atomic, but it's the only reliable way to update with DuckDB's current limitations.
-//
-// Note: Concurrent updates may conflict (optimistic concurrency - last write wins).
-//
-// See: https://github.com/duckdb/duckdb/issues/11915
-// See: https://github.com/duckdb/duckdb/issues/8764
+// UpdateRepositoryMetrics updates activity and metrics data for a repository
 func (r *DuckDBRepository) UpdateRepositoryMetrics(
 	ctx context.Context,
 	fullName string,
 	metrics RepositoryMetrics,
 ) error {
-	// Step 1: Get existing repository data to preserve non-metrics fields
-	var existingData struct {
-		id                string
-		fullName          string
-		description       string
-		homepage          string
-		language          string
-		stargazersCount   int
-		forksCount        int
-		sizeKB            int
-		createdAt         time.Time
-		updatedAt         time.Time
-		lastSynced        time.Time
-		topicsArray       interface{}
-		licenseName       string
-		licenseSPDXID     string
-		contentHash       string
-		purpose           sql.NullString
-		summaryGeneratedAt *time.Time
-		summaryVersion    int
-	}
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, full_name, description, COALESCE(homepage, ''), language,
-			stargazers_count, forks_count, size_kb,
-			created_at, updated_at, last_synced,
-			COALESCE(topics_array, '[]'),
-			COALESCE(license_name, ''), COALESCE(license_spdx_id, ''),
-			COALESCE(content_hash, ''),
-			purpose, summary_generated_at, COALESCE(summary_version, 0)
-		FROM repositories
-		WHERE full_name = ?`,
-		fullName).
-		Scan(&existingData.id, &existingData.fullName, &existingData.description,
-			&existingData.homepage, &existingData.language,
-			&existingData.stargazersCount, &existingData.forksCount, &existingData.sizeKB,
-			&existingData.createdAt, &existingData.updatedAt, &existingData.lastSynced,
-			&existingData.topicsArray,
-			&existingData.licenseName, &existingData.licenseSPDXID,
-			&existingData.contentHash,
-			&existingData.purpose, &existingData.summaryGeneratedAt, &existingData.summaryVersion,
-		)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("no repository found with full_name: %s", fullName)
-		}
-		return fmt.Errorf("failed to get repository: %w", err)
-	}
-
-	// Step 2: Delete existing repository
-	_, err = r.db.ExecContext(ctx, "DELETE FROM repositories WHERE id = ?", existingData.id)
-	if err != nil {
-		return fmt.Errorf("failed to delete repository: %w", err)
-	}
-
-	// Step 3: Convert JSON data
-	languagesJSON, err := json.Marshal(metrics.Languages)
-	if err != nil {
-		return fmt.Errorf("failed to marshal languages: %w", err)
-	}
-
-	contributorsJSON, err := json.Marshal(metrics.Contributors)
-	if err != nil {
-		return fmt.Errorf("failed to marshal contributors: %w", err)
-	}
-
-	topicsJSON, err := json.Marshal(existingData.topicsArray)
-	if err != nil {
-		return fmt.Errorf("failed to marshal topics: %w", err)
-	}
-
-	// Build contributors_text from metrics
-	var contributorLogins []string
-	for _, c := range metrics.Contributors {
-		contributorLogins = append(contributorLogins, c.Login)
-	}
-	contributorsText := strings.Join(contributorLogins, " ")
-
-	// Step 4: INSERT with updated metrics but preserved other fields
-	insertSQL := `
-		INSERT INTO repositories (
-			id, full_name, description, homepage, language, stargazers_count, forks_count, size_kb,
-			created_at, updated_at, last_synced,
-			open_issues_open, open_issues_total, open_prs_open, open_prs_total,
-			commits_30d, commits_1y, commits_total,
-			topics_array, languages, contributors,
-			license_name, license_spdx_id, content_hash,
-			purpose, summary_generated_at, summary_version,
-			contributors_text
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-
-	var purposeVal interface{}
-	if existingData.purpose.Valid {
-		purposeVal = existingData.purpose.String
-	}
-
-	_, err = r.db.ExecContext(ctx, insertSQL,
-		existingData.id, existingData.fullName, existingData.description,
-		metrics.Homepage, existingData.language,
-		existingData.stargazersCount, existingData.forksCount, existingData.sizeKB,
-		existingData.createdAt, existingData.updatedAt,
-		metrics.OpenIssuesOpen, metrics.OpenIssuesTotal,
-		metrics.OpenPRsOpen, metrics.OpenPRsTotal,
-		metrics.Commits30d, metrics.Commits1y, metrics.CommitsTotal,
-		string(topicsJSON), string(languagesJSON), string(contributorsJSON),
-		existingData.licenseName, existingData.licenseSPDXID, existingData.contentHash,
-		purposeVal, existingData.summaryGeneratedAt, existingData.summaryVersion,
-		contributorsText,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to insert updated repository: %w", err)
-	}
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
 
-	return nil
+	return updateMetrics(ctx, r.db, fullName, metrics)
 }
 
 // UpdateRepositoryEmbedding updates the embedding for a repository
@@ -892,20 +973,9 @@ func (r *DuckDBRepository) UpdateRepositoryEmbedding(
 	fullName string,
 	embedding []float32,
 ) error {
-	// Convert []float32 to the format DuckDB expects for FLOAT arrays
-	embeddingJSON, err := json.Marshal(embedding)
-	if err != nil {
-		return fmt.Errorf("failed to marshal embedding: %w", err)
-	}
-
-	updateSQL := `UPDATE repositories SET repo_embedding = ? WHERE full_name = ?`
-
-	_, err = r.db.ExecContext(ctx, updateSQL, string(embeddingJSON), fullName)
-	if err != nil {
-		return fmt.Errorf("failed to update repository embedding: %w", err)
-	}
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
 
-	return nil
+	return updateEmbedding(ctx, r.db, fullName, embedding)
 }
 
 // UpdateRepositorySummary updates the AI-generated summary for a repository
@@ -914,6 +984,8 @@ func (r *DuckDBRepository) UpdateRepositorySummary(
 	fullName string,
 	purpose string,
 ) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
 	updateSQL := `
 	UPDATE repositories SET
 		purpose = ?,
@@ -1011,12 +1083,45 @@ func (r *DuckDBRepository) GetRepositoriesNeedingSummaryUpdate(
 	return fullNames, rows.Err()
 }
 
+// GetRepositoriesNeedingEmbedding returns repositories without a stored embedding,
+// or every repository when forceUpdate is set
+func (r *DuckDBRepository) GetRepositoriesNeedingEmbedding(
+	ctx context.Context,
+	forceUpdate bool,
+) ([]string, error) {
+	query := `SELECT full_name FROM repositories WHERE repo_embedding IS NULL ORDER BY full_name`
+	if forceUpdate {
+		query = `SELECT full_name FROM repositories ORDER BY full_name`
+	}
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repositories needing embedding: %w", err)
+	}
+	defer rows.Close()
+
+	var fullNames []string
+
+	for rows.Next() {
+		var fullName string
+		if err := rows.Scan(&fullName); err != nil {
+			return nil, err
+		}
+
+		fullNames = append(fullNames, fullName)
+	}
+
+	return fullNames, rows.Err()
+}
+
 // RebuildFTSIndex installs the FTS extension and creates a full-text search index
 func (r *DuckDBRepository) RebuildFTSIndex(ctx context.Context) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
 	statements := []string{
 		"INSTALL fts",
 		"LOAD fts",
-		"PRAGMA create_fts_index('repositories', 'id', 'full_name', 'description', 'purpose', 'topics_text', 'contributors_text', stemmer = 'porter', stopwords = 'english', overwrite = 1)",
+		"PRAGMA create_fts_index('repositories', 'id', 'full_name', 'description', 'purpose', 'topics_text', 'contributors_text', 'keywords', stemmer = 'porter', stopwords = 'english', strip_accents = 1, lower = 1, overwrite = 1)",
 	}
 	for _, stmt := range statements {
 		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
@@ -1042,9 +1147,7 @@ func (r *DuckDBRepository) SearchByEmbedding(
 	}
 
 	searchQuery := `
-	SELECT r.id, r.full_name, r.description, r.language, r.stargazers_count, r.forks_count, r.size_kb,
-		   r.created_at, r.updated_at, r.last_synced, r.topics_array, r.license_name, r.license_spdx_id,
-		   r.content_hash, r.purpose,
+	SELECT ` + searchRepoColumns + `,
 		   array_cosine_similarity(
 			   CAST(repo_embedding AS FLOAT[384]),
 			   ?::FLOAT[384]
@@ -1067,33 +1170,13 @@ func (r *DuckDBRepository) SearchByEmbedding(
 
 	var results []SearchResult
 	for rows.Next() {
-		var repo StoredRepo
 		var score float64
-		var topicsData interface{}
-		var purpose sql.NullString
 
-		err := rows.Scan(
-			&repo.ID, &repo.FullName, &repo.Description, &repo.Language,
-			&repo.StargazersCount, &repo.ForksCount, &repo.SizeKB,
-			&repo.CreatedAt, &repo.UpdatedAt, &repo.LastSynced,
-			&topicsData, &repo.LicenseName, &repo.LicenseSPDXID,
-			&repo.ContentHash, &purpose,
-			&score,
-		)
+		repo, err := scanSearchRepo(rows, &score)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan embedding search result: %w", err)
 		}
 
-		if purpose.Valid {
-			repo.Purpose = purpose.String
-		}
-
-		if topicsData != nil {
-			if topicsBytes, err := json.Marshal(topicsData); err == nil {
-				_ = json.Unmarshal(topicsBytes, &repo.Topics)
-			}
-		}
-
 		results = append(results, SearchResult{
 			Repository: repo,
 			Score:      score,
@@ -2,10 +2,15 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/KyleKing/gh-star-search/internal/config"
 	"github.com/KyleKing/gh-star-search/internal/github"
 	"github.com/KyleKing/gh-star-search/internal/processor"
 )
@@ -739,6 +744,212 @@ func TestEmbeddingRoundTrip(t *testing.T) {
 	}
 }
 
+func TestConnectionPoolOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.DatabaseConfig
+		wantMaxOpen int
+	}{
+		{
+			name: "defaults when unset",
+			cfg: config.DatabaseConfig{
+				QueryTimeout: "30s",
+			},
+			wantMaxOpen: DefaultMaxOpenConns,
+		},
+		{
+			name: "configured max connections",
+			cfg: config.DatabaseConfig{
+				MaxConnections:  4,
+				MaxIdleConns:    2,
+				ConnMaxLifetime: "10m",
+				ConnMaxIdleTime: "1m",
+				QueryTimeout:    "30s",
+			},
+			wantMaxOpen: 4,
+		},
+		{
+			name: "single connection overrides max connections",
+			cfg: config.DatabaseConfig{
+				MaxConnections:   8,
+				SingleConnection: true,
+				QueryTimeout:     "30s",
+			},
+			wantMaxOpen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Path = filepath.Join(t.TempDir(), "pool.db")
+
+			repo, err := NewDuckDBRepositoryFromConfig(&tt.cfg)
+			if err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+			defer repo.Close()
+
+			if got := repo.db.Stats().MaxOpenConnections; got != tt.wantMaxOpen {
+				t.Errorf("Expected max open connections %d, got %d", tt.wantMaxOpen, got)
+			}
+		})
+	}
+
+	t.Run("invalid lifetime", func(t *testing.T) {
+		cfg := config.DatabaseConfig{
+			Path:            filepath.Join(t.TempDir(), "pool.db"),
+			ConnMaxLifetime: "forever",
+			QueryTimeout:    "30s",
+		}
+
+		if _, err := NewDuckDBRepositoryFromConfig(&cfg); err == nil {
+			t.Error("Expected error for invalid conn_max_lifetime")
+		}
+	})
+
+	t.Run("invalid open backoff", func(t *testing.T) {
+		cfg := config.DatabaseConfig{
+			Path:         filepath.Join(t.TempDir(), "pool.db"),
+			OpenBackoff:  "briefly",
+			QueryTimeout: "30s",
+		}
+
+		if _, err := NewDuckDBRepositoryFromConfig(&cfg); err == nil {
+			t.Error("Expected error for invalid open_backoff")
+		}
+	})
+}
+
+func TestOpenWithRetry(t *testing.T) {
+	failure := errors.New("transient")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "first try succeeds", attempts: 3, failures: 0, wantCalls: 1},
+		{name: "succeeds after retries", attempts: 3, failures: 2, wantCalls: 3},
+		{name: "gives up after attempts", attempts: 3, failures: 5, wantCalls: 3, wantErr: true},
+		{name: "zero attempts tries once", attempts: 0, failures: 5, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			open := func(string) (*sql.DB, error) {
+				calls++
+				if calls <= tt.failures {
+					return nil, failure
+				}
+
+				return &sql.DB{}, nil
+			}
+
+			_, err := openWithRetry("test.db", tt.attempts, time.Millisecond, open)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("openWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, failure) {
+				t.Errorf("Expected last error to be wrapped, got %v", err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d open calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWaitForLock(t *testing.T) {
+	locked := classifyOpenError("test.db", errors.New("Could not set lock on file"))
+	broken := errors.New("not a valid DuckDB database file")
+
+	tests := []struct {
+		name       string
+		results    []error
+		wait       time.Duration
+		wantCalls  int
+		wantErr    error
+		wantLocked bool
+	}{
+		{name: "lock released", results: []error{locked, locked, nil}, wait: time.Second, wantCalls: 3},
+		{name: "other error stops waiting", results: []error{locked, broken}, wait: time.Second, wantCalls: 2, wantErr: broken},
+		{name: "times out while locked", results: []error{locked, locked, locked, locked}, wait: 3 * time.Millisecond, wantLocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			open := func(string) (*sql.DB, error) {
+				err := tt.results[min(calls, len(tt.results)-1)]
+				calls++
+
+				if err != nil {
+					return nil, err
+				}
+
+				return &sql.DB{}, nil
+			}
+
+			_, err := waitForLock("test.db", tt.wait, time.Millisecond, open)
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got %v", tt.wantErr, err)
+				}
+			case tt.wantLocked:
+				if !errors.Is(err, ErrDatabaseLocked) {
+					t.Errorf("Expected ErrDatabaseLocked after timeout, got %v", err)
+				}
+			case err != nil:
+				t.Errorf("Expected lock to be acquired, got %v", err)
+			}
+
+			if tt.wantCalls > 0 && calls != tt.wantCalls {
+				t.Errorf("Expected %d open calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestClassifyOpenError(t *testing.T) {
+	locked := classifyOpenError("/tmp/db", errors.New(
+		`IO Error: Could not set lock on file "/tmp/db": Conflicting lock is held in gh (PID 42)`))
+	if !errors.Is(locked, ErrDatabaseLocked) {
+		t.Errorf("Expected lock conflict to match ErrDatabaseLocked, got %v", locked)
+	}
+
+	other := classifyOpenError("/tmp/db", errors.New(`IO Error: Cannot open file "/tmp/db"`))
+	if errors.Is(other, ErrDatabaseLocked) {
+		t.Errorf("Expected open failure not to match ErrDatabaseLocked, got %v", other)
+	}
+}
+
+func TestNewDuckDBRepository_InvalidFile(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "corrupt.db")
+	if err := os.WriteFile(dbPath, []byte("not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewDuckDBRepository(dbPath, WithOpenAttempts(2), WithOpenBackoff(time.Millisecond))
+	if err == nil {
+		t.Fatal("Expected error opening a non-DuckDB file")
+	}
+
+	if errors.Is(err, ErrDatabaseLocked) {
+		t.Errorf("Expected a plain open error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "after 2 attempt(s)") {
+		t.Errorf("Expected attempt count in error, got %v", err)
+	}
+}
+
 func abs32(x float32) float32 {
 	if x < 0 {
 		return -x
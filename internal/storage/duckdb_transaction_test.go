@@ -254,6 +254,63 @@ func TestUpdateRepository_WithMetadataChanges(t *testing.T) {
 	assert.ElementsMatch(t, []string{"updated", "test", "new"}, stored.Topics)
 }
 
+func TestUpdateRepository_PreservesMetricsAndSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping transaction test in short mode")
+	}
+
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/kept-repo")))
+
+	metrics := RepositoryMetrics{
+		OpenIssuesOpen:  2,
+		OpenIssuesTotal: 9,
+		OpenPRsOpen:     1,
+		OpenPRsTotal:    4,
+		Commits30d:      6,
+		Commits1y:       60,
+		CommitsTotal:    600,
+		Languages:       map[string]int64{"Go": 5000},
+		Contributors:    []Contributor{{Login: "user1", Contributions: 10}},
+	}
+	require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/kept-repo", metrics))
+	require.NoError(t, repo.UpdateRepositorySummary(ctx, "user/kept-repo", "A test purpose"))
+
+	updatedRepo := testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(
+			testutil.WithFullName("user/kept-repo"),
+			testutil.WithDescription("Updated description"),
+			testutil.WithStars(500),
+		),
+		[]processor.ContentChunk{
+			testutil.NewTestChunk("README.md", "Updated content"),
+		},
+	)
+	require.NoError(t, repo.UpdateRepository(ctx, updatedRepo))
+
+	stored, err := repo.GetRepository(ctx, "user/kept-repo")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Updated description", stored.Description)
+	assert.Equal(t, 500, stored.StargazersCount)
+
+	assert.Equal(t, 2, stored.OpenIssuesOpen)
+	assert.Equal(t, 9, stored.OpenIssuesTotal)
+	assert.Equal(t, 1, stored.OpenPRsOpen)
+	assert.Equal(t, 4, stored.OpenPRsTotal)
+	assert.Equal(t, 6, stored.Commits30d)
+	assert.Equal(t, 60, stored.Commits1y)
+	assert.Equal(t, 600, stored.CommitsTotal)
+	assert.Equal(t, map[string]int64{"Go": 5000}, stored.Languages)
+	assert.Equal(t, metrics.Contributors, stored.Contributors)
+	assert.Equal(t, "A test purpose", stored.Purpose)
+	assert.NotNil(t, stored.SummaryGeneratedAt)
+}
+
 func TestDeleteRepository_Transaction(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping transaction test in short mode")
@@ -418,6 +475,46 @@ func TestUpdateRepositorySummary_Transaction(t *testing.T) {
 	assert.Equal(t, 1, stored.SummaryVersion)
 }
 
+func TestUpsertRepository_ContentRefreshPreservesMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping transaction test in short mode")
+	}
+
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	initialRepo := testutil.NewTestProcessedRepoSimple("user/content-repo")
+	require.NoError(t, repo.StoreRepository(ctx, initialRepo))
+
+	metrics := RepositoryMetrics{
+		OpenIssuesOpen: 3,
+		CommitsTotal:   42,
+		Contributors:   []Contributor{{Login: "user1", Contributions: 10}},
+	}
+	require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/content-repo", metrics))
+	require.NoError(t, repo.UpdateRepositorySummary(ctx, "user/content-repo", "A test purpose"))
+
+	refreshed := initialRepo
+	refreshed.ContentHash = "new-content-hash"
+	chunks := []processor.ContentChunk{{Source: "README.md", Type: "readme", Content: "refreshed"}}
+	require.NoError(t, repo.UpsertRepository(ctx, refreshed, UpsertOptions{ReplaceChunks: true, Chunks: chunks}))
+
+	stored, err := repo.GetRepository(ctx, "user/content-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "new-content-hash", stored.ContentHash)
+	assert.Equal(t, 3, stored.OpenIssuesOpen)
+	assert.Equal(t, 42, stored.CommitsTotal)
+	assert.Len(t, stored.Contributors, 1)
+	assert.Equal(t, "A test purpose", stored.Purpose)
+
+	storedChunks, err := repo.GetChunksNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, storedChunks, 1, "the hash and chunks are written together")
+	assert.Equal(t, "refreshed", storedChunks[0].Content)
+}
+
 func TestConcurrentMetricsUpdates(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping concurrency test in short mode")
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestGetRepositoriesNeedingEmbedding(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, name := range []string{"user/embedded", "user/pending"} {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(testutil.WithFullName(name)), nil,
+		)))
+	}
+
+	require.NoError(t, repo.UpdateRepositoryEmbedding(ctx, "user/embedded", []float32{0.1, 0.2}))
+
+	pending, err := repo.GetRepositoriesNeedingEmbedding(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/pending"}, pending)
+
+	all, err := repo.GetRepositoriesNeedingEmbedding(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/embedded", "user/pending"}, all)
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// QueryPlan is the DuckDB EXPLAIN output for a search query
+type QueryPlan struct {
+	Raw        string          `json:"raw"`
+	Operations []PlanOperation `json:"operations"`
+}
+
+// PlanOperation is a single physical operator parsed from a query plan
+type PlanOperation struct {
+	Name          string `json:"name"`
+	EstimatedRows int    `json:"estimated_rows"` // -1 when the plan has no estimate
+}
+
+// UsesIndex reports whether any operator in the plan is an index scan
+func (p *QueryPlan) UsesIndex() bool {
+	for _, op := range p.Operations {
+		if strings.Contains(op.Name, "INDEX") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExplainTextSearch runs the fuzzy search SQL through EXPLAIN without executing it
+func (r *DuckDBRepository) ExplainTextSearch(ctx context.Context, query string) (*QueryPlan, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	sqlQuery, args := textSearch(query, Filter{})
+
+	rows, err := r.db.QueryContext(queryCtx, "EXPLAIN "+sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain search query: %w", err)
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan query plan: %w", err)
+		}
+
+		plan.WriteString(value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading query plan: %w", err)
+	}
+
+	return ParseQueryPlan(plan.String()), nil
+}
+
+var (
+	planOperatorPattern  = regexp.MustCompile(`^[A-Z][A-Z_]+$`)
+	planEstimatePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^~(\d+) [Rr]ows$`),
+		regexp.MustCompile(`^EC: ?(\d+)$`),
+	}
+	// planKeywords are upper-case plan details that are not operators
+	planKeywords = map[string]bool{
+		"INNER": true, "LEFT": true, "RIGHT": true, "OUTER": true, "SEMI": true,
+		"ANTI": true, "MARK": true, "ASC": true, "DESC": true, "AND": true, "OR": true,
+	}
+)
+
+// ParseQueryPlan extracts operator names and row estimates from DuckDB's
+// box-drawn EXPLAIN output. Estimates are assigned to operators in the order
+// they appear, which also holds for side-by-side boxes on the same lines.
+func ParseQueryPlan(raw string) *QueryPlan {
+	plan := &QueryPlan{Raw: raw}
+	nextEstimate := 0
+
+	for _, line := range strings.Split(raw, "\n") {
+		for _, cell := range strings.FieldsFunc(line, isPlanBoxRune) {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+
+			if planOperatorPattern.MatchString(cell) && !planKeywords[cell] {
+				plan.Operations = append(plan.Operations, PlanOperation{Name: cell, EstimatedRows: -1})
+				continue
+			}
+
+			for _, pattern := range planEstimatePatterns {
+				match := pattern.FindStringSubmatch(cell)
+				if match == nil || nextEstimate >= len(plan.Operations) {
+					continue
+				}
+
+				if rows, err := strconv.Atoi(match[1]); err == nil {
+					plan.Operations[nextEstimate].EstimatedRows = rows
+					nextEstimate++
+				}
+			}
+		}
+	}
+
+	return plan
+}
+
+// isPlanBoxRune reports whether r is a box-drawing character used to frame plan operators
+func isPlanBoxRune(r rune) bool {
+	return r >= '─' && r <= '╿'
+}
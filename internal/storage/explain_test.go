@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantOps   []PlanOperation
+		wantIndex bool
+	}{
+		{
+			name: "single column plan with row estimates",
+			raw: `┌───────────────────────────┐
+│           TOP_N           │
+│    ────────────────────   │
+│          Top: 50          │
+│          ~3 Rows          │
+└─────────────┬─────────────┘
+┌─────────────┴─────────────┐
+│         SEQ_SCAN          │
+│    ────────────────────   │
+│     Table: repositories   │
+│          ~3 Rows          │
+└───────────────────────────┘`,
+			wantOps: []PlanOperation{
+				{Name: "TOP_N", EstimatedRows: 3},
+				{Name: "SEQ_SCAN", EstimatedRows: 3},
+			},
+		},
+		{
+			name: "side by side boxes with legacy estimates",
+			raw: `┌───────────────────────────┐┌───────────────────────────┐
+│         HASH_JOIN         ││         INDEX_SCAN        │
+│           INNER           ││                           │
+│           EC: 10          ││           EC: 1           │
+└───────────────────────────┘└───────────────────────────┘`,
+			wantOps: []PlanOperation{
+				{Name: "HASH_JOIN", EstimatedRows: 10},
+				{Name: "INDEX_SCAN", EstimatedRows: 1},
+			},
+			wantIndex: true,
+		},
+		{
+			name: "operator without estimate",
+			raw: `┌───────────────────────────┐
+│         PROJECTION        │
+│        score DESC         │
+└───────────────────────────┘`,
+			wantOps: []PlanOperation{{Name: "PROJECTION", EstimatedRows: -1}},
+		},
+		{
+			name: "empty plan",
+			raw:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := ParseQueryPlan(tt.raw)
+			assert.Equal(t, tt.raw, plan.Raw)
+			assert.Equal(t, tt.wantOps, plan.Operations)
+			assert.Equal(t, tt.wantIndex, plan.UsesIndex())
+		})
+	}
+}
+
+func TestExplainTextSearch(t *testing.T) {
+	repo, ctx := setupSearchTestDB(t)
+
+	plan, err := repo.ExplainTextSearch(ctx, "terraform")
+	require.NoError(t, err)
+	assert.NotEmpty(t, plan.Raw)
+	assert.NotEmpty(t, plan.Operations)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fallbackSearchFields are the columns matched when the FTS index is unavailable,
+// weighted to mirror textSearchSQL: names count most and README-derived keywords
+// count at half of a curated field.
+var fallbackSearchFields = []struct {
+	column string
+	weight float64
+}{
+	{"full_name", 3},
+	{"description", 2},
+	{"purpose", 2},
+	{"topics_text", 2},
+	{"contributors_text", 1},
+	{"keywords", 1},
+}
+
+// isFTSUnavailable reports whether err means the FTS index or extension is missing
+func isFTSUnavailable(err error) bool {
+	msg := err.Error()
+
+	return strings.Contains(msg, "fts_main_repositories") || strings.Contains(msg, "match_bm25")
+}
+
+// fallbackTextSearch builds an ILIKE search returning the same columns as
+// textSearchSQL. Each query term scores the weight of every field containing it,
+// so repositories matching more terms in more prominent fields rank first. Both
+// sides are passed through strip_accents so matching folds accents like the FTS
+// index does. Like textSearchSQL, only repositories matching filter are scored.
+func fallbackTextSearch(query string, filter Filter) (string, []any) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		terms = []string{query}
+	}
+
+	var cases []string
+	var args []any
+
+	for _, term := range terms {
+		pattern := "%" + escapeLikePattern(term) + "%"
+
+		for _, field := range fallbackSearchFields {
+			cases = append(cases, fmt.Sprintf(
+				`CASE WHEN strip_accents(COALESCE(%s, '')) ILIKE strip_accents(?) ESCAPE '\' THEN %g ELSE 0 END`,
+				field.column, field.weight))
+			args = append(args, pattern)
+		}
+	}
+
+	where, filterArgs := filter.whereClause()
+	args = append(args, filterArgs...)
+
+	sqlQuery := `
+	SELECT ` + searchRepoColumns + `,
+		   score
+	FROM (
+		SELECT r.*, ` + strings.Join(cases, " + ") + ` AS score
+		FROM repositories r
+		` + where + `
+	)
+	WHERE score > 0
+	ORDER BY score DESC, full_name
+	LIMIT 50`
+
+	return sqlQuery, args
+}
+
+// escapeLikePattern escapes LIKE wildcards so terms match literally
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
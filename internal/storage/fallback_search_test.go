@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestFallbackTextSearch_Ranking(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	store := func(name, description string, topics ...string) {
+		t.Helper()
+
+		github := testutil.NewTestRepository(
+			testutil.WithFullName(name),
+			testutil.WithDescription(description),
+			testutil.WithTopics(topics...),
+		)
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(github, nil)))
+	}
+
+	store("user/web-server", "A fast HTTP server", "http", "server")
+	store("user/http-client", "An HTTP client library", "http")
+	store("user/image-tools", "Image resizing utilities", "images")
+	store("user/misc", "Mentions a server in passing")
+
+	// No FTS index is built, so this exercises the ILIKE fallback
+	results, err := repo.SearchRepositories(ctx, "http server")
+	require.NoError(t, err)
+
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Repository.FullName)
+	}
+
+	assert.Equal(t, []string{"user/web-server", "user/http-client", "user/misc"}, names)
+
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+}
+
+func TestFallbackTextSearch_LiteralWildcards(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/snake_case")))
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/snakeXcase")))
+
+	results, err := repo.SearchRepositories(ctx, "snake_case")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "user/snake_case", results[0].Repository.FullName)
+
+	results, err = repo.SearchRepositories(ctx, "100%")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestFallbackTextSearch_FoldsAccents(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(
+			testutil.WithFullName("user/outils"),
+			testutil.WithDescription("Outils réseau pour Linux"),
+		), nil,
+	)))
+
+	for _, query := range []string{"reseau", "RÉSEAU", "Réseau"} {
+		results, err := repo.SearchRepositories(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, results, 1, query)
+		assert.Equal(t, "user/outils", results[0].Repository.FullName)
+	}
+}
+
+func TestSearchFilteredRepositories_FiltersBeforeLimit(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// More better-ranked Python matches than the search returns
+	for i := range 60 {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(
+				testutil.WithFullName(fmt.Sprintf("user/server-%02d", i)),
+				testutil.WithDescription("An HTTP server"),
+				testutil.WithLanguage("Python"),
+			), nil,
+		)))
+	}
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(
+			testutil.WithFullName("user/gateway"),
+			testutil.WithDescription("An HTTP server"),
+			testutil.WithLanguage("Go"),
+		), nil,
+	)))
+
+	results, err := repo.SearchRepositories(ctx, "server")
+	require.NoError(t, err)
+	require.Len(t, results, 50)
+
+	for _, result := range results {
+		assert.NotEqual(t, "user/gateway", result.Repository.FullName)
+	}
+
+	results, err = repo.SearchFilteredRepositories(ctx, "server", Filter{Language: "go"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "user/gateway", results[0].Repository.FullName)
+
+	// Tags and keywords live outside the indexed columns but filter the same way
+	require.NoError(t, repo.AddTag(ctx, "user/gateway", "evaluate"))
+	require.NoError(t, repo.UpdateRepositoryKeywords(ctx, "user/gateway", []string{"proxy"}))
+
+	for _, filter := range []Filter{{Tag: "evaluate"}, {Keyword: "proxy"}} {
+		results, err = repo.SearchFilteredRepositories(ctx, "server", filter)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "user/gateway", results[0].Repository.FullName)
+	}
+
+	// Case-sensitive terms also match contributors, as the text search does
+	require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/gateway", RepositoryMetrics{
+		Contributors: []Contributor{{Login: "Server-Team", Contributions: 5}},
+	}))
+
+	results, err = repo.SearchFilteredRepositories(ctx, "server", Filter{CaseSensitiveTerms: []string{"Server-Team"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "user/gateway", results[0].Repository.FullName)
+
+	results, err = repo.SearchFilteredRepositories(ctx, "server", Filter{CaseSensitiveTerms: []string{"SERVER"}})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSearchResults_CarryStoredFields(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	synced := testutil.NewTestProcessedRepo(testutil.NewTestRepository(
+		testutil.WithFullName("user/synced-parser"), testutil.WithDescription("A parser"),
+		testutil.WithGitHubID(42)), nil)
+	require.NoError(t, repo.UpsertRepository(ctx, synced, UpsertOptions{
+		Metrics: &RepositoryMetrics{
+			Homepage:       "https://parser.example",
+			OpenIssuesOpen: 3, OpenIssuesTotal: 10, OpenPRsOpen: 1, OpenPRsTotal: 4,
+			Commits30d: 5, Commits1y: 50, CommitsTotal: 2000,
+			Languages:    map[string]int64{"Go": 1000},
+			Contributors: []Contributor{{Login: "alice", Contributions: 3}},
+		},
+		Embedding: []float32{0.1, 0.2},
+	}))
+
+	bare := testutil.NewTestProcessedRepo(testutil.NewTestRepository(
+		testutil.WithFullName("user/bare-parser"), testutil.WithDescription("A parser")), nil)
+	require.NoError(t, repo.UpsertRepository(ctx, bare, UpsertOptions{}))
+
+	results, err := repo.SearchRepositories(ctx, "parser")
+	require.NoError(t, err)
+
+	byName := make(map[string]StoredRepo)
+	for _, result := range results {
+		byName[result.Repository.FullName] = result.Repository
+	}
+
+	require.Contains(t, byName, "user/synced-parser")
+	got := byName["user/synced-parser"]
+	assert.Equal(t, "https://parser.example", got.Homepage)
+	assert.Equal(t, int64(42), got.GitHubID)
+	assert.Equal(t, 2000, got.CommitsTotal)
+	assert.Equal(t, 3, got.OpenIssuesOpen)
+	assert.Equal(t, map[string]int64{"Go": 1000}, got.Languages)
+	assert.Equal(t, []Contributor{{Login: "alice", Contributions: 3}}, got.Contributors)
+	assert.Len(t, got.RepoEmbedding, 2)
+
+	for _, name := range []string{"user/synced-parser", "user/bare-parser"} {
+		want, err := repo.GetRepository(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, *want, byName[name], "search reads %s as GetRepository does", name)
+	}
+
+	byContributor, err := repo.SearchByContributor(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, byContributor, 1)
+	assert.Equal(t, 2000, byContributor[0].Repository.CommitsTotal)
+}
+
+func TestIsFTSUnavailable(t *testing.T) {
+	tests := []struct {
+		err  string
+		want bool
+	}{
+		{"Catalog Error: Schema with name fts_main_repositories does not exist!", true},
+		{"Catalog Error: Scalar Function with name match_bm25 does not exist!", true},
+		{"IO Error: Could not read file", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isFTSUnavailable(errors.New(tt.err)), tt.err)
+	}
+}
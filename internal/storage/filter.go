@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Filter selects repositories by structured attributes. Zero fields match every
+// repository and set fields combine with AND.
+type Filter struct {
+	Topics       []string  `json:"topics,omitempty"`       // Repositories must carry every one of these topics
+	Language     string    `json:"language,omitempty"`     // Primary language, case-insensitive
+	MinStars     int       `json:"min_stars,omitempty"`    // Minimum stargazer count, inclusive; 0 for no minimum
+	MaxStars     int       `json:"max_stars,omitempty"`    // Maximum stargazer count, inclusive; 0 for no maximum
+	UpdatedAfter time.Time `json:"updated_after,omitzero"` // Earliest GitHub update time, inclusive; zero for no bound
+	Archived     *bool     `json:"archived,omitempty"`     // Archived state to match; nil matches both
+	Licenses     []string  `json:"licenses,omitempty"`     // SPDX IDs, any of which matches, case-insensitive
+
+	// Set from the query's own options rather than the shared filter flags
+	Tag             string `json:"-"` // Local tag, exact
+	Keyword         string `json:"-"` // README-derived keyword, case-insensitive
+	ContentLanguage string `json:"-"` // ISO 639-1 README language, case-insensitive
+	ReadmeFormat    string `json:"-"` // README format, case-insensitive; ReadmeFormatNone for no README
+
+	// Each term must appear verbatim, with exact case and accents, in a text-searched column
+	CaseSensitiveTerms []string `json:"-"`
+}
+
+// ReadmeFormatNone selects repositories without a README in Filter.ReadmeFormat
+const ReadmeFormatNone = "none"
+
+// IsZero reports whether the filter matches every repository
+func (f Filter) IsZero() bool {
+	return len(f.Topics) == 0 && f.Language == "" && f.MinStars <= 0 && f.MaxStars <= 0 &&
+		f.UpdatedAfter.IsZero() && f.Archived == nil && len(f.Licenses) == 0 &&
+		f.Tag == "" && f.Keyword == "" && f.ContentLanguage == "" && f.ReadmeFormat == "" &&
+		len(f.CaseSensitiveTerms) == 0
+}
+
+// whereClause returns the WHERE clause for f and its parameters, or "" when f
+// matches everything. Values are always bound as parameters.
+func (f Filter) whereClause() (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	// GitHub stores topics in lowercase
+	for _, topic := range f.Topics {
+		conditions = append(conditions, "list_contains(CAST(COALESCE(topics_array, '[]') AS VARCHAR[]), ?)")
+		args = append(args, strings.ToLower(topic))
+	}
+
+	if f.Language != "" {
+		conditions = append(conditions, "lower(language) = lower(?)")
+		args = append(args, f.Language)
+	}
+
+	if f.MinStars > 0 {
+		conditions = append(conditions, "stargazers_count >= ?")
+		args = append(args, f.MinStars)
+	}
+
+	if f.MaxStars > 0 {
+		conditions = append(conditions, "stargazers_count <= ?")
+		args = append(args, f.MaxStars)
+	}
+
+	if !f.UpdatedAfter.IsZero() {
+		conditions = append(conditions, "updated_at >= ?")
+		args = append(args, f.UpdatedAfter)
+	}
+
+	if f.Archived != nil {
+		conditions = append(conditions, "COALESCE(archived, false) = ?")
+		args = append(args, *f.Archived)
+	}
+
+	if len(f.Licenses) > 0 {
+		condition, licenseArgs := licenseCondition(f.Licenses)
+		conditions = append(conditions, condition)
+		args = append(args, licenseArgs...)
+	}
+
+	if f.Tag != "" {
+		conditions = append(conditions, "full_name IN (SELECT t.full_name FROM repository_tags t WHERE t.tag = ?)")
+		args = append(args, f.Tag)
+	}
+
+	// keywords holds the space-joined keywords chosen at sync
+	if keyword := strings.ToLower(strings.TrimSpace(f.Keyword)); keyword != "" {
+		conditions = append(conditions, "list_contains(string_split(lower(COALESCE(keywords, '')), ' '), ?)")
+		args = append(args, keyword)
+	}
+
+	if language := strings.TrimSpace(f.ContentLanguage); language != "" {
+		conditions = append(conditions, "lower(COALESCE(content_language, '')) = lower(?)")
+		args = append(args, language)
+	}
+
+	if format := strings.ToLower(strings.TrimSpace(f.ReadmeFormat)); format != "" {
+		if format == ReadmeFormatNone {
+			format = ""
+		}
+
+		conditions = append(conditions, "COALESCE(readme_format, '') = ?")
+		args = append(args, format)
+	}
+
+	for _, term := range f.CaseSensitiveTerms {
+		condition, termArgs := caseSensitiveCondition(term)
+		conditions = append(conditions, condition)
+		args = append(args, termArgs...)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// licenseCondition matches repositories whose SPDX ID is one of spdxIDs or,
+// when the SPDX ID is missing, whose license name normalizes to one of them
+func licenseCondition(spdxIDs []string) (string, []any) {
+	args := make([]any, 0, len(spdxIDs))
+	for _, id := range spdxIDs {
+		args = append(args, strings.ToUpper(strings.TrimSpace(id)))
+	}
+
+	condition := "upper(COALESCE(license_spdx_id, '')) IN (" + placeholders(len(spdxIDs)) + ")"
+
+	names := licenseNamesFor(spdxIDs)
+	if len(names) == 0 {
+		return condition, args
+	}
+
+	args = append(args, noAssertionSPDX)
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	return "(" + condition + " OR (upper(COALESCE(license_spdx_id, '')) IN ('', ?) AND " +
+		"lower(trim(COALESCE(license_name, ''))) IN (" + placeholders(len(names)) + ")))", args
+}
+
+// caseSensitiveCondition matches repositories where term is a substring of any
+// column the text search covers, comparing bytes so case and accents must match
+func caseSensitiveCondition(term string) (string, []any) {
+	columns := make([]string, 0, len(fallbackSearchFields))
+	args := make([]any, 0, len(fallbackSearchFields))
+
+	for _, field := range fallbackSearchFields {
+		columns = append(columns, fmt.Sprintf("strpos(COALESCE(%s, ''), ?) > 0", field.column))
+		args = append(args, term)
+	}
+
+	return "(" + strings.Join(columns, " OR ") + ")", args
+}
+
+// placeholders returns n comma-separated query parameter placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// FilterRepositories returns every repository matching filter, most stars
+// first. Each result scores 1.0, since all matches satisfy the filter equally,
+// and lists the filter topics it matched.
+func (r *DuckDBRepository) FilterRepositories(ctx context.Context, filter Filter) ([]SearchResult, error) {
+	repos, err := r.ListRepositoriesSorted(ctx, ListOptions{Limit: math.MaxInt32, Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(repos))
+
+	for i, repo := range repos {
+		var matches []Match
+
+		for _, topic := range filter.Topics {
+			matches = append(matches, Match{Field: "topics", Content: strings.ToLower(topic), Score: 1.0})
+		}
+
+		results[i] = SearchResult{Repository: repo, Score: 1.0, Matches: matches}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/github"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestFilterRepositories(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	base := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	fixtures := []github.Repository{
+		testutil.NewTestRepository(testutil.WithFullName("user/cli-go"),
+			testutil.WithTopics("cli", "go"), testutil.WithLanguage("Go"), testutil.WithStars(300),
+			testutil.WithUpdatedAt(base.Add(48*time.Hour))),
+		testutil.NewTestRepository(testutil.WithFullName("user/cli-rust"),
+			testutil.WithTopics("cli", "rust"), testutil.WithLanguage("Rust"), testutil.WithStars(200),
+			testutil.WithUpdatedAt(base), testutil.WithLicense("apache-2.0", "Apache License 2.0", "Apache-2.0")),
+		testutil.NewTestRepository(testutil.WithFullName("user/web-go"),
+			testutil.WithTopics("web", "go"), testutil.WithLanguage("Go"), testutil.WithStars(100), testutil.WithArchived(),
+			testutil.WithUpdatedAt(base.Add(-time.Second)), testutil.WithLicense("other", "Other", "NOASSERTION")),
+		testutil.NewTestRepository(testutil.WithFullName("user/untagged"),
+			testutil.WithTopics(), testutil.WithLanguage("Go"), testutil.WithStars(50),
+			testutil.WithUpdatedAt(base.AddDate(-1, 0, 0)), testutil.WithLicense("", "Apache License 2.0", "")),
+	}
+
+	for _, f := range fixtures {
+		processed := testutil.NewTestProcessedRepo(f, nil)
+		if f.FullName == "user/cli-rust" {
+			processed.ContentLanguage = "zh"
+			processed.ReadmePath = "README.rst"
+			processed.ReadmeFormat = "rst"
+		}
+
+		require.NoError(t, repo.StoreRepository(ctx, processed))
+	}
+
+	require.NoError(t, repo.AddTag(ctx, "user/web-go", "evaluate"))
+	require.NoError(t, repo.UpdateRepositoryKeywords(ctx, "user/cli-go", []string{"tokenizer", "parser"}))
+
+	archived, active := true, false
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{
+			name:   "zero filter matches everything, most stars first",
+			filter: Filter{},
+			want:   []string{"user/cli-go", "user/cli-rust", "user/web-go", "user/untagged"},
+		},
+		{
+			name:   "single topic",
+			filter: Filter{Topics: []string{"cli"}},
+			want:   []string{"user/cli-go", "user/cli-rust"},
+		},
+		{
+			name:   "topics combine with AND",
+			filter: Filter{Topics: []string{"cli", "go"}},
+			want:   []string{"user/cli-go"},
+		},
+		{
+			name:   "topics match case-insensitively",
+			filter: Filter{Topics: []string{"GO"}},
+			want:   []string{"user/cli-go", "user/web-go"},
+		},
+		{
+			name:   "no repository has every topic",
+			filter: Filter{Topics: []string{"cli", "web"}},
+			want:   []string{},
+		},
+		{
+			name:   "language is case-insensitive",
+			filter: Filter{Language: "go", Topics: []string{"go"}},
+			want:   []string{"user/cli-go", "user/web-go"},
+		},
+		{
+			name:   "minimum stars is inclusive",
+			filter: Filter{MinStars: 200},
+			want:   []string{"user/cli-go", "user/cli-rust"},
+		},
+		{
+			name:   "maximum stars is inclusive",
+			filter: Filter{MaxStars: 100},
+			want:   []string{"user/web-go", "user/untagged"},
+		},
+		{
+			name:   "equal minimum and maximum",
+			filter: Filter{MinStars: 200, MaxStars: 200},
+			want:   []string{"user/cli-rust"},
+		},
+		{
+			name:   "star range with nothing inside",
+			filter: Filter{MinStars: 201, MaxStars: 299},
+			want:   []string{},
+		},
+		{
+			name:   "updated after is inclusive",
+			filter: Filter{UpdatedAfter: base},
+			want:   []string{"user/cli-go", "user/cli-rust"},
+		},
+		{
+			name:   "updated after combines with language",
+			filter: Filter{UpdatedAfter: base.Add(-time.Hour), Language: "GO"},
+			want:   []string{"user/cli-go", "user/web-go"},
+		},
+		{
+			name:   "archived only",
+			filter: Filter{Archived: &archived},
+			want:   []string{"user/web-go"},
+		},
+		{
+			name:   "active only",
+			filter: Filter{Archived: &active, Topics: []string{"go"}},
+			want:   []string{"user/cli-go"},
+		},
+		{
+			name:   "license matches SPDX ID case-insensitively",
+			filter: Filter{Licenses: []string{"mit"}},
+			want:   []string{"user/cli-go"},
+		},
+		{
+			name:   "license name without SPDX ID matches by SPDX",
+			filter: Filter{Licenses: []string{"Apache-2.0"}},
+			want:   []string{"user/cli-rust", "user/untagged"},
+		},
+		{
+			name:   "licenses combine with OR",
+			filter: Filter{Licenses: []string{"MIT", "Apache-2.0"}, Language: "go"},
+			want:   []string{"user/cli-go", "user/untagged"},
+		},
+		{
+			name:   "local tag",
+			filter: Filter{Tag: "evaluate"},
+			want:   []string{"user/web-go"},
+		},
+		{
+			name:   "tag is exact",
+			filter: Filter{Tag: "eval"},
+			want:   []string{},
+		},
+		{
+			name:   "keyword is case-insensitive and whole",
+			filter: Filter{Keyword: "Tokenizer"},
+			want:   []string{"user/cli-go"},
+		},
+		{
+			name:   "keyword prefix does not match",
+			filter: Filter{Keyword: "token"},
+			want:   []string{},
+		},
+		{
+			name:   "content language is case-insensitive",
+			filter: Filter{ContentLanguage: "ZH"},
+			want:   []string{"user/cli-rust"},
+		},
+		{
+			name:   "README format",
+			filter: Filter{ReadmeFormat: "RST"},
+			want:   []string{"user/cli-rust"},
+		},
+		{
+			name:   "no README",
+			filter: Filter{ReadmeFormat: ReadmeFormatNone, Language: "go"},
+			want:   []string{"user/cli-go", "user/web-go", "user/untagged"},
+		},
+		{
+			name:   "unidentified license matches no SPDX ID",
+			filter: Filter{Licenses: []string{"GPL-3.0"}},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.FilterRepositories(ctx, tt.filter)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(results))
+			for _, result := range results {
+				names = append(names, result.Repository.FullName)
+				assert.InDelta(t, 1.0, result.Score, 1e-9)
+				assert.Len(t, result.Matches, len(tt.filter.Topics))
+			}
+
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestFilter_IsZero(t *testing.T) {
+	archived := false
+
+	assert.True(t, Filter{}.IsZero())
+	assert.True(t, Filter{Topics: []string{}}.IsZero())
+	assert.False(t, Filter{Topics: []string{"cli"}}.IsZero())
+	assert.False(t, Filter{Archived: &archived}.IsZero())
+	assert.False(t, Filter{MaxStars: 10}.IsZero())
+	assert.False(t, Filter{UpdatedAfter: time.Now()}.IsZero())
+	assert.False(t, Filter{Licenses: []string{"MIT"}}.IsZero())
+	assert.False(t, Filter{Tag: "work"}.IsZero())
+	assert.False(t, Filter{Keyword: "parser"}.IsZero())
+	assert.False(t, Filter{ContentLanguage: "en"}.IsZero())
+	assert.False(t, Filter{ReadmeFormat: ReadmeFormatNone}.IsZero())
+	assert.False(t, Filter{CaseSensitiveTerms: []string{"Go"}}.IsZero())
+}
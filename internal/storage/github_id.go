@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// BackfillGitHubIDs sets github_id for repositories stored before the column existed.
+// Rows that already have an id are left untouched. It returns the number of rows updated.
+func (r *DuckDBRepository) BackfillGitHubIDs(ctx context.Context, ids map[string]int64) (int, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	updated := 0
+
+	for fullName, id := range ids {
+		result, err := tx.ExecContext(ctx,
+			"UPDATE repositories SET github_id = ? WHERE full_name = ? AND github_id IS NULL",
+			id, fullName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to backfill github_id for %s: %w", fullName, err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		updated += int(rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit github_id backfill: %w", err)
+	}
+
+	return updated, nil
+}
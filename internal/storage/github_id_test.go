@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestBackfillGitHubIDs(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/legacy")))
+	require.NoError(t, repo.UpsertRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(testutil.WithFullName("user/known"), testutil.WithGitHubID(7)),
+		nil,
+	), UpsertOptions{}))
+
+	updated, err := repo.BackfillGitHubIDs(ctx, map[string]int64{
+		"user/legacy":  100,
+		"user/known":   200,
+		"user/missing": 300,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated, "only rows without an id are backfilled")
+
+	legacy, err := repo.GetRepository(ctx, "user/legacy")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), legacy.GitHubID)
+
+	known, err := repo.GetRepository(ctx, "user/known")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), known.GitHubID)
+}
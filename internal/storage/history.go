@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// RecordQuery appends a search invocation to the query history
+func (r *DuckDBRepository) RecordQuery(ctx context.Context, entry QueryHistoryEntry) error {
+	filters, err := encodeJSONColumn(entry.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode query filters: %w", err)
+	}
+
+	insertSQL := `
+	INSERT INTO query_history (query_text, mode, result_limit, result_count, filters)
+	VALUES (?, ?, ?, ?, ?)`
+
+	_, err = r.db.ExecContext(ctx, insertSQL, entry.Query, entry.Mode, entry.Limit, entry.ResultCount, filters)
+	if err != nil {
+		return fmt.Errorf("failed to record query: %w", err)
+	}
+
+	return nil
+}
+
+// ListQueryHistory returns the most recent query history entries, newest first
+func (r *DuckDBRepository) ListQueryHistory(
+	ctx context.Context,
+	limit int,
+) ([]QueryHistoryEntry, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+	SELECT id, query_text, mode, result_limit, COALESCE(result_count, 0), filters, executed_at
+	FROM query_history
+	ORDER BY id DESC
+	LIMIT ?`
+
+	rows, err := r.db.QueryContext(queryCtx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list query history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []QueryHistoryEntry
+
+	for rows.Next() {
+		var (
+			entry   QueryHistoryEntry
+			filters any
+		)
+
+		if err := rows.Scan(
+			&entry.ID, &entry.Query, &entry.Mode, &entry.Limit, &entry.ResultCount, &filters, &entry.ExecutedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan query history entry: %w", err)
+		}
+
+		decodeJSONColumn(filters, &entry.Filters)
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating query history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetQueryHistoryEntry retrieves a single query history entry by ID
+func (r *DuckDBRepository) GetQueryHistoryEntry(
+	ctx context.Context,
+	id int64,
+) (*QueryHistoryEntry, error) {
+	query := `
+	SELECT id, query_text, mode, result_limit, COALESCE(result_count, 0), filters, executed_at
+	FROM query_history
+	WHERE id = ?`
+
+	var (
+		entry   QueryHistoryEntry
+		filters any
+	)
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&entry.ID, &entry.Query, &entry.Mode, &entry.Limit, &entry.ResultCount, &filters, &entry.ExecutedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("query history entry not found: %d", id)
+		}
+
+		return nil, fmt.Errorf("failed to get query history entry: %w", err)
+	}
+
+	decodeJSONColumn(filters, &entry.Filters)
+
+	return &entry, nil
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryHistory(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	entries := []QueryHistoryEntry{
+		{Query: "web framework", Mode: "fuzzy", Limit: 10, ResultCount: 4},
+		{Query: "machine learning", Mode: "vector", Limit: 5, ResultCount: 0, Filters: SearchFilters{
+			Tag: "work", Filter: Filter{Topics: []string{"ml"}, MinStars: 100}, Chunks: true,
+		}},
+		{Query: "terminal ui", Mode: "fuzzy", Limit: 20, ResultCount: 12},
+	}
+	for _, entry := range entries {
+		require.NoError(t, repo.RecordQuery(ctx, entry))
+	}
+
+	t.Run("list newest first", func(t *testing.T) {
+		history, err := repo.ListQueryHistory(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, "terminal ui", history[0].Query)
+		assert.Equal(t, "web framework", history[2].Query)
+		assert.False(t, history[0].ExecutedAt.IsZero())
+	})
+
+	t.Run("list respects limit", func(t *testing.T) {
+		history, err := repo.ListQueryHistory(ctx, 2)
+		require.NoError(t, err)
+		assert.Len(t, history, 2)
+	})
+
+	t.Run("get by id", func(t *testing.T) {
+		history, err := repo.ListQueryHistory(ctx, 10)
+		require.NoError(t, err)
+
+		entry, err := repo.GetQueryHistoryEntry(ctx, history[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "machine learning", entry.Query)
+		assert.Equal(t, "vector", entry.Mode)
+		assert.Equal(t, 5, entry.Limit)
+		assert.Equal(t, SearchFilters{
+			Tag: "work", Filter: Filter{Topics: []string{"ml"}, MinStars: 100}, Chunks: true,
+		}, entry.Filters)
+	})
+
+	t.Run("get missing id", func(t *testing.T) {
+		_, err := repo.GetQueryHistoryEntry(ctx, 9999)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package storage
+
+import "encoding/json"
+
+// The structured columns (topics_array, languages, contributors, repo_embedding,
+// keyword_terms and the filters of saved searches and query history) are
+// declared JSON and written as JSON text, so the schema
+// does not depend on engine-specific array types. Every store and scan path goes
+// through encodeJSONColumn and decodeJSONColumn.
+
+// encodeJSONColumn serializes v as JSON text for a JSON column
+func encodeJSONColumn(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// decodeJSONColumn decodes a scanned JSON column into dst. DuckDB returns JSON
+// values already parsed (slices and maps) while text-based drivers return the
+// JSON text, so both are accepted. NULL or malformed values leave dst unchanged.
+func decodeJSONColumn(src, dst any) {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		return
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+
+		data = encoded
+	}
+
+	_ = json.Unmarshal(data, dst)
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJSONColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want []string
+	}{
+		{name: "parsed by the driver", src: []any{"cli", "go"}, want: []string{"cli", "go"}},
+		{name: "JSON text", src: `["cli","go"]`, want: []string{"cli", "go"}},
+		{name: "JSON bytes", src: []byte(`["cli"]`), want: []string{"cli"}},
+		{name: "NULL", src: nil, want: nil},
+		{name: "malformed", src: "not json", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var topics []string
+
+			decodeJSONColumn(tt.src, &topics)
+			assert.Equal(t, tt.want, topics)
+		})
+	}
+}
+
+func TestEncodeJSONColumn_RoundTrip(t *testing.T) {
+	encoded, err := encodeJSONColumn(map[string]int64{"Go": 1024})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Go":1024}`, encoded)
+
+	var languages map[string]int64
+
+	decodeJSONColumn(encoded, &languages)
+	assert.Equal(t, map[string]int64{"Go": 1024}, languages)
+}
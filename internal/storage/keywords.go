@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// ListKeywordTerms returns the stored candidate term counts for every repository, keyed by full name
+func (r *DuckDBRepository) ListKeywordTerms(ctx context.Context) (map[string]map[string]int, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(queryCtx,
+		"SELECT full_name, COALESCE(keyword_terms, '{}') FROM repositories")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keyword terms: %w", err)
+	}
+	defer rows.Close()
+
+	corpus := make(map[string]map[string]int)
+
+	for rows.Next() {
+		var fullName string
+
+		var termsData interface{}
+		if err := rows.Scan(&fullName, &termsData); err != nil {
+			return nil, fmt.Errorf("failed to scan keyword terms: %w", err)
+		}
+
+		terms := make(map[string]int)
+
+		decodeJSONColumn(termsData, &terms)
+
+		corpus[fullName] = terms
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating keyword terms: %w", err)
+	}
+
+	return corpus, nil
+}
+
+// UpdateRepositoryKeywords replaces the selected keywords for a repository
+func (r *DuckDBRepository) UpdateRepositoryKeywords(
+	ctx context.Context,
+	fullName string,
+	keywords []string,
+) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE repositories SET keywords = ? WHERE full_name = ?",
+		strings.Join(keywords, " "), fullName)
+	if err != nil {
+		return fmt.Errorf("failed to update repository keywords: %w", err)
+	}
+
+	return requireRowAffected(result, fullName)
+}
+
+// UpdateKeywords stores the selected keywords of many repositories, keyed by
+// full name, in one transaction. Repositories whose stored keywords already
+// match, or that are no longer in the database, are skipped. It returns the
+// number of repositories updated.
+func (r *DuckDBRepository) UpdateKeywords(ctx context.Context, selected map[string][]string) (int, error) {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, "SELECT full_name, COALESCE(keywords, '') FROM repositories")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read repository keywords: %w", err)
+	}
+
+	stored := make(map[string]string)
+
+	for rows.Next() {
+		var fullName, keywords string
+		if err := rows.Scan(&fullName, &keywords); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan repository keywords: %w", err)
+		}
+
+		stored[fullName] = keywords
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating repository keywords: %w", err)
+	}
+
+	updated := 0
+
+	for fullName, keywords := range selected {
+		joined := strings.Join(keywords, " ")
+		if current, ok := stored[fullName]; !ok || current == joined {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE repositories SET keywords = ? WHERE full_name = ?", joined, fullName); err != nil {
+			return 0, fmt.Errorf("failed to update keywords for %s: %w", fullName, err)
+		}
+
+		updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit keywords: %w", err)
+	}
+
+	return updated, nil
+}
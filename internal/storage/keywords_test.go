@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestKeywords(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertRepository(ctx, testutil.NewTestProcessedRepoSimple("user/alpha"),
+		UpsertOptions{KeywordTerms: map[string]int{"tokenizer": 3, "parser": 1}}))
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/beta")))
+
+	t.Run("list keyword terms", func(t *testing.T) {
+		corpus, err := repo.ListKeywordTerms(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"tokenizer": 3, "parser": 1}, corpus["user/alpha"])
+		assert.Empty(t, corpus["user/beta"])
+	})
+
+	t.Run("update keywords", func(t *testing.T) {
+		require.NoError(t, repo.UpdateRepositoryKeywords(ctx, "user/alpha", []string{"tokenizer", "parser"}))
+
+		stored, err := repo.GetRepository(ctx, "user/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tokenizer", "parser"}, stored.Keywords)
+	})
+
+	t.Run("update unknown repository fails", func(t *testing.T) {
+		assert.Error(t, repo.UpdateRepositoryKeywords(ctx, "user/missing", []string{"cli"}))
+	})
+
+	t.Run("batch update skips unchanged and unknown repositories", func(t *testing.T) {
+		updated, err := repo.UpdateKeywords(ctx, map[string][]string{
+			"user/alpha":   {"tokenizer", "parser"},
+			"user/beta":    {"cli"},
+			"user/missing": {"cli"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+
+		stored, err := repo.GetRepository(ctx, "user/beta")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cli"}, stored.Keywords)
+
+		updated, err = repo.UpdateKeywords(ctx, map[string][]string{"user/beta": {"cli"}})
+		require.NoError(t, err)
+		assert.Zero(t, updated)
+	})
+
+	t.Run("keywords survive upsert", func(t *testing.T) {
+		updated := testutil.NewTestProcessedRepoSimple("user/alpha")
+		updated.Repository.Description = "updated during sync"
+		require.NoError(t, repo.UpsertRepository(ctx, updated,
+			UpsertOptions{KeywordTerms: map[string]int{"lexer": 2}}))
+
+		stored, err := repo.GetRepository(ctx, "user/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tokenizer", "parser"}, stored.Keywords)
+
+		corpus, err := repo.ListKeywordTerms(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"lexer": 2}, corpus["user/alpha"])
+	})
+}
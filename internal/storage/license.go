@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+)
+
+// noAssertionSPDX is the SPDX ID GitHub reports for a license it detected but
+// could not identify, such as "Other"
+const noAssertionSPDX = "NOASSERTION"
+
+// licenseNameToSPDX maps common license names, lowercased, to their SPDX IDs.
+// It covers the names GitHub reports alongside each SPDX ID and the informal
+// spellings that show up when the ID is missing.
+var licenseNameToSPDX = map[string]string{
+	"mit":                                     "MIT",
+	"mit license":                             "MIT",
+	"the mit license":                         "MIT",
+	"apache 2.0":                              "Apache-2.0",
+	"apache license 2.0":                      "Apache-2.0",
+	"apache license, version 2.0":             "Apache-2.0",
+	"apache software license 2.0":             "Apache-2.0",
+	"bsd 2-clause license":                    "BSD-2-Clause",
+	`bsd 2-clause "simplified" license`:       "BSD-2-Clause",
+	"bsd 3-clause license":                    "BSD-3-Clause",
+	`bsd 3-clause "new" or "revised" license`: "BSD-3-Clause",
+	"isc":                                         "ISC",
+	"isc license":                                 "ISC",
+	"gnu general public license v2.0":             "GPL-2.0",
+	"gnu general public license v3.0":             "GPL-3.0",
+	"gnu lesser general public license v2.1":      "LGPL-2.1",
+	"gnu lesser general public license v3.0":      "LGPL-3.0",
+	"gnu affero general public license v3.0":      "AGPL-3.0",
+	"mozilla public license 2.0":                  "MPL-2.0",
+	"eclipse public license 2.0":                  "EPL-2.0",
+	"boost software license 1.0":                  "BSL-1.0",
+	"the unlicense":                               "Unlicense",
+	"unlicense":                                   "Unlicense",
+	"creative commons zero v1.0 universal":        "CC0-1.0",
+	"do what the f*ck you want to public license": "WTFPL",
+	"zlib license":                                "Zlib",
+}
+
+// NormalizeLicense returns the SPDX ID for a repository license, mapping
+// common license names to SPDX when spdxID is empty or GitHub's NOASSERTION.
+// It returns "" when neither identifies a known license.
+func NormalizeLicense(spdxID, name string) string {
+	spdxID = strings.TrimSpace(spdxID)
+	if spdxID != "" && !strings.EqualFold(spdxID, noAssertionSPDX) {
+		return CanonicalSPDX(spdxID)
+	}
+
+	return licenseNameToSPDX[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// CanonicalSPDX returns the conventional casing of a known SPDX ID, e.g.
+// "apache-2.0" becomes "Apache-2.0". Unknown IDs are returned trimmed.
+func CanonicalSPDX(id string) string {
+	id = strings.TrimSpace(id)
+
+	for _, known := range licenseNameToSPDX {
+		if strings.EqualFold(known, id) {
+			return known
+		}
+	}
+
+	return id
+}
+
+// licenseNamesFor returns the lowercased license names that normalize to any
+// of spdxIDs, sorted so generated queries are stable
+func licenseNamesFor(spdxIDs []string) []string {
+	var names []string
+
+	for name, id := range licenseNameToSPDX {
+		for _, want := range spdxIDs {
+			if strings.EqualFold(id, want) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
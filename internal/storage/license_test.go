@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLicense(t *testing.T) {
+	tests := []struct {
+		name   string
+		spdxID string
+		lic    string
+		want   string
+	}{
+		{name: "SPDX ID wins", spdxID: "MIT", lic: "Apache License 2.0", want: "MIT"},
+		{name: "SPDX ID casing is canonicalized", spdxID: "apache-2.0", want: "Apache-2.0"},
+		{name: "unknown SPDX ID is kept", spdxID: "EUPL-1.2", want: "EUPL-1.2"},
+		{name: "name maps when SPDX ID is empty", lic: "GNU General Public License v3.0", want: "GPL-3.0"},
+		{name: "name maps case-insensitively", lic: "  the mit license ", want: "MIT"},
+		{name: "name maps when SPDX ID is NOASSERTION", spdxID: "NOASSERTION", lic: "BSD 3-Clause License", want: "BSD-3-Clause"},
+		{name: "unknown name", spdxID: "NOASSERTION", lic: "Other", want: ""},
+		{name: "no license", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeLicense(tt.spdxID, tt.lic))
+		})
+	}
+}
+
+func TestLicenseNamesFor(t *testing.T) {
+	assert.Equal(t, []string{"mit", "mit license", "the mit license"}, licenseNamesFor([]string{"mit"}))
+	assert.Empty(t, licenseNamesFor([]string{"EUPL-1.2"}))
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ListSort is a field repositories can be listed by
+type ListSort string
+
+// Supported list sort fields
+const (
+	ListSortStars   ListSort = "stars"
+	ListSortForks   ListSort = "forks"
+	ListSortUpdated ListSort = "updated"
+	ListSortName    ListSort = "name"
+
+	// ListSortRandom shuffles repositories. It is not among ListSorts since
+	// pages of a random order are not stable; Order is ignored.
+	ListSortRandom ListSort = "random"
+)
+
+// SortOrder is the direction of a list sort
+type SortOrder string
+
+// Supported sort orders
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// ListSorts returns the supported sort fields in display order
+func ListSorts() []ListSort {
+	return []ListSort{ListSortStars, ListSortForks, ListSortUpdated, ListSortName}
+}
+
+// listSortColumns whitelists the column behind each sort field. ORDER BY cannot
+// be bound as a parameter, so only these fixed identifiers reach the SQL.
+var listSortColumns = map[ListSort]string{
+	ListSortStars:   "stargazers_count",
+	ListSortForks:   "forks_count",
+	ListSortUpdated: "updated_at",
+	ListSortName:    "full_name",
+}
+
+// ListOptions selects the page and ordering of ListRepositoriesSorted
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Sort   ListSort  // Empty sorts by stars
+	Order  SortOrder // Empty sorts names ascending and everything else descending
+	Filter Filter    // Zero lists every repository
+}
+
+// orderByClause returns the ORDER BY expression for opts. Ties fall back to the
+// full name so pages are stable.
+func (opts ListOptions) orderByClause() (string, error) {
+	sort := opts.Sort
+	if sort == "" {
+		sort = ListSortStars
+	}
+
+	if sort == ListSortRandom {
+		return "random()", nil
+	}
+
+	column, ok := listSortColumns[sort]
+	if !ok {
+		return "", fmt.Errorf("unsupported sort field: %q (must be one of %v)", opts.Sort, ListSorts())
+	}
+
+	order := opts.Order
+	if order == "" {
+		order = SortDescending
+		if sort == ListSortName {
+			order = SortAscending
+		}
+	}
+
+	if !slices.Contains([]SortOrder{SortAscending, SortDescending}, order) {
+		return "", fmt.Errorf("unsupported sort order: %q (must be asc or desc)", opts.Order)
+	}
+
+	direction := "DESC"
+	if order == SortAscending {
+		direction = "ASC"
+	}
+
+	if sort == ListSortName {
+		return column + " " + direction, nil
+	}
+
+	return column + " " + direction + ", full_name", nil
+}
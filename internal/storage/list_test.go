@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestListRepositoriesSorted(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fixtures := []struct {
+		name    string
+		stars   int
+		forks   int
+		updated time.Time
+	}{
+		{name: "user/bravo", stars: 30, forks: 1, updated: base.Add(2 * time.Hour)},
+		{name: "user/alpha", stars: 10, forks: 5, updated: base.Add(3 * time.Hour)},
+		{name: "user/charlie", stars: 20, forks: 5, updated: base.Add(1 * time.Hour)},
+	}
+
+	for _, f := range fixtures {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(
+				testutil.WithFullName(f.name),
+				testutil.WithStars(f.stars),
+				testutil.WithForks(f.forks),
+				testutil.WithUpdatedAt(f.updated),
+			), nil,
+		)))
+	}
+
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{
+			name: "default sorts by stars descending",
+			opts: ListOptions{Limit: 10},
+			want: []string{"user/bravo", "user/charlie", "user/alpha"},
+		},
+		{
+			name: "stars ascending",
+			opts: ListOptions{Limit: 10, Sort: ListSortStars, Order: SortAscending},
+			want: []string{"user/alpha", "user/charlie", "user/bravo"},
+		},
+		{
+			name: "forks ties fall back to name",
+			opts: ListOptions{Limit: 10, Sort: ListSortForks},
+			want: []string{"user/alpha", "user/charlie", "user/bravo"},
+		},
+		{
+			name: "updated descending",
+			opts: ListOptions{Limit: 10, Sort: ListSortUpdated},
+			want: []string{"user/alpha", "user/bravo", "user/charlie"},
+		},
+		{
+			name: "name defaults to ascending",
+			opts: ListOptions{Limit: 10, Sort: ListSortName},
+			want: []string{"user/alpha", "user/bravo", "user/charlie"},
+		},
+		{
+			name: "name descending with offset",
+			opts: ListOptions{Limit: 1, Offset: 1, Sort: ListSortName, Order: SortDescending},
+			want: []string{"user/bravo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := repo.ListRepositoriesSorted(ctx, tt.opts)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(repos))
+			for _, r := range repos {
+				names = append(names, r.FullName)
+			}
+
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestListRepositoriesSorted_RejectsUnknownFields(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := repo.ListRepositoriesSorted(ctx, ListOptions{Limit: 10, Sort: "stars; DROP TABLE repositories"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported sort field")
+
+	_, err = repo.ListRepositoriesSorted(ctx, ListOptions{Limit: 10, Order: "sideways"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported sort order")
+}
+
+func TestListRepositoriesSorted_Random(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i, name := range []string{"user/go-a", "user/go-b", "user/go-c", "user/rust-a"} {
+		language := "Go"
+		if name == "user/rust-a" {
+			language = "Rust"
+		}
+
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(
+				testutil.WithFullName(name),
+				testutil.WithLanguage(language),
+				testutil.WithStars(10*(i+1)),
+			), nil,
+		)))
+	}
+
+	// The order is random, so only the sample's size and membership are checked
+	repos, err := repo.ListRepositoriesSorted(ctx, ListOptions{
+		Limit:  2,
+		Sort:   ListSortRandom,
+		Filter: Filter{Language: "go", MinStars: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+
+	for _, r := range repos {
+		assert.Contains(t, []string{"user/go-b", "user/go-c"}, r.FullName)
+	}
+
+	assert.NotEqual(t, repos[0].FullName, repos[1].FullName)
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
+// ErrSchemaTooNew is returned when a database was migrated by a newer build
+// than this one, so its schema has migrations this build does not know
+var ErrSchemaTooNew = errors.New("database schema is newer than this version supports")
+
 // SchemaManager handles database schema creation and migrations
 type SchemaManager struct {
 	db *sql.DB
@@ -24,7 +30,9 @@ func NewSchemaManager(db *sql.DB) *SchemaManager {
 	return &SchemaManager{db: db}
 }
 
-// Initialize runs all pending migrations to bring schema to latest version
+// Initialize runs all pending migrations to bring schema to latest version. A
+// database already past the latest known version fails with ErrSchemaTooNew
+// rather than being used with a schema this build does not understand.
 func (m *SchemaManager) Initialize(ctx context.Context) error {
 	// Create schema_version table if it doesn't exist
 	if err := m.createVersionTable(ctx); err != nil {
@@ -43,6 +51,11 @@ func (m *SchemaManager) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
+	if len(migrations) > 0 && currentVersion > migrations[len(migrations)-1].version {
+		return fmt.Errorf("%w: database is at version %d, latest known is %d",
+			ErrSchemaTooNew, currentVersion, migrations[len(migrations)-1].version)
+	}
+
 	// Run pending migrations
 	for _, migration := range migrations {
 		if migration.version > currentVersion {
@@ -60,6 +73,64 @@ func (m *SchemaManager) CreateLatestSchema(ctx context.Context) error {
 	return m.Initialize(ctx)
 }
 
+// MigrationStatus reports whether a known migration has been applied
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// Status lists every embedded migration sorted by version, with when it was applied
+func (m *SchemaManager) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.createVersionTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create version table: %w", err)
+	}
+
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int]time.Time)
+
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+
+		appliedAt[version] = at
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, mig := range migrations {
+		status := MigrationStatus{Version: mig.version, Name: mig.name}
+		if at, ok := appliedAt[mig.version]; ok {
+			status.Applied = true
+			status.AppliedAt = &at
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
 // migration represents a single SQL migration file
 type migration struct {
 	version int
@@ -133,6 +204,21 @@ func (m *SchemaManager) loadMigrations() ([]migration, error) {
 	return migrations, nil
 }
 
+// LatestSchemaVersion returns the version of the newest embedded migration, which
+// is the schema every database opened by this build is migrated to
+func LatestSchemaVersion() (int, error) {
+	migrations, err := (&SchemaManager{}).loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+
+	return migrations[len(migrations)-1].version, nil
+}
+
 // parseMigrationFilename extracts version and name from filename
 // Expected format: "001_description.sql" returns (1, "description")
 func parseMigrationFilename(filename string) (int, string, error) {
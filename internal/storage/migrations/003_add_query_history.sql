@@ -0,0 +1,13 @@
+-- Local log of search invocations so useful queries can be recalled and re-run
+CREATE SEQUENCE IF NOT EXISTS query_history_id_seq START 1;
+
+CREATE TABLE IF NOT EXISTS query_history (
+    id BIGINT PRIMARY KEY DEFAULT nextval('query_history_id_seq'),
+    query_text VARCHAR NOT NULL,
+    mode VARCHAR NOT NULL,
+    result_limit INTEGER NOT NULL,
+    result_count INTEGER DEFAULT 0,
+    executed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_query_history_executed_at ON query_history(executed_at);
@@ -0,0 +1,8 @@
+-- Named searches so frequently used query parameters don't need retyping
+CREATE TABLE IF NOT EXISTS saved_searches (
+    name VARCHAR PRIMARY KEY,
+    query_text VARCHAR NOT NULL,
+    mode VARCHAR NOT NULL,
+    result_limit INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
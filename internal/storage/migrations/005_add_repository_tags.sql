@@ -0,0 +1,10 @@
+-- Local-only annotations on starred repositories. Keyed by full_name without a
+-- foreign key so tags survive the DELETE+INSERT update path used during sync.
+CREATE TABLE IF NOT EXISTS repository_tags (
+    full_name VARCHAR NOT NULL,
+    tag VARCHAR NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (full_name, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_repository_tags_tag ON repository_tags(tag);
@@ -0,0 +1,10 @@
+-- DuckDB rewrites an UPDATE that touches an indexed column as DELETE+INSERT, which
+-- trips false PRIMARY KEY violations (duckdb/duckdb#11915, #8764). Dropping these
+-- ART indexes lets repository updates run as plain, transactional UPDATEs. Range
+-- filters on these columns are still served by DuckDB's min/max zonemaps, and
+-- full_name lookups use the index created by its UNIQUE constraint.
+DROP INDEX IF EXISTS idx_repositories_language;
+DROP INDEX IF EXISTS idx_repositories_updated_at;
+DROP INDEX IF EXISTS idx_repositories_stargazers;
+DROP INDEX IF EXISTS idx_repositories_full_name;
+DROP INDEX IF EXISTS idx_repositories_commits_total;
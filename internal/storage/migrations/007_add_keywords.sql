@@ -0,0 +1,5 @@
+-- README-derived keywords. keyword_terms holds each repository's candidate term
+-- counts so TF-IDF can be recomputed across the corpus without re-fetching content;
+-- keywords holds the selected top terms as space-joined text for FTS indexing.
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS keyword_terms JSON DEFAULT '{}';
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS keywords VARCHAR DEFAULT '';
@@ -0,0 +1,5 @@
+-- Stable numeric GitHub repository id. Unlike full_name it survives renames and
+-- transfers, so sync can match a renamed repository to its existing row.
+-- Existing rows are backfilled on the next sync. The column is deliberately not
+-- indexed: sync writes it on every upsert (see DUCKDB_WORKAROUND.md).
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS github_id BIGINT;
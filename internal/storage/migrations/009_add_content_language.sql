@@ -0,0 +1,5 @@
+-- ISO 639-1 code of the README's natural language, detected during content
+-- processing ('' when unknown). Used to skip summarizing unsupported languages
+-- and for the --content-language search filter. Written on every upsert, so it
+-- is deliberately not indexed (see DUCKDB_WORKAROUND.md).
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS content_language VARCHAR DEFAULT '';
@@ -0,0 +1,7 @@
+-- Top-level README file name and markup format (markdown, rst or text), found
+-- during content processing ('' when the repository has no README). Lets detail
+-- views render RST and plain text READMEs correctly and backs the
+-- --readme-format search filter. Written on every upsert, so it is deliberately
+-- not indexed (see DUCKDB_WORKAROUND.md).
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS readme_path VARCHAR DEFAULT '';
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS readme_format VARCHAR DEFAULT '';
@@ -0,0 +1,4 @@
+-- Whether GitHub reports the repository as archived. Backs the archived
+-- filter of FilterRepositories. Written on every upsert, so it is deliberately
+-- not indexed (see DUCKDB_WORKAROUND.md).
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS archived BOOLEAN DEFAULT false;
@@ -0,0 +1,14 @@
+-- Content chunks of each repository with an optional embedding, for
+-- chunk-level semantic search. Chunk ids are fresh UUIDs on every replacement,
+-- so replacing a repository's chunks inside a transaction never re-inserts a
+-- deleted key (see DUCKDB_WORKAROUND.md). Keyed by full_name without a foreign
+-- key, like repository_tags; full_name is not indexed because renames update it.
+CREATE TABLE IF NOT EXISTS content_chunk_embeddings (
+    id VARCHAR PRIMARY KEY,
+    full_name VARCHAR NOT NULL,
+    chunk_index INTEGER NOT NULL,
+    source VARCHAR NOT NULL,
+    chunk_type VARCHAR NOT NULL,
+    content VARCHAR NOT NULL,
+    embedding JSON
+);
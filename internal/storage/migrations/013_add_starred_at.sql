@@ -0,0 +1,5 @@
+-- When the user starred the repository, as reported by the starred list. NULL
+-- for rows synced before it was recorded or fetched individually (sync --repo).
+-- Written on every upsert, so it is deliberately not indexed (see
+-- DUCKDB_WORKAROUND.md).
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS starred_at TIMESTAMP;
@@ -0,0 +1,6 @@
+-- Git blob SHA of the file each content chunk was cut from, and how many
+-- chunks that file produced, so a re-sync can reuse the chunks of unchanged
+-- files instead of decoding and chunking them again. NULL for chunks stored
+-- before they were recorded, which are simply rebuilt once.
+ALTER TABLE content_chunk_embeddings ADD COLUMN IF NOT EXISTS source_sha VARCHAR;
+ALTER TABLE content_chunk_embeddings ADD COLUMN IF NOT EXISTS source_parts INTEGER;
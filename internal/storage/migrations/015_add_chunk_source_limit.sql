@@ -0,0 +1,5 @@
+-- The per-chunk token limit each content chunk's file was cut with. Chunks are
+-- only reused while the configured limit matches, since a different limit
+-- cuts the same file differently. NULL for chunks stored before it was
+-- recorded, which are simply rebuilt once.
+ALTER TABLE content_chunk_embeddings ADD COLUMN IF NOT EXISTS source_limit INTEGER;
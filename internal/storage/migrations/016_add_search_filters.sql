@@ -0,0 +1,6 @@
+-- The filters and matching options of saved searches and logged queries (tag,
+-- topics, language, stars, keyword, min score, ...) as JSON, so running a
+-- saved search or re-running a history entry returns the same selection.
+-- NULL for rows stored before they were recorded, which run unfiltered.
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS filters JSON;
+ALTER TABLE query_history ADD COLUMN IF NOT EXISTS filters JSON;
@@ -0,0 +1,6 @@
+-- Forget the content hash of repositories without stored chunks (synced
+-- before 012, or restored by import, which carries no chunks), so the next
+-- sync treats their content as changed and writes the chunks instead of
+-- skipping them as unchanged
+UPDATE repositories SET content_hash = ''
+WHERE full_name NOT IN (SELECT DISTINCT full_name FROM content_chunk_embeddings);
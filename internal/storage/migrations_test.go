@@ -3,7 +3,9 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -129,3 +131,186 @@ func TestRepositoryMetricsUpdate(t *testing.T) {
 		t.Logf("UpdateRepositoryEmbedding returned error (expected for nonexistent repo): %v", err)
 	}
 }
+
+func TestSchemaManagerStatus(t *testing.T) {
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	schemaManager := NewSchemaManager(db)
+	ctx := context.Background()
+
+	statuses, err := schemaManager.Status(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+
+	if len(statuses) == 0 {
+		t.Fatal("Expected embedded migrations to be listed")
+	}
+
+	for _, status := range statuses {
+		if status.Applied || status.AppliedAt != nil {
+			t.Errorf("Migration %d reported applied before Initialize", status.Version)
+		}
+	}
+
+	if err := schemaManager.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	statuses, err = schemaManager.Status(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+
+	for i, status := range statuses {
+		if i > 0 && status.Version <= statuses[i-1].Version {
+			t.Errorf("Statuses not sorted by version: %d after %d", status.Version, statuses[i-1].Version)
+		}
+
+		if !status.Applied || status.AppliedAt == nil || status.AppliedAt.IsZero() {
+			t.Errorf("Migration %d should be applied with a timestamp, got %+v", status.Version, status)
+		}
+	}
+}
+
+func TestLatestSchemaVersion(t *testing.T) {
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	schemaManager := NewSchemaManager(db)
+	ctx := context.Background()
+
+	if err := schemaManager.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	applied, err := schemaManager.getCurrentVersion(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get current version: %v", err)
+	}
+
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatalf("LatestSchemaVersion failed: %v", err)
+	}
+
+	if latest == 0 || latest != applied {
+		t.Errorf("LatestSchemaVersion() = %d, want the initialized version %d", latest, applied)
+	}
+}
+
+func TestInitializeMigratesVersion1Database(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "v1.db")
+
+	db, err := sql.Open("duckdb", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	schemaManager := NewSchemaManager(db)
+
+	migrations, err := schemaManager.loadMigrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if err := schemaManager.createVersionTable(ctx); err != nil {
+		t.Fatalf("Failed to create version table: %v", err)
+	}
+
+	if err := schemaManager.runMigration(ctx, migrations[0]); err != nil {
+		t.Fatalf("Failed to apply migration 1: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO repositories (id, full_name, description, homepage, language, stargazers_count, forks_count,
+			size_kb, created_at, updated_at, last_synced, topics_array, license_name, license_spdx_id,
+			content_hash, purpose)
+		VALUES ('1', 'owner/legacy', 'Written before any upgrade', '', 'Go', 42, 7, 128,
+			'2020-01-01', '2024-01-01', '2024-06-01', '["cli"]', 'MIT License', 'MIT', 'abc123', 'A legacy tool')`)
+	if err != nil {
+		t.Fatalf("Failed to insert version 1 row: %v", err)
+	}
+
+	db.Close()
+
+	repo, err := NewDuckDBRepository(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to migrate version 1 database: %v", err)
+	}
+
+	statuses, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+
+	for _, status := range statuses {
+		if !status.Applied {
+			t.Errorf("Migration %d (%s) not applied", status.Version, status.Name)
+		}
+	}
+
+	stored, err := repo.GetRepository(ctx, "owner/legacy")
+	if err != nil {
+		t.Fatalf("Version 1 row lost after migration: %v", err)
+	}
+
+	if stored.Description != "Written before any upgrade" || stored.StargazersCount != 42 ||
+		stored.LicenseSPDXID != "MIT" || stored.Purpose != "A legacy tool" ||
+		len(stored.Topics) != 1 || stored.Topics[0] != "cli" {
+		t.Errorf("Version 1 data changed by migration: %+v", stored)
+	}
+
+	if stored.Archived || stored.StarredAt != nil {
+		t.Errorf("Columns added by later migrations should take their defaults, got archived=%v starred_at=%v",
+			stored.Archived, stored.StarredAt)
+	}
+
+	if stored.ContentHash != "" {
+		t.Errorf("Content hash of a repository without chunks should be reset so sync chunks it, got %q",
+			stored.ContentHash)
+	}
+}
+
+func TestInitializeRejectsNewerSchema(t *testing.T) {
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	schemaManager := NewSchemaManager(db)
+	ctx := context.Background()
+
+	if err := schemaManager.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatalf("LatestSchemaVersion failed: %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx,
+		"INSERT INTO schema_version (version, name) VALUES (?, 'from_the_future')", latest+1); err != nil {
+		t.Fatalf("Failed to record future migration: %v", err)
+	}
+
+	err = schemaManager.Initialize(ctx)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Initialize() error = %v, want ErrSchemaTooNew", err)
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchFieldName is the Match.Field reported when the query names the repository
+// itself, ignoring the owner (e.g. "ripgrep" for BurntSushi/ripgrep)
+const MatchFieldName = "name"
+
+// Match.Score values for MatchFieldName
+const (
+	NameMatchExact  = 1.0 // The query is the repository name
+	NameMatchPrefix = 0.5 // The repository name starts with the query
+)
+
+// nameMatch reports how query matches the repository name of fullName. Case and
+// separators are ignored, so "tree sitter" names tree-sitter; a query containing
+// "/" is compared with the full owner/name.
+func nameMatch(fullName, query string) (float64, bool) {
+	name := fullName
+	if !strings.Contains(query, "/") {
+		name = fullName[strings.LastIndex(fullName, "/")+1:]
+	}
+
+	nameKey, queryKey := nameMatchKey(name), nameMatchKey(query)
+
+	switch {
+	case queryKey == "":
+		return 0, false
+	case nameKey == queryKey:
+		return NameMatchExact, true
+	case strings.HasPrefix(nameKey, queryKey):
+		return NameMatchPrefix, true
+	default:
+		return 0, false
+	}
+}
+
+// nameMatchKey lowercases s and drops everything but letters and digits
+func nameMatchKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, s)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		fullName  string
+		query     string
+		wantScore float64
+		wantOK    bool
+	}{
+		{name: "exact name", fullName: "BurntSushi/ripgrep", query: "ripgrep", wantScore: NameMatchExact, wantOK: true},
+		{name: "case ignored", fullName: "BurntSushi/ripgrep", query: "RipGrep", wantScore: NameMatchExact, wantOK: true},
+		{name: "separators ignored", fullName: "tree-sitter/tree-sitter", query: "tree sitter", wantScore: NameMatchExact, wantOK: true},
+		{name: "full name", fullName: "BurntSushi/ripgrep", query: "burntsushi/ripgrep", wantScore: NameMatchExact, wantOK: true},
+		{name: "prefix", fullName: "phiresky/ripgrep-all", query: "ripgrep", wantScore: NameMatchPrefix, wantOK: true},
+		{name: "owner only is not a name match", fullName: "ripgrep/tools", query: "ripgrep", wantOK: false},
+		{name: "substring is not a name match", fullName: "user/fast-ripgrep", query: "ripgrep", wantOK: false},
+		{name: "punctuation-only query", fullName: "user/repo", query: "--", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := nameMatch(tt.fullName, tt.query)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.wantScore, score, 1e-9)
+		})
+	}
+}
+
+func TestFindMatches_ReportsNameMatch(t *testing.T) {
+	r := &DuckDBRepository{}
+
+	matches := r.findMatches(StoredRepo{FullName: "BurntSushi/ripgrep", Description: "ripgrep searches"}, "ripgrep")
+
+	assert.Contains(t, matches, Match{Field: MatchFieldName, Content: "BurntSushi/ripgrep", Score: NameMatchExact})
+	assert.True(t, slices.ContainsFunc(matches, func(m Match) bool {
+		return m.Field == "description" && m.Content == "ripgrep searches"
+	}), "the description mention is reported too")
+
+	matches = r.findMatches(StoredRepo{FullName: "user/tools", Description: "wraps ripgrep"}, "ripgrep")
+
+	for _, m := range matches {
+		assert.NotEqual(t, MatchFieldName, m.Field, "a description mention is not a name match")
+	}
+}
+
+func TestFindMatches_ScoresByCloseness(t *testing.T) {
+	r := &DuckDBRepository{}
+
+	descriptionScore := func(description, query string) float64 {
+		for _, m := range r.findMatches(StoredRepo{FullName: "user/tool", Description: description}, query) {
+			if m.Field == "description" {
+				return m.Score
+			}
+		}
+
+		return 0
+	}
+
+	exact := descriptionScore("Terminal emulator", "terminal emulator")
+	partial := descriptionScore("A fast terminal emulator for developers", "terminal emulator")
+	typo := descriptionScore("Kubernetes operator", "kubernets operator")
+
+	assert.InDelta(t, 0.8, exact, 1e-9, "an exact match scores the field weight")
+	assert.Greater(t, exact, partial)
+	assert.Positive(t, partial)
+	assert.Positive(t, typo, "a typo still matches")
+	assert.Zero(t, descriptionScore("Kubernetes operator", "database"))
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// RenameRepository moves a repository, its local tags and content chunks from oldFullName to
+// newFullName, preserving metrics, summaries, embeddings and keywords.
+//
+// DuckDB rejects in-place updates of the indexed full_name column (see
+// DUCKDB_WORKAROUND.md), so the row is copied under the new name with a new id
+// and the old row is deleted, all in one transaction.
+func (r *DuckDBRepository) RenameRepository(ctx context.Context, oldFullName, newFullName string) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+	INSERT INTO repositories
+	SELECT * REPLACE (gen_random_uuid()::VARCHAR AS id, ?::VARCHAR AS full_name)
+	FROM repositories WHERE full_name = ?`,
+		newFullName, oldFullName)
+	if err != nil {
+		return fmt.Errorf("failed to copy repository %s to %s: %w", oldFullName, newFullName, err)
+	}
+
+	if err := requireRowAffected(result, oldFullName); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM repositories WHERE full_name = ?", oldFullName); err != nil {
+		return fmt.Errorf("failed to delete renamed repository %s: %w", oldFullName, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE repository_tags SET full_name = ? WHERE full_name = ?",
+		newFullName, oldFullName); err != nil {
+		return fmt.Errorf("failed to move repository tags: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE content_chunk_embeddings SET full_name = ? WHERE full_name = ?",
+		newFullName, oldFullName); err != nil {
+		return fmt.Errorf("failed to move content chunks: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rename: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestRenameRepository(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	original := testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(testutil.WithFullName("user/old"), testutil.WithGitHubID(42)),
+		nil,
+	)
+	require.NoError(t, repo.UpsertRepository(ctx, original, UpsertOptions{
+		Metrics:   &RepositoryMetrics{CommitsTotal: 7},
+		Embedding: []float32{0.1, 0.2},
+	}))
+	require.NoError(t, repo.AddTag(ctx, "user/old", "cli"))
+	require.NoError(t, repo.UpdateRepositoryKeywords(ctx, "user/old", []string{"parser"}))
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/other")))
+
+	t.Run("moves row and local data", func(t *testing.T) {
+		require.NoError(t, repo.RenameRepository(ctx, "user/old", "org/new"))
+
+		renamed, err := repo.GetRepository(ctx, "org/new")
+		require.NoError(t, err)
+		assert.Equal(t, "org/new", renamed.FullName)
+		assert.Equal(t, int64(42), renamed.GitHubID)
+		assert.Equal(t, 7, renamed.CommitsTotal)
+		assert.Equal(t, []float32{0.1, 0.2}, renamed.RepoEmbedding)
+		assert.Equal(t, []string{"parser"}, renamed.Keywords)
+		assert.Equal(t, []string{"cli"}, renamed.Tags)
+
+		_, err = repo.GetRepository(ctx, "user/old")
+		require.Error(t, err)
+
+		names, err := repo.ListTaggedRepositories(ctx, "cli")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"org/new"}, names)
+	})
+
+	t.Run("unknown repository fails", func(t *testing.T) {
+		assert.Error(t, repo.RenameRepository(ctx, "user/missing", "user/elsewhere"))
+	})
+
+	t.Run("existing target name fails without changes", func(t *testing.T) {
+		require.Error(t, repo.RenameRepository(ctx, "org/new", "user/other"))
+
+		_, err := repo.GetRepository(ctx, "org/new")
+		require.NoError(t, err, "failed rename should leave the original row")
+	})
+}
@@ -12,11 +12,19 @@ type Repository interface {
 	Initialize(ctx context.Context) error
 	StoreRepository(ctx context.Context, repo processor.ProcessedRepo) error
 	UpdateRepository(ctx context.Context, repo processor.ProcessedRepo) error
+	UpsertRepository(ctx context.Context, repo processor.ProcessedRepo, opts UpsertOptions) error
 	DeleteRepository(ctx context.Context, fullName string) error
+	RenameRepository(ctx context.Context, oldFullName, newFullName string) error
+	BackfillGitHubIDs(ctx context.Context, ids map[string]int64) (int, error)
 	SearchRepositories(ctx context.Context, query string) ([]SearchResult, error)
+	SearchFilteredRepositories(ctx context.Context, query string, filter Filter) ([]SearchResult, error)
 	GetRepository(ctx context.Context, fullName string) (*StoredRepo, error)
 	ListRepositories(ctx context.Context, limit, offset int) ([]StoredRepo, error)
+	ListRepositoriesSorted(ctx context.Context, opts ListOptions) ([]StoredRepo, error)
+	FilterRepositories(ctx context.Context, filter Filter) ([]SearchResult, error)
+	SearchByContributor(ctx context.Context, login string) ([]SearchResult, error)
 	GetStats(ctx context.Context) (*Stats, error)
+	GetSizeBreakdown(ctx context.Context, topN int) (*SizeBreakdown, error)
 	Clear(ctx context.Context) error
 	Close() error
 
@@ -26,18 +34,48 @@ type Repository interface {
 	UpdateRepositorySummary(ctx context.Context, fullName, purpose string) error
 	GetRepositoriesNeedingMetricsUpdate(ctx context.Context, staleDays int) ([]string, error)
 	GetRepositoriesNeedingSummaryUpdate(ctx context.Context, forceUpdate bool) ([]string, error)
+	GetRepositoriesNeedingEmbedding(ctx context.Context, forceUpdate bool) ([]string, error)
 
 	// FTS and vector search
 	RebuildFTSIndex(ctx context.Context) error
 	SearchByEmbedding(ctx context.Context, queryEmbedding []float32, limit int, minScore float64) ([]SearchResult, error)
 
+	// Content chunk embeddings
+	GetChunksNeedingEmbedding(ctx context.Context, forceUpdate bool) ([]StoredChunk, error)
+	UpdateChunkEmbedding(ctx context.Context, id string, embedding []float32) error
+	SearchChunksByEmbedding(
+		ctx context.Context, queryEmbedding []float32, limit int, minScore float64,
+	) ([]SearchResult, error)
+
 	// Related counts
 	GetRelatedCounts(ctx context.Context, fullName string) (sameOrg int, sharedContrib int, err error)
+
+	// Query history
+	RecordQuery(ctx context.Context, entry QueryHistoryEntry) error
+	ListQueryHistory(ctx context.Context, limit int) ([]QueryHistoryEntry, error)
+	GetQueryHistoryEntry(ctx context.Context, id int64) (*QueryHistoryEntry, error)
+
+	// Saved searches
+	SaveSearch(ctx context.Context, search SavedSearch) error
+	GetSavedSearch(ctx context.Context, name string) (*SavedSearch, error)
+	ListSavedSearches(ctx context.Context) ([]SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, name string) error
+
+	// README-derived keywords
+	ListKeywordTerms(ctx context.Context) (map[string]map[string]int, error)
+	UpdateKeywords(ctx context.Context, selected map[string][]string) (int, error)
+
+	// Local tags
+	AddTag(ctx context.Context, fullName, tag string) error
+	RemoveTag(ctx context.Context, fullName, tag string) error
+	GetTags(ctx context.Context, fullName string) ([]string, error)
+	ListTaggedRepositories(ctx context.Context, tag string) ([]string, error)
 }
 
 // StoredRepo represents a repository as stored in the database
 type StoredRepo struct {
 	ID              string    `json:"id"`
+	GitHubID        int64     `json:"github_id,omitempty"` // Stable numeric GitHub id (0 until backfilled)
 	FullName        string    `json:"full_name"`
 	Description     string    `json:"description"`
 	Homepage        string    `json:"homepage"`
@@ -48,6 +86,10 @@ type StoredRepo struct {
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 	LastSynced      time.Time `json:"last_synced"`
+	Archived        bool      `json:"archived"`
+
+	// StarredAt is when the repository was starred; nil when not known
+	StarredAt *time.Time `json:"starred_at,omitempty"`
 
 	// Activity & Metrics
 	OpenIssuesOpen  int `json:"open_issues_open"`
@@ -68,7 +110,10 @@ type StoredRepo struct {
 	LicenseSPDXID string `json:"license_spdx_id"`
 
 	// Content tracking
-	ContentHash string `json:"content_hash"`
+	ContentHash     string `json:"content_hash"`
+	ContentLanguage string `json:"content_language,omitempty"` // ISO 639-1 code from the README; "" when unknown
+	ReadmePath      string `json:"readme_path,omitempty"`      // Top-level README file, e.g. README.rst; "" when none was found
+	ReadmeFormat    string `json:"readme_format,omitempty"`    // markdown, rst or text; "" when none was found
 
 	// Summarization (AI-generated summaries)
 	Purpose            string     `json:"purpose,omitempty"`
@@ -78,6 +123,12 @@ type StoredRepo struct {
 	// Embedding
 	RepoEmbedding []float32 `json:"repo_embedding,omitempty"`
 
+	// README-derived keywords (TF-IDF across the corpus)
+	Keywords []string `json:"keywords,omitempty"`
+
+	// Local tags (stored separately so sync never overwrites them)
+	Tags []string `json:"tags,omitempty"`
+
 	// Transient computed fields (not persisted)
 	RelatedSameOrgCount       int `json:"-"`
 	RelatedSharedContribCount int `json:"-"`
@@ -89,7 +140,12 @@ type Contributor struct {
 	Contributions int    `json:"contributions"`
 }
 
-// RepositoryMetrics represents activity and metrics data for a repository
+// UnknownCount marks a count that could not be fetched. It is stored as NULL
+// and read back as UnknownCount so formatters can show it as unknown.
+const UnknownCount = -1
+
+// RepositoryMetrics represents activity and metrics data for a repository.
+// Negative counts are stored as NULL.
 type RepositoryMetrics struct {
 	OpenIssuesOpen  int              `json:"open_issues_open"`
 	OpenIssuesTotal int              `json:"open_issues_total"`
@@ -113,7 +169,8 @@ type SearchResult struct {
 // Match represents a specific field match in search results
 type Match struct {
 	Field   string  `json:"field"`
-	Content string  `json:"content"`
+	Source  string  `json:"source,omitempty"` // File or section of a MatchFieldChunk match
+	Content string  `json:"content"`          // Full field value; see formatter.MatchSnippet for display trimming
 	Score   float64 `json:"score"`
 }
 
@@ -125,3 +182,39 @@ type Stats struct {
 	LanguageBreakdown map[string]int `json:"language_breakdown"`
 	TopicBreakdown    map[string]int `json:"topic_breakdown"`
 }
+
+// QueryHistoryEntry represents a logged search invocation
+type QueryHistoryEntry struct {
+	ID          int64         `json:"id"`
+	Query       string        `json:"query"`
+	Mode        string        `json:"mode"`
+	Limit       int           `json:"limit"`
+	ResultCount int           `json:"result_count"`
+	Filters     SearchFilters `json:"filters"`
+	ExecutedAt  time.Time     `json:"executed_at"`
+}
+
+// SavedSearch represents a named, persisted set of query parameters
+type SavedSearch struct {
+	Name      string        `json:"name"`
+	Query     string        `json:"query"`
+	Mode      string        `json:"mode"`
+	Limit     int           `json:"limit"`
+	Filters   SearchFilters `json:"filters"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// SearchFilters are the filters and matching options of a query beyond its
+// text, mode and limit, stored with saved searches and the query history so
+// they run again with the same selection
+type SearchFilters struct {
+	MinScore        *float64 `json:"min_score,omitempty"` // nil applies search.min_score
+	Tag             string   `json:"tag,omitempty"`
+	Filter          Filter   `json:"filter"`
+	Keyword         string   `json:"keyword,omitempty"`
+	ContentLanguage string   `json:"content_language,omitempty"`
+	ReadmeFormat    string   `json:"readme_format,omitempty"`
+	MaxPerOwner     int      `json:"max_per_owner,omitempty"`
+	CaseSensitive   bool     `json:"case_sensitive,omitempty"`
+	Chunks          bool     `json:"chunks,omitempty"`
+}
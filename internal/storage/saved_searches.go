@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SaveSearch stores a named search, replacing any existing search with the same name
+func (r *DuckDBRepository) SaveSearch(ctx context.Context, search SavedSearch) error {
+	filters, err := encodeJSONColumn(search.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode search filters: %w", err)
+	}
+
+	insertSQL := `
+	INSERT INTO saved_searches (name, query_text, mode, result_limit, filters)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (name) DO UPDATE SET
+		query_text = excluded.query_text,
+		mode = excluded.mode,
+		result_limit = excluded.result_limit,
+		filters = excluded.filters,
+		created_at = now()`
+
+	_, err = r.db.ExecContext(ctx, insertSQL, search.Name, search.Query, search.Mode, search.Limit, filters)
+	if err != nil {
+		return fmt.Errorf("failed to save search: %w", err)
+	}
+
+	return nil
+}
+
+// GetSavedSearch retrieves a saved search by name
+func (r *DuckDBRepository) GetSavedSearch(ctx context.Context, name string) (*SavedSearch, error) {
+	query := `
+	SELECT name, query_text, mode, result_limit, filters, created_at
+	FROM saved_searches
+	WHERE name = ?`
+
+	var (
+		search  SavedSearch
+		filters any
+	)
+
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&search.Name, &search.Query, &search.Mode, &search.Limit, &filters, &search.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("saved search not found: %s", name)
+		}
+
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+
+	decodeJSONColumn(filters, &search.Filters)
+
+	return &search, nil
+}
+
+// ListSavedSearches returns all saved searches ordered by name
+func (r *DuckDBRepository) ListSavedSearches(ctx context.Context) ([]SavedSearch, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+	SELECT name, query_text, mode, result_limit, filters, created_at
+	FROM saved_searches
+	ORDER BY name`
+
+	rows, err := r.db.QueryContext(queryCtx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []SavedSearch
+
+	for rows.Next() {
+		var (
+			search  SavedSearch
+			filters any
+		)
+
+		if err := rows.Scan(
+			&search.Name, &search.Query, &search.Mode, &search.Limit, &filters, &search.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+
+		decodeJSONColumn(filters, &search.Filters)
+
+		searches = append(searches, search)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// DeleteSavedSearch removes a saved search by name
+func (r *DuckDBRepository) DeleteSavedSearch(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM saved_searches WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("saved search not found: %s", name)
+	}
+
+	return nil
+}
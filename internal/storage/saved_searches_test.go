@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearches(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	minScore := 1.5
+	filters := SearchFilters{
+		MinScore: &minScore,
+		Tag:      "evaluate",
+		Filter: Filter{
+			Topics:       []string{"cli"},
+			Language:     "Rust",
+			UpdatedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Licenses:     []string{"MIT"},
+		},
+		Keyword:       "terminal",
+		MaxPerOwner:   2,
+		CaseSensitive: true,
+	}
+
+	require.NoError(t, repo.SaveSearch(ctx, SavedSearch{
+		Name: "rust-clis", Query: "rust cli", Mode: "fuzzy", Limit: 20, Filters: filters,
+	}))
+	require.NoError(t, repo.SaveSearch(ctx, SavedSearch{
+		Name: "ml", Query: "machine learning", Mode: "vector", Limit: 5,
+	}))
+
+	t.Run("get", func(t *testing.T) {
+		search, err := repo.GetSavedSearch(ctx, "rust-clis")
+		require.NoError(t, err)
+		assert.Equal(t, "rust cli", search.Query)
+		assert.Equal(t, "fuzzy", search.Mode)
+		assert.Equal(t, 20, search.Limit)
+		assert.Equal(t, filters, search.Filters)
+		assert.False(t, search.CreatedAt.IsZero())
+	})
+
+	t.Run("list ordered by name", func(t *testing.T) {
+		searches, err := repo.ListSavedSearches(ctx)
+		require.NoError(t, err)
+		require.Len(t, searches, 2)
+		assert.Equal(t, "ml", searches[0].Name)
+		assert.Equal(t, SearchFilters{}, searches[0].Filters)
+		assert.Equal(t, "rust-clis", searches[1].Name)
+		assert.Equal(t, filters, searches[1].Filters)
+	})
+
+	t.Run("save replaces existing", func(t *testing.T) {
+		require.NoError(t, repo.SaveSearch(ctx, SavedSearch{
+			Name: "rust-clis", Query: "rust terminal", Mode: "fuzzy", Limit: 10,
+		}))
+
+		search, err := repo.GetSavedSearch(ctx, "rust-clis")
+		require.NoError(t, err)
+		assert.Equal(t, "rust terminal", search.Query)
+		assert.Equal(t, 10, search.Limit)
+		assert.Equal(t, SearchFilters{}, search.Filters)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteSavedSearch(ctx, "ml"))
+
+		_, err := repo.GetSavedSearch(ctx, "ml")
+		assert.Error(t, err)
+
+		assert.Error(t, repo.DeleteSavedSearch(ctx, "ml"))
+	})
+}
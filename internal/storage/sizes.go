@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// TableSize approximates the space used by one table
+type TableSize struct {
+	Table string `json:"table"`
+	Rows  int    `json:"rows"`
+	Bytes int64  `json:"bytes"` // Sum of each row's text representation
+}
+
+// RepositorySize approximates the space used by one repository's content
+type RepositorySize struct {
+	FullName string `json:"full_name"`
+	Bytes    int64  `json:"bytes"` // Text length of its content chunks
+}
+
+// SizeBreakdown shows where the database bytes go. Sizes are approximations
+// from row text lengths, not on-disk block usage (which includes compression,
+// indexes and free space).
+type SizeBreakdown struct {
+	Tables              []TableSize      `json:"tables"`
+	LargestRepositories []RepositorySize `json:"largest_repositories"`
+}
+
+// GetSizeBreakdown returns approximate rows and bytes per table and the topN
+// largest repositories by the text of their content chunks, which is what grows
+// with large READMEs and docs. Repositories without chunks are not listed.
+func (r *DuckDBRepository) GetSizeBreakdown(ctx context.Context, topN int) (*SizeBreakdown, error) {
+	tables, err := r.listTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &SizeBreakdown{}
+
+	for _, table := range tables {
+		size := TableSize{Table: table}
+
+		// Table names come from the catalog, so quoting is enough
+		err := r.db.QueryRowContext(ctx, fmt.Sprintf(
+			`SELECT COUNT(*), COALESCE(SUM(strlen(CAST(t AS VARCHAR))), 0) FROM "%s" t`, table,
+		)).Scan(&size.Rows, &size.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure table %s: %w", table, err)
+		}
+
+		breakdown.Tables = append(breakdown.Tables, size)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT full_name, SUM(strlen(content)) AS content_bytes
+		FROM content_chunk_embeddings
+		GROUP BY full_name
+		ORDER BY content_bytes DESC, full_name
+		LIMIT ?`, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure repositories: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var size RepositorySize
+		if err := rows.Scan(&size.FullName, &size.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan repository size: %w", err)
+		}
+
+		breakdown.LargestRepositories = append(breakdown.LargestRepositories, size)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate repository sizes: %w", err)
+	}
+
+	return breakdown, nil
+}
+
+// listTables returns the user tables in the main schema, sorted by name
+func (r *DuckDBRepository) listTables(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT table_name FROM duckdb_tables() WHERE schema_name = 'main' ORDER BY table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tables: %w", err)
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestGetSizeBreakdown(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// A long description without content must not outrank stored content
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(
+			testutil.WithFullName("user/wordy"),
+			testutil.WithDescription(strings.Repeat("verbose ", 200)),
+		), nil,
+	)))
+	upsertWithChunks(t, repo, "user/large", strings.Repeat("content ", 100), strings.Repeat("guide ", 100))
+	upsertWithChunks(t, repo, "user/medium", strings.Repeat("content ", 50))
+	upsertWithChunks(t, repo, "user/small", "tiny")
+
+	breakdown, err := repo.GetSizeBreakdown(ctx, 2)
+	require.NoError(t, err)
+
+	tables := make(map[string]TableSize)
+	for _, table := range breakdown.Tables {
+		tables[table.Table] = table
+	}
+
+	require.Contains(t, tables, "repositories")
+	assert.Equal(t, 4, tables["repositories"].Rows)
+	assert.Positive(t, tables["repositories"].Bytes)
+	assert.Contains(t, tables, "schema_version")
+
+	require.Len(t, breakdown.LargestRepositories, 2, "limited to topN")
+	assert.Equal(t, RepositorySize{FullName: "user/large", Bytes: 1400}, breakdown.LargestRepositories[0])
+	assert.Equal(t, RepositorySize{FullName: "user/medium", Bytes: 400}, breakdown.LargestRepositories[1])
+
+	breakdown, err = repo.GetSizeBreakdown(ctx, 10)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(breakdown.LargestRepositories))
+	for _, size := range breakdown.LargestRepositories {
+		names = append(names, size.FullName)
+	}
+
+	assert.Equal(t, []string{"user/large", "user/medium", "user/small"}, names, "repositories without chunks are not listed")
+}
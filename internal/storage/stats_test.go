@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestGetStats_Breakdowns(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	seed := []struct {
+		name     string
+		language string
+		topics   []string
+	}{
+		{"user/cli-go", "Go", []string{"cli", "terminal"}},
+		{"user/tui-go", "Go", []string{"cli", "tui", "cli"}},
+		{"user/web-py", "Python", []string{"web"}},
+		{"user/no-topics", "", nil},
+	}
+
+	for _, s := range seed {
+		require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepo(
+			testutil.NewTestRepository(
+				testutil.WithFullName(s.name),
+				testutil.WithLanguage(s.language),
+				testutil.WithTopics(s.topics...),
+			), nil,
+		)))
+	}
+
+	stats, err := repo.GetStats(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, stats.TotalRepositories)
+	assert.Equal(t, 2, stats.LanguageBreakdown["Go"])
+	assert.Equal(t, 1, stats.LanguageBreakdown["Python"])
+	assert.Equal(t, map[string]int{"cli": 2, "terminal": 1, "tui": 1, "web": 1}, stats.TopicBreakdown,
+		"a topic listed twice on one repository counts once")
+}
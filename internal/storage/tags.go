@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddTag attaches a local tag to a stored repository. Adding an existing tag is a no-op.
+func (r *DuckDBRepository) AddTag(ctx context.Context, fullName, tag string) error {
+	var exists bool
+
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM repositories WHERE full_name = ?)", fullName,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check repository: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("repository not found: %s", fullName)
+	}
+
+	insertSQL := `
+	INSERT INTO repository_tags (full_name, tag)
+	VALUES (?, ?)
+	ON CONFLICT (full_name, tag) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, insertSQL, fullName, tag); err != nil {
+		return fmt.Errorf("failed to add tag: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveTag detaches a local tag from a repository
+func (r *DuckDBRepository) RemoveTag(ctx context.Context, fullName, tag string) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM repository_tags WHERE full_name = ? AND tag = ?", fullName, tag)
+	if err != nil {
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tag %q not found on %s", tag, fullName)
+	}
+
+	return nil
+}
+
+// GetTags returns the local tags for a repository in alphabetical order
+func (r *DuckDBRepository) GetTags(ctx context.Context, fullName string) ([]string, error) {
+	return r.queryStrings(ctx,
+		"SELECT tag FROM repository_tags WHERE full_name = ? ORDER BY tag", fullName)
+}
+
+// ListTaggedRepositories returns the full names of repositories carrying a tag
+func (r *DuckDBRepository) ListTaggedRepositories(ctx context.Context, tag string) ([]string, error) {
+	return r.queryStrings(ctx,
+		"SELECT full_name FROM repository_tags WHERE tag = ? ORDER BY full_name", tag)
+}
+
+// queryStrings runs a single-column query and collects the results
+func (r *DuckDBRepository) queryStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	queryCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var values []string
+
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	return values, nil
+}
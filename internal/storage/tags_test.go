@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func TestTags(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/alpha")))
+	require.NoError(t, repo.StoreRepository(ctx, testutil.NewTestProcessedRepoSimple("user/beta")))
+
+	require.NoError(t, repo.AddTag(ctx, "user/alpha", "evaluate for work"))
+	require.NoError(t, repo.AddTag(ctx, "user/alpha", "cli"))
+	require.NoError(t, repo.AddTag(ctx, "user/beta", "cli"))
+
+	t.Run("add is idempotent", func(t *testing.T) {
+		require.NoError(t, repo.AddTag(ctx, "user/alpha", "cli"))
+
+		tags, err := repo.GetTags(ctx, "user/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cli", "evaluate for work"}, tags)
+	})
+
+	t.Run("add to unknown repository fails", func(t *testing.T) {
+		assert.Error(t, repo.AddTag(ctx, "user/missing", "cli"))
+	})
+
+	t.Run("list tagged repositories", func(t *testing.T) {
+		names, err := repo.ListTaggedRepositories(ctx, "cli")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user/alpha", "user/beta"}, names)
+	})
+
+	t.Run("get repository includes tags", func(t *testing.T) {
+		stored, err := repo.GetRepository(ctx, "user/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cli", "evaluate for work"}, stored.Tags)
+	})
+
+	t.Run("tags survive update", func(t *testing.T) {
+		updated := testutil.NewTestProcessedRepoSimple("user/alpha")
+		updated.Repository.Description = "updated during sync"
+		require.NoError(t, repo.UpdateRepository(ctx, updated))
+
+		require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/alpha", RepositoryMetrics{Commits30d: 3}))
+
+		tags, err := repo.GetTags(ctx, "user/alpha")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cli", "evaluate for work"}, tags)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		require.NoError(t, repo.RemoveTag(ctx, "user/beta", "cli"))
+		assert.Error(t, repo.RemoveTag(ctx, "user/beta", "cli"))
+
+		tags, err := repo.GetTags(ctx, "user/beta")
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("delete repository drops tags", func(t *testing.T) {
+		require.NoError(t, repo.DeleteRepository(ctx, "user/alpha"))
+
+		names, err := repo.ListTaggedRepositories(ctx, "cli")
+		require.NoError(t, err)
+		assert.Empty(t, names)
+	})
+}
@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	"github.com/KyleKing/gh-star-search/internal/timing"
+)
+
+// UpsertOptions selects additional data written in the same transaction as an upsert
+type UpsertOptions struct {
+	Metrics      *RepositoryMetrics // Replace activity metrics when non-nil
+	Embedding    []float32          // Replace the stored embedding when non-empty
+	KeywordTerms map[string]int     // Replace README-derived keyword candidates when non-nil
+
+	// ReplaceChunks replaces the stored content chunks with Chunks, keeping the
+	// embeddings of chunks whose content is unchanged
+	ReplaceChunks bool
+	Chunks        []processor.ContentChunk
+
+	// KeepSyncTime leaves last_synced at the ProcessedAt time instead of the time
+	// metrics are written, for restoring records that were synced earlier
+	KeepSyncTime bool
+
+	// Summary replaces the stored summary when non-nil, keeping its generation
+	// time and version, for restoring records that were summarized earlier
+	Summary *RepositorySummary
+}
+
+// RepositorySummary is a generated summary as stored with a repository
+type RepositorySummary struct {
+	Purpose     string
+	GeneratedAt *time.Time // nil when not known
+	Version     int
+}
+
+// sqlExecer is satisfied by both *sql.DB and *sql.Tx
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// githubColumns are the GitHub-derived columns replaced on every sync. Metrics,
+// summaries, embeddings and local tags are managed separately and never touched here.
+var githubColumns = []string{
+	"description", "homepage", "language", "stargazers_count", "forks_count", "size_kb",
+	"created_at", "updated_at", "last_synced",
+	"topics_array", "license_name", "license_spdx_id", "content_hash", "topics_text",
+	"github_id", "content_language", "readme_path", "readme_format", "archived",
+	"starred_at",
+}
+
+// UpsertRepository inserts a repository or replaces the GitHub-derived columns of an
+// existing one, together with any metrics, embedding or summary in opts, in a single transaction.
+// An interrupted upsert leaves the previous row, and everything attached to it, intact.
+func (r *DuckDBRepository) UpsertRepository(
+	ctx context.Context,
+	repo processor.ProcessedRepo,
+	opts UpsertOptions,
+) error {
+	defer timing.FromContext(ctx).Track(timing.PhaseDatabase)()
+
+	values, err := githubColumnValues(repo)
+	if err != nil {
+		return err
+	}
+
+	updates := make([]string, len(githubColumns))
+	for i, col := range githubColumns {
+		updates[i] = fmt.Sprintf("%s = excluded.%s", col, col)
+
+		// Repositories fetched individually carry no star time; keep the known one
+		if col == "starred_at" {
+			updates[i] = "starred_at = COALESCE(excluded.starred_at, repositories.starred_at)"
+		}
+	}
+
+	upsertSQL := fmt.Sprintf(`
+	INSERT INTO repositories (id, full_name, %s)
+	VALUES (?, ?%s)
+	ON CONFLICT (full_name) DO UPDATE SET %s`,
+		strings.Join(githubColumns, ", "),
+		strings.Repeat(", ?", len(githubColumns)),
+		strings.Join(updates, ", "))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	args := append([]any{uuid.New().String(), repo.Repository.FullName}, values...)
+	if _, err := tx.ExecContext(ctx, upsertSQL, args...); err != nil {
+		return fmt.Errorf("failed to upsert repository: %w", err)
+	}
+
+	if opts.Metrics != nil {
+		if err := updateMetrics(ctx, tx, repo.Repository.FullName, *opts.Metrics); err != nil {
+			return err
+		}
+	}
+
+	if opts.Metrics != nil && opts.KeepSyncTime {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE repositories SET last_synced = ? WHERE full_name = ?",
+			repo.ProcessedAt, repo.Repository.FullName,
+		); err != nil {
+			return fmt.Errorf("failed to restore sync time: %w", err)
+		}
+	}
+
+	if len(opts.Embedding) > 0 {
+		if err := updateEmbedding(ctx, tx, repo.Repository.FullName, opts.Embedding); err != nil {
+			return err
+		}
+	}
+
+	if opts.KeywordTerms != nil {
+		termsJSON, err := encodeJSONColumn(opts.KeywordTerms)
+		if err != nil {
+			return fmt.Errorf("failed to marshal keyword terms: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE repositories SET keyword_terms = ? WHERE full_name = ?",
+			termsJSON, repo.Repository.FullName,
+		); err != nil {
+			return fmt.Errorf("failed to update keyword terms: %w", err)
+		}
+	}
+
+	if opts.Summary != nil {
+		if _, err := tx.ExecContext(ctx, `
+		UPDATE repositories SET purpose = ?, summary_generated_at = ?, summary_version = ?
+		WHERE full_name = ?`,
+			opts.Summary.Purpose, opts.Summary.GeneratedAt, opts.Summary.Version, repo.Repository.FullName,
+		); err != nil {
+			return fmt.Errorf("failed to restore summary: %w", err)
+		}
+	}
+
+	if opts.ReplaceChunks {
+		if err := replaceChunks(ctx, tx, repo.Repository.FullName, opts.Chunks); err != nil {
+			return err
+		}
+	}
+
+	if r.beforeCommit != nil {
+		if err := r.beforeCommit(); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit upsert: %w", err)
+	}
+
+	return nil
+}
+
+// githubColumnValues returns the values for githubColumns, in order
+func githubColumnValues(repo processor.ProcessedRepo) ([]any, error) {
+	topicsJSON, err := encodeJSONColumn(repo.Repository.Topics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal topics: %w", err)
+	}
+
+	var licenseName, licenseSPDXID string
+	if repo.Repository.License != nil {
+		licenseName = repo.Repository.License.Name
+		licenseSPDXID = repo.Repository.License.SPDXID
+	}
+
+	return []any{
+		repo.Repository.Description,
+		repo.Repository.Homepage,
+		repo.Repository.Language,
+		repo.Repository.StargazersCount,
+		repo.Repository.ForksCount,
+		repo.Repository.Size,
+		repo.Repository.CreatedAt,
+		repo.Repository.UpdatedAt,
+		repo.ProcessedAt,
+		topicsJSON,
+		licenseName,
+		licenseSPDXID,
+		repo.ContentHash,
+		strings.Join(repo.Repository.Topics, " "),
+		sql.NullInt64{Int64: repo.Repository.ID, Valid: repo.Repository.ID != 0},
+		repo.ContentLanguage,
+		repo.ReadmePath,
+		repo.ReadmeFormat,
+		repo.Repository.Archived,
+		sql.NullTime{Time: repo.Repository.StarredAt, Valid: !repo.Repository.StarredAt.IsZero()},
+	}, nil
+}
+
+// updateMetrics replaces the activity metrics for a repository
+func updateMetrics(ctx context.Context, db sqlExecer, fullName string, metrics RepositoryMetrics) error {
+	languagesJSON, err := encodeJSONColumn(metrics.Languages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal languages: %w", err)
+	}
+
+	contributorsJSON, err := encodeJSONColumn(metrics.Contributors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contributors: %w", err)
+	}
+
+	contributorLogins := make([]string, 0, len(metrics.Contributors))
+	for _, c := range metrics.Contributors {
+		contributorLogins = append(contributorLogins, c.Login)
+	}
+
+	updateSQL := `
+	UPDATE repositories SET
+		homepage = ?,
+		open_issues_open = ?, open_issues_total = ?,
+		open_prs_open = ?, open_prs_total = ?,
+		commits_30d = ?, commits_1y = ?, commits_total = ?,
+		languages = ?, contributors = ?, contributors_text = ?,
+		last_synced = CURRENT_TIMESTAMP
+	WHERE full_name = ?`
+
+	result, err := db.ExecContext(ctx, updateSQL,
+		metrics.Homepage,
+		nullableCount(metrics.OpenIssuesOpen), nullableCount(metrics.OpenIssuesTotal),
+		nullableCount(metrics.OpenPRsOpen), nullableCount(metrics.OpenPRsTotal),
+		nullableCount(metrics.Commits30d), nullableCount(metrics.Commits1y), nullableCount(metrics.CommitsTotal),
+		languagesJSON, contributorsJSON, strings.Join(contributorLogins, " "),
+		fullName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update repository metrics: %w", err)
+	}
+
+	return requireRowAffected(result, fullName)
+}
+
+// nullableCount maps negative (unknown) counts to NULL
+func nullableCount(count int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(count), Valid: count >= 0}
+}
+
+// updateEmbedding replaces the embedding for a repository
+func updateEmbedding(ctx context.Context, db sqlExecer, fullName string, embedding []float32) error {
+	embeddingJSON, err := encodeJSONColumn(embedding)
+	if err != nil {
+		return fmt.Errorf("failed to marshal embedding: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		`UPDATE repositories SET repo_embedding = ? WHERE full_name = ?`,
+		embeddingJSON, fullName)
+	if err != nil {
+		return fmt.Errorf("failed to update repository embedding: %w", err)
+	}
+
+	return nil
+}
+
+// requireRowAffected returns an error when an update matched no repository
+func requireRowAffected(result sql.Result, fullName string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("repository not found: %s", fullName)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/processor"
+	testutil "github.com/KyleKing/gh-star-search/internal/testutil"
+)
+
+func newUpsertTestRepo(description string, stars int) processor.ProcessedRepo {
+	return testutil.NewTestProcessedRepo(
+		testutil.NewTestRepository(
+			testutil.WithFullName("user/upsert-repo"),
+			testutil.WithDescription(description),
+			testutil.WithStars(stars),
+			testutil.WithLanguage("Go"),
+			testutil.WithTopics("cli"),
+		),
+		[]processor.ContentChunk{testutil.NewTestChunk("README.md", description)},
+	)
+}
+
+func TestUpsertRepository_PreservesManagedData(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertRepository(ctx, newUpsertTestRepo("Initial", 10), UpsertOptions{}))
+
+	original, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+
+	metrics := RepositoryMetrics{
+		CommitsTotal: 42,
+		Contributors: []Contributor{{Login: "user1", Contributions: 10}},
+	}
+	require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/upsert-repo", metrics))
+	require.NoError(t, repo.UpdateRepositorySummary(ctx, "user/upsert-repo", "A test purpose"))
+	require.NoError(t, repo.UpdateRepositoryEmbedding(ctx, "user/upsert-repo", []float32{0.1, 0.2}))
+	require.NoError(t, repo.AddTag(ctx, "user/upsert-repo", "keep"))
+
+	updated := newUpsertTestRepo("Updated", 20)
+	updated.Repository.Language = "Rust"
+	updated.ContentLanguage = "zh"
+	updated.ReadmePath = "README.rst"
+	updated.ReadmeFormat = "rst"
+	updated.Repository.Archived = true
+	require.NoError(t, repo.UpsertRepository(ctx, updated, UpsertOptions{}))
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+
+	// GitHub-derived columns are replaced
+	assert.Equal(t, "Updated", stored.Description)
+	assert.Equal(t, 20, stored.StargazersCount)
+	assert.Equal(t, "Rust", stored.Language)
+	assert.Equal(t, "zh", stored.ContentLanguage)
+	assert.Equal(t, "README.rst", stored.ReadmePath)
+	assert.Equal(t, "rst", stored.ReadmeFormat)
+	assert.True(t, stored.Archived)
+
+	listed, err := repo.ListRepositories(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "zh", listed[0].ContentLanguage)
+	assert.Equal(t, "rst", listed[0].ReadmeFormat)
+
+	// Identity and locally managed data are preserved
+	assert.Equal(t, original.ID, stored.ID)
+	assert.Equal(t, 42, stored.CommitsTotal)
+	assert.Len(t, stored.Contributors, 1)
+	assert.Equal(t, "A test purpose", stored.Purpose)
+	assert.Equal(t, []float32{0.1, 0.2}, stored.RepoEmbedding)
+	assert.Equal(t, []string{"keep"}, stored.Tags)
+}
+
+func TestUpsertRepository_WithOptions(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	opts := UpsertOptions{
+		Metrics:   &RepositoryMetrics{OpenIssuesOpen: 3, Commits30d: 7},
+		Embedding: []float32{0.5},
+	}
+	require.NoError(t, repo.UpsertRepository(ctx, newUpsertTestRepo("With options", 5), opts))
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.OpenIssuesOpen)
+	assert.Equal(t, 7, stored.Commits30d)
+	assert.Equal(t, []float32{0.5}, stored.RepoEmbedding)
+}
+
+func TestUpsertRepository_KeepSyncTime(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	restored := newUpsertTestRepo("Restored", 5)
+	restored.ProcessedAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	opts := UpsertOptions{Metrics: &RepositoryMetrics{CommitsTotal: 9}, KeepSyncTime: true}
+	require.NoError(t, repo.UpsertRepository(ctx, restored, opts))
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+	assert.Equal(t, 9, stored.CommitsTotal)
+	assert.True(t, stored.LastSynced.Equal(restored.ProcessedAt), "last_synced = %v", stored.LastSynced)
+}
+
+func TestUpsertRepository_StarredAt(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	starred := newUpsertTestRepo("Starred", 5)
+	starred.Repository.StarredAt = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.UpsertRepository(ctx, starred, UpsertOptions{}))
+
+	// A repository fetched on its own has no star time and keeps the stored one
+	require.NoError(t, repo.UpsertRepository(ctx, newUpsertTestRepo("Refetched", 6), UpsertOptions{}))
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "Refetched", stored.Description)
+	require.NotNil(t, stored.StarredAt)
+	assert.True(t, stored.StarredAt.Equal(starred.Repository.StarredAt), "starred_at = %v", stored.StarredAt)
+
+	listed, err := repo.ListRepositoriesSorted(ctx, ListOptions{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.NotNil(t, listed[0].StarredAt)
+}
+
+func TestUpsertRepository_InterruptedWriteLeavesRowIntact(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertRepository(ctx, newUpsertTestRepo("Before crash", 10), UpsertOptions{}))
+	require.NoError(t, repo.UpdateRepositorySummary(ctx, "user/upsert-repo", "A test purpose"))
+
+	// Simulate a crash after all statements ran but before the commit
+	repo.beforeCommit = func() error { return errors.New("simulated crash") }
+
+	opts := UpsertOptions{Metrics: &RepositoryMetrics{CommitsTotal: 99}}
+	err := repo.UpsertRepository(ctx, newUpsertTestRepo("After crash", 20), opts)
+	require.Error(t, err)
+
+	err = repo.UpsertRepository(ctx, testutil.NewTestProcessedRepoSimple("user/new-repo"), UpsertOptions{})
+	require.Error(t, err)
+
+	repo.beforeCommit = nil
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+	assert.Equal(t, "Before crash", stored.Description)
+	assert.Equal(t, 10, stored.StargazersCount)
+	assert.Equal(t, 0, stored.CommitsTotal)
+	assert.Equal(t, "A test purpose", stored.Purpose)
+
+	_, err = repo.GetRepository(ctx, "user/new-repo")
+	assert.Error(t, err, "interrupted insert should not leave a row behind")
+}
+
+func TestUpdateRepositoryMetrics_UnknownCountsStoredAsNull(t *testing.T) {
+	repo, cleanup := NewTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertRepository(ctx, newUpsertTestRepo("Unknown counts", 5), UpsertOptions{}))
+
+	metrics := RepositoryMetrics{
+		OpenIssuesOpen:  UnknownCount,
+		OpenIssuesTotal: UnknownCount,
+		OpenPRsOpen:     0,
+		OpenPRsTotal:    4,
+		Commits30d:      UnknownCount,
+		Commits1y:       UnknownCount,
+		CommitsTotal:    UnknownCount,
+	}
+	require.NoError(t, repo.UpdateRepositoryMetrics(ctx, "user/upsert-repo", metrics))
+
+	var nullCount int
+
+	err := repo.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM repositories
+		WHERE full_name = 'user/upsert-repo'
+		  AND open_issues_open IS NULL AND commits_total IS NULL AND open_prs_open = 0`,
+	).Scan(&nullCount)
+	require.NoError(t, err)
+	assert.Equal(t, 1, nullCount, "unknown counts should be NULL while a real zero stays 0")
+
+	stored, err := repo.GetRepository(ctx, "user/upsert-repo")
+	require.NoError(t, err)
+	assert.Equal(t, UnknownCount, stored.OpenIssuesOpen)
+	assert.Equal(t, UnknownCount, stored.CommitsTotal)
+	assert.Equal(t, 0, stored.OpenPRsOpen)
+	assert.Equal(t, 4, stored.OpenPRsTotal)
+}
@@ -3,12 +3,12 @@ package summarizer
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/KyleKing/gh-star-search/internal/python"
+	"github.com/KyleKing/gh-star-search/internal/timing"
 )
 
 // Method represents the summarization method used
@@ -57,6 +57,8 @@ func (s *Summarizer) Summarize(ctx context.Context, text string, method Method)
 		return &Result{Summary: strings.TrimSpace(text), Method: "passthrough"}, nil
 	}
 
+	defer timing.FromContext(ctx).Track(timing.PhaseLLM)()
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -93,7 +95,7 @@ func (s *Summarizer) Summarize(ctx context.Context, text string, method Method)
 
 	// Parse JSON output
 	var result Result
-	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+	if err := python.DecodeJSON(stdout.Bytes(), &result); err != nil {
 		return nil, fmt.Errorf("failed to parse summarization result: %w", err)
 	}
 
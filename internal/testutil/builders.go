@@ -1,7 +1,9 @@
 package testutil
 
 import (
+	"crypto/sha1"
 	"encoding/base64"
+	"fmt"
 	"time"
 
 	"github.com/KyleKing/gh-star-search/internal/github"
@@ -92,6 +94,20 @@ func WithUpdatedAt(t time.Time) RepositoryOption {
 	}
 }
 
+// WithGitHubID sets the stable numeric GitHub repository id
+func WithGitHubID(id int64) RepositoryOption {
+	return func(r *github.Repository) {
+		r.ID = id
+	}
+}
+
+// WithArchived marks the repository as archived
+func WithArchived() RepositoryOption {
+	return func(r *github.Repository) {
+		r.Archived = true
+	}
+}
+
 // NewTestRepository creates a test repository with sensible defaults
 // and applies any provided options.
 func NewTestRepository(opts ...RepositoryOption) github.Repository {
@@ -130,10 +146,15 @@ func NewTestContent(path, content string) github.Content {
 		Content:  encoded,
 		Size:     len(content),
 		Encoding: "base64",
-		SHA:      "abc123", // Placeholder SHA
+		SHA:      blobSHA(content),
 	}
 }
 
+// blobSHA returns the git blob SHA of content, as GitHub reports it
+func blobSHA(content string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content))))
+}
+
 // ContentChunkOption is a functional option for configuring content chunks
 type ContentChunkOption func(*processor.ContentChunk)
 
@@ -3,6 +3,7 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/KyleKing/gh-star-search/internal/github"
@@ -13,6 +14,7 @@ type MockGitHubClient struct {
 	mu sync.RWMutex
 
 	starredRepos []github.Repository
+	unstarred    []github.Repository
 	content      map[string][]github.Content
 	metadata     map[string]*github.Metadata
 	errors       map[string]error
@@ -29,6 +31,13 @@ func WithStarredRepos(repos []github.Repository) MockOption {
 	}
 }
 
+// WithUnstarredRepos sets repositories GetRepository returns that are not starred
+func WithUnstarredRepos(repos []github.Repository) MockOption {
+	return func(m *MockGitHubClient) {
+		m.unstarred = repos
+	}
+}
+
 // WithContent sets the content map for specific repositories
 func WithContent(content map[string][]github.Content) MockOption {
 	return func(m *MockGitHubClient) {
@@ -86,6 +95,55 @@ func (m *MockGitHubClient) GetStarredRepos(
 	return m.starredRepos, nil
 }
 
+// GetRepository returns the starred repository with the given full name
+func (m *MockGitHubClient) GetRepository(
+	_ context.Context,
+	fullName string,
+) (*github.Repository, error) {
+	m.mu.Lock()
+	m.callCounts["GetRepository"]++
+	m.mu.Unlock()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err, exists := m.errors[fullName+":repository"]; exists {
+		return nil, err
+	}
+
+	for _, repos := range [][]github.Repository{m.starredRepos, m.unstarred} {
+		for _, repo := range repos {
+			if repo.FullName == fullName {
+				return &repo, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("repository not found: %s", fullName)
+}
+
+// IsStarred reports whether fullName is one of the starred repositories
+func (m *MockGitHubClient) IsStarred(_ context.Context, fullName string) (bool, error) {
+	m.mu.Lock()
+	m.callCounts["IsStarred"]++
+	m.mu.Unlock()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err, exists := m.errors[fullName+":starred"]; exists {
+		return false, err
+	}
+
+	for _, repo := range m.starredRepos {
+		if strings.EqualFold(repo.FullName, fullName) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // GetRepositoryContent returns the configured content for a repository
 func (m *MockGitHubClient) GetRepositoryContent(
 	_ context.Context,
@@ -0,0 +1,141 @@
+// Package timing accumulates time spent in the phases of a command (GitHub API
+// calls, content processing, database writes and LLM calls) for --timings.
+package timing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Phase names a category of work that is timed
+type Phase string
+
+const (
+	PhaseGitHub     Phase = "GitHub API"
+	PhaseProcessing Phase = "Content processing"
+	PhaseDatabase   Phase = "Database writes"
+	PhaseLLM        Phase = "LLM calls"
+)
+
+// Phases lists the phases in report order
+var Phases = []Phase{PhaseGitHub, PhaseProcessing, PhaseDatabase, PhaseLLM}
+
+// PhaseTotal is the accumulated time and call count for one phase
+type PhaseTotal struct {
+	Phase    Phase
+	Duration time.Duration
+	Calls    int
+}
+
+// Recorder accumulates durations per phase. It is safe for concurrent use, and
+// a nil *Recorder ignores all calls so instrumented code needs no checks.
+type Recorder struct {
+	mu     sync.Mutex
+	start  time.Time
+	totals map[Phase]*PhaseTotal
+}
+
+// NewRecorder creates a recorder; wall time is measured from now
+func NewRecorder() *Recorder {
+	return &Recorder{
+		start:  time.Now(),
+		totals: make(map[Phase]*PhaseTotal),
+	}
+}
+
+// Add records one call of duration d in phase
+func (r *Recorder) Add(phase Phase, d time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total, ok := r.totals[phase]
+	if !ok {
+		total = &PhaseTotal{Phase: phase}
+		r.totals[phase] = total
+	}
+
+	total.Duration += d
+	total.Calls++
+}
+
+// Track starts timing a call in phase and returns a func that stops it:
+//
+//	defer timing.FromContext(ctx).Track(timing.PhaseGitHub)()
+func (r *Recorder) Track(phase Phase) func() {
+	if r == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() { r.Add(phase, time.Since(start)) }
+}
+
+// Totals returns the accumulated totals for every phase in report order
+func (r *Recorder) Totals() []PhaseTotal {
+	totals := make([]PhaseTotal, 0, len(Phases))
+
+	if r == nil {
+		return totals
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, phase := range Phases {
+		if total, ok := r.totals[phase]; ok {
+			totals = append(totals, *total)
+		} else {
+			totals = append(totals, PhaseTotal{Phase: phase})
+		}
+	}
+
+	return totals
+}
+
+// Elapsed returns the wall time since the recorder was created
+func (r *Recorder) Elapsed() time.Duration {
+	if r == nil {
+		return 0
+	}
+
+	return time.Since(r.start)
+}
+
+// Write prints the phase breakdown followed by the wall time
+func (r *Recorder) Write(w io.Writer) {
+	fmt.Fprintln(w, "\nTimings:")
+
+	for _, total := range r.Totals() {
+		if total.Calls == 0 {
+			fmt.Fprintf(w, "  %-20s %10s\n", total.Phase, "-")
+			continue
+		}
+
+		fmt.Fprintf(w, "  %-20s %10s  (%d calls)\n",
+			total.Phase, total.Duration.Round(time.Millisecond), total.Calls)
+	}
+
+	fmt.Fprintf(w, "  %-20s %10s\n", "Total (wall)", r.Elapsed().Round(time.Millisecond))
+	fmt.Fprintln(w, "  Phases run concurrently, so their sum can exceed the wall time.")
+}
+
+type contextKey struct{}
+
+// WithRecorder returns a context carrying r
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the recorder carried by ctx, or nil when timing is off
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(contextKey{}).(*Recorder)
+	return r
+}
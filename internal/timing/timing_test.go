@@ -0,0 +1,82 @@
+package timing
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Add(t *testing.T) {
+	r := NewRecorder()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			r.Add(PhaseGitHub, time.Millisecond)
+		}()
+	}
+
+	wg.Wait()
+	r.Add(PhaseDatabase, 5*time.Millisecond)
+
+	totals := r.Totals()
+	require.Len(t, totals, len(Phases))
+
+	assert.Equal(t, PhaseTotal{Phase: PhaseGitHub, Duration: 10 * time.Millisecond, Calls: 10}, totals[0])
+	assert.Equal(t, PhaseTotal{Phase: PhaseProcessing}, totals[1])
+	assert.Equal(t, PhaseTotal{Phase: PhaseDatabase, Duration: 5 * time.Millisecond, Calls: 1}, totals[2])
+	assert.Equal(t, PhaseTotal{Phase: PhaseLLM}, totals[3])
+}
+
+func TestRecorder_Track(t *testing.T) {
+	r := NewRecorder()
+
+	stop := r.Track(PhaseLLM)
+	time.Sleep(2 * time.Millisecond)
+	stop()
+
+	total := r.Totals()[3]
+	assert.Equal(t, 1, total.Calls)
+	assert.GreaterOrEqual(t, total.Duration, 2*time.Millisecond)
+}
+
+func TestRecorder_Nil(t *testing.T) {
+	var r *Recorder
+
+	assert.NotPanics(t, func() {
+		r.Track(PhaseGitHub)()
+		r.Add(PhaseDatabase, time.Second)
+	})
+	assert.Empty(t, r.Totals())
+	assert.Zero(t, r.Elapsed())
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	r := NewRecorder()
+	assert.Same(t, r, FromContext(WithRecorder(context.Background(), r)))
+}
+
+func TestRecorder_Write(t *testing.T) {
+	r := NewRecorder()
+	r.Add(PhaseGitHub, 1500*time.Millisecond)
+	r.Add(PhaseGitHub, 500*time.Millisecond)
+
+	var buf bytes.Buffer
+	r.Write(&buf)
+
+	out := buf.String()
+	assert.Contains(t, out, "Timings:")
+	assert.Contains(t, out, "GitHub API                   2s  (2 calls)")
+	assert.Contains(t, out, "LLM calls                     -")
+	assert.Contains(t, out, "Total (wall)")
+}
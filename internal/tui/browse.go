@@ -0,0 +1,476 @@
+// Package tui implements the interactive browse mode: a scrollable list of
+// stored repositories with live filtering, a detail pane and a keybinding to
+// open the selected repository in the browser.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+const (
+	// PageSize is how many repositories are loaded from storage at a time
+	PageSize = 100
+	// splitWidth is the narrowest terminal that shows the list and the detail
+	// pane side by side; narrower ones toggle between them with enter
+	splitWidth = 100
+	// chromeLines are the lines taken by the header, filter and footer
+	chromeLines = 3
+)
+
+// Lister pages through stored repositories; storage.Repository satisfies it
+type Lister interface {
+	ListRepositories(ctx context.Context, limit, offset int) ([]storage.StoredRepo, error)
+}
+
+// Options supplies the rendering and browser actions of the browse mode
+type Options struct {
+	Render func(repo storage.StoredRepo) string // Detail pane text of a repository
+	Open   func(repo storage.StoredRepo) error  // Opens a repository in the browser
+}
+
+// pageMsg carries one page loaded from the Lister
+type pageMsg struct {
+	repos []storage.StoredRepo
+	err   error
+}
+
+// openedMsg reports the outcome of opening a repository in the browser
+type openedMsg struct {
+	fullName string
+	err      error
+}
+
+// Model is the bubbletea model of the browse mode. Repositories are loaded a
+// page at a time, only when the list (or a filter) needs more to fill the screen.
+type Model struct {
+	ctx    context.Context
+	lister Lister
+	opts   Options
+
+	repos     []storage.StoredRepo
+	visible   []int // Indexes into repos matching the filter
+	loading   bool
+	exhausted bool // The Lister has no more pages
+
+	cursor       int // Position in visible
+	top          int // First visible row shown in the list
+	detailOffset int // First line of the detail pane shown
+
+	filter    string
+	filtering bool // The filter line has focus
+	detail    bool // The detail pane fills the screen (narrow terminals)
+
+	width, height int
+	status        string
+	err           error
+}
+
+// NewModel returns a browse model that lists repositories from lister
+func NewModel(ctx context.Context, lister Lister, opts Options) Model {
+	return Model{ctx: ctx, lister: lister, opts: opts, loading: true, width: 80, height: 24}
+}
+
+// Run shows the browse mode until the user quits
+func Run(ctx context.Context, lister Lister, opts Options) error {
+	program := tea.NewProgram(NewModel(ctx, lister, opts), tea.WithAltScreen(), tea.WithContext(ctx))
+
+	final, err := program.Run()
+	if err != nil {
+		return err
+	}
+
+	if m, ok := final.(Model); ok && m.err != nil {
+		return m.err
+	}
+
+	return nil
+}
+
+// Init implements tea.Model by loading the first page
+func (m Model) Init() tea.Cmd {
+	return m.loadPage(0)
+}
+
+// loadPage fetches the page of repositories starting at offset
+func (m Model) loadPage(offset int) tea.Cmd {
+	return func() tea.Msg {
+		repos, err := m.lister.ListRepositories(m.ctx, PageSize, offset)
+		return pageMsg{repos: repos, err: err}
+	}
+}
+
+// maybeLoad starts loading the next page when the filtered list is too short
+// to fill the screen below the cursor and more repositories remain
+func (m *Model) maybeLoad() tea.Cmd {
+	if m.loading || m.exhausted || len(m.visible) > m.cursor+m.listHeight() {
+		return nil
+	}
+
+	m.loading = true
+
+	return m.loadPage(len(m.repos))
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+
+		return m, m.maybeLoad()
+	case pageMsg:
+		m.loading = false
+
+		if msg.err != nil {
+			m.err = fmt.Errorf("failed to list repositories: %w", msg.err)
+			return m, tea.Quit
+		}
+
+		m.exhausted = len(msg.repos) < PageSize
+		m.repos = append(m.repos, msg.repos...)
+		m.applyFilter()
+
+		return m, m.maybeLoad()
+	case openedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Could not open %s: %v", msg.fullName, msg.err)
+		} else {
+			m.status = "Opened " + msg.fullName
+		}
+
+		return m, nil
+	case tea.KeyMsg:
+		m.status = ""
+
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		return m.updateBrowse(msg)
+	}
+
+	return m, nil
+}
+
+// updateFilter handles keys while the filter line has focus. The list narrows
+// with every keystroke.
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.filtering = false
+		return m, nil
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			runes := []rune(m.filter)
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.applyFilter()
+
+	return m, m.maybeLoad()
+}
+
+// updateBrowse handles keys while moving through the list
+func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		switch {
+		case m.detail:
+			m.detail = false
+		case m.filter != "":
+			m.filter = ""
+			m.applyFilter()
+		}
+	case "/":
+		m.filtering = true
+		m.detail = false
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.listHeight())
+	case "pgdown", " ":
+		m.moveCursor(m.listHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.visible))
+	case "end", "G":
+		m.moveCursor(len(m.visible))
+	case "ctrl+u":
+		m.detailOffset = max(m.detailOffset-m.listHeight()/2, 0)
+	case "ctrl+d":
+		m.detailOffset += m.listHeight() / 2
+	case "enter":
+		if m.width < splitWidth {
+			m.detail = !m.detail
+			m.detailOffset = 0
+		}
+	case "o":
+		if repo, ok := m.selected(); ok && m.opts.Open != nil {
+			return m, func() tea.Msg {
+				return openedMsg{fullName: repo.FullName, err: m.opts.Open(repo)}
+			}
+		}
+	}
+
+	return m, m.maybeLoad()
+}
+
+// moveCursor moves the selection by delta rows, within the filtered list
+func (m *Model) moveCursor(delta int) {
+	if len(m.visible) == 0 {
+		return
+	}
+
+	cursor := min(max(m.cursor+delta, 0), len(m.visible)-1)
+	if cursor != m.cursor {
+		m.detailOffset = 0
+	}
+
+	m.cursor = cursor
+	m.scrollToCursor()
+}
+
+// scrollToCursor keeps the cursor inside the list window
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+
+	if m.cursor >= m.top+height {
+		m.top = m.cursor - height + 1
+	}
+}
+
+// applyFilter recomputes the repositories matching the filter, keeping the
+// selection on the same repository when it still matches
+func (m *Model) applyFilter() {
+	current, hadSelection := m.selected()
+
+	terms := strings.Fields(strings.ToLower(m.filter))
+	m.visible = make([]int, 0, len(m.repos))
+
+	for i, repo := range m.repos {
+		if matchesFilter(repo, terms) {
+			m.visible = append(m.visible, i)
+		}
+	}
+
+	m.cursor = 0
+
+	if hadSelection {
+		for i, idx := range m.visible {
+			if m.repos[idx].FullName == current.FullName {
+				m.cursor = i
+				break
+			}
+		}
+	}
+
+	m.top = min(m.top, m.cursor)
+	m.scrollToCursor()
+}
+
+// matchesFilter reports whether every term occurs in the repository's name,
+// description, language or topics
+func matchesFilter(repo storage.StoredRepo, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+
+	text := strings.ToLower(strings.Join([]string{
+		repo.FullName, repo.Description, repo.Language, strings.Join(repo.Topics, " "),
+	}, " "))
+
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selected returns the repository under the cursor
+func (m Model) selected() (storage.StoredRepo, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return storage.StoredRepo{}, false
+	}
+
+	return m.repos[m.visible[m.cursor]], true
+}
+
+// listHeight is the number of rows available to the list and detail pane
+func (m Model) listHeight() int {
+	return max(m.height-chromeLines, 1)
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.headerLine())
+	b.WriteString("\n")
+
+	height := m.listHeight()
+
+	switch {
+	case m.width >= splitWidth:
+		listWidth := m.width * 2 / 5
+		list := m.listLines(listWidth, height)
+		detail := m.detailLines(m.width-listWidth-3, height)
+
+		for i := range height {
+			b.WriteString(padRight(list[i], listWidth))
+			b.WriteString(" │ ")
+			b.WriteString(detail[i])
+			b.WriteString("\n")
+		}
+	case m.detail:
+		writeLines(&b, m.detailLines(m.width, height))
+	default:
+		writeLines(&b, m.listLines(m.width, height))
+	}
+
+	b.WriteString(m.filterLine())
+	b.WriteString("\n")
+	b.WriteString(ansi.Truncate(m.footerLine(), m.width, "…"))
+
+	return b.String()
+}
+
+func (m Model) headerLine() string {
+	count := fmt.Sprintf("%d repositories", len(m.repos))
+	if !m.exhausted {
+		count += "+"
+	}
+
+	if m.filter != "" {
+		count = fmt.Sprintf("%d of %s", len(m.visible), count)
+	}
+
+	return ansi.Truncate("gh star-search browse: "+count, m.width, "…")
+}
+
+func (m Model) filterLine() string {
+	switch {
+	case m.filtering:
+		return ansi.Truncate("Filter: "+m.filter+"█", m.width, "…")
+	case m.filter != "":
+		return ansi.Truncate("Filter: "+m.filter+" (esc to clear)", m.width, "…")
+	default:
+		return ""
+	}
+}
+
+func (m Model) footerLine() string {
+	if m.status != "" {
+		return m.status
+	}
+
+	if m.filtering {
+		return "type to filter · enter keep · esc clear"
+	}
+
+	help := "↑/↓ move · / filter · o open in browser · ctrl+d/ctrl+u scroll details · q quit"
+	if m.width < splitWidth {
+		help = "↑/↓ move · / filter · enter details · o open in browser · q quit"
+	}
+
+	return help
+}
+
+// listLines renders height rows of the list window, width columns wide
+func (m Model) listLines(width, height int) []string {
+	lines := make([]string, height)
+
+	for row := range height {
+		i := m.top + row
+		if i >= len(m.visible) {
+			break
+		}
+
+		repo := m.repos[m.visible[i]]
+
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		line := fmt.Sprintf("%s%s ★%d", prefix, repo.FullName, repo.StargazersCount)
+		if repo.Language != "" {
+			line += " · " + repo.Language
+		}
+
+		lines[row] = ansi.Truncate(line, width, "…")
+	}
+
+	switch {
+	case len(m.visible) == 0 && m.loading:
+		lines[0] = "Loading..."
+	case len(m.visible) == 0 && m.filter != "":
+		lines[0] = "No repositories match the filter."
+	case len(m.visible) == 0:
+		lines[0] = "No repositories found. Run 'gh star-search sync' to populate the database."
+	}
+
+	return lines
+}
+
+// detailLines renders height lines of the selected repository's details,
+// starting at the detail scroll offset
+func (m Model) detailLines(width, height int) []string {
+	lines := make([]string, height)
+
+	repo, ok := m.selected()
+	if !ok || m.opts.Render == nil {
+		return lines
+	}
+
+	text := strings.Split(strings.TrimRight(m.opts.Render(repo), "\n"), "\n")
+	offset := min(m.detailOffset, max(len(text)-1, 0))
+
+	for row := range height {
+		if offset+row >= len(text) {
+			break
+		}
+
+		lines[row] = ansi.Truncate(text[offset+row], width, "…")
+	}
+
+	return lines
+}
+
+func writeLines(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// padRight pads s with spaces to width display columns
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+}
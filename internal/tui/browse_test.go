@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyleKing/gh-star-search/internal/storage"
+)
+
+// fakeLister serves repos a page at a time and records the offsets requested
+type fakeLister struct {
+	repos   []storage.StoredRepo
+	offsets []int
+}
+
+func (l *fakeLister) ListRepositories(_ context.Context, limit, offset int) ([]storage.StoredRepo, error) {
+	l.offsets = append(l.offsets, offset)
+
+	if offset >= len(l.repos) {
+		return nil, nil
+	}
+
+	return l.repos[offset:min(offset+limit, len(l.repos))], nil
+}
+
+func newFakeLister(n int) *fakeLister {
+	lister := &fakeLister{}
+
+	for i := range n {
+		lister.repos = append(lister.repos, storage.StoredRepo{
+			FullName:    fmt.Sprintf("owner/repo%03d", i),
+			Description: "Repository " + fmt.Sprint(i),
+			Language:    "Go",
+		})
+	}
+
+	lister.repos[PageSize+5].Description = "A terminal UI toolkit"
+
+	return lister
+}
+
+// send applies msg and runs any command it returns to completion, feeding
+// the resulting messages back in, as the bubbletea runtime would
+func send(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+
+	for msg != nil {
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+
+		msg = nil
+		if cmd != nil {
+			msg = cmd()
+		}
+	}
+
+	return m
+}
+
+func keys(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModel_LoadsPagesLazily(t *testing.T) {
+	lister := newFakeLister(PageSize * 2)
+	m := NewModel(context.Background(), lister, Options{})
+
+	m = send(t, m, m.Init()())
+	assert.Equal(t, []int{0}, lister.offsets, "one page fills the screen")
+	assert.Len(t, m.visible, PageSize)
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnd})
+	assert.Equal(t, []int{0, PageSize}, lister.offsets, "reaching the end loads the next page")
+	assert.Len(t, m.visible, PageSize*2)
+}
+
+func TestModel_FilterLoadsUntilMatchesFillScreen(t *testing.T) {
+	lister := newFakeLister(PageSize * 2)
+	m := NewModel(context.Background(), lister, Options{})
+	m = send(t, m, m.Init()())
+
+	m = send(t, m, keys("/"))
+	require.True(t, m.filtering)
+
+	m = send(t, m, keys("terminal"))
+	assert.Equal(t, []int{0, PageSize, PageSize * 2}, lister.offsets, "too few matches load every page")
+	require.Len(t, m.visible, 1)
+
+	repo, ok := m.selected()
+	require.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("owner/repo%03d", PageSize+5), repo.FullName)
+	assert.Contains(t, m.View(), "1 of 200 repositories")
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.filtering)
+	assert.Len(t, m.visible, PageSize*2)
+}
+
+func TestModel_OpenAndDetail(t *testing.T) {
+	var opened []string
+
+	m := NewModel(context.Background(), newFakeLister(PageSize+10), Options{
+		Render: func(repo storage.StoredRepo) string { return "Details of " + repo.FullName },
+		Open: func(repo storage.StoredRepo) error {
+			opened = append(opened, repo.FullName)
+			return nil
+		},
+	})
+	m = send(t, m, m.Init()())
+	m = send(t, m, tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	m = send(t, m, keys("j"))
+	m = send(t, m, keys("o"))
+	assert.Equal(t, []string{"owner/repo001"}, opened)
+	assert.Contains(t, m.View(), "Opened owner/repo001")
+
+	view := m.View()
+	assert.Contains(t, view, "> owner/repo001")
+	assert.Contains(t, view, "Details of owner/repo001", "wide terminals show the detail pane beside the list")
+
+	// Narrow terminals toggle between the list and the details
+	m = send(t, m, tea.WindowSizeMsg{Width: 60, Height: 30})
+	assert.NotContains(t, m.View(), "Details of")
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, m.View(), "Details of owner/repo001")
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 60, "line wider than the terminal: %q", line)
+	}
+}